@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/thethan/fdr-users/pkg/draft"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"github.com/thethan/fdr-users/pkg/draft/repositories"
+	"github.com/thethan/fdr-users/pkg/mongo"
+	"os"
+	"path/filepath"
+)
+
+// exportDraftCommand writes a league's draft export files straight from Mongo:
+//
+//	fdr-player-import export-draft -league 399.l.1234 -out ./exports -format csv
+const exportDraftCommand = "export-draft"
+
+// exportDraft runs the export-draft subcommand and returns the process exit code. It only needs the
+// MONGO_* environment, none of the server's.
+func exportDraft(ctx context.Context, args []string) int {
+	flags := flag.NewFlagSet(exportDraftCommand, flag.ContinueOnError)
+	leagueKey := flags.String("league", "", "league key of the draft to export")
+	out := flags.String("out", ".", "directory the files are written to")
+	format := flags.String("format", "all", "csv, grid, json or all")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *leagueKey == "" {
+		fmt.Fprintln(flags.Output(), "-league is required")
+		flags.Usage()
+		return 2
+	}
+
+	formats := draft.ExportFormats
+	if *format != "all" {
+		exportFormat, err := draft.ParseExportFormat(*format)
+		if err != nil {
+			fmt.Fprintln(flags.Output(), err)
+			return 2
+		}
+		formats = []draft.ExportFormat{exportFormat}
+	}
+
+	logger := log.NewJSONLogger(os.Stderr)
+	mongoClient, err := mongo.NewMongoDBClient(ctx, os.Getenv("MONGO_USERNAME"), os.Getenv("MONGO_PASSWORD"), os.Getenv("MONGO_HOST"), os.Getenv("MONGO_PORT"))
+	if err != nil {
+		level.Error(logger).Log("message", "error in initializing mongo client", "error", err)
+		return 1
+	}
+	defer mongoClient.Disconnect(ctx)
+
+	mongoRepo := repositories.NewMongoRepository(logger, mongoClient, "fdr", "draft", "fdr_user", "roster")
+	// exporting never broadcasts, so the service gets no broadcast repository
+	service := draft.NewService(logger, &mongoRepo, nil)
+	export, err := service.ExportDraft(ctx, *leagueKey)
+	if err != nil {
+		level.Error(logger).Log("message", "could not export draft", "error", err, "league_key", *leagueKey)
+		return 1
+	}
+
+	for _, exportFormat := range formats {
+		path := filepath.Join(*out, exportFormat.FileName(*leagueKey))
+		if err := writeExportFile(path, exportFormat, export); err != nil {
+			level.Error(logger).Log("message", "could not write draft export", "error", err, "path", path)
+			return 1
+		}
+		fmt.Println(path)
+	}
+	return 0
+}
+
+func isExportDraft() bool {
+	return len(os.Args) > 1 && os.Args[1] == exportDraftCommand
+}
+
+func writeExportFile(path string, format draft.ExportFormat, export *entities.DraftExport) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	err = draft.WriteDraftExport(file, format, *export)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
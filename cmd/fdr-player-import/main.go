@@ -99,7 +99,7 @@ func init() {
 
 	if addr := os.Getenv("SERVICE_ACCOUNT_FILE_LOCATION"); addr != "" {
 		DefaultConfig.ServiceAccountFileLocation = addr
-	} else {
+	} else if !isExportDraft() {
 		fmt.Println(fmt.Sprintf("could not get service account location %s"))
 		os.Exit(1)
 	}
@@ -145,6 +145,9 @@ func initMeter() *prometheus.Exporter {
 // passed config and logger
 func main() {
 	ctx := context.Background()
+	if isExportDraft() {
+		os.Exit(exportDraft(ctx, os.Args[2:]))
+	}
 	exporter := initMeter()
 	initTracer()
 
@@ -169,6 +172,22 @@ func main() {
 		os.Exit(1)
 	}
 	mongoRepo := repositories.NewMongoRepository(logger, mongoClient, "fdr", "draft", "fdr_user", "roster")
+	if err := mongoRepo.EnsureDraftResultIndexes(ctx); err != nil {
+		logger.Log("message", "error in creating draft result indexes", "error", err)
+		os.Exit(1)
+	}
+	if err := mongoRepo.EnsureDraftEventIndexes(ctx); err != nil {
+		logger.Log("message", "error in creating draft event indexes", "error", err)
+		os.Exit(1)
+	}
+	if err := mongoRepo.EnsureChatIndexes(ctx); err != nil {
+		logger.Log("message", "error in creating chat indexes", "error", err)
+		os.Exit(1)
+	}
+	if err := mongoRepo.EnsureMockDraftIndexes(ctx); err != nil {
+		logger.Log("message", "error in creating mock draft indexes", "error", err)
+		os.Exit(1)
+	}
 
 	oauthRepo := repositories2.NewMongoOauthRepository(logger, mongoClient, tracer)
 
@@ -195,7 +214,9 @@ func main() {
 	})
 
 	// fdr-players-import
-	yahooRepository := yahoo2.NewYahooRepository(logger, oauthConfig, tracer, &metrics)
+	// every Yahoo call in the process shares one transport, so its rate limits hold across the clients
+	yahooTransport := yahoo.NewTransport(logger, yahoo.WithMeter(metrics))
+	yahooRepository := yahoo2.NewYahooRepository(logger, oauthConfig, tracer, &metrics).WithTransport(yahooTransport)
 	broadcastMq := queue.NewQueueImportStats(logger, kubemqClient)
 	statsRepo := repositories3.NewMongoStatsRepo(logger, mongoClient)
 	// new importer
@@ -239,7 +260,8 @@ func main() {
 	importPlayerStat := make(chan entities.ImportPlayerStat, 25)
 	defer close(importPlayerChannel)
 	defer close(importPlayerStat)
-	yahooService := yahoo.NewService(logger, &firebaseRepo)
+	// the importer's requests are made with the user's stored token, refreshed and saved back when it expires
+	yahooService := yahoo.NewService(logger, yahoo.NewStoredTokenInformation(&oauthRepo)).WithTransport(yahooTransport).WithTokenStore(oauthConfig, &oauthRepo)
 	leagueImportService := league.NewImportService(logger, yahooService, &mongoRepo, tracer)
 	coordinatorEndpoints := coordinator.NewEndpoints(logrusLogger, leagueImportService, authMiddleware)
 	ogGrouter = transports.NewHTTPServer(logrusLogger, ogGrouter, coordinatorEndpoints, authSvc.ServerBefore)
@@ -9,6 +9,7 @@ import (
 	"github.com/caarlos0/env"
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
+	"github.com/go-redis/redis/v7"
 	muxhandlers "github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 	"github.com/grpc-ecosystem/go-grpc-middleware"
@@ -23,8 +24,15 @@ import (
 	"go.opentelemetry.io/otel/sdk/metric/controller/pull"
 	"go.opentelemetry.io/otel/sdk/resource"
 
+	"github.com/thethan/fdr-users/pkg/broadcast"
+	"github.com/thethan/fdr-users/pkg/draft"
+	"github.com/thethan/fdr-users/pkg/draft/repositories"
+	"github.com/thethan/fdr-users/pkg/draft/stream"
+	"github.com/thethan/fdr-users/pkg/draft/transports"
 	"github.com/thethan/fdr-users/pkg/kubemq"
 	"github.com/thethan/fdr-users/pkg/mongo"
+	yahoo4 "github.com/thethan/fdr-users/pkg/yahoo"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel/api/global"
 	"go.opentelemetry.io/otel/exporters/trace/jaeger"
 	"go.opentelemetry.io/otel/label"
@@ -67,6 +75,13 @@ type KubemqConfig struct {
 	Port     int    `env:"KUBEMQ_PORT" envDefault:"50000"`
 }
 
+// BroadcastConfig picks where draft room messages are published: kubemq, redis or memory.
+// memory needs no infrastructure but only reaches clients of this instance.
+type BroadcastConfig struct {
+	Backend      string `env:"BROADCAST_BACKEND" envDefault:"kubemq"`
+	RedisAddress string `env:"REDIS_ADDRESS" envDefault:"localhost:6379"`
+}
+
 var oauthConfig *oauth2.Config
 
 var logrusLogger = &logrus.Logger{
@@ -163,12 +178,14 @@ func main() {
 	logger := gokitLogrus.NewLogrusLogger(logrusLogger)
 	logger = log.WithPrefix(logger, "caller_a", log.DefaultCaller, "caller_b", log.Caller(2), "caller_c", log.Caller(1))
 
-	_, firebaseauthclient := initializeAppDefault(ctx, DefaultConfig, logger)
+	firestoreClient, firebaseauthclient := initializeAppDefault(ctx, DefaultConfig, logger)
 
 	//repo := firebase2.NewFirebaseRepository(logger, firestoreclient, firebaseauthclient)
 	authRepo := firebase2.NewFirestoreAuthRepo(logger, firebaseauthclient)
 	authSvc := auth.NewAuthService(logger, &authRepo)
 	authMiddleware := authSvc.NewAuthMiddleware(tracer, meter)
+	firebaseRepo := firebase2.NewFirebaseRepository(logger, firestoreClient, firebaseauthclient)
+	getUserInfoMiddleware := authSvc.UserInformationToContext(&firebaseRepo)
 
 	mongoClient, err := mongo.NewMongoDBClient(ctx, os.Getenv("MONGO_USERNAME"), os.Getenv("MONGO_PASSWORD"), os.Getenv("MONGO_HOST"), os.Getenv("MONGO_PORT"))
 	if err != nil {
@@ -177,24 +194,55 @@ func main() {
 	}
 
 	oauthRepo := repositories2.NewMongoOauthRepository(logger, mongoClient, tracer)
-	oauthYahooService := yahoo.NewOauthYahooService(logger, tracer, oauthConfig, &oauthRepo)
+	yahooTransport := yahoo4.NewTransport(logger, yahoo4.WithMeter(meter))
+	oauthYahooService := yahoo.NewOauthYahooService(logger, tracer, oauthConfig, &oauthRepo).WithTransport(otelhttp.NewTransport(yahooTransport))
 
 	oauthYahooEndpoints := handlers2.NewYahooHandlersEndpoints(logger, oauthConfig, tracer, &oauthYahooService, authMiddleware)
 
-	var kubemqConfig KubemqConfig
-	err = env.Parse(&kubemqConfig)
-	if err != err {
-		level.Error(logger).Log("message", "could not parse kubemq config", "error", err)
+	var broadcastConfig BroadcastConfig
+	err = env.Parse(&broadcastConfig)
+	if err != nil {
+		level.Error(logger).Log("message", "could not parse broadcast config", "error", err)
 		os.Exit(1)
 	}
 
-	kubemqClient, err := kubemq.NewKubeMQClient(ctx, kubemqConfig.Address, kubemqConfig.Port, kubemqConfig.ClientID)
+	broadcastBackend, closeBroadcast, err := newBroadcastBackend(ctx, logger, broadcastConfig)
 	if err != nil {
-		level.Error(logger).Log("message", "could initiate kubemq client", "error", err)
+		level.Error(logger).Log("message", "could not initiate broadcast backend", "error", err, "backend", broadcastConfig.Backend)
 		os.Exit(1)
 	}
 
-	defer kubemqClient.Close()
+	defer closeBroadcast()
+
+	// draft room
+	mongoRepo := repositories.NewMongoRepository(logger, mongoClient, "fdr", "draft", "fdr_user", "roster")
+	// the draft room relies on these for one result per pick and player, the event sequence, chat paging
+	// and expiring mock drafts, so it creates them itself rather than waiting on fdr-player-import
+	if err := mongoRepo.EnsureDraftResultIndexes(ctx); err != nil {
+		level.Error(logger).Log("message", "error in creating draft result indexes", "error", err)
+		os.Exit(1)
+	}
+	if err := mongoRepo.EnsureDraftEventIndexes(ctx); err != nil {
+		level.Error(logger).Log("message", "error in creating draft event indexes", "error", err)
+		os.Exit(1)
+	}
+	if err := mongoRepo.EnsureChatIndexes(ctx); err != nil {
+		level.Error(logger).Log("message", "error in creating chat indexes", "error", err)
+		os.Exit(1)
+	}
+	if err := mongoRepo.EnsureMockDraftIndexes(ctx); err != nil {
+		level.Error(logger).Log("message", "error in creating mock draft indexes", "error", err)
+		os.Exit(1)
+	}
+	broadcastRepo := broadcast.NewRepository(logger, broadcastBackend)
+	// everything the draft room is sent is also logged for the draft timeline and replay
+	broadcastRepo.WithEventLog(&mongoRepo)
+	// completed offline drafts are sent to yahoo with the commissioner's stored token, over the shared transport
+	draftYahooService := yahoo4.NewService(logger, yahoo4.NewStoredTokenInformation(&oauthRepo)).WithTransport(yahooTransport).WithTokenStore(oauthConfig, &oauthRepo)
+	draftService := draft.NewService(logger, &mongoRepo, &broadcastRepo)
+	draftService.WithYahoo(draftYahooService)
+	draftEndpoints := draft.NewEndpoints(logger, &draftService, &authSvc, authMiddleware, getUserInfoMiddleware)
+
 	ogGrouter := mux.NewRouter()
 	ogGrouter.Use(otelmux.Middleware("fdr-users"))
 	// prometheus metrics
@@ -208,6 +256,8 @@ func main() {
 	})
 
 	ogGrouter = handlers2.MakeHTTPHandler(logger, oauthYahooEndpoints, ogGrouter, authSvc.ServerBefore, tracer)
+	ogGrouter = transports.MakeStreamHandler(logger, stream.NewGateway(logger, broadcastBackend), ogGrouter, authSvc.ServerBefore, authMiddleware)
+	transports.MakeHTTPHandler(logger, draftEndpoints, ogGrouter, authSvc.ServerBefore)
 
 	// Mechanical domain.
 	errc := make(chan error)
@@ -329,3 +379,31 @@ func initializeFirebaseAuth(ctx context.Context, logger log.Logger, app *firebas
 
 	return firebaseAuthClient
 }
+
+// newBroadcastBackend connects to the configured broadcast backend. The returned func closes its connection.
+func newBroadcastBackend(ctx context.Context, logger log.Logger, config BroadcastConfig) (broadcast.Backend, func(), error) {
+	switch config.Backend {
+	case "memory":
+		return broadcast.NewMemory(), func() {}, nil
+	case "redis":
+		client := redis.NewClient(&redis.Options{Addr: config.RedisAddress})
+		err := client.WithContext(ctx).Ping().Err()
+		if err != nil {
+			return nil, nil, err
+		}
+		return broadcast.NewRedis(logger, client), func() { _ = client.Close() }, nil
+	case "kubemq":
+		var kubemqConfig KubemqConfig
+		err := env.Parse(&kubemqConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+		kubemqClient, err := kubemq.NewKubeMQClient(ctx, kubemqConfig.Address, kubemqConfig.Port, kubemqConfig.ClientID)
+		if err != nil {
+			return nil, nil, err
+		}
+		repository := kubemq.NewDraftRepository(logger, kubemqClient)
+		return &repository, func() { _ = kubemqClient.Close() }, nil
+	}
+	return nil, nil, fmt.Errorf("unknown broadcast backend %q", config.Backend)
+}
@@ -5,13 +5,16 @@ go 1.15
 require (
 	cloud.google.com/go/firestore v1.2.0
 	firebase.google.com/go v3.12.1+incompatible
+	github.com/alicebob/miniredis/v2 v2.30.0
 	github.com/aws/aws-sdk-go v1.29.15
 	github.com/caarlos0/env v3.5.0+incompatible
 	github.com/go-kit/kit v0.10.0
+	github.com/go-redis/redis/v7 v7.4.0
 	github.com/gogo/protobuf v1.3.1
 	github.com/google/uuid v1.1.1
 	github.com/gorilla/handlers v1.4.2
 	github.com/gorilla/mux v1.8.0
+	github.com/gorilla/websocket v1.4.0
 	github.com/grpc-ecosystem/go-grpc-middleware v1.2.0
 	github.com/kubemq-io/kubemq-go v1.4.0
 	github.com/kubemq-io/protobuf v1.1.0
@@ -34,6 +37,7 @@ require (
 	go.opentelemetry.io/otel/exporters/trace/jaeger v0.12.0
 	go.opentelemetry.io/otel/sdk v0.12.0
 	golang.org/x/oauth2 v0.0.0-20200902213428-5d25da1a8d43
+	golang.org/x/sync v0.0.0-20200625203802-6e8e738ad208
 	google.golang.org/api v0.32.0
 	google.golang.org/grpc v1.32.0
 	google.golang.org/grpc/examples v0.0.0-20201002194053-b2c5f4a808fd // indirect
@@ -28,8 +28,9 @@ type playersRepo interface {
 	SavePlayers(context.Context, []pkgEntities.PlayerSeason) ([]pkgEntities.PlayerSeason, error)
 }
 
+// oauthRepo keeps the users' tokens, refreshed tokens are saved back to it
 type oauthRepo interface {
-	GetUserOAuthToken(ctx context.Context, guid string) (oauth2.Token, error)
+	yahoo.TokenStore
 }
 
 type SavePlayerStats interface {
@@ -37,7 +38,7 @@ type SavePlayerStats interface {
 }
 
 type YahooService interface {
-	GetPlayerResourcesStats(ctx context.Context, playerKey string, week int, ) (*yahoo.PlayerResourcesStats, error)
+	GetPlayerResourcesStats(ctx context.Context, playerKey string, options yahoo.Options) (*yahoo.PlayerResourcesStats, error)
 }
 
 type Service struct {
@@ -245,13 +246,9 @@ func (s Service) StartPlayersWorker(ctx context.Context, messageChannel <-chan e
 
 func (s *Service) getClientService(ctx context.Context, guid string) *http.Client {
 	if client, ok := s.clientGuid[guid]; !ok || client == nil {
-		token, err := s.oauthRepo.GetUserOAuthToken(ctx, guid)
-		if err != nil {
-			level.Error(s.logger).Log("message", "could not get oauth token")
-		}
-
+		client = s.yahooService.Client(ctx, s.oauthRepo, guid)
 		s.mu.Lock()
-		s.clientGuid[guid] = oauth2.NewClient(ctx, s.oauthConfig.TokenSource(ctx, &token))
+		s.clientGuid[guid] = client
 		s.mu.Unlock()
 		return client
 	}
@@ -5,6 +5,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
 	"github.com/thethan/fdr-users/pkg/yahoo"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel"
@@ -13,17 +14,47 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strconv"
+	"strings"
 )
 
+// fantasyURL is where the fantasy API lives unless WithBaseURL points the repository somewhere else
+const fantasyURL = "https://fantasysports.yahooapis.com/fantasy/v2"
+
 type YahooRepository struct {
-	logger log.Logger
-	conf   *oauth2.Config
-	tracer otel.Tracer
-	meter  *otel.Meter
+	logger    log.Logger
+	conf      *oauth2.Config
+	tracer    otel.Tracer
+	meter     *otel.Meter
+	transport http.RoundTripper
+	baseURL   string
 }
 
 func NewYahooRepository(logger log.Logger, conf *oauth2.Config, tracer otel.Tracer, meter *otel.Meter) YahooRepository {
-	return YahooRepository{logger: logger, conf: conf, tracer: tracer, meter: meter}
+	var options []yahoo.TransportOption
+	if meter != nil {
+		options = append(options, yahoo.WithMeter(*meter))
+	}
+	return YahooRepository{logger: logger, conf: conf, tracer: tracer, meter: meter, transport: yahoo.NewTransport(logger, options...), baseURL: fantasyURL}
+}
+
+// WithTransport sends the repository's requests through transport, so it can share one yahoo.Transport with the
+// other Yahoo clients in the process
+func (y YahooRepository) WithTransport(transport http.RoundTripper) YahooRepository {
+	y.transport = transport
+	return y
+}
+
+// WithBaseURL sends the repository's requests to baseURL instead of the fantasy API
+func (y YahooRepository) WithBaseURL(baseURL string) YahooRepository {
+	y.baseURL = strings.TrimSuffix(baseURL, "/")
+	return y
+}
+
+// Client makes requests with the stored token of the user with guid, refreshing it when it expires and saving the
+// refreshed token to tokens. The requests and the refresh both go through the repository's transport.
+func (y YahooRepository) Client(ctx context.Context, tokens yahoo.TokenStore, guid string) *http.Client {
+	client := &http.Client{Transport: otelhttp.NewTransport(y.transport)}
+	return &http.Client{Transport: &oauth2.Transport{Source: yahoo.NewTokenSource(ctx, y.logger, y.conf, tokens, guid, client), Base: client.Transport}}
 }
 
 type ClientOption struct {
@@ -40,13 +71,17 @@ func (y YahooRepository) GetPlayerResourceStats(ctx context.Context, client *htt
 	if week != "" {
 		weekString = fmt.Sprintf(";type=week;week=%s", week)
 	}
-	url := fmt.Sprintf("https://fantasysports.yahooapis.com/fantasy/v2/player/%s/stats%s", playerKey, weekString)
+	url := fmt.Sprintf("%s/player/%s/stats%s", y.baseURL, playerKey, weekString)
 	req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
 
 	res, err := client.Do(req)
 	if err != nil {
 		return nil, err
 	}
+	err = yahoo.CheckResponse(res)
+	if err != nil {
+		return nil, err
+	}
 
 	defer res.Body.Close()
 	v := yahoo.PlayerResourcesStats{}
@@ -56,7 +91,7 @@ func (y YahooRepository) GetPlayerResourceStats(ctx context.Context, client *htt
 	}
 	err = xml.Unmarshal(bytes, &v)
 	if err != nil {
-		fmt.Printf("error: %v", err)
+		level.Error(y.logger).Log("message", "could not unmarshal yahoo response", "error", err, "url", url)
 		return nil, err
 	}
 	return &v, nil
@@ -64,18 +99,20 @@ func (y YahooRepository) GetPlayerResourceStats(ctx context.Context, client *htt
 
 // GetGameResourcesPlayers
 func (s *YahooRepository) GetGameResourcesPlayers(ctx context.Context, client *http.Client, gameKey int, start, count int) (yahoo.GameResourcePlayerResponse, error) {
-	client.Transport = otelhttp.NewTransport(http.DefaultTransport)
-
 	ctx, span := s.tracer.Start(ctx, "GetGameResourcesPlayers")
 	span.SetAttributes(label.String("game_id", strconv.Itoa(gameKey)), label.Int64("offset", int64(count)), label.Int64("start", int64(start)))
 	defer span.End()
 
 	v := yahoo.GameResourcePlayerResponse{}
-	url := fmt.Sprintf("https://fantasysports.yahooapis.com/fantasy/v2/game/%d/fdr-players-import/stats?start=%d&count=%d", gameKey, start, count)
+	url := fmt.Sprintf("%s/game/%d/players%s/stats", s.baseURL, gameKey, yahoo.NewOptions().Start(start).Count(count))
 	req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
 
 	res, err := client.Do(req)
 
+	if err != nil {
+		return v, err
+	}
+	err = yahoo.CheckResponse(res)
 	if err != nil {
 		return v, err
 	}
@@ -89,7 +126,7 @@ func (s *YahooRepository) GetGameResourcesPlayers(ctx context.Context, client *h
 	// transform response to games
 	err = xml.Unmarshal(bytes, &v)
 	if err != nil {
-		fmt.Printf("error: %v", err)
+		level.Error(s.logger).Log("message", "could not unmarshal yahoo response", "error", err, "url", url)
 		return v, err
 	}
 	return v, nil
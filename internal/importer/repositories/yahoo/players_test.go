@@ -1,3 +1,52 @@
 package yahoo
 
+import (
+	"context"
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/thethan/fdr-users/internal/test_helpers"
+	"github.com/thethan/fdr-users/pkg/yahoo/yahootest"
+	"golang.org/x/oauth2"
+	"testing"
+)
 
+// fixtureTokens holds the fake's token for every user
+type fixtureTokens struct{}
+
+func (fixtureTokens) GetUserOAuthToken(ctx context.Context, guid string) (oauth2.Token, error) {
+	return oauth2.Token{AccessToken: yahootest.Token}, nil
+}
+
+func (fixtureTokens) SaveOauthToken(ctx context.Context, guid string, token oauth2.Token) error {
+	return nil
+}
+
+func fixtureRepository(t *testing.T) (YahooRepository, *yahootest.Server) {
+	server := yahootest.NewServer(t, yahootest.Fixtures())
+	repo := NewYahooRepository(log.NewNopLogger(), &oauth2.Config{}, test_helpers.TestingTracer(), nil).WithBaseURL(server.URL)
+	return repo, server
+}
+
+func TestYahooRepository_GetGameResourcesPlayers(t *testing.T) {
+	repo, server := fixtureRepository(t)
+	ctx := context.Background()
+	client := repo.Client(ctx, fixtureTokens{}, "guid-manager-1")
+
+	res, err := repo.GetGameResourcesPlayers(ctx, client, 399, 0, 25)
+	assert.Nil(t, err)
+	assert.Len(t, res.Game.Players.Player, 3)
+	assert.Equal(t, []string{"/game/399/players;start=0;count=25/stats"}, server.Requests())
+}
+
+func TestYahooRepository_GetPlayerResourceStats(t *testing.T) {
+	repo, server := fixtureRepository(t)
+	ctx := context.Background()
+	client := repo.Client(ctx, fixtureTokens{}, "guid-manager-1")
+
+	_, err := repo.GetPlayerResourceStats(ctx, client, "399.p.30123", "1")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"/player/399.p.30123/stats;type=week;week=1"}, server.Requests())
+
+	_, err = repo.GetPlayerResourceStats(ctx, client, "399.p.0", "1")
+	assert.Error(t, err)
+}
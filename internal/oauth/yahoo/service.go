@@ -18,6 +18,7 @@ type Service struct {
 	tracer          otel.Tracer
 	oauthConfig     *oauth2.Config
 	oauthRepository OauthRepository
+	client          *http.Client
 }
 
 func NewOauthYahooService(logger log.Logger, tracer otel.Tracer, oauthConfig *oauth2.Config, repo OauthRepository) Service {
@@ -29,6 +30,12 @@ func NewOauthYahooService(logger log.Logger, tracer otel.Tracer, oauthConfig *oa
 	}
 }
 
+// WithTransport exchanges codes for tokens through transport
+func (service Service) WithTransport(transport http.RoundTripper) Service {
+	service.client = &http.Client{Transport: transport}
+	return service
+}
+
 type OauthRepository interface {
 	SaveOauthToken(ctx context.Context, uuid string, token oauth2.Token) error
 }
@@ -80,6 +87,9 @@ func (service Service) getUserInfo(ctx context.Context, logger log.Logger, state
 	if state == "" {
 		return nil, fmt.Errorf("invalid OauthConfig state")
 	}
+	if service.client != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, service.client)
+	}
 	token, err := service.oauthConfig.Exchange(ctx, code)
 	if err != nil {
 		return nil, fmt.Errorf("code exchange failed: %s", err.Error())
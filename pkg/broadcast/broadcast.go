@@ -0,0 +1,211 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"github.com/thethan/fdr-users/pkg/draft/stream"
+	"go.elastic.co/apm"
+	"time"
+)
+
+// DefaultMaxEvents is how many events a backend keeps on each league's draft channel before trimming the oldest.
+// Clients only resume from the channel after a dropped connection, the whole draft is in the EventLog.
+const DefaultMaxEvents = 1000
+
+// Publisher sends a message to everyone following a league's draft room. Implementations publish on
+// entities.DraftChannel(leagueKey) with the message JSON encoded as the body.
+type Publisher interface {
+	Publish(ctx context.Context, leagueKey string, message entities.BroadcastDraftResult) error
+}
+
+// Backend is a publisher the streaming gateway can also read a league's draft room from
+type Backend interface {
+	Publisher
+	stream.Source
+}
+
+// EventLog keeps every draft room message for the draft timeline and replay, whichever backend delivered it
+type EventLog interface {
+	AppendDraftEvent(ctx context.Context, event entities.DraftEvent) error
+}
+
+// Repository builds the draft room messages for the draft service and hands them to a backend
+type Repository struct {
+	logger    log.Logger
+	publisher Publisher
+	eventLog  EventLog
+}
+
+func NewRepository(logger log.Logger, publisher Publisher) Repository {
+	return Repository{
+		logger:    logger,
+		publisher: publisher,
+	}
+}
+
+// WithEventLog appends every message the repository publishes to eventLog
+func (r *Repository) WithEventLog(eventLog EventLog) {
+	r.eventLog = eventLog
+}
+
+func (r *Repository) BroadCastDraftResult(ctx context.Context, league entities.League, user entities.User, team entities.Team, draftResult entities.DraftResult, pick, round int, rosters map[string]entities.Roster) error {
+	span, ctx := apm.StartSpan(ctx, "BroadCastDraftResult", "broadcast")
+	defer span.End()
+
+	level.Debug(r.logger).Log("message", "broadcasting draft result", "pick", pick, "round", round, "league_key", league.LeagueKey)
+	return r.publish(ctx, league, entities.BroadcastDraftResult{
+		Type:        entities.BroadCastTypePlayerDrafted,
+		Message:     "Player has been drafted",
+		Team:        team,
+		League:      league,
+		User:        user,
+		DraftResult: draftResult,
+		Rosters:     rosters,
+	})
+}
+
+// BroadCastDraftCorrection tells the draft room a commissioner changed the board, with the rebuilt rosters
+func (r *Repository) BroadCastDraftCorrection(ctx context.Context, league entities.League, user entities.User, correction entities.DraftCorrection, rosters map[string]entities.Roster) error {
+	span, ctx := apm.StartSpan(ctx, "BroadCastDraftCorrection", "broadcast")
+	defer span.End()
+
+	return r.publish(ctx, league, entities.BroadcastDraftResult{
+		Type:       entities.BroadCastTypePickCorrected,
+		Message:    fmt.Sprintf("Pick %d corrected by commissioner", correction.Pick),
+		League:     league,
+		User:       user,
+		Rosters:    rosters,
+		Correction: &correction,
+	})
+}
+
+// BroadCastAuction sends nominations, bids and sales of an auction draft. draftResult and rosters are only set for a sale.
+func (r *Repository) BroadCastAuction(ctx context.Context, league entities.League, broadcastType entities.BroadcastType, lot entities.AuctionLot, draftResult *entities.DraftResult, rosters map[string]entities.Roster) error {
+	span, ctx := apm.StartSpan(ctx, "BroadCastAuction", "broadcast")
+	defer span.End()
+
+	message := entities.BroadcastDraftResult{
+		Type:    broadcastType,
+		Message: fmt.Sprintf("%s bid %d on %s", lot.HighBid.TeamKey, lot.HighBid.Amount, lot.PlayerKey),
+		League:  league,
+		Rosters: rosters,
+		Auction: &lot,
+	}
+	if draftResult != nil {
+		message.Message = fmt.Sprintf("%s sold to %s for %d", lot.PlayerKey, draftResult.TeamKey, draftResult.Cost)
+		message.DraftResult = *draftResult
+	}
+	if broadcastType == entities.BroadCastTypeLotUnsold {
+		message.Message = fmt.Sprintf("%s was not sold, %s nominates again", lot.PlayerKey, lot.NominatedBy)
+	}
+	return r.publish(ctx, league, message)
+}
+
+// BroadCastDraftGrades sends every team's grade once the draft completes
+func (r *Repository) BroadCastDraftGrades(ctx context.Context, league entities.League, report entities.DraftReport) error {
+	span, ctx := apm.StartSpan(ctx, "BroadCastDraftGrades", "broadcast")
+	defer span.End()
+
+	return r.publish(ctx, league, entities.BroadcastDraftResult{
+		Type:    entities.BroadCastTypeDraftGraded,
+		Message: "draft is graded",
+		League:  league,
+		Grades:  &report,
+	})
+}
+
+// BroadCastChatMessage sends a chat message to the draft room. Deletions and reactions send the whole message again.
+func (r *Repository) BroadCastChatMessage(ctx context.Context, league entities.League, message entities.ChatMessage) error {
+	span, ctx := apm.StartSpan(ctx, "BroadCastChatMessage", "broadcast")
+	defer span.End()
+
+	return r.publish(ctx, league, entities.BroadcastDraftResult{
+		Type:    entities.BroadCastTypeChatMessage,
+		Message: message.Text,
+		League:  league,
+		User:    entities.User{Guid: message.UserGUID, Nickname: message.Nickname},
+		Chat:    &message,
+	})
+}
+
+// BroadCastLotteryDraw reveals one slot of a draft lottery
+func (r *Repository) BroadCastLotteryDraw(ctx context.Context, league entities.League, step entities.LotteryStep) error {
+	span, ctx := apm.StartSpan(ctx, "BroadCastLotteryDraw", "broadcast")
+	defer span.End()
+
+	return r.publish(ctx, league, entities.BroadcastDraftResult{
+		Type:    entities.BroadCastTypeLotteryDraw,
+		Message: fmt.Sprintf("%s drew slot %d", step.TeamKey, step.Slot),
+		League:  league,
+		Team:    entities.Team{TeamKey: step.TeamKey},
+		Lottery: &step,
+	})
+}
+
+// BroadCastPresence tells the draft room a manager joined or left it
+func (r *Repository) BroadCastPresence(ctx context.Context, league entities.League, broadcastType entities.BroadcastType, user entities.User, team entities.Team) error {
+	span, ctx := apm.StartSpan(ctx, "BroadCastPresence", "broadcast")
+	defer span.End()
+
+	message := user.Nickname + " joined the draft room"
+	if broadcastType == entities.BroadCastTypeManagerLeft {
+		message = user.Nickname + " left the draft room"
+	}
+	return r.publish(ctx, league, entities.BroadcastDraftResult{
+		Type:    broadcastType,
+		Message: message,
+		League:  league,
+		User:    user,
+		Team:    team,
+	})
+}
+
+func (r *Repository) BroadCastLeagueInformation(ctx context.Context, league entities.League, message string, broadcastType entities.BroadcastType) error {
+	span, ctx := apm.StartSpan(ctx, "BroadCastLeagueInformation", "broadcast")
+	defer span.End()
+
+	return r.publish(ctx, league, entities.BroadcastDraftResult{
+		Type:    broadcastType,
+		League:  league,
+		Message: message,
+	})
+}
+
+func (r *Repository) ChangeTeamName(ctx context.Context, league entities.League, user entities.User, team entities.Team) error {
+	return errors.New("not yet implemented")
+}
+
+func (r *Repository) publish(ctx context.Context, league entities.League, message entities.BroadcastDraftResult) error {
+	err := r.publisher.Publish(ctx, league.LeagueKey, message)
+	if err != nil {
+		level.Error(r.logger).Log("message", "could not publish broadcast", "error", err, "league_key", league.LeagueKey, "type", message.Type, "channel_name", entities.DraftChannel(league.LeagueKey))
+	}
+	r.logEvent(ctx, message)
+	return err
+}
+
+// logEvent appends the message to the event log. The draft already moved on when it is broadcast, so a message
+// missing from the log only costs the timeline an event and is not an error for the caller.
+func (r *Repository) logEvent(ctx context.Context, message entities.BroadcastDraftResult) {
+	if r.eventLog == nil || !isDraftEvent(message.Type) {
+		return
+	}
+	err := r.eventLog.AppendDraftEvent(ctx, entities.NewDraftEvent(message, time.Now()))
+	if err != nil {
+		level.Error(r.logger).Log("message", "could not log draft event", "error", err, "league_key", message.League.LeagueKey, "type", message.Type)
+	}
+}
+
+// isDraftEvent leaves out the messages that do not change the draft. Chat has its own history, presence is
+// only about who is connected right now, and a lottery is saved whole with the draft order it drew.
+func isDraftEvent(broadcastType entities.BroadcastType) bool {
+	switch broadcastType {
+	case entities.BroadCastTypeChatMessage, entities.BroadCastTypeManagerJoined, entities.BroadCastTypeManagerLeft, entities.BroadCastTypeLotteryDraw:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,74 @@
+package broadcast_test
+
+import (
+	"context"
+	"errors"
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/thethan/fdr-users/pkg/broadcast"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"testing"
+)
+
+type fakeEventLog struct {
+	events []entities.DraftEvent
+	err    error
+}
+
+func (f *fakeEventLog) AppendDraftEvent(ctx context.Context, event entities.DraftEvent) error {
+	f.events = append(f.events, event)
+	return f.err
+}
+
+type failingPublisher struct{}
+
+func (failingPublisher) Publish(ctx context.Context, leagueKey string, message entities.BroadcastDraftResult) error {
+	return errors.New("backend is down")
+}
+
+func TestRepository_WithEventLog(t *testing.T) {
+	ctx := context.Background()
+	eventLog := &fakeEventLog{}
+	repository := broadcast.NewRepository(log.NewNopLogger(), broadcast.NewMemory())
+	repository.WithEventLog(eventLog)
+
+	league := entities.League{LeagueKey: "399.l.1", DraftOrder: []string{"399.l.1.t.2", "399.l.1.t.1"}, CurrentPick: 2}
+	league.SetState(entities.DraftStateOpen)
+	result := entities.DraftResult{PlayerKey: "399.p.1", TeamKey: "399.l.1.t.2", Pick: 1, Round: 1, League: league}
+	assert.Nil(t, repository.BroadCastLeagueInformation(ctx, league, "league is opened", entities.BroadCastTypeDraftOpen))
+	assert.Nil(t, repository.BroadCastDraftResult(ctx, league, entities.User{Guid: "manager-2"}, entities.Team{TeamKey: result.TeamKey}, result, 1, 1, nil))
+	assert.Nil(t, repository.BroadCastDraftCorrection(ctx, league, entities.User{Guid: "commish"}, entities.DraftCorrection{Action: entities.CorrectionUndo, Pick: 1, Previous: &result}, nil))
+
+	if !assert.Len(t, eventLog.events, 3) {
+		return
+	}
+	opened, drafted, corrected := eventLog.events[0], eventLog.events[1], eventLog.events[2]
+	assert.Equal(t, league.DraftOrder, opened.DraftOrder)
+	assert.Equal(t, entities.DraftStateOpen, opened.State)
+	assert.Nil(t, opened.DraftResult)
+
+	assert.Equal(t, entities.BroadCastTypePlayerDrafted, drafted.Type)
+	assert.Nil(t, drafted.DraftOrder, "only the events that can change the draft order keep it")
+	assert.Equal(t, "manager-2", drafted.UserGUID)
+	assert.Equal(t, 2, drafted.CurrentPick)
+	if assert.NotNil(t, drafted.DraftResult) {
+		assert.Equal(t, "399.p.1", drafted.DraftResult.PlayerKey)
+		assert.Empty(t, drafted.DraftResult.League.LeagueKey, "the league is not logged with every result")
+	}
+	if assert.NotNil(t, corrected.Correction) {
+		assert.Equal(t, entities.CorrectionUndo, corrected.Correction.Action)
+		assert.Empty(t, corrected.Correction.Previous.League.LeagueKey)
+	}
+	assert.Equal(t, "399.l.1", result.League.LeagueKey, "the broadcast result is left as it was")
+}
+
+func TestRepository_WithEventLog_LogsWhatFailedToPublish(t *testing.T) {
+	eventLog := &fakeEventLog{err: errors.New("mongo is down")}
+	repository := broadcast.NewRepository(log.NewNopLogger(), failingPublisher{})
+	repository.WithEventLog(eventLog)
+
+	league := entities.League{LeagueKey: "399.l.1"}
+	err := repository.BroadCastLeagueInformation(context.Background(), league, "draft is paused", entities.BroadCastTypeDraftPaused)
+	assert.EqualError(t, err, "backend is down", "a failed log does not hide the publish error")
+	assert.Len(t, eventLog.events, 1, "the draft changed whether or not the room heard about it")
+}
@@ -0,0 +1,278 @@
+// Package broadcasttest is the conformance suite for broadcast backends. Every backend runs it from its own
+// tests, so the draft service and the streaming gateway behave the same whichever one is configured.
+package broadcasttest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-kit/kit/log"
+	"github.com/thethan/fdr-users/pkg/broadcast"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"github.com/thethan/fdr-users/pkg/draft/stream"
+	"testing"
+	"time"
+)
+
+// Timeout is how long the suite waits for an event before failing
+var Timeout = 5 * time.Second
+
+// Run runs the suite. newBackend is called once per case; league keys are unique per case,
+// so backends sharing a server do not need to be emptied in between.
+func Run(t *testing.T, newBackend func(t *testing.T) broadcast.Backend) {
+	cases := []struct {
+		name string
+		test func(t *testing.T, backend broadcast.Backend)
+	}{
+		{"DraftRoomMessages", testDraftRoomMessages},
+		{"LeaguesAreSeparate", testLeaguesAreSeparate},
+		{"NewEventsOnly", testNewEventsOnly},
+		{"ResumeAfterSequence", testResumeAfterSequence},
+		{"EverySubscriber", testEverySubscriber},
+		{"CancelClosesSubscription", testCancelClosesSubscription},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newBackend(t))
+		})
+	}
+}
+
+// RunRetention checks a backend configured to keep maxEvents per channel trims the oldest events first and
+// still resumes subscribers from before the trim. Backends that trim approximately may keep more than
+// maxEvents, but never fewer, and maxEvents has to be large enough for them to trim at all.
+func RunRetention(t *testing.T, backend broadcast.Backend, maxEvents int) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	league := leagueKey()
+	published := 3 * maxEvents
+	for idx := 1; idx <= published; idx++ {
+		publish(ctx, t, backend, league, fmt.Sprintf("event %d", idx))
+	}
+
+	events := subscribe(ctx, t, backend, league, 1)
+	first := receive(t, events, 1)[0]
+	if first.Sequence <= 2 {
+		t.Fatalf("resumed from event %d, none of %d events were trimmed", first.Sequence, published)
+	}
+	kept := published - int(first.Sequence) + 1
+	if kept < maxEvents {
+		t.Errorf("kept %d events, want at least %d", kept, maxEvents)
+	}
+	if got := decode(t, first); got.Message != fmt.Sprintf("event %d", first.Sequence) {
+		t.Errorf("event %d is %q", first.Sequence, got.Message)
+	}
+	last := receive(t, events, kept-1)
+	if got := decode(t, last[len(last)-1]); got.Message != fmt.Sprintf("event %d", published) {
+		t.Errorf("the latest event is %q", got.Message)
+	}
+}
+
+func testDraftRoomMessages(t *testing.T, backend broadcast.Backend) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	league := entities.League{LeagueKey: leagueKey()}
+	events := subscribe(ctx, t, backend, league.LeagueKey, 0)
+
+	repository := broadcast.NewRepository(log.NewNopLogger(), backend)
+	result := entities.DraftResult{PlayerKey: "399.p.100", TeamKey: league.LeagueKey + ".t.1", Pick: 1, Round: 1}
+	rosters := map[string]entities.Roster{}
+	check(t, repository.BroadCastLeagueInformation(ctx, league, "league is opened", entities.BroadCastTypeDraftOpen))
+	check(t, repository.BroadCastDraftResult(ctx, league, entities.User{Guid: "user-1"}, entities.Team{TeamKey: result.TeamKey}, result, 1, 1, rosters))
+	check(t, repository.BroadCastDraftCorrection(ctx, league, entities.User{Guid: "commish"}, entities.DraftCorrection{Pick: 1}, rosters))
+	lot := entities.AuctionLot{PlayerKey: "399.p.200", HighBid: entities.Bid{TeamKey: result.TeamKey, Amount: 5}}
+	check(t, repository.BroadCastAuction(ctx, league, entities.BroadCastTypeBid, lot, nil, nil))
+	report := entities.DraftReport{LeagueKey: league.LeagueKey, Teams: []entities.TeamGrade{{Team: entities.Team{TeamKey: result.TeamKey}, Grade: "A"}}}
+	check(t, repository.BroadCastDraftGrades(ctx, league, report))
+	chat := entities.ChatMessage{ID: "chat-1", LeagueKey: league.LeagueKey, UserGUID: "user-1", Nickname: "One", Text: "nice pick"}
+	check(t, repository.BroadCastChatMessage(ctx, league, chat))
+	check(t, repository.BroadCastPresence(ctx, league, entities.BroadCastTypeManagerJoined, entities.User{Guid: "user-1", Nickname: "One"}, entities.Team{TeamKey: result.TeamKey}))
+
+	step := entities.LotteryStep{Slot: 4, TeamKey: result.TeamKey, Ticket: 2, TotalTickets: 3}
+	check(t, repository.BroadCastLotteryDraw(ctx, league, step))
+
+	received := receive(t, events, 8)
+	for idx := 1; idx < len(received); idx++ {
+		if received[idx].Sequence <= received[idx-1].Sequence {
+			t.Errorf("sequence %d follows %d", received[idx].Sequence, received[idx-1].Sequence)
+		}
+	}
+
+	want := []struct {
+		broadcastType entities.BroadcastType
+		message       string
+	}{
+		{entities.BroadCastTypeDraftOpen, "league is opened"},
+		{entities.BroadCastTypePlayerDrafted, "Player has been drafted"},
+		{entities.BroadCastTypePickCorrected, "Pick 1 corrected by commissioner"},
+		{entities.BroadCastTypeBid, fmt.Sprintf("%s bid 5 on 399.p.200", result.TeamKey)},
+		{entities.BroadCastTypeDraftGraded, "draft is graded"},
+		{entities.BroadCastTypeChatMessage, "nice pick"},
+		{entities.BroadCastTypeManagerJoined, "One joined the draft room"},
+		{entities.BroadCastTypeLotteryDraw, fmt.Sprintf("%s drew slot 4", result.TeamKey)},
+	}
+	messages := make([]entities.BroadcastDraftResult, len(received))
+	for idx, event := range received {
+		if err := json.Unmarshal(event.Data, &messages[idx]); err != nil {
+			t.Fatalf("event %d is not a draft room message: %v", event.Sequence, err)
+		}
+		if messages[idx].Type != want[idx].broadcastType || messages[idx].Message != want[idx].message {
+			t.Errorf("message %d is %d %q, want %d %q", idx, messages[idx].Type, messages[idx].Message, want[idx].broadcastType, want[idx].message)
+		}
+		if messages[idx].League.LeagueKey != league.LeagueKey {
+			t.Errorf("message %d is for league %q", idx, messages[idx].League.LeagueKey)
+		}
+	}
+	if messages[1].DraftResult.Pick != result.Pick || messages[1].Team.TeamKey != result.TeamKey || messages[1].User.Guid != "user-1" {
+		t.Errorf("draft result message lost the pick: %+v", messages[1].DraftResult)
+	}
+	if messages[2].Correction == nil || messages[2].Correction.Pick != 1 {
+		t.Errorf("correction message lost the correction: %+v", messages[2].Correction)
+	}
+	if messages[3].Auction == nil || messages[3].Auction.PlayerKey != lot.PlayerKey {
+		t.Errorf("auction message lost the lot: %+v", messages[3].Auction)
+	}
+	if messages[4].Grades == nil || len(messages[4].Grades.Teams) != 1 || messages[4].Grades.Teams[0].Grade != "A" {
+		t.Errorf("grades message lost the report: %+v", messages[4].Grades)
+	}
+	if messages[5].Chat == nil || messages[5].Chat.ID != chat.ID || messages[5].User.Guid != chat.UserGUID {
+		t.Errorf("chat message lost the chat: %+v", messages[5].Chat)
+	}
+	if messages[6].User.Guid != "user-1" || messages[6].Team.TeamKey != result.TeamKey {
+		t.Errorf("presence message lost the manager: %+v %+v", messages[6].User, messages[6].Team)
+	}
+	if messages[7].Lottery == nil || messages[7].Lottery.Slot != step.Slot || messages[7].Lottery.TeamKey != step.TeamKey {
+		t.Errorf("lottery message lost the draw: %+v", messages[7].Lottery)
+	}
+}
+
+func testLeaguesAreSeparate(t *testing.T, backend broadcast.Backend) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	league, other := leagueKey(), leagueKey()
+	events := subscribe(ctx, t, backend, league, 0)
+
+	publish(ctx, t, backend, other, "other league")
+	publish(ctx, t, backend, league, "this league")
+
+	if got := decode(t, receive(t, events, 1)[0]); got.Message != "this league" {
+		t.Errorf("received %q from another league", got.Message)
+	}
+}
+
+func testNewEventsOnly(t *testing.T, backend broadcast.Backend) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	league := leagueKey()
+	publish(ctx, t, backend, league, "before")
+	events := subscribe(ctx, t, backend, league, 0)
+	publish(ctx, t, backend, league, "after")
+
+	if got := decode(t, receive(t, events, 1)[0]); got.Message != "after" {
+		t.Errorf("a subscription from zero received %q, published before it started", got.Message)
+	}
+}
+
+func testResumeAfterSequence(t *testing.T, backend broadcast.Backend) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	league := leagueKey()
+	events := subscribe(ctx, t, backend, league, 0)
+	for idx := 1; idx <= 3; idx++ {
+		publish(ctx, t, backend, league, fmt.Sprintf("event %d", idx))
+	}
+	first := receive(t, events, 3)
+
+	resumed := receive(t, subscribe(ctx, t, backend, league, first[0].Sequence), 2)
+	for idx, event := range resumed {
+		if event.Sequence != first[idx+1].Sequence || string(event.Data) != string(first[idx+1].Data) {
+			t.Errorf("resumed event %d is %d %s, want %d %s", idx, event.Sequence, event.Data, first[idx+1].Sequence, first[idx+1].Data)
+		}
+	}
+}
+
+func testEverySubscriber(t *testing.T, backend broadcast.Backend) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	league := leagueKey()
+	first := subscribe(ctx, t, backend, league, 0)
+	second := subscribe(ctx, t, backend, league, 0)
+	publish(ctx, t, backend, league, "everyone")
+
+	for _, events := range []<-chan stream.Event{first, second} {
+		if got := decode(t, receive(t, events, 1)[0]); got.Message != "everyone" {
+			t.Errorf("subscriber received %q", got.Message)
+		}
+	}
+}
+
+func testCancelClosesSubscription(t *testing.T, backend broadcast.Backend) {
+	ctx, cancel := context.WithCancel(context.Background())
+	events := subscribe(ctx, t, backend, leagueKey(), 0)
+	cancel()
+
+	deadline := time.After(Timeout)
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+		case <-deadline:
+			t.Fatal("subscription was not closed after its context was cancelled")
+		}
+	}
+}
+
+func leagueKey() string {
+	return fmt.Sprintf("399.l.%d", time.Now().UnixNano())
+}
+
+func subscribe(ctx context.Context, t *testing.T, backend broadcast.Backend, leagueKey string, after uint64) <-chan stream.Event {
+	t.Helper()
+	events, err := backend.Subscribe(ctx, leagueKey, after)
+	if err != nil {
+		t.Fatalf("could not subscribe to %s: %v", leagueKey, err)
+	}
+	return events
+}
+
+func publish(ctx context.Context, t *testing.T, backend broadcast.Backend, leagueKey, message string) {
+	t.Helper()
+	league := entities.League{LeagueKey: leagueKey}
+	check(t, backend.Publish(ctx, leagueKey, entities.BroadcastDraftResult{League: league, Message: message}))
+}
+
+func receive(t *testing.T, events <-chan stream.Event, count int) []stream.Event {
+	t.Helper()
+	received := make([]stream.Event, 0, count)
+	deadline := time.After(Timeout)
+	for len(received) < count {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				t.Fatalf("subscription closed after %d of %d events", len(received), count)
+			}
+			received = append(received, event)
+		case <-deadline:
+			t.Fatalf("received %d of %d events", len(received), count)
+		}
+	}
+	return received
+}
+
+func decode(t *testing.T, event stream.Event) entities.BroadcastDraftResult {
+	t.Helper()
+	var message entities.BroadcastDraftResult
+	if err := json.Unmarshal(event.Data, &message); err != nil {
+		t.Fatalf("event %d is not a draft room message: %v", event.Sequence, err)
+	}
+	return message
+}
+
+func check(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,115 @@
+package broadcast
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"github.com/thethan/fdr-users/pkg/draft/stream"
+	"sync"
+)
+
+// Memory is a backend that keeps every channel in this process. It needs no infrastructure, so it
+// suits local development and tests, but only clients of this instance see its broadcasts.
+type Memory struct {
+	mu       *sync.Mutex
+	channels map[string]*memoryChannel
+	// MaxEvents is how many events each channel keeps
+	MaxEvents int
+}
+
+// memoryChannel is one draft channel's history, without the trimmed events before it. notify is closed
+// and replaced on every publish so subscribers waiting for the next event wake up.
+type memoryChannel struct {
+	events  []stream.Event
+	trimmed uint64
+	notify  chan struct{}
+}
+
+func NewMemory() *Memory {
+	return &Memory{mu: &sync.Mutex{}, channels: make(map[string]*memoryChannel), MaxEvents: DefaultMaxEvents}
+}
+
+var _ Backend = &Memory{}
+
+func (m *Memory) Publish(ctx context.Context, leagueKey string, message entities.BroadcastDraftResult) error {
+	data, err := json.Marshal(&message)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	channel := m.channel(entities.DraftChannel(leagueKey))
+	channel.events = append(channel.events, stream.Event{Sequence: channel.last() + 1, Data: data})
+	if len(channel.events) > m.MaxEvents {
+		trim := len(channel.events) - m.MaxEvents
+		channel.events = channel.events[trim:]
+		channel.trimmed += uint64(trim)
+	}
+	close(channel.notify)
+	channel.notify = make(chan struct{})
+	return nil
+}
+
+// Subscribe never blocks Publish: each subscriber reads the channel's history at its own pace.
+// A subscriber that falls behind the trimmed events carries on from the oldest event left.
+func (m *Memory) Subscribe(ctx context.Context, leagueKey string, after uint64) (<-chan stream.Event, error) {
+	m.mu.Lock()
+	channel := m.channel(entities.DraftChannel(leagueKey))
+	if after == 0 {
+		after = channel.last()
+	}
+	m.mu.Unlock()
+
+	events := make(chan stream.Event)
+	go func() {
+		defer close(events)
+		next := after
+		for {
+			m.mu.Lock()
+			var pending []stream.Event
+			if next < channel.last() {
+				start := uint64(0)
+				if next > channel.trimmed {
+					start = next - channel.trimmed
+				}
+				pending = channel.events[start:]
+			}
+			notify := channel.notify
+			m.mu.Unlock()
+
+			for _, event := range pending {
+				select {
+				case events <- event:
+					next = event.Sequence
+				case <-ctx.Done():
+					return
+				}
+			}
+			if len(pending) > 0 {
+				continue
+			}
+			select {
+			case <-notify:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
+
+// last is the sequence of the latest event, it must be called with mu held
+func (c *memoryChannel) last() uint64 {
+	return c.trimmed + uint64(len(c.events))
+}
+
+// channel must be called with mu held
+func (m *Memory) channel(name string) *memoryChannel {
+	channel, ok := m.channels[name]
+	if !ok {
+		channel = &memoryChannel{notify: make(chan struct{})}
+		m.channels[name] = channel
+	}
+	return channel
+}
@@ -0,0 +1,19 @@
+package broadcast_test
+
+import (
+	"github.com/thethan/fdr-users/pkg/broadcast"
+	"github.com/thethan/fdr-users/pkg/broadcast/broadcasttest"
+	"testing"
+)
+
+func TestMemory(t *testing.T) {
+	broadcasttest.Run(t, func(t *testing.T) broadcast.Backend {
+		return broadcast.NewMemory()
+	})
+}
+
+func TestMemory_Retention(t *testing.T) {
+	backend := broadcast.NewMemory()
+	backend.MaxEvents = 10
+	broadcasttest.RunRetention(t, backend, backend.MaxEvents)
+}
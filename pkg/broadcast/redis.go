@@ -0,0 +1,123 @@
+package broadcast
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/go-redis/redis/v7"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"github.com/thethan/fdr-users/pkg/draft/stream"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultRedisBlock is how long a subscriber waits on XREAD before checking whether it was cancelled
+const DefaultRedisBlock = 5 * time.Second
+
+// publishScript numbers the league's events and appends the message under the stream id 0-<sequence>,
+// so the stream id is the sequence clients resume from. Both happen in the script to keep concurrent
+// publishers from adding ids out of order. The stream is trimmed to about ARGV[2] entries as it goes.
+var publishScript = redis.NewScript(`
+local sequence = redis.call('INCR', KEYS[2])
+redis.call('XADD', KEYS[1], 'MAXLEN', '~', ARGV[2], '0-' .. sequence, 'data', ARGV[1])
+return sequence
+`)
+
+// Redis is a backend on Redis Streams. Every league's draft channel is a stream keyed by its channel name.
+type Redis struct {
+	logger log.Logger
+	client *redis.Client
+	// Block is how long one XREAD waits for new events
+	Block time.Duration
+	// MaxEvents is roughly how many events each stream keeps. Redis trims whole nodes of the stream,
+	// so it can keep a few more.
+	MaxEvents int
+}
+
+func NewRedis(logger log.Logger, client *redis.Client) *Redis {
+	return &Redis{logger: logger, client: client, Block: DefaultRedisBlock, MaxEvents: DefaultMaxEvents}
+}
+
+var _ Backend = &Redis{}
+
+func (r *Redis) Publish(ctx context.Context, leagueKey string, message entities.BroadcastDraftResult) error {
+	data, err := json.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	channelName := entities.DraftChannel(leagueKey)
+	return publishScript.Run(r.client.WithContext(ctx), []string{channelName, sequenceKey(channelName)}, data, r.MaxEvents).Err()
+}
+
+func (r *Redis) Subscribe(ctx context.Context, leagueKey string, after uint64) (<-chan stream.Event, error) {
+	channelName := entities.DraftChannel(leagueKey)
+	client := r.client.WithContext(ctx)
+
+	// start after whatever is already in the stream, not at the first XREAD, so nothing
+	// published between now and then is missed
+	lastID := streamID(after)
+	if after == 0 {
+		latest, err := client.XRevRangeN(channelName, "+", "-", 1).Result()
+		if err != nil {
+			level.Error(r.logger).Log("message", "could not read draft stream", "error", err, "channel_name", channelName)
+			return nil, err
+		}
+		if len(latest) > 0 {
+			lastID = latest[0].ID
+		}
+	}
+
+	events := make(chan stream.Event)
+	go func() {
+		defer close(events)
+		for ctx.Err() == nil {
+			streams, err := client.XRead(&redis.XReadArgs{Streams: []string{channelName, lastID}, Block: r.Block}).Result()
+			if err == redis.Nil {
+				continue
+			}
+			if err != nil {
+				if ctx.Err() == nil {
+					level.Error(r.logger).Log("message", "draft stream subscription failed", "error", err, "channel_name", channelName)
+				}
+				return
+			}
+			for _, message := range streams[0].Messages {
+				lastID = message.ID
+				event, err := streamEvent(message)
+				if err != nil {
+					level.Error(r.logger).Log("message", "skipping malformed draft stream entry", "error", err, "channel_name", channelName, "id", message.ID)
+					continue
+				}
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return events, nil
+}
+
+func sequenceKey(channelName string) string {
+	return channelName + ":sequence"
+}
+
+func streamID(sequence uint64) string {
+	return fmt.Sprintf("0-%d", sequence)
+}
+
+func streamEvent(message redis.XMessage) (stream.Event, error) {
+	sequence, err := strconv.ParseUint(strings.TrimPrefix(message.ID, "0-"), 10, 64)
+	if err != nil {
+		return stream.Event{}, err
+	}
+	data, ok := message.Values["data"].(string)
+	if !ok {
+		return stream.Event{}, fmt.Errorf("entry %s has no data", message.ID)
+	}
+	return stream.Event{Sequence: sequence, Data: []byte(data)}, nil
+}
@@ -0,0 +1,57 @@
+package broadcast_test
+
+import (
+	"context"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-kit/kit/log"
+	"github.com/go-redis/redis/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/thethan/fdr-users/pkg/broadcast"
+	"github.com/thethan/fdr-users/pkg/broadcast/broadcasttest"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"testing"
+	"time"
+)
+
+func newRedis(t *testing.T) (*broadcast.Redis, *miniredis.Miniredis) {
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(server.Close)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	backend := broadcast.NewRedis(log.NewNopLogger(), client)
+	backend.Block = 50 * time.Millisecond
+	return backend, server
+}
+
+func TestRedis(t *testing.T) {
+	broadcasttest.Run(t, func(t *testing.T) broadcast.Backend {
+		backend, _ := newRedis(t)
+		return backend
+	})
+}
+
+func TestRedis_Retention(t *testing.T) {
+	backend, _ := newRedis(t)
+	backend.MaxEvents = 100
+	broadcasttest.RunRetention(t, backend, backend.MaxEvents)
+}
+
+func TestRedis_Publish(t *testing.T) {
+	backend, server := newRedis(t)
+	ctx := context.Background()
+	for idx := 0; idx < 2; idx++ {
+		assert.Nil(t, backend.Publish(ctx, "399.l.1", entities.BroadcastDraftResult{Message: "league is opened"}))
+	}
+
+	entries, err := server.Stream(entities.DraftChannel("399.l.1"))
+	assert.Nil(t, err)
+	if assert.Len(t, entries, 2, "published on the draft channel") {
+		assert.Equal(t, "0-1", entries[0].ID)
+		assert.Equal(t, "0-2", entries[1].ID)
+		assert.Contains(t, entries[1].Values[1], `"message":"league is opened"`)
+	}
+}
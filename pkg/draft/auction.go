@@ -0,0 +1,406 @@
+package draft
+
+import (
+	"context"
+	"github.com/go-kit/kit/log/level"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"go.elastic.co/apm"
+	"sync"
+	"time"
+)
+
+// auctionHouse keeps the player on the block for every auction league. Like the pick clock it lives
+// in memory; a lot is persisted only once it sells, as a draft result carrying its cost.
+type auctionHouse struct {
+	mu     *sync.Mutex
+	blocks map[string]*auctionBlock
+}
+
+type auctionBlock struct {
+	lot entities.AuctionLot
+	// bids counts bids on the lot so a timer that fired just before a new bid does not sell the player
+	bids   int
+	timer  *time.Timer
+	onSold func(leagueKey string, lot entities.AuctionLot)
+	// frozen holds the lot while the draft is paused, with the bid time it had left
+	frozen    bool
+	remaining time.Duration
+}
+
+func newAuctionHouse() *auctionHouse {
+	return &auctionHouse{
+		mu:     &sync.Mutex{},
+		blocks: make(map[string]*auctionBlock),
+	}
+}
+
+// nominate puts lot on the block unless another player is already up for bid
+func (a *auctionHouse) nominate(leagueKey string, lot entities.AuctionLot, duration time.Duration, onSold func(leagueKey string, lot entities.AuctionLot)) (entities.AuctionLot, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.blocks[leagueKey]; ok {
+		return entities.AuctionLot{}, false
+	}
+	block := &auctionBlock{lot: lot, onSold: onSold}
+	a.blocks[leagueKey] = block
+	a.restartTimer(leagueKey, block, duration)
+	return block.lot, true
+}
+
+// bid raises the high bid on the block and gives everyone the full bid timer again
+func (a *auctionHouse) bid(leagueKey string, bid entities.Bid, duration time.Duration) (entities.AuctionLot, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	block, ok := a.blocks[leagueKey]
+	if !ok {
+		return entities.AuctionLot{}, &ErrorNoLot{}
+	}
+	if bid.Amount <= block.lot.HighBid.Amount {
+		return entities.AuctionLot{}, &ErrorBidTooLow{amount: bid.Amount, highBid: block.lot.HighBid.Amount}
+	}
+	block.lot.HighBid = bid
+	block.bids++
+	a.restartTimer(leagueKey, block, duration)
+	return block.lot, nil
+}
+
+func (a *auctionHouse) restartTimer(leagueKey string, block *auctionBlock, duration time.Duration) {
+	if block.timer != nil {
+		block.timer.Stop()
+	}
+	bids := block.bids
+	block.lot.BidExpiresAt = time.Now().Add(duration)
+	block.timer = time.AfterFunc(duration, func() {
+		if lot, ok := a.sold(leagueKey, bids); ok {
+			block.onSold(leagueKey, lot)
+		}
+	})
+}
+
+// freeze stops the bid timer of a paused draft. The lot stays on the block and keeps the time it had left.
+func (a *auctionHouse) freeze(leagueKey string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	block, ok := a.blocks[leagueKey]
+	if !ok || block.frozen {
+		return
+	}
+	block.timer.Stop()
+	block.frozen = true
+	block.remaining = time.Until(block.lot.BidExpiresAt)
+	if block.remaining < 0 {
+		block.remaining = 0
+	}
+}
+
+// thaw restarts the bid timer of a resumed draft with the time the lot had left when it was frozen
+func (a *auctionHouse) thaw(leagueKey string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	block, ok := a.blocks[leagueKey]
+	if !ok || !block.frozen {
+		return
+	}
+	block.frozen = false
+	a.restartTimer(leagueKey, block, block.remaining)
+}
+
+// cancel takes the lot off the block without selling it
+func (a *auctionHouse) cancel(leagueKey string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	block, ok := a.blocks[leagueKey]
+	if !ok {
+		return
+	}
+	block.timer.Stop()
+	delete(a.blocks, leagueKey)
+}
+
+// sold takes the lot off the block when no bid has come in since the timer that fired was started
+// and the draft has not been paused since
+func (a *auctionHouse) sold(leagueKey string, bids int) (entities.AuctionLot, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	block, ok := a.blocks[leagueKey]
+	if !ok || block.frozen || block.bids != bids {
+		return entities.AuctionLot{}, false
+	}
+	delete(a.blocks, leagueKey)
+	return block.lot, true
+}
+
+func (a *auctionHouse) current(leagueKey string) (entities.AuctionLot, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	block, ok := a.blocks[leagueKey]
+	if !ok {
+		return entities.AuctionLot{}, false
+	}
+	return block.lot, true
+}
+
+// Nominate puts a player up for bid. Only the team whose turn it is to nominate can do so, and its
+// opening bid counts as the first bid.
+func (s *Service) Nominate(ctx context.Context, leagueKey, teamKey, playerKey string, amount int) (*entities.AuctionLot, error) {
+	span, ctx := apm.StartSpan(ctx, "Nominate", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	span.Context.SetLabel("team_key", teamKey)
+	defer span.End()
+
+	league, results, err := s.auctionDraft(ctx, leagueKey, teamKey, "nominate")
+	if err != nil {
+		return nil, err
+	}
+	budgets := auctionBudgets(league, results)
+	if nominating := nominatingTeam(league, budgets); nominating != teamKey {
+		return nil, &ErrorNotNominatingTeam{teamKey: teamKey, nominatingTeam: nominating}
+	}
+	for _, result := range results {
+		if result.PlayerKey == playerKey {
+			return nil, &ErrorPlayerAlreadyDrafted{playerKey: playerKey}
+		}
+	}
+	err = validateBid(league, budgets, teamKey, amount)
+	if err != nil {
+		return nil, err
+	}
+	player, err := s.getPlayer(ctx, playerKey)
+	if err != nil {
+		return nil, err
+	}
+
+	lot := entities.AuctionLot{
+		Pick:        league.CurrentPick,
+		PlayerKey:   player.PlayerKey,
+		Player:      player,
+		NominatedBy: teamKey,
+		HighBid:     s.newBid(ctx, teamKey, amount),
+	}
+	lot, ok := s.auctions.nominate(leagueKey, lot, league.DraftSettings.Auction.BidDuration(), s.sellLot)
+	if !ok {
+		return nil, &ErrorLotInProgress{}
+	}
+
+	err = s.broadCastRepo.BroadCastAuction(ctx, league, entities.BroadCastTypeNomination, lot, nil, nil)
+	if err != nil {
+		level.Error(s.logger).Log("message", "could not broadcast nomination", "error", err, "league_key", leagueKey, "player_key", playerKey)
+	}
+	return &lot, nil
+}
+
+// Bid raises the high bid on the player on the block for a team
+func (s *Service) Bid(ctx context.Context, leagueKey, teamKey string, amount int) (*entities.AuctionLot, error) {
+	span, ctx := apm.StartSpan(ctx, "Bid", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	span.Context.SetLabel("team_key", teamKey)
+	defer span.End()
+
+	league, results, err := s.auctionDraft(ctx, leagueKey, teamKey, "bid")
+	if err != nil {
+		return nil, err
+	}
+	err = validateBid(league, auctionBudgets(league, results), teamKey, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	lot, err := s.auctions.bid(leagueKey, s.newBid(ctx, teamKey, amount), league.DraftSettings.Auction.BidDuration())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.broadCastRepo.BroadCastAuction(ctx, league, entities.BroadCastTypeBid, lot, nil, nil)
+	if err != nil {
+		level.Error(s.logger).Log("message", "could not broadcast bid", "error", err, "league_key", leagueKey, "team_key", teamKey)
+	}
+	return &lot, nil
+}
+
+// GetAuction returns the player on the block, who nominates next and every team's budget
+func (s *Service) GetAuction(ctx context.Context, leagueKey string) (*entities.Auction, error) {
+	span, ctx := apm.StartSpan(ctx, "GetAuction", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	defer span.End()
+
+	league, err := s.draftRepo.GetLeague(ctx, leagueKey)
+	if err != nil {
+		level.Error(s.logger).Log("message", "could not get league", "error", err, "league_key", leagueKey)
+		return nil, err
+	}
+	if !league.IsAuction() {
+		return nil, &ErrorNotAuctionDraft{}
+	}
+	results, err := s.draftRepo.GetDraftResults(ctx, leagueKey)
+	if err != nil {
+		level.Error(s.logger).Log("message", "could not get draft results", "error", err, "league_key", leagueKey)
+		return nil, err
+	}
+
+	budgets := auctionBudgets(league, results)
+	auction := entities.Auction{LeagueKey: leagueKey, Budgets: budgets}
+	if lot, ok := s.auctions.current(leagueKey); ok {
+		auction.Lot = &lot
+	} else if league.State().CanPick() {
+		auction.NominatingTeam = nominatingTeam(league, budgets)
+	}
+	return &auction, nil
+}
+
+// sellLot runs on the bid timer's goroutine and saves the player to the team with the high bid. The lot is
+// already off the block, so a lot that cannot be sold is called off and the pick stays with the nominator.
+func (s *Service) sellLot(leagueKey string, lot entities.AuctionLot) {
+	tx := apm.DefaultTracer.StartTransaction("SellLot", "auction")
+	defer tx.End()
+	ctx := apm.ContextWithTransaction(context.Background(), tx)
+
+	league, err := s.draftRepo.GetLeague(ctx, leagueKey)
+	if err != nil {
+		level.Error(s.logger).Log("message", "could not get league for sold lot", "error", err, "league_key", leagueKey, "player_key", lot.PlayerKey)
+		s.unsoldLot(ctx, entities.League{LeagueKey: leagueKey}, lot)
+		return
+	}
+	if !league.State().CanPick() {
+		level.Debug(s.logger).Log("message", "lot is not sold while the draft is not open", "league_key", leagueKey, "player_key", lot.PlayerKey, "state", league.State())
+		s.unsoldLot(ctx, league, lot)
+		return
+	}
+	team, ok := leagueTeam(league, lot.HighBid.TeamKey)
+	if !ok {
+		level.Error(s.logger).Log("message", "winning team is not in the league", "league_key", leagueKey, "team_key", lot.HighBid.TeamKey)
+		s.unsoldLot(ctx, league, lot)
+		return
+	}
+
+	user := entities.User{Guid: lot.HighBid.UserGUID}
+	result, err := s.draftRepo.SaveAuctionResult(ctx, league, user, team, lot.Player, lot.Pick, lot.HighBid.Amount)
+	if err != nil {
+		level.Error(s.logger).Log("message", "could not save sold lot", "error", err, "league_key", leagueKey, "player_key", lot.PlayerKey)
+		s.unsoldLot(ctx, league, lot)
+		return
+	}
+	s.advancePick(ctx, &league, lot.Pick)
+
+	rosters, err := s.buildRosters(ctx, league)
+	if err != nil {
+		level.Error(s.logger).Log("message", "could not build rosters for sold lot", "error", err, "league_key", leagueKey)
+	}
+	err = s.broadCastRepo.BroadCastAuction(ctx, league, entities.BroadCastTypePlayerSold, lot, result, rosters)
+	if err != nil {
+		level.Error(s.logger).Log("message", "could not broadcast sold lot", "error", err, "league_key", leagueKey, "player_key", lot.PlayerKey)
+	}
+	s.completeIfFull(ctx, &league)
+}
+
+// unsoldLot tells the draft room the lot was called off. The current pick has not moved, so the team
+// that nominated the player nominates again.
+func (s *Service) unsoldLot(ctx context.Context, league entities.League, lot entities.AuctionLot) {
+	err := s.broadCastRepo.BroadCastAuction(ctx, league, entities.BroadCastTypeLotUnsold, lot, nil, nil)
+	if err != nil {
+		level.Error(s.logger).Log("message", "could not broadcast unsold lot", "error", err, "league_key", league.LeagueKey, "player_key", lot.PlayerKey)
+	}
+}
+
+// auctionDraft loads an open auction draft that the logged in user can bid in for teamKey
+func (s *Service) auctionDraft(ctx context.Context, leagueKey, teamKey, action string) (entities.League, []entities.DraftResult, error) {
+	league, err := s.draftRepo.GetLeague(ctx, leagueKey)
+	if err != nil {
+		level.Error(s.logger).Log("message", "could not get league", "error", err, "league_key", leagueKey)
+		return league, nil, err
+	}
+	if !league.IsAuction() {
+		return league, nil, &ErrorNotAuctionDraft{}
+	}
+	if !league.State().CanPick() {
+		return league, nil, &ErrorDraftState{state: league.State(), action: action}
+	}
+	if _, ok := leagueTeam(league, teamKey); !ok {
+		return league, nil, &ErrorUnknownTeam{teamKey: teamKey}
+	}
+	if !isUserTeamManager(ctx, league, teamKey) && !isUserCommissioner(ctx, league) {
+		return league, nil, &ErrorNotTeamManager{teamKey: teamKey}
+	}
+
+	results, err := s.draftRepo.GetDraftResults(ctx, leagueKey)
+	if err != nil {
+		level.Error(s.logger).Log("message", "could not get draft results", "error", err, "league_key", leagueKey)
+		return league, nil, err
+	}
+	return league, results, nil
+}
+
+func (s *Service) newBid(ctx context.Context, teamKey string, amount int) entities.Bid {
+	bid := entities.Bid{TeamKey: teamKey, Amount: amount, Timestamp: time.Now()}
+	if user, ok := userFromContext(ctx); ok {
+		bid.UserGUID = user.GUID
+	}
+	return bid
+}
+
+// auctionBudgets works out what every team in the draft order has spent and can still bid
+func auctionBudgets(league entities.League, results []entities.DraftResult) []entities.TeamBudget {
+	settings := league.DraftSettings.Auction
+	spent := make(map[string]int, len(league.DraftOrder))
+	won := make(map[string]int, len(league.DraftOrder))
+	for _, result := range results {
+		spent[result.TeamKey] += result.Cost
+		won[result.TeamKey]++
+	}
+
+	slots := numberOfRounds(league)
+	budgets := make([]entities.TeamBudget, len(league.DraftOrder))
+	for idx, teamKey := range league.DraftOrder {
+		budget := entities.TeamBudget{
+			TeamKey:   teamKey,
+			Budget:    settings.TeamBudget(),
+			Spent:     spent[teamKey],
+			Remaining: settings.TeamBudget() - spent[teamKey],
+			OpenSlots: slots - won[teamKey],
+		}
+		if budget.OpenSlots > 0 {
+			// every other open slot still needs at least the minimum bid
+			budget.MaxBid = budget.Remaining - (budget.OpenSlots-1)*settings.MinimumBid()
+		}
+		if budget.MaxBid < 0 {
+			budget.MaxBid = 0
+		}
+		budgets[idx] = budget
+	}
+	return budgets
+}
+
+// nominatingTeam rotates through the draft order one sale at a time, passing over teams with full rosters
+func nominatingTeam(league entities.League, budgets []entities.TeamBudget) string {
+	if len(budgets) == 0 || league.CurrentPick < 1 {
+		return ""
+	}
+	start := (league.CurrentPick - 1) % len(budgets)
+	for offset := range budgets {
+		budget := budgets[(start+offset)%len(budgets)]
+		if budget.OpenSlots > 0 {
+			return budget.TeamKey
+		}
+	}
+	return ""
+}
+
+func validateBid(league entities.League, budgets []entities.TeamBudget, teamKey string, amount int) error {
+	minBid := league.DraftSettings.Auction.MinimumBid()
+	for _, budget := range budgets {
+		if budget.TeamKey != teamKey {
+			continue
+		}
+		if amount < minBid || amount > budget.MaxBid {
+			return &ErrorInvalidBid{amount: amount, minBid: minBid, maxBid: budget.MaxBid}
+		}
+		return nil
+	}
+	return &ErrorUnknownTeam{teamKey: teamKey}
+}
@@ -0,0 +1,228 @@
+package draft
+
+import (
+	"context"
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/thethan/fdr-users/pkg/auth"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	userEntities "github.com/thethan/fdr-users/pkg/users/entities"
+	"testing"
+	"time"
+)
+
+// auctionLeague is testLeague as an open $50 auction with seven roster slots per team
+func auctionLeague() entities.League {
+	league := testLeague()
+	league.Settings.IsAuctionDraft = true
+	league.DraftSettings.Auction = entities.AuctionSettings{Budget: 50}
+	league.SetState(entities.DraftStateOpen)
+	league.CurrentPick = 1
+	return league
+}
+
+func Test_auctionBudgets(t *testing.T) {
+	league := auctionLeague()
+	results := []entities.DraftResult{
+		{TeamKey: "399.l.1.t.1", Cost: 30},
+		{TeamKey: "399.l.1.t.1", Cost: 10},
+		{TeamKey: "399.l.1.t.2", Cost: 1},
+	}
+
+	budgets := auctionBudgets(league, results)
+	assert.Len(t, budgets, 4)
+	assert.Equal(t, entities.TeamBudget{TeamKey: "399.l.1.t.1", Budget: 50, Spent: 40, Remaining: 10, OpenSlots: 5, MaxBid: 6}, budgets[0])
+	assert.Equal(t, entities.TeamBudget{TeamKey: "399.l.1.t.2", Budget: 50, Spent: 1, Remaining: 49, OpenSlots: 6, MaxBid: 44}, budgets[1])
+	assert.Equal(t, entities.TeamBudget{TeamKey: "399.l.1.t.3", Budget: 50, Remaining: 50, OpenSlots: 7, MaxBid: 44}, budgets[2])
+}
+
+func Test_nominatingTeam(t *testing.T) {
+	league := auctionLeague()
+	budgets := auctionBudgets(league, nil)
+	assert.Equal(t, "399.l.1.t.1", nominatingTeam(league, budgets))
+
+	league.CurrentPick = 6
+	assert.Equal(t, "399.l.1.t.2", nominatingTeam(league, budgets))
+
+	budgets[1].OpenSlots = 0
+	assert.Equal(t, "399.l.1.t.3", nominatingTeam(league, budgets), "teams with full rosters are passed over")
+}
+
+func Test_auctionHouse(t *testing.T) {
+	house := newAuctionHouse()
+	sold := make(chan entities.AuctionLot, 1)
+	onSold := func(leagueKey string, lot entities.AuctionLot) { sold <- lot }
+
+	lot := entities.AuctionLot{Pick: 1, PlayerKey: "399.p.1", HighBid: entities.Bid{TeamKey: "t.1", Amount: 1}}
+	_, ok := house.nominate("399.l.1", lot, time.Hour, onSold)
+	assert.True(t, ok)
+	_, ok = house.nominate("399.l.1", lot, time.Hour, onSold)
+	assert.False(t, ok, "only one player is on the block at a time")
+
+	_, err := house.bid("399.l.1", entities.Bid{TeamKey: "t.2", Amount: 1}, time.Hour)
+	assert.IsType(t, &ErrorBidTooLow{}, err)
+	_, err = house.bid("399.l.2", entities.Bid{TeamKey: "t.2", Amount: 2}, time.Hour)
+	assert.IsType(t, &ErrorNoLot{}, err)
+
+	_, ok = house.sold("399.l.1", 0)
+	assert.True(t, ok)
+
+	_, ok = house.nominate("399.l.1", lot, time.Hour, onSold)
+	assert.True(t, ok)
+	_, err = house.bid("399.l.1", entities.Bid{TeamKey: "t.2", Amount: 5}, time.Millisecond)
+	assert.Nil(t, err)
+	_, ok = house.sold("399.l.1", 0)
+	assert.False(t, ok, "a timer started before the last bid does not sell the player")
+
+	select {
+	case lot := <-sold:
+		assert.Equal(t, "t.2", lot.HighBid.TeamKey)
+		assert.Equal(t, 5, lot.HighBid.Amount)
+	case <-time.After(time.Second):
+		t.Fatal("bid timer did not sell the player")
+	}
+	_, ok = house.current("399.l.1")
+	assert.False(t, ok)
+}
+
+func TestService_Auction(t *testing.T) {
+	league := auctionLeague()
+	repo := newFakeDraftRepository(league)
+	repo.players = []entities.PlayerSeason{testPlayer("399.p.1", "RB"), testPlayer("399.p.2", "QB")}
+	broadcaster := newFakeBroadcaster()
+	service := NewService(log.NewNopLogger(), repo, broadcaster)
+	managerContext := context.WithValue(context.Background(), auth.User, &userEntities.User{GUID: "manager-2"})
+
+	_, err := service.SaveDraftRequest(commissionerContext(), entities.User{Guid: "commish"}, league, league.Teams[0], testPlayer("399.p.1"), 1)
+	assert.IsType(t, &ErrorAuctionDraft{}, err)
+
+	_, err = service.Nominate(managerContext, league.LeagueKey, "399.l.1.t.2", "399.p.1", 1)
+	assert.IsType(t, &ErrorNotNominatingTeam{}, err)
+	_, err = service.Nominate(commissionerContext(), league.LeagueKey, "399.l.1.t.1", "399.p.1", 45)
+	assert.IsType(t, &ErrorInvalidBid{}, err, "the max bid keeps a dollar for each other open slot")
+
+	lot, err := service.Nominate(commissionerContext(), league.LeagueKey, "399.l.1.t.1", "399.p.1", 3)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, lot.HighBid.Amount)
+	assert.Equal(t, entities.BroadCastTypeNomination, broadcaster.last().Type)
+
+	_, err = service.Nominate(commissionerContext(), league.LeagueKey, "399.l.1.t.1", "399.p.2", 1)
+	assert.IsType(t, &ErrorLotInProgress{}, err)
+	_, err = service.Bid(managerContext, league.LeagueKey, "399.l.1.t.3", 4)
+	assert.IsType(t, &ErrorNotTeamManager{}, err)
+	_, err = service.Bid(managerContext, league.LeagueKey, "399.l.1.t.2", 3)
+	assert.IsType(t, &ErrorBidTooLow{}, err)
+
+	lot, err = service.Bid(managerContext, league.LeagueKey, "399.l.1.t.2", 12)
+	assert.Nil(t, err)
+	assert.Equal(t, "manager-2", lot.HighBid.UserGUID)
+	assert.Equal(t, entities.BroadCastTypeBid, broadcaster.last().Type)
+
+	// the bid timer running out sells the player
+	soldLot, ok := service.auctions.sold(league.LeagueKey, 1)
+	assert.True(t, ok)
+	service.sellLot(league.LeagueKey, soldLot)
+
+	results, _ := repo.GetDraftResults(context.Background(), league.LeagueKey)
+	if assert.Len(t, results, 1) {
+		assert.Equal(t, "399.l.1.t.2", results[0].TeamKey)
+		assert.Equal(t, 12, results[0].Cost)
+	}
+	sold := broadcaster.last()
+	assert.Equal(t, entities.BroadCastTypePlayerSold, sold.Type)
+	assert.Equal(t, 12, sold.Result.Cost)
+
+	auction, err := service.GetAuction(commissionerContext(), league.LeagueKey)
+	assert.Nil(t, err)
+	assert.Nil(t, auction.Lot)
+	assert.Equal(t, "399.l.1.t.2", auction.NominatingTeam)
+	assert.Equal(t, 38, auction.Budgets[1].Remaining)
+}
+
+func TestService_Auction_NotSaved(t *testing.T) {
+	league := auctionLeague()
+	repo := newFakeDraftRepository(league)
+	repo.players = []entities.PlayerSeason{testPlayer("399.p.1", "RB")}
+	broadcaster := newFakeBroadcaster()
+	service := NewService(log.NewNopLogger(), repo, broadcaster)
+
+	_, err := service.Nominate(commissionerContext(), league.LeagueKey, "399.l.1.t.1", "399.p.1", 3)
+	assert.Nil(t, err)
+	// another instance saved pick 1 first, so the sale conflicts
+	repo.results[league.LeagueKey] = []entities.DraftResult{{LeagueKey: league.LeagueKey, TeamKey: "399.l.1.t.3", PlayerKey: "399.p.9", Pick: 1}}
+
+	lot, ok := service.auctions.sold(league.LeagueKey, 0)
+	assert.True(t, ok)
+	service.sellLot(league.LeagueKey, lot)
+
+	unsold := broadcaster.last()
+	assert.Equal(t, entities.BroadCastTypeLotUnsold, unsold.Type)
+	if assert.NotNil(t, unsold.Lot) {
+		assert.Equal(t, "399.p.1", unsold.Lot.PlayerKey)
+	}
+	auction, err := service.GetAuction(commissionerContext(), league.LeagueKey)
+	assert.Nil(t, err)
+	assert.Nil(t, auction.Lot, "the lot is off the block")
+	assert.Equal(t, 1, repo.leagues[league.LeagueKey].CurrentPick)
+	assert.Equal(t, "399.l.1.t.1", auction.NominatingTeam, "the nominator gets the pick back")
+}
+
+func Test_auctionHouse_Freeze(t *testing.T) {
+	house := newAuctionHouse()
+	sold := make(chan entities.AuctionLot, 1)
+	onSold := func(leagueKey string, lot entities.AuctionLot) { sold <- lot }
+
+	lot := entities.AuctionLot{Pick: 1, PlayerKey: "399.p.1", HighBid: entities.Bid{TeamKey: "t.1", Amount: 1}}
+	_, ok := house.nominate("399.l.1", lot, 20*time.Millisecond, onSold)
+	assert.True(t, ok)
+	house.freeze("399.l.1")
+	_, ok = house.sold("399.l.1", 0)
+	assert.False(t, ok, "a timer that fires as the draft is paused does not sell the player")
+
+	select {
+	case <-sold:
+		t.Fatal("the player sold while the draft was paused")
+	case <-time.After(50 * time.Millisecond):
+	}
+	_, ok = house.current("399.l.1")
+	assert.True(t, ok, "the player stays on the block")
+
+	house.thaw("399.l.1")
+	select {
+	case lot := <-sold:
+		assert.Equal(t, "399.p.1", lot.PlayerKey)
+	case <-time.After(time.Second):
+		t.Fatal("bid timer did not sell the player after the draft resumed")
+	}
+
+	_, ok = house.nominate("399.l.1", lot, time.Hour, onSold)
+	assert.True(t, ok)
+	house.cancel("399.l.1")
+	_, ok = house.current("399.l.1")
+	assert.False(t, ok, "a cancelled lot is taken off the block")
+}
+
+func TestService_Auction_PauseAndClose(t *testing.T) {
+	league := auctionLeague()
+	repo := newFakeDraftRepository(league)
+	repo.players = []entities.PlayerSeason{testPlayer("399.p.1", "RB")}
+	service := NewService(log.NewNopLogger(), repo, newFakeBroadcaster())
+
+	_, err := service.Nominate(commissionerContext(), league.LeagueKey, "399.l.1.t.1", "399.p.1", 3)
+	assert.Nil(t, err)
+	_, err = service.PauseDraft(commissionerContext(), league.LeagueKey)
+	assert.Nil(t, err)
+
+	_, ok := service.auctions.sold(league.LeagueKey, 0)
+	assert.False(t, ok, "the bid timer is frozen while the draft is paused")
+	// a lot that sold as the draft was paused is not saved
+	lot, _ := service.auctions.current(league.LeagueKey)
+	service.sellLot(league.LeagueKey, lot)
+	results, _ := repo.GetDraftResults(context.Background(), league.LeagueKey)
+	assert.Empty(t, results)
+
+	_, err = service.CloseDraft(commissionerContext(), league.LeagueKey)
+	assert.Nil(t, err)
+	_, ok = service.auctions.current(league.LeagueKey)
+	assert.False(t, ok, "closing the draft takes the player off the block unsold")
+}
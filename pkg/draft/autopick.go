@@ -0,0 +1,176 @@
+package draft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-kit/kit/log/level"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"go.elastic.co/apm"
+	"sort"
+)
+
+// autoPickPageSize is how many ranked players are looked at per query when the preference queue runs dry
+const autoPickPageSize = 100
+
+const autoPickRank = "yahoo"
+
+// autoDraftIfEnabled autopicks for the team on the clock when its manager has autodraft turned on.
+// It runs on its own goroutine after every pick, so it loads everything it needs fresh.
+func (s *Service) autoDraftIfEnabled(leagueKey string, pick int) {
+	tx := apm.DefaultTracer.StartTransaction("AutoDraft", "autopick")
+	defer tx.End()
+	ctx := apm.ContextWithTransaction(context.Background(), tx)
+
+	league, err := s.draftRepo.GetLeague(ctx, leagueKey)
+	if err != nil {
+		level.Error(s.logger).Log("message", "could not get league for autodraft", "error", err, "league_key", leagueKey, "pick", pick)
+		return
+	}
+	if !league.State().CanPick() || league.IsAuction() || league.CurrentPick != pick || pick > totalPicks(league) {
+		return
+	}
+
+	_, manager, err := teamOnTheClock(league, pick)
+	if err != nil {
+		return
+	}
+	preference, err := s.draftRepo.GetUserPlayerPreference(ctx, manager.Guid, leagueKey)
+	if err != nil || !preference.AutoDraft {
+		return
+	}
+
+	_, err = s.autoPick(ctx, league, pick)
+	if err != nil {
+		level.Error(s.logger).Log("message", "could not autodraft", "error", err, "league_key", leagueKey, "pick", pick)
+	}
+}
+
+// autoPick makes pick for the team on the clock. It takes the first player in the manager's preference
+// queue that is still available and fits the roster, then falls back to the best yahoo ranked player.
+func (s *Service) autoPick(ctx context.Context, league entities.League, pick int) (*entities.DraftResult, error) {
+	span, ctx := apm.StartSpan(ctx, "autoPick", "service")
+	span.Context.SetLabel("league_key", league.LeagueKey)
+	span.Context.SetLabel("pick", pick)
+	defer span.End()
+
+	team, manager, err := teamOnTheClock(league, pick)
+	if err != nil {
+		return nil, err
+	}
+
+	preference, err := s.draftRepo.GetUserPlayerPreference(ctx, manager.Guid, league.LeagueKey)
+	if err != nil {
+		level.Debug(s.logger).Log("message", "no player preference for autopick", "error", err, "league_key", league.LeagueKey, "guid", manager.Guid)
+		preference = entities.UserPlayerPreference{}
+	}
+
+	results, err := s.draftRepo.GetDraftResults(ctx, league.LeagueKey)
+	if err != nil {
+		return nil, err
+	}
+	drafted := make(map[string]bool, len(results))
+	for _, result := range results {
+		drafted[result.PlayerKey] = true
+	}
+
+	rosters, err := s.buildRosters(ctx, league)
+	if err != nil {
+		return nil, err
+	}
+	roster, ok := rosters[team.TeamKey]
+	if !ok {
+		roster = makeRoster(league)
+	}
+
+	var player entities.PlayerSeason
+	if len(preference.Preference) > 0 {
+		queue, err := s.draftRepo.GetPlayers(ctx, preference.Preference)
+		if err != nil {
+			return nil, err
+		}
+		player, ok = chooseAutoPick(orderByPlayerKeys(queue, preference.Preference), preference, drafted, roster)
+	}
+
+	for page := 0; !ok; page++ {
+		ranked, err := s.draftRepo.GetAvailablePlayersByRank(ctx, league.LeagueKey, autoPickPageSize, page)
+		if err != nil {
+			return nil, err
+		}
+		if len(ranked) == 0 {
+			return nil, fmt.Errorf("no available player fits the roster of team %s", team.TeamKey)
+		}
+		sortByRank(ranked, autoPickRank)
+		player, ok = chooseAutoPick(ranked, preference, drafted, roster)
+	}
+
+	user := entities.User{Email: manager.Email, Name: manager.Name, Nickname: manager.Nickname, Guid: manager.Guid}
+	level.Info(s.logger).Log("message", "autopick", "league_key", league.LeagueKey, "pick", pick, "team_key", team.TeamKey, "player_key", player.PlayerKey, "team_present", s.isTeamPresent(league.LeagueKey, team.TeamKey))
+
+	return s.savePick(ctx, league, user, team, player, pick)
+}
+
+// teamOnTheClock returns the team that owns pick and the manager drafting for it
+func teamOnTheClock(league entities.League, pick int) (entities.Team, entities.User, error) {
+	teamKey, ok := teamKeyForPick(league, pick)
+	if !ok {
+		return entities.Team{}, entities.User{}, fmt.Errorf("pick %d is not part of the draft", pick)
+	}
+	for _, team := range league.Teams {
+		if team.TeamKey != teamKey {
+			continue
+		}
+		if len(team.Manager) == 0 {
+			return team, entities.User{}, fmt.Errorf("team %s has no manager", teamKey)
+		}
+		return team, team.Manager[0], nil
+	}
+	return entities.Team{}, entities.User{}, errors.New("team on the clock is not in the league")
+}
+
+// chooseAutoPick returns the first candidate that has not been drafted, is not on the
+// do not draft list and still has an open roster slot
+func chooseAutoPick(candidates []entities.PlayerSeason, preference entities.UserPlayerPreference, drafted map[string]bool, roster entities.Roster) (entities.PlayerSeason, bool) {
+	doNotDraft := make(map[string]bool, len(preference.DoNotDraft))
+	for _, playerKey := range preference.DoNotDraft {
+		doNotDraft[playerKey] = true
+	}
+
+	for idx := range candidates {
+		player := candidates[idx]
+		if player.PlayerKey == "" || drafted[player.PlayerKey] || doNotDraft[player.PlayerKey] || len(player.EligiblePositions) == 0 {
+			continue
+		}
+		if _, open := rosterSlotFor(roster, &player); open {
+			return player, true
+		}
+	}
+	return entities.PlayerSeason{}, false
+}
+
+// orderByPlayerKeys puts players back into the order of playerKeys, dropping any that were not found
+func orderByPlayerKeys(players []entities.PlayerSeason, playerKeys []string) []entities.PlayerSeason {
+	byKey := make(map[string]entities.PlayerSeason, len(players))
+	for _, player := range players {
+		byKey[player.PlayerKey] = player
+	}
+
+	ordered := make([]entities.PlayerSeason, 0, len(playerKeys))
+	for _, playerKey := range playerKeys {
+		if player, ok := byKey[playerKey]; ok {
+			ordered = append(ordered, player)
+		}
+	}
+	return ordered
+}
+
+// sortByRank orders players best rank first, unranked players last
+func sortByRank(players []entities.PlayerSeason, source string) {
+	sort.SliceStable(players, func(i, j int) bool {
+		rankI, rankJ := players[i].Ranks[source], players[j].Ranks[source]
+		if rankI == 0 || rankJ == 0 {
+			return rankJ == 0 && rankI != 0
+		}
+		return rankI < rankJ
+	})
+}
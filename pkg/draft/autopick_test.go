@@ -0,0 +1,150 @@
+package draft
+
+import (
+	"context"
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"testing"
+)
+
+func Test_chooseAutoPick(t *testing.T) {
+	league := testLeague()
+	fullQB := makeRoster(league)
+	fullQB = buildTeamRoster([]entities.DraftResult{
+		{PlayerKey: "qb-1", Player: []*entities.PlayerSeason{{PlayerKey: "qb-1", EligiblePositions: []string{"QB"}}}},
+		{PlayerKey: "qb-2", Player: []*entities.PlayerSeason{{PlayerKey: "qb-2", EligiblePositions: []string{"QB"}}}},
+		{PlayerKey: "qb-3", Player: []*entities.PlayerSeason{{PlayerKey: "qb-3", EligiblePositions: []string{"QB"}}}},
+	}, fullQB)
+
+	tests := []struct {
+		name       string
+		candidates []entities.PlayerSeason
+		preference entities.UserPlayerPreference
+		drafted    map[string]bool
+		roster     entities.Roster
+		want       string
+		wantOK     bool
+	}{
+		{
+			name:       "first candidate",
+			candidates: []entities.PlayerSeason{testPlayer("rb-1", "RB"), testPlayer("wr-1", "WR")},
+			roster:     makeRoster(league),
+			want:       "rb-1",
+			wantOK:     true,
+		},
+		{
+			name:       "skips drafted players",
+			candidates: []entities.PlayerSeason{testPlayer("rb-1", "RB"), testPlayer("wr-1", "WR")},
+			drafted:    map[string]bool{"rb-1": true},
+			roster:     makeRoster(league),
+			want:       "wr-1",
+			wantOK:     true,
+		},
+		{
+			name:       "skips do not draft players",
+			candidates: []entities.PlayerSeason{testPlayer("rb-1", "RB"), testPlayer("wr-1", "WR")},
+			preference: entities.UserPlayerPreference{DoNotDraft: []string{"rb-1"}},
+			roster:     makeRoster(league),
+			want:       "wr-1",
+			wantOK:     true,
+		},
+		{
+			name:       "skips positions without an open slot",
+			candidates: []entities.PlayerSeason{testPlayer("qb-4", "QB"), testPlayer("te-1", "TE")},
+			roster:     fullQB,
+			want:       "te-1",
+			wantOK:     true,
+		},
+		{
+			name:       "nothing fits",
+			candidates: []entities.PlayerSeason{testPlayer("qb-4", "QB")},
+			roster:     fullQB,
+			wantOK:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := chooseAutoPick(tt.candidates, tt.preference, tt.drafted, tt.roster)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got.PlayerKey)
+		})
+	}
+}
+
+func Test_sortByRank(t *testing.T) {
+	players := []entities.PlayerSeason{rankedPlayer("c", 0, "QB"), rankedPlayer("b", 20, "QB"), rankedPlayer("a", 3, "QB")}
+	sortByRank(players, "yahoo")
+	assert.Equal(t, []string{"a", "b", "c"}, []string{players[0].PlayerKey, players[1].PlayerKey, players[2].PlayerKey})
+}
+
+func TestService_autoPick(t *testing.T) {
+	league := testLeague()
+	league.DraftStarted = true
+	league.CurrentPick = 1
+
+	t.Run("takes the first available player from the preference queue", func(t *testing.T) {
+		repo := newFakeDraftRepository(league)
+		repo.players = []entities.PlayerSeason{rankedPlayer("rb-1", 1, "RB"), rankedPlayer("wr-1", 2, "WR"), rankedPlayer("qb-1", 3, "QB")}
+		_ = repo.SaveUserPlayerPreference(context.Background(), entities.UserPlayerPreference{
+			LeagueKey: league.LeagueKey, UserID: "commish", Preference: []string{"rb-1", "qb-1"}, DoNotDraft: []string{"rb-1"},
+		})
+		service := NewService(log.NewNopLogger(), repo, newFakeBroadcaster())
+
+		result, err := service.autoPick(context.Background(), league, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, "qb-1", result.PlayerKey)
+		assert.Equal(t, "399.l.1.t.1", result.TeamKey)
+		assert.Equal(t, "commish", result.UserGUID)
+	})
+
+	t.Run("falls back to yahoo rank when the queue is empty", func(t *testing.T) {
+		repo := newFakeDraftRepository(league)
+		repo.players = []entities.PlayerSeason{rankedPlayer("wr-1", 2, "WR"), rankedPlayer("qb-1", 3, "QB"), rankedPlayer("rb-1", 1, "RB")}
+		broadcaster := newFakeBroadcaster()
+		service := NewService(log.NewNopLogger(), repo, broadcaster)
+
+		result, err := service.autoPick(context.Background(), league, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, "rb-1", result.PlayerKey)
+		assert.Contains(t, broadcaster.types(), entities.BroadCastTypePlayerDrafted)
+	})
+}
+
+func TestService_pickClockExpired_AutoPicks(t *testing.T) {
+	league := testLeague()
+	league.DraftStarted = true
+	league.CurrentPick = 2
+	league.DraftSettings = entities.DraftSettings{PickSeconds: 90, ExpiryAction: entities.ClockExpiryAutoPick}
+	repo := newFakeDraftRepository(league)
+	repo.players = []entities.PlayerSeason{rankedPlayer("rb-1", 1, "RB")}
+	broadcaster := newFakeBroadcaster()
+	service := NewService(log.NewNopLogger(), repo, broadcaster)
+	defer service.clock.stop(league.LeagueKey)
+
+	service.startPickClock(&league)
+	service.pickClockExpired(league.LeagueKey, 2)
+
+	results, _ := repo.GetDraftResults(context.Background(), league.LeagueKey)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "399.l.1.t.2", results[0].TeamKey)
+	assert.Equal(t, []entities.BroadcastType{entities.BroadCastTypeClockExpired, entities.BroadCastTypePlayerDrafted}, broadcaster.types())
+}
+
+func TestService_autoDraftIfEnabled(t *testing.T) {
+	league := testLeague()
+	league.DraftStarted = true
+	league.CurrentPick = 2
+	repo := newFakeDraftRepository(league)
+	repo.players = []entities.PlayerSeason{rankedPlayer("rb-1", 1, "RB")}
+	service := NewService(log.NewNopLogger(), repo, newFakeBroadcaster())
+
+	service.autoDraftIfEnabled(league.LeagueKey, 2)
+	results, _ := repo.GetDraftResults(context.Background(), league.LeagueKey)
+	assert.Len(t, results, 0, "manager without autodraft should not be picked for")
+
+	_ = repo.SaveUserPlayerPreference(context.Background(), entities.UserPlayerPreference{LeagueKey: league.LeagueKey, UserID: "manager-2", AutoDraft: true})
+	service.autoDraftIfEnabled(league.LeagueKey, 2)
+	results, _ = repo.GetDraftResults(context.Background(), league.LeagueKey)
+	assert.Len(t, results, 1)
+}
@@ -0,0 +1,343 @@
+package draft
+
+import (
+	"context"
+	"errors"
+	"github.com/go-kit/kit/log/level"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"go.elastic.co/apm"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// maxChatLength is the most characters a chat message can have
+	maxChatLength = 500
+	// maxEmojiLength is the most characters of a reaction, enough for emoji joined from several code points
+	maxEmojiLength = 8
+	// chatLimit messages and reactions can be sent by a user in any chatWindow
+	chatLimit  = 5
+	chatWindow = 10 * time.Second
+	// chat history is paged defaultChatPage messages at a time unless the client asks for up to maxChatPage
+	defaultChatPage = 50
+	maxChatPage     = 100
+)
+
+// chatLimiter counts what each user sent to a league's chat in the last chatWindow. Like the pick clock it lives in
+// memory, so each instance limits the users it serves.
+type chatLimiter struct {
+	mu   *sync.Mutex
+	sent map[string][]time.Time
+}
+
+func newChatLimiter() *chatLimiter {
+	return &chatLimiter{mu: &sync.Mutex{}, sent: make(map[string][]time.Time)}
+}
+
+// allow records a message at now unless the user already sent chatLimit in the window before it
+func (l *chatLimiter) allow(leagueKey, userGUID string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := leagueKey + "|" + userGUID
+	recent := l.sent[key][:0]
+	for _, sentAt := range l.sent[key] {
+		if now.Sub(sentAt) < chatWindow {
+			recent = append(recent, sentAt)
+		}
+	}
+	if len(recent) >= chatLimit {
+		l.sent[key] = recent
+		return false
+	}
+	l.sent[key] = append(recent, now)
+	return true
+}
+
+// SendChatMessage posts a message from the logged in user to the league's draft room
+func (service *Service) SendChatMessage(ctx context.Context, leagueKey, text string) (*entities.ChatMessage, error) {
+	span, ctx := apm.StartSpan(ctx, "SendChatMessage", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	defer span.End()
+
+	league, member, err := service.chatLeague(ctx, leagueKey)
+	if err != nil {
+		return nil, err
+	}
+	text = strings.TrimSpace(text)
+	if text == "" || utf8.RuneCountInString(text) > maxChatLength {
+		return nil, &ErrorChatMessage{reason: "a message must have between 1 and 500 characters"}
+	}
+	err = service.checkCanChat(league, member.Guid)
+	if err != nil {
+		return nil, err
+	}
+
+	message := entities.ChatMessage{
+		ID:        primitive.NewObjectID().Hex(),
+		LeagueKey: leagueKey,
+		UserGUID:  member.Guid,
+		Nickname:  member.Nickname,
+		TeamKey:   managerTeamKey(league, member.Guid),
+		Text:      text,
+		Reactions: []entities.ChatReaction{},
+		SentAt:    time.Now(),
+	}
+	err = service.draftRepo.SaveChatMessage(ctx, message)
+	if err != nil {
+		return nil, &ErrorUpdateDraft{}
+	}
+	service.broadcastChat(ctx, league, message)
+	return &message, nil
+}
+
+// ChatHistory returns a page of the league's chat, oldest message first, from before the message with id before.
+// An empty before returns the newest page, for a client joining the draft room.
+func (service *Service) ChatHistory(ctx context.Context, leagueKey, before string, limit int) (*entities.ChatPage, error) {
+	span, ctx := apm.StartSpan(ctx, "ChatHistory", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	defer span.End()
+
+	_, _, err := service.chatLeague(ctx, leagueKey)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultChatPage
+	}
+	if limit > maxChatPage {
+		limit = maxChatPage
+	}
+
+	// one more than the page tells whether there is an older page
+	messages, err := service.draftRepo.GetChatMessages(ctx, leagueKey, before, limit+1)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not get chat messages", "error", err, "league_key", leagueKey)
+		return nil, err
+	}
+	page := entities.ChatPage{LeagueKey: leagueKey}
+	if len(messages) > limit {
+		messages = messages[:limit]
+		page.Before = messages[limit-1].ID
+	}
+	page.Messages = make([]entities.ChatMessage, len(messages))
+	for idx, message := range messages {
+		page.Messages[len(messages)-1-idx] = message
+	}
+	return &page, nil
+}
+
+// DeleteChatMessage takes the text out of a message. Users can delete their own messages and commissioners anyone's.
+func (service *Service) DeleteChatMessage(ctx context.Context, leagueKey, messageID string) (*entities.ChatMessage, error) {
+	span, ctx := apm.StartSpan(ctx, "DeleteChatMessage", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	defer span.End()
+
+	league, member, err := service.chatLeague(ctx, leagueKey)
+	if err != nil {
+		return nil, err
+	}
+	message, err := service.chatMessage(ctx, leagueKey, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if message.UserGUID != member.Guid && !isUserCommissioner(ctx, league) {
+		return nil, &ErrorNotCommissioner{}
+	}
+	if message.DeletedAt != nil {
+		return &message, nil
+	}
+
+	message, err = service.draftRepo.DeleteChatMessage(ctx, leagueKey, messageID, member.Guid, time.Now())
+	if err != nil {
+		return nil, service.chatUpdateError(err, leagueKey, messageID)
+	}
+	service.broadcastChat(ctx, league, message)
+	return &message, nil
+}
+
+// ReactToChatMessage puts the logged in user's emoji on a message, or takes it off when they already reacted with it
+func (service *Service) ReactToChatMessage(ctx context.Context, leagueKey, messageID, emoji string) (*entities.ChatMessage, error) {
+	span, ctx := apm.StartSpan(ctx, "ReactToChatMessage", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	defer span.End()
+
+	league, member, err := service.chatLeague(ctx, leagueKey)
+	if err != nil {
+		return nil, err
+	}
+	emoji = strings.TrimSpace(emoji)
+	if emoji == "" || utf8.RuneCountInString(emoji) > maxEmojiLength {
+		return nil, &ErrorChatMessage{reason: "a reaction must be a single emoji"}
+	}
+	message, err := service.chatMessage(ctx, leagueKey, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if message.DeletedAt != nil {
+		return nil, &ErrorChatMessageNotFound{messageID: messageID}
+	}
+	err = service.checkCanChat(league, member.Guid)
+	if err != nil {
+		return nil, err
+	}
+
+	reaction := entities.ChatReaction{Emoji: emoji, UserGUID: member.Guid}
+	add := true
+	for _, existing := range message.Reactions {
+		if existing == reaction {
+			add = false
+		}
+	}
+	message, err = service.draftRepo.SaveChatReaction(ctx, leagueKey, messageID, reaction, add)
+	if err != nil {
+		return nil, service.chatUpdateError(err, leagueKey, messageID)
+	}
+	service.broadcastChat(ctx, league, message)
+	return &message, nil
+}
+
+// MuteChatUser keeps a manager out of the league's chat for duration, or until they are unmuted when duration is 0
+func (service *Service) MuteChatUser(ctx context.Context, leagueKey, userGUID string, duration time.Duration) ([]entities.ChatMute, error) {
+	span, ctx := apm.StartSpan(ctx, "MuteChatUser", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	defer span.End()
+
+	league, err := service.commissionerLeague(ctx, leagueKey)
+	if err != nil {
+		return nil, err
+	}
+	if duration < 0 {
+		return nil, &ErrorChatMessage{reason: "a mute can not end before it starts"}
+	}
+	_, manager, ok := leagueManager(league, userGUID)
+	if !ok {
+		return nil, &ErrorChatMessage{reason: "only managers in the league can be muted"}
+	}
+	if manager.IsCommissioner {
+		return nil, &ErrorChatMessage{reason: "commissioners can not be muted"}
+	}
+
+	now := time.Now()
+	mute := entities.ChatMute{UserGUID: userGUID, MutedBy: commissionerUser(ctx).Guid, MutedAt: now}
+	if duration > 0 {
+		until := now.Add(duration)
+		mute.Until = &until
+	}
+	mutes := append(activeMutes(league, now, userGUID), mute)
+	return mutes, service.saveChatMutes(ctx, leagueKey, mutes)
+}
+
+// UnmuteChatUser lets a muted manager back into the league's chat
+func (service *Service) UnmuteChatUser(ctx context.Context, leagueKey, userGUID string) ([]entities.ChatMute, error) {
+	span, ctx := apm.StartSpan(ctx, "UnmuteChatUser", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	defer span.End()
+
+	league, err := service.commissionerLeague(ctx, leagueKey)
+	if err != nil {
+		return nil, err
+	}
+	mutes := activeMutes(league, time.Now(), userGUID)
+	return mutes, service.saveChatMutes(ctx, leagueKey, mutes)
+}
+
+// chatLeague loads the league for a chat operation and the logged in user as a manager of it
+func (service *Service) chatLeague(ctx context.Context, leagueKey string) (entities.League, entities.User, error) {
+	league, err := service.draftRepo.GetLeague(ctx, leagueKey)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not get league", "error", err, "league_key", leagueKey)
+		return league, entities.User{}, err
+	}
+	user, ok := userFromContext(ctx)
+	if !ok {
+		return league, entities.User{}, &ErrorNotInLeague{leagueKey: leagueKey}
+	}
+	_, manager, ok := leagueManager(league, user.GUID)
+	if !ok {
+		return league, entities.User{}, &ErrorNotInLeague{leagueKey: leagueKey}
+	}
+	if manager.Nickname == "" {
+		manager.Nickname = user.NickName
+	}
+	return league, manager, nil
+}
+
+func (service *Service) chatMessage(ctx context.Context, leagueKey, messageID string) (entities.ChatMessage, error) {
+	message, err := service.draftRepo.GetChatMessage(ctx, leagueKey, messageID)
+	if err != nil {
+		return message, service.chatUpdateError(err, leagueKey, messageID)
+	}
+	return message, nil
+}
+
+// checkCanChat fails when the user is muted or has sent too much too quickly
+func (service *Service) checkCanChat(league entities.League, userGUID string) error {
+	now := time.Now()
+	for _, mute := range league.ChatMutes {
+		if mute.UserGUID == userGUID && mute.Active(now) {
+			return &ErrorChatMuted{until: mute.Until}
+		}
+	}
+	if !service.chat.allow(league.LeagueKey, userGUID, now) {
+		return &ErrorChatRateLimited{}
+	}
+	return nil
+}
+
+func (service *Service) chatUpdateError(err error, leagueKey, messageID string) error {
+	if errors.Is(err, entities.ErrChatMessageNotFound) {
+		return &ErrorChatMessageNotFound{messageID: messageID}
+	}
+	level.Error(service.logger).Log("message", "could not get chat message", "error", err, "league_key", leagueKey, "chat_message_id", messageID)
+	return err
+}
+
+func (service *Service) saveChatMutes(ctx context.Context, leagueKey string, mutes []entities.ChatMute) error {
+	err := service.draftRepo.SaveChatMutes(ctx, leagueKey, mutes)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not save chat mutes", "error", err, "league_key", leagueKey)
+		return &ErrorUpdateDraft{}
+	}
+	return nil
+}
+
+// broadcastChat sends a message to the draft room. The message is already saved, so a client that missed it
+// finds it in the history.
+func (service *Service) broadcastChat(ctx context.Context, league entities.League, message entities.ChatMessage) {
+	err := service.broadCastRepo.BroadCastChatMessage(ctx, league, message)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not broadcast chat message", "error", err, "league_key", league.LeagueKey, "chat_message_id", message.ID)
+	}
+}
+
+// activeMutes are the league's mutes still running at now, leaving out userGUID's
+func activeMutes(league entities.League, now time.Time, userGUID string) []entities.ChatMute {
+	mutes := make([]entities.ChatMute, 0, len(league.ChatMutes))
+	for _, mute := range league.ChatMutes {
+		if mute.UserGUID != userGUID && mute.Active(now) {
+			mutes = append(mutes, mute)
+		}
+	}
+	return mutes
+}
+
+// leagueManager finds the team userGUID manages in the league
+func leagueManager(league entities.League, userGUID string) (entities.Team, entities.User, bool) {
+	for _, team := range league.Teams {
+		for _, manager := range team.Manager {
+			if manager.Guid == userGUID {
+				return team, manager, true
+			}
+		}
+	}
+	return entities.Team{}, entities.User{}, false
+}
+
+func managerTeamKey(league entities.League, userGUID string) string {
+	team, _, _ := leagueManager(league, userGUID)
+	return team.TeamKey
+}
@@ -0,0 +1,165 @@
+package draft
+
+import (
+	"context"
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/thethan/fdr-users/pkg/auth"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	userEntities "github.com/thethan/fdr-users/pkg/users/entities"
+	"strings"
+	"testing"
+	"time"
+)
+
+func chatService() (*Service, *fakeDraftRepository, *fakeBroadcaster) {
+	repo := newFakeDraftRepository(testLeague())
+	broadcaster := newFakeBroadcaster()
+	service := NewService(log.NewNopLogger(), repo, broadcaster)
+	return &service, repo, broadcaster
+}
+
+func chatContext(guid string) context.Context {
+	return context.WithValue(context.Background(), auth.User, &userEntities.User{GUID: guid, NickName: guid + " nickname"})
+}
+
+func TestService_SendChatMessage(t *testing.T) {
+	service, repo, broadcaster := chatService()
+
+	message, err := service.SendChatMessage(chatContext("manager-2"), "399.l.1", "  on the clock!  ")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "on the clock!", message.Text)
+	assert.Equal(t, "399.l.1.t.2", message.TeamKey)
+	assert.Equal(t, "manager-2 nickname", message.Nickname)
+	assert.Len(t, repo.chats["399.l.1"], 1)
+	if assert.Len(t, broadcaster.broadcasts, 1) {
+		assert.Equal(t, entities.BroadCastTypeChatMessage, broadcaster.broadcasts[0].Type)
+		assert.Equal(t, message.ID, broadcaster.broadcasts[0].Chat.ID)
+	}
+
+	_, err = service.SendChatMessage(chatContext("stranger"), "399.l.1", "hi")
+	assert.IsType(t, &ErrorNotInLeague{}, err)
+	_, err = service.SendChatMessage(chatContext("manager-2"), "399.l.1", " ")
+	assert.IsType(t, &ErrorChatMessage{}, err)
+	_, err = service.SendChatMessage(chatContext("manager-2"), "399.l.1", strings.Repeat("a", maxChatLength+1))
+	assert.IsType(t, &ErrorChatMessage{}, err)
+}
+
+func TestService_SendChatMessage_RateLimited(t *testing.T) {
+	service, _, _ := chatService()
+
+	for idx := 0; idx < chatLimit; idx++ {
+		_, err := service.SendChatMessage(chatContext("manager-2"), "399.l.1", "pick already")
+		assert.Nil(t, err)
+	}
+	_, err := service.SendChatMessage(chatContext("manager-2"), "399.l.1", "pick already")
+	assert.IsType(t, &ErrorChatRateLimited{}, err)
+
+	_, err = service.SendChatMessage(chatContext("manager-3"), "399.l.1", "relax")
+	assert.Nil(t, err, "each user has their own limit")
+}
+
+func Test_chatLimiter_Window(t *testing.T) {
+	limiter := newChatLimiter()
+	now := time.Now()
+	for idx := 0; idx < chatLimit; idx++ {
+		assert.True(t, limiter.allow("399.l.1", "manager-2", now))
+	}
+	assert.False(t, limiter.allow("399.l.1", "manager-2", now.Add(chatWindow-time.Second)))
+	assert.True(t, limiter.allow("399.l.1", "manager-2", now.Add(chatWindow)), "messages older than the window no longer count")
+}
+
+func TestService_MuteChatUser(t *testing.T) {
+	service, repo, _ := chatService()
+
+	_, err := service.MuteChatUser(chatContext("manager-3"), "399.l.1", "manager-2", 0)
+	assert.IsType(t, &ErrorNotCommissioner{}, err)
+	_, err = service.MuteChatUser(commissionerContext(), "399.l.1", "commish", 0)
+	assert.IsType(t, &ErrorChatMessage{}, err)
+
+	mutes, err := service.MuteChatUser(commissionerContext(), "399.l.1", "manager-2", 10*time.Minute)
+	if assert.Nil(t, err) && assert.Len(t, mutes, 1) {
+		assert.Equal(t, "commish", mutes[0].MutedBy)
+		assert.NotNil(t, mutes[0].Until)
+	}
+	_, err = service.SendChatMessage(chatContext("manager-2"), "399.l.1", "hello?")
+	assert.IsType(t, &ErrorChatMuted{}, err)
+
+	mutes, err = service.UnmuteChatUser(commissionerContext(), "399.l.1", "manager-2")
+	assert.Nil(t, err)
+	assert.Empty(t, mutes)
+	assert.Empty(t, repo.leagues["399.l.1"].ChatMutes)
+	_, err = service.SendChatMessage(chatContext("manager-2"), "399.l.1", "hello?")
+	assert.Nil(t, err)
+}
+
+func TestService_DeleteChatMessage(t *testing.T) {
+	service, _, broadcaster := chatService()
+	message, _ := service.SendChatMessage(chatContext("manager-2"), "399.l.1", "something rude")
+
+	_, err := service.DeleteChatMessage(chatContext("manager-3"), "399.l.1", message.ID)
+	assert.IsType(t, &ErrorNotCommissioner{}, err, "managers can only delete their own messages")
+
+	deleted, err := service.DeleteChatMessage(commissionerContext(), "399.l.1", message.ID)
+	if assert.Nil(t, err) {
+		assert.Empty(t, deleted.Text)
+		assert.Equal(t, "commish", deleted.DeletedBy)
+		assert.NotNil(t, deleted.DeletedAt)
+	}
+	assert.Len(t, broadcaster.broadcasts, 2, "the room is told the message was deleted")
+
+	_, err = service.DeleteChatMessage(commissionerContext(), "399.l.1", "missing")
+	assert.IsType(t, &ErrorChatMessageNotFound{}, err)
+	_, err = service.ReactToChatMessage(chatContext("manager-3"), "399.l.1", message.ID, "👍")
+	assert.IsType(t, &ErrorChatMessageNotFound{}, err, "deleted messages can not be reacted to")
+}
+
+func TestService_ReactToChatMessage(t *testing.T) {
+	service, _, _ := chatService()
+	message, _ := service.SendChatMessage(chatContext("manager-2"), "399.l.1", "took my sleeper")
+
+	reacted, err := service.ReactToChatMessage(chatContext("manager-3"), "399.l.1", message.ID, "😂")
+	if assert.Nil(t, err) {
+		assert.Equal(t, []entities.ChatReaction{{Emoji: "😂", UserGUID: "manager-3"}}, reacted.Reactions)
+	}
+	reacted, err = service.ReactToChatMessage(chatContext("manager-3"), "399.l.1", message.ID, "😂")
+	if assert.Nil(t, err) {
+		assert.Empty(t, reacted.Reactions, "reacting again takes the reaction off")
+	}
+
+	_, err = service.ReactToChatMessage(chatContext("manager-3"), "399.l.1", message.ID, "not an emoji")
+	assert.IsType(t, &ErrorChatMessage{}, err)
+}
+
+func TestService_ChatHistory(t *testing.T) {
+	service, _, _ := chatService()
+	for _, guid := range []string{"manager-2", "manager-3", "manager-4", "commish"} {
+		for idx := 0; idx < 3; idx++ {
+			_, err := service.SendChatMessage(chatContext(guid), "399.l.1", guid)
+			assert.Nil(t, err)
+		}
+	}
+
+	newest, err := service.ChatHistory(chatContext("manager-2"), "399.l.1", "", 5)
+	if !assert.Nil(t, err) || !assert.Len(t, newest.Messages, 5) {
+		return
+	}
+	assert.Equal(t, "manager-4", newest.Messages[0].Text, "pages are oldest message first")
+	assert.Equal(t, "commish", newest.Messages[4].Text)
+	assert.Equal(t, newest.Messages[0].ID, newest.Before)
+
+	older, err := service.ChatHistory(chatContext("manager-2"), "399.l.1", newest.Before, 5)
+	if assert.Nil(t, err) && assert.Len(t, older.Messages, 5) {
+		assert.True(t, older.Messages[4].ID < newest.Messages[0].ID)
+	}
+	oldest, err := service.ChatHistory(chatContext("manager-2"), "399.l.1", older.Before, 5)
+	if assert.Nil(t, err) {
+		assert.Len(t, oldest.Messages, 2)
+		assert.Empty(t, oldest.Before, "there is nothing older")
+	}
+
+	_, err = service.ChatHistory(chatContext("stranger"), "399.l.1", "", 0)
+	assert.IsType(t, &ErrorNotInLeague{}, err)
+}
@@ -0,0 +1,190 @@
+package draft
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-kit/kit/log/level"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"go.elastic.co/apm"
+	"sync"
+	"time"
+)
+
+// pickClock keeps one running timer per league for the pick that is on the clock.
+// Timers live in memory; the pick they belong to is persisted on the league as CurrentPick.
+type pickClock struct {
+	mu     *sync.Mutex
+	timers map[string]*clockTimer
+}
+
+type clockTimer struct {
+	pick      int
+	expiresAt time.Time
+	timer     *time.Timer
+}
+
+func newPickClock() *pickClock {
+	return &pickClock{
+		mu:     &sync.Mutex{},
+		timers: make(map[string]*clockTimer),
+	}
+}
+
+// start puts pick on the clock for the league, replacing any timer that is already running
+func (c *pickClock) start(leagueKey string, pick int, duration time.Duration, onExpire func(leagueKey string, pick int)) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if running, ok := c.timers[leagueKey]; ok {
+		running.timer.Stop()
+	}
+
+	expiresAt := time.Now().Add(duration)
+	c.timers[leagueKey] = &clockTimer{
+		pick:      pick,
+		expiresAt: expiresAt,
+		timer: time.AfterFunc(duration, func() {
+			onExpire(leagueKey, pick)
+		}),
+	}
+	return expiresAt
+}
+
+func (c *pickClock) stop(leagueKey string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if running, ok := c.timers[leagueKey]; ok {
+		running.timer.Stop()
+		delete(c.timers, leagueKey)
+	}
+}
+
+// current returns the pick on the clock for the league and when it expires
+func (c *pickClock) current(leagueKey string) (int, time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	running, ok := c.timers[leagueKey]
+	if !ok {
+		return 0, time.Time{}, false
+	}
+	return running.pick, running.expiresAt, true
+}
+
+// expire clears the league's timer if it still belongs to pick.
+// It returns false when the pick was made or the clock was restarted before the timer fired.
+func (c *pickClock) expire(leagueKey string, pick int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	running, ok := c.timers[leagueKey]
+	if !ok || running.pick != pick {
+		return false
+	}
+	delete(c.timers, leagueKey)
+	return true
+}
+
+// startPickClock puts the league's current pick on the clock when the league has a pick clock configured.
+// Auction drafts run on the bid timer instead, and a draft that is not open has no pick on the clock.
+func (s *Service) startPickClock(league *entities.League) {
+	if !league.DraftSettings.HasPickClock() || league.CurrentPick == 0 || league.IsAuction() || !league.State().CanPick() {
+		return
+	}
+	duration := time.Duration(league.DraftSettings.PickSeconds) * time.Second
+	expiresAt := s.clock.start(league.LeagueKey, league.CurrentPick, duration, s.pickClockExpired)
+	league.ClockExpiresAt = &expiresAt
+}
+
+func (s *Service) setClockExpiresAt(league *entities.League) {
+	pick, expiresAt, ok := s.clock.current(league.LeagueKey)
+	if ok && pick == league.CurrentPick {
+		league.ClockExpiresAt = &expiresAt
+	}
+}
+
+// advancePick moves the league past pick and resets the clock for the next one.
+// The repository has already moved current_pick on by the time this runs.
+func (s *Service) advancePick(ctx context.Context, league *entities.League, pick int) {
+	span, ctx := apm.StartSpan(ctx, "advancePick", "service")
+	defer span.End()
+
+	next := pick + 1
+	// keepers filled their picks when the draft opened, so the clock passes over them
+	for league.IsKeeperPick(next) {
+		err := s.draftRepo.AdvanceCurrentPick(ctx, league.LeagueKey, next)
+		if err != nil {
+			level.Debug(s.logger).Log("message", "could not advance past keeper pick", "error", err, "league_key", league.LeagueKey, "pick", next)
+			break
+		}
+		next++
+	}
+	league.CurrentPick = next
+	league.ClockExpiresAt = nil
+
+	if next > totalPicks(*league) {
+		s.clock.stop(league.LeagueKey)
+		return
+	}
+	s.startPickClock(league)
+}
+
+// pickClockExpired runs on the timer's goroutine once a pick's clock runs out
+func (s *Service) pickClockExpired(leagueKey string, pick int) {
+	if !s.clock.expire(leagueKey, pick) {
+		return
+	}
+
+	tx := apm.DefaultTracer.StartTransaction("PickClockExpired", "clock")
+	defer tx.End()
+	ctx := apm.ContextWithTransaction(context.Background(), tx)
+
+	league, err := s.draftRepo.GetLeague(ctx, leagueKey)
+	if err != nil {
+		level.Error(s.logger).Log("message", "could not get league for expired pick clock", "error", err, "league_key", leagueKey, "pick", pick)
+		return
+	}
+	if league.CurrentPick != pick || !league.State().CanPick() {
+		level.Debug(s.logger).Log("message", "pick clock expired for a pick that is no longer on the clock", "league_key", leagueKey, "pick", pick, "current_pick", league.CurrentPick)
+		return
+	}
+
+	if team, _, err := teamOnTheClock(league, pick); err == nil {
+		level.Info(s.logger).Log("message", "pick clock expired", "league_key", leagueKey, "pick", pick, "team_key", team.TeamKey, "team_present", s.isTeamPresent(leagueKey, team.TeamKey))
+	}
+
+	err = s.broadCastRepo.BroadCastLeagueInformation(ctx, league, fmt.Sprintf("pick %d clock expired", pick), entities.BroadCastTypeClockExpired)
+	if err != nil {
+		level.Error(s.logger).Log("message", "could not broadcast expired pick clock", "error", err, "league_key", leagueKey, "pick", pick)
+	}
+
+	if league.DraftSettings.ExpiryAction == entities.ClockExpiryAutoPick {
+		_, err = s.autoPick(ctx, league, pick)
+		if err == nil {
+			return
+		}
+		level.Error(s.logger).Log("message", "could not autopick for expired pick clock, skipping pick", "error", err, "league_key", leagueKey, "pick", pick)
+	}
+	s.skipPick(ctx, &league, pick)
+}
+
+// skipPick leaves pick empty and puts the next pick on the clock
+func (s *Service) skipPick(ctx context.Context, league *entities.League, pick int) {
+	span, ctx := apm.StartSpan(ctx, "skipPick", "service")
+	defer span.End()
+
+	err := s.draftRepo.AdvanceCurrentPick(ctx, league.LeagueKey, pick)
+	if err != nil {
+		// a pick that lands while the clock runs out wins
+		level.Debug(s.logger).Log("message", "could not skip pick", "error", err, "league_key", league.LeagueKey, "pick", pick)
+		return
+	}
+	s.advancePick(ctx, league, pick)
+
+	err = s.broadCastRepo.BroadCastLeagueInformation(ctx, *league, fmt.Sprintf("pick %d skipped", pick), entities.BroadCastTypePickSkipped)
+	if err != nil {
+		level.Error(s.logger).Log("message", "could not broadcast skipped pick", "error", err, "league_key", league.LeagueKey, "pick", pick)
+	}
+	go s.autoDraftIfEnabled(league.LeagueKey, league.CurrentPick)
+}
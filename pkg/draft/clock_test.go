@@ -0,0 +1,99 @@
+package draft
+
+import (
+	"context"
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"testing"
+	"time"
+)
+
+func Test_pickClock_expire(t *testing.T) {
+	clock := newPickClock()
+	expired := make(chan int, 1)
+	clock.start("399.l.1", 3, time.Hour, func(leagueKey string, pick int) { expired <- pick })
+
+	pick, _, ok := clock.current("399.l.1")
+	assert.True(t, ok)
+	assert.Equal(t, 3, pick)
+
+	assert.False(t, clock.expire("399.l.1", 2), "stale timers should not expire the running pick")
+	assert.True(t, clock.expire("399.l.1", 3))
+
+	_, _, ok = clock.current("399.l.1")
+	assert.False(t, ok)
+
+	clock.start("399.l.1", 4, time.Millisecond, func(leagueKey string, pick int) { expired <- pick })
+	select {
+	case pick := <-expired:
+		assert.Equal(t, 4, pick)
+	case <-time.After(time.Second):
+		t.Fatal("pick clock did not fire")
+	}
+}
+
+func TestService_OpenDraft_StartsPickClock(t *testing.T) {
+	league := testLeague()
+	league.DraftSettings = entities.DraftSettings{PickSeconds: 90, ExpiryAction: entities.ClockExpirySkip}
+	repo := newFakeDraftRepository(league)
+	service := NewService(log.NewNopLogger(), repo, newFakeBroadcaster())
+	defer service.clock.stop(league.LeagueKey)
+
+	opened, err := service.OpenDraft(commissionerContext(), league.LeagueKey)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, opened.CurrentPick)
+	assert.NotNil(t, opened.ClockExpiresAt)
+
+	pick, _, ok := service.clock.current(league.LeagueKey)
+	assert.True(t, ok)
+	assert.Equal(t, 1, pick)
+}
+
+func TestService_SaveDraftRequest_ResetsPickClock(t *testing.T) {
+	league := testLeague()
+	league.DraftStarted = true
+	league.CurrentPick = 1
+	league.DraftSettings = entities.DraftSettings{PickSeconds: 90, ExpiryAction: entities.ClockExpirySkip}
+	repo := newFakeDraftRepository(league)
+	repo.players = []entities.PlayerSeason{testPlayer("399.p.1", "QB"), testPlayer("399.p.2", "QB")}
+	service := NewService(log.NewNopLogger(), repo, newFakeBroadcaster())
+	defer service.clock.stop(league.LeagueKey)
+
+	_, err := service.SaveDraftRequest(commissionerContext(), entities.User{Guid: "commish"}, league, league.Teams[0], testPlayer("399.p.1", "QB"), 1)
+	assert.Nil(t, err)
+
+	pick, _, ok := service.clock.current(league.LeagueKey)
+	assert.True(t, ok)
+	assert.Equal(t, 2, pick)
+	saved, _ := repo.GetLeague(context.Background(), league.LeagueKey)
+	assert.Equal(t, 2, saved.CurrentPick)
+
+	_, err = service.SaveDraftRequest(commissionerContext(), entities.User{Guid: "manager-3"}, league, league.Teams[2], testPlayer("399.p.2", "QB"), 3)
+	assert.IsType(t, &ErrorPickNotOnClock{}, err)
+}
+
+func TestService_pickClockExpired_SkipsPick(t *testing.T) {
+	league := testLeague()
+	league.DraftStarted = true
+	league.CurrentPick = 5
+	league.DraftSettings = entities.DraftSettings{PickSeconds: 90, ExpiryAction: entities.ClockExpirySkip}
+	repo := newFakeDraftRepository(league)
+	broadcaster := newFakeBroadcaster()
+	service := NewService(log.NewNopLogger(), repo, broadcaster)
+	defer service.clock.stop(league.LeagueKey)
+
+	service.startPickClock(&league)
+	service.pickClockExpired(league.LeagueKey, 5)
+
+	assert.Equal(t, []entities.BroadcastType{entities.BroadCastTypeClockExpired, entities.BroadCastTypePickSkipped}, broadcaster.types())
+	saved, _ := repo.GetLeague(context.Background(), league.LeagueKey)
+	assert.Equal(t, 6, saved.CurrentPick)
+	pick, _, ok := service.clock.current(league.LeagueKey)
+	assert.True(t, ok)
+	assert.Equal(t, 6, pick)
+
+	// a timer for a pick that has already moved on does nothing
+	service.pickClockExpired(league.LeagueKey, 5)
+	assert.Len(t, broadcaster.types(), 2)
+}
@@ -0,0 +1,217 @@
+package draft
+
+import (
+	"context"
+	"errors"
+	"github.com/go-kit/kit/log/level"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"go.elastic.co/apm"
+)
+
+// UndoLastPick takes back the pick saved most recently. It goes back on the clock when it is the latest pick on the
+// board; a skipped pick the commissioner filled in is skipped again and the clock stays where it is.
+func (service *Service) UndoLastPick(ctx context.Context, leagueKey string) (*entities.DraftCorrection, error) {
+	span, ctx := apm.StartSpan(ctx, "UndoLastPick", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	defer span.End()
+
+	league, results, err := service.commissionerDraft(ctx, leagueKey)
+	if err != nil {
+		return nil, err
+	}
+	// keepers were placed before the draft and are not picks to take back
+	var last entities.DraftResult
+	latestPick := 0
+	for _, result := range results {
+		if result.Keeper {
+			continue
+		}
+		if result.Pick > latestPick {
+			latestPick = result.Pick
+		}
+		if last.Pick == 0 || result.Timestamp.After(last.Timestamp) || (result.Timestamp.Equal(last.Timestamp) && result.Pick > last.Pick) {
+			last = result
+		}
+	}
+	if last.Pick == 0 {
+		return nil, &ErrorPickNotMade{}
+	}
+
+	err = service.draftRepo.DeleteDraftResult(ctx, leagueKey, last.Pick)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not undo pick", "error", err, "league_key", leagueKey, "pick", last.Pick)
+		return nil, &ErrorUpdateDraft{}
+	}
+
+	// a completed board is no longer full, so it waits for the commissioner to resume
+	if league.State() == entities.DraftStateCompleted {
+		err = service.transitionDraft(ctx, &league, entities.DraftStatePaused, "reopen")
+		if err != nil {
+			return nil, err
+		}
+	}
+	if league.CurrentPick != 0 && last.Pick == latestPick {
+		err = service.draftRepo.SaveCurrentPick(ctx, leagueKey, last.Pick)
+		if err != nil {
+			level.Error(service.logger).Log("message", "could not save current pick", "error", err, "league_key", leagueKey, "pick", last.Pick)
+			return nil, &ErrorUpdateDraft{}
+		}
+		league.CurrentPick = last.Pick
+		if league.State().CanPick() {
+			service.startPickClock(&league)
+		}
+	}
+
+	correction := entities.DraftCorrection{Action: entities.CorrectionUndo, Pick: last.Pick, Previous: &last}
+	return &correction, service.broadcastCorrection(ctx, league, correction)
+}
+
+// ReplacePickPlayer swaps the player on a pick that has already been made, keeping the team and the clock as they are
+func (service *Service) ReplacePickPlayer(ctx context.Context, leagueKey string, pick int, playerKey string) (*entities.DraftCorrection, error) {
+	span, ctx := apm.StartSpan(ctx, "ReplacePickPlayer", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	span.Context.SetLabel("pick", pick)
+	defer span.End()
+
+	league, results, err := service.commissionerDraft(ctx, leagueKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var previous *entities.DraftResult
+	for idx := range results {
+		if results[idx].PlayerKey == playerKey {
+			return nil, &ErrorPlayerAlreadyDrafted{playerKey: playerKey}
+		}
+		if results[idx].Pick == pick {
+			previous = &results[idx]
+		}
+	}
+	if previous == nil {
+		return nil, &ErrorPickNotMade{pick: pick}
+	}
+
+	player, err := service.getPlayer(ctx, playerKey)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := service.draftRepo.ReplaceDraftResultPlayer(ctx, leagueKey, pick, player)
+	if errors.Is(err, entities.ErrPickConflict) {
+		return nil, &ErrorPlayerAlreadyDrafted{playerKey: playerKey}
+	}
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not replace player on pick", "error", err, "league_key", leagueKey, "pick", pick)
+		return nil, &ErrorUpdateDraft{}
+	}
+
+	correction := entities.DraftCorrection{Action: entities.CorrectionReplace, Pick: pick, Previous: previous, Result: result}
+	return &correction, service.broadcastCorrection(ctx, league, correction)
+}
+
+// AssignPick makes a pick on behalf of any team. It can fill the pick on the clock or one that was skipped,
+// but not a pick that has not come up yet. The pick on the clock is made like any other pick and sent to the
+// draft room as drafted, only a skipped pick that is filled in is sent as a correction.
+func (service *Service) AssignPick(ctx context.Context, leagueKey, teamKey, playerKey string, pick int) (*entities.DraftCorrection, error) {
+	span, ctx := apm.StartSpan(ctx, "AssignPick", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	span.Context.SetLabel("pick", pick)
+	defer span.End()
+
+	league, results, err := service.commissionerDraft(ctx, leagueKey)
+	if err != nil {
+		return nil, err
+	}
+	if state := league.State(); state != entities.DraftStateOpen && state != entities.DraftStatePaused {
+		return nil, &ErrorDraftState{state: state, action: "assign picks"}
+	}
+	err = validatePickAvailable(league, results, playerKey, pick)
+	if err != nil {
+		return nil, err
+	}
+	if league.CurrentPick != 0 && pick > league.CurrentPick {
+		return nil, &ErrorPickNotOnClock{pick: pick, currentPick: league.CurrentPick}
+	}
+	team, ok := leagueTeam(league, teamKey)
+	if !ok {
+		return nil, &ErrorUnknownTeam{teamKey: teamKey}
+	}
+	player, err := service.getPlayer(ctx, playerKey)
+	if err != nil {
+		return nil, err
+	}
+
+	user := commissionerUser(ctx)
+	if pick == league.CurrentPick || league.CurrentPick == 0 {
+		result, err := service.savePick(ctx, league, user, team, player, pick)
+		if err != nil {
+			return nil, err
+		}
+		return &entities.DraftCorrection{Action: entities.CorrectionAssign, Pick: pick, Result: result}, nil
+	}
+
+	result, err := service.draftRepo.SaveDraftResultFromUser(ctx, league, user, team, player, pick, getRound(pick, league))
+	if errors.Is(err, entities.ErrPickConflict) {
+		return nil, &ErrorPickConflict{pick: pick}
+	}
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not assign pick", "error", err, "league_key", leagueKey, "pick", pick)
+		return nil, &ErrorUpdateDraft{}
+	}
+	service.completeIfFull(ctx, &league)
+
+	correction := entities.DraftCorrection{Action: entities.CorrectionAssign, Pick: pick, Result: result}
+	return &correction, service.broadcastCorrection(ctx, league, correction)
+}
+
+// commissionerDraft loads the league and its picks for a commissioner only operation
+func (service *Service) commissionerDraft(ctx context.Context, leagueKey string) (entities.League, []entities.DraftResult, error) {
+	league, err := service.commissionerLeague(ctx, leagueKey)
+	if err != nil {
+		return league, nil, err
+	}
+
+	results, err := service.draftRepo.GetDraftResults(ctx, leagueKey)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not get draft results", "error", err, "league_key", leagueKey)
+		return league, nil, err
+	}
+	return league, results, nil
+}
+
+// commissionerLeague loads the league for a commissioner only operation
+func (service *Service) commissionerLeague(ctx context.Context, leagueKey string) (entities.League, error) {
+	league, err := service.draftRepo.GetLeague(ctx, leagueKey)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not get league", "error", err, "league_key", leagueKey)
+		return league, err
+	}
+	if !isUserCommissioner(ctx, league) {
+		return league, &ErrorNotCommissioner{}
+	}
+	return league, nil
+}
+
+// broadcastCorrection rebuilds every roster and sends them with the correction to the draft room
+func (service *Service) broadcastCorrection(ctx context.Context, league entities.League, correction entities.DraftCorrection) error {
+	rosters, err := service.buildRosters(ctx, league)
+	if err != nil {
+		return err
+	}
+
+	err = service.broadCastRepo.BroadCastDraftCorrection(ctx, league, commissionerUser(ctx), correction, rosters)
+	if err != nil {
+		level.Error(service.logger).Log("message", "error in broadcasting draft correction", "error", err, "league_key", league.LeagueKey, "pick", correction.Pick)
+		return &ErrorUpdateDraft{}
+	}
+	return nil
+}
+
+// commissionerUser is the logged in user as the draft room knows them
+func commissionerUser(ctx context.Context) entities.User {
+	user, ok := userFromContext(ctx)
+	if !ok {
+		return entities.User{}
+	}
+	return entities.User{Email: user.Email, Name: user.Name, Nickname: user.NickName, Guid: user.GUID, IsCommissioner: true}
+}
@@ -0,0 +1,174 @@
+package draft
+
+import (
+	"context"
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/thethan/fdr-users/pkg/auth"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	userEntities "github.com/thethan/fdr-users/pkg/users/entities"
+	"testing"
+)
+
+// draftedLeague is testLeague with the first two picks made and the third on the clock
+func draftedLeague(t *testing.T) (entities.League, *fakeDraftRepository, *fakeBroadcaster, Service) {
+	league := testLeague()
+	league.DraftStarted = true
+	league.CurrentPick = 1
+	repo := newFakeDraftRepository(league)
+	repo.players = []entities.PlayerSeason{
+		testPlayer("399.p.1", "QB"), testPlayer("399.p.2", "RB"), testPlayer("399.p.3", "WR"), testPlayer("399.p.4", "RB"),
+	}
+	broadcaster := newFakeBroadcaster()
+	service := NewService(log.NewNopLogger(), repo, broadcaster)
+
+	_, err := service.SaveDraftRequest(commissionerContext(), entities.User{Guid: "commish"}, league, league.Teams[0], testPlayer("399.p.1"), 1)
+	assert.Nil(t, err)
+	_, err = service.SaveDraftRequest(commissionerContext(), entities.User{Guid: "commish"}, league, league.Teams[1], testPlayer("399.p.2"), 2)
+	assert.Nil(t, err)
+	league, _ = repo.GetLeague(context.Background(), league.LeagueKey)
+	return league, repo, broadcaster, service
+}
+
+func TestService_CommissionerOnly(t *testing.T) {
+	league, _, _, service := draftedLeague(t)
+	managerContext := context.WithValue(context.Background(), auth.User, &userEntities.User{GUID: "manager-2"})
+
+	_, err := service.UndoLastPick(managerContext, league.LeagueKey)
+	assert.IsType(t, &ErrorNotCommissioner{}, err)
+	_, err = service.ReplacePickPlayer(managerContext, league.LeagueKey, 2, "399.p.3")
+	assert.IsType(t, &ErrorNotCommissioner{}, err)
+	_, err = service.AssignPick(managerContext, league.LeagueKey, "399.l.1.t.2", "399.p.3", 3)
+	assert.IsType(t, &ErrorNotCommissioner{}, err)
+}
+
+func TestService_UndoLastPick(t *testing.T) {
+	league, repo, broadcaster, service := draftedLeague(t)
+	defer service.clock.stop(league.LeagueKey)
+
+	correction, err := service.UndoLastPick(commissionerContext(), league.LeagueKey)
+	assert.Nil(t, err)
+	assert.Equal(t, entities.CorrectionUndo, correction.Action)
+	assert.Equal(t, 2, correction.Pick)
+	assert.Equal(t, "399.p.2", correction.Previous.PlayerKey)
+
+	results, _ := repo.GetDraftResults(context.Background(), league.LeagueKey)
+	assert.Len(t, results, 1)
+	saved, _ := repo.GetLeague(context.Background(), league.LeagueKey)
+	assert.Equal(t, 2, saved.CurrentPick, "the undone pick goes back on the clock")
+
+	broadcast := broadcaster.last()
+	assert.Equal(t, entities.BroadCastTypePickCorrected, broadcast.Type)
+	assert.Empty(t, broadcast.Rosters["399.l.1.t.2"].Roster["RB"].DraftResults)
+
+	_, err = service.UndoLastPick(commissionerContext(), league.LeagueKey)
+	assert.Nil(t, err)
+	_, err = service.UndoLastPick(commissionerContext(), league.LeagueKey)
+	assert.IsType(t, &ErrorPickNotMade{}, err)
+}
+
+func TestService_ReplacePickPlayer(t *testing.T) {
+	league, repo, broadcaster, service := draftedLeague(t)
+
+	_, err := service.ReplacePickPlayer(commissionerContext(), league.LeagueKey, 2, "399.p.1")
+	assert.IsType(t, &ErrorPlayerAlreadyDrafted{}, err)
+	_, err = service.ReplacePickPlayer(commissionerContext(), league.LeagueKey, 3, "399.p.3")
+	assert.IsType(t, &ErrorPickNotMade{}, err)
+	_, err = service.ReplacePickPlayer(commissionerContext(), league.LeagueKey, 2, "399.p.9")
+	assert.IsType(t, &ErrorUnknownPlayer{}, err)
+
+	correction, err := service.ReplacePickPlayer(commissionerContext(), league.LeagueKey, 2, "399.p.3")
+	assert.Nil(t, err)
+	assert.Equal(t, "399.p.2", correction.Previous.PlayerKey)
+	assert.Equal(t, "399.p.3", correction.Result.PlayerKey)
+	assert.Equal(t, "399.l.1.t.2", correction.Result.TeamKey)
+
+	saved, _ := repo.GetLeague(context.Background(), league.LeagueKey)
+	assert.Equal(t, 3, saved.CurrentPick, "replacing a player does not move the clock")
+
+	broadcast := broadcaster.last()
+	assert.Equal(t, entities.BroadCastTypePickCorrected, broadcast.Type)
+	assert.Len(t, broadcast.Rosters["399.l.1.t.2"].Roster["WR"].DraftResults, 1)
+}
+
+func TestService_AssignPick(t *testing.T) {
+	league, repo, broadcaster, service := draftedLeague(t)
+	defer service.clock.stop(league.LeagueKey)
+
+	_, err := service.AssignPick(commissionerContext(), league.LeagueKey, "399.l.1.t.3", "399.p.3", 4)
+	assert.IsType(t, &ErrorPickNotOnClock{}, err)
+	_, err = service.AssignPick(commissionerContext(), league.LeagueKey, "399.l.1.t.9", "399.p.3", 3)
+	assert.IsType(t, &ErrorUnknownTeam{}, err)
+	_, err = service.AssignPick(commissionerContext(), league.LeagueKey, "399.l.1.t.3", "399.p.1", 3)
+	assert.IsType(t, &ErrorPlayerAlreadyDrafted{}, err)
+
+	// any team can be given the pick on the clock, which goes out to the draft room once like any other pick
+	before := len(broadcaster.types())
+	correction, err := service.AssignPick(commissionerContext(), league.LeagueKey, "399.l.1.t.4", "399.p.3", 3)
+	assert.Nil(t, err)
+	assert.Equal(t, entities.CorrectionAssign, correction.Action)
+	assert.Equal(t, "399.l.1.t.4", correction.Result.TeamKey)
+	saved, _ := repo.GetLeague(context.Background(), league.LeagueKey)
+	assert.Equal(t, 4, saved.CurrentPick)
+	assert.Equal(t, []entities.BroadcastType{entities.BroadCastTypePlayerDrafted}, broadcaster.types()[before:])
+
+	// a skipped pick can be filled in without touching the clock
+	_, err = service.UndoLastPick(commissionerContext(), league.LeagueKey)
+	assert.Nil(t, err)
+	_ = repo.SaveCurrentPick(context.Background(), league.LeagueKey, 5)
+	correction, err = service.AssignPick(commissionerContext(), league.LeagueKey, "399.l.1.t.3", "399.p.4", 3)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, correction.Result.Round, "pick three of a four team league is in round one")
+	saved, _ = repo.GetLeague(context.Background(), league.LeagueKey)
+	assert.Equal(t, 5, saved.CurrentPick)
+	assert.Equal(t, entities.BroadCastTypePickCorrected, broadcaster.last().Type)
+}
+
+func TestService_UndoLastPick_FilledInPick(t *testing.T) {
+	league, repo, _, service := draftedLeague(t)
+	defer service.clock.stop(league.LeagueKey)
+
+	// picks three and four are skipped and five is made before the commissioner fills in three
+	_ = repo.SaveCurrentPick(context.Background(), league.LeagueKey, 5)
+	league.CurrentPick = 5
+	_, err := service.SaveDraftRequest(commissionerContext(), entities.User{Guid: "commish"}, league, league.Teams[3], testPlayer("399.p.4"), 5)
+	assert.Nil(t, err)
+	_, err = service.AssignPick(commissionerContext(), league.LeagueKey, "399.l.1.t.3", "399.p.3", 3)
+	assert.Nil(t, err)
+
+	correction, err := service.UndoLastPick(commissionerContext(), league.LeagueKey)
+	if assert.Nil(t, err) {
+		assert.Equal(t, 3, correction.Pick, "the pick filled in last is undone, not the highest pick")
+	}
+	saved, _ := repo.GetLeague(context.Background(), league.LeagueKey)
+	assert.Equal(t, 6, saved.CurrentPick, "a filled in pick is skipped again without moving the clock")
+
+	correction, err = service.UndoLastPick(commissionerContext(), league.LeagueKey)
+	if assert.Nil(t, err) {
+		assert.Equal(t, 5, correction.Pick)
+	}
+	saved, _ = repo.GetLeague(context.Background(), league.LeagueKey)
+	assert.Equal(t, 5, saved.CurrentPick)
+}
+
+func TestService_AssignPick_Paused(t *testing.T) {
+	league := testLeague()
+	league.DraftSettings = entities.DraftSettings{PickSeconds: 90, ExpiryAction: entities.ClockExpirySkip}
+	repo := newFakeDraftRepository(league)
+	repo.players = []entities.PlayerSeason{testPlayer("399.p.1", "QB")}
+	service := NewService(log.NewNopLogger(), repo, newFakeBroadcaster())
+	defer service.clock.stop(league.LeagueKey)
+
+	_, err := service.OpenDraft(commissionerContext(), league.LeagueKey)
+	assert.Nil(t, err)
+	_, err = service.PauseDraft(commissionerContext(), league.LeagueKey)
+	assert.Nil(t, err)
+
+	_, err = service.AssignPick(commissionerContext(), league.LeagueKey, "399.l.1.t.1", "399.p.1", 1)
+	assert.Nil(t, err)
+	saved, _ := repo.GetLeague(context.Background(), league.LeagueKey)
+	assert.Equal(t, 2, saved.CurrentPick)
+	assert.Equal(t, entities.DraftStatePaused, saved.State())
+	_, _, running := service.clock.current(league.LeagueKey)
+	assert.False(t, running, "the next pick waits for the draft to resume")
+}
@@ -0,0 +1,63 @@
+package draft
+
+import (
+	"fmt"
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestService_SaveDraftRequest_ParallelPicks(t *testing.T) {
+	const requests = 50
+
+	league := testLeague()
+	league.DraftStarted = true
+	league.CurrentPick = 1
+	repo := newFakeDraftRepository(league)
+	for idx := 0; idx < requests; idx++ {
+		repo.players = append(repo.players, testPlayer(fmt.Sprintf("399.p.%d", idx), "RB"))
+	}
+	service := NewService(log.NewNopLogger(), repo, newFakeBroadcaster())
+
+	var wg sync.WaitGroup
+	errs := make(chan error, requests)
+	start := make(chan struct{})
+	for idx := 0; idx < requests; idx++ {
+		// half the requests fight over the same player, the rest over the same pick
+		playerKey := fmt.Sprintf("399.p.%d", idx)
+		if idx%2 == 0 {
+			playerKey = "399.p.0"
+		}
+		wg.Add(1)
+		go func(playerKey string) {
+			defer wg.Done()
+			<-start
+			_, err := service.SaveDraftRequest(commissionerContext(), entities.User{Guid: "commish"}, league, league.Teams[0], testPlayer(playerKey), 1)
+			errs <- err
+		}(playerKey)
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+
+	saved := 0
+	for err := range errs {
+		if err == nil {
+			saved++
+			continue
+		}
+		statusErr, ok := err.(interface{ StatusCode() int })
+		if assert.True(t, ok, "losing request should get a typed error, got %v", err) {
+			assert.Equal(t, http.StatusConflict, statusErr.StatusCode(), err.Error())
+		}
+	}
+	assert.Equal(t, 1, saved)
+
+	results, _ := repo.GetDraftResults(commissionerContext(), league.LeagueKey)
+	assert.Len(t, results, 1)
+	current, _ := repo.GetLeague(commissionerContext(), league.LeagueKey)
+	assert.Equal(t, 2, current.CurrentPick)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/thethan/fdr-users/pkg/draft/entities"
 	entities2 "github.com/thethan/fdr-users/pkg/users/entities"
 	"go.elastic.co/apm"
+	"time"
 )
 
 type Endpoints struct {
@@ -23,6 +24,42 @@ type Endpoints struct {
 	GetUserPlayerPreference  endpoint.Endpoint
 	OpenDraft                endpoint.Endpoint
 	ShuffleDraftOrder        endpoint.Endpoint
+	UpdateDraftSettings      endpoint.Endpoint
+	UndoLastPick             endpoint.Endpoint
+	ReplacePickPlayer        endpoint.Endpoint
+	AssignPick               endpoint.Endpoint
+	PauseDraft               endpoint.Endpoint
+	ResumeDraft              endpoint.Endpoint
+	CloseDraft               endpoint.Endpoint
+	GetDraftGrades           endpoint.Endpoint
+	ExportDraft              endpoint.Endpoint
+	GetDraftTimeline         endpoint.Endpoint
+	ReplayDraft              endpoint.Endpoint
+	SyncDraftToYahoo         endpoint.Endpoint
+	GetAuction               endpoint.Endpoint
+	Nominate                 endpoint.Endpoint
+	Bid                      endpoint.Endpoint
+	GetKeepers               endpoint.Endpoint
+	DeclareKeepers           endpoint.Endpoint
+	GetPickTrades            endpoint.Endpoint
+	ProposePickTrade         endpoint.Endpoint
+	ApprovePickTrade         endpoint.Endpoint
+	RejectPickTrade          endpoint.Endpoint
+	StartMockDraft           endpoint.Endpoint
+	ListMockDrafts           endpoint.Endpoint
+	GetMockDraft             endpoint.Endpoint
+	MockDraftPick            endpoint.Endpoint
+	GetChatHistory           endpoint.Endpoint
+	SendChatMessage          endpoint.Endpoint
+	DeleteChatMessage        endpoint.Endpoint
+	ReactToChatMessage       endpoint.Endpoint
+	MuteChatUser             endpoint.Endpoint
+	UnmuteChatUser           endpoint.Endpoint
+	GetDraftPresence         endpoint.Endpoint
+	Heartbeat                endpoint.Endpoint
+	LeaveDraftRoom           endpoint.Endpoint
+	RunDraftLottery          endpoint.Endpoint
+	GetDraftLottery          endpoint.Endpoint
 }
 
 func NewEndpoints(logger log.Logger, service *Service, authService *auth.AuthService, authMiddleware endpoint.Middleware, getUserInfoMiddleWare endpoint.Middleware) Endpoints {
@@ -35,6 +72,42 @@ func NewEndpoints(logger log.Logger, service *Service, authService *auth.AuthSer
 		SaveUserPlayerPreference: authMiddleware(getUserInfoMiddleWare(makeSaveUserPlayerPreference(logger, service))),
 		OpenDraft:                authMiddleware(getUserInfoMiddleWare(makeOpenDraft(logger, service))),
 		ShuffleDraftOrder:        authMiddleware(getUserInfoMiddleWare(makeShuffle(logger, service))),
+		UpdateDraftSettings:      authMiddleware(getUserInfoMiddleWare(makeUpdateDraftSettings(logger, service))),
+		UndoLastPick:             authMiddleware(getUserInfoMiddleWare(makeUndoLastPick(logger, service))),
+		ReplacePickPlayer:        authMiddleware(getUserInfoMiddleWare(makeReplacePickPlayer(logger, service))),
+		AssignPick:               authMiddleware(getUserInfoMiddleWare(makeAssignPick(logger, service))),
+		PauseDraft:               authMiddleware(getUserInfoMiddleWare(makeDraftState(logger, service.PauseDraft))),
+		ResumeDraft:              authMiddleware(getUserInfoMiddleWare(makeDraftState(logger, service.ResumeDraft))),
+		CloseDraft:               authMiddleware(getUserInfoMiddleWare(makeDraftState(logger, service.CloseDraft))),
+		GetDraftGrades:           authMiddleware(getUserInfoMiddleWare(makeGetDraftGrades(logger, service))),
+		ExportDraft:              authMiddleware(getUserInfoMiddleWare(makeExportDraft(logger, service))),
+		GetDraftTimeline:         authMiddleware(makeGetDraftTimeline(logger, service)),
+		ReplayDraft:              authMiddleware(makeReplayDraft(logger, service)),
+		SyncDraftToYahoo:         authMiddleware(getUserInfoMiddleWare(makeSyncDraftToYahoo(logger, service))),
+		GetAuction:               authMiddleware(makeGetAuction(logger, service)),
+		Nominate:                 authMiddleware(getUserInfoMiddleWare(makeNominate(logger, service))),
+		Bid:                      authMiddleware(getUserInfoMiddleWare(makeBid(logger, service))),
+		GetKeepers:               authMiddleware(makeGetKeepers(logger, service)),
+		DeclareKeepers:           authMiddleware(getUserInfoMiddleWare(makeDeclareKeepers(logger, service))),
+		GetPickTrades:            authMiddleware(makeGetPickTrades(logger, service)),
+		ProposePickTrade:         authMiddleware(getUserInfoMiddleWare(makeProposePickTrade(logger, service))),
+		ApprovePickTrade:         authMiddleware(getUserInfoMiddleWare(makePickTradeDecision(logger, service.ApprovePickTrade))),
+		RejectPickTrade:          authMiddleware(getUserInfoMiddleWare(makePickTradeDecision(logger, service.RejectPickTrade))),
+		StartMockDraft:           authMiddleware(getUserInfoMiddleWare(makeStartMockDraft(logger, service))),
+		ListMockDrafts:           authMiddleware(getUserInfoMiddleWare(makeListMockDrafts(logger, service))),
+		GetMockDraft:             authMiddleware(getUserInfoMiddleWare(makeGetMockDraft(logger, service))),
+		MockDraftPick:            authMiddleware(getUserInfoMiddleWare(makeMockDraftPick(logger, service))),
+		GetChatHistory:           authMiddleware(getUserInfoMiddleWare(makeGetChatHistory(logger, service))),
+		SendChatMessage:          authMiddleware(getUserInfoMiddleWare(makeSendChatMessage(logger, service))),
+		DeleteChatMessage:        authMiddleware(getUserInfoMiddleWare(makeDeleteChatMessage(logger, service))),
+		ReactToChatMessage:       authMiddleware(getUserInfoMiddleWare(makeReactToChatMessage(logger, service))),
+		MuteChatUser:             authMiddleware(getUserInfoMiddleWare(makeMuteChatUser(logger, service))),
+		UnmuteChatUser:           authMiddleware(getUserInfoMiddleWare(makeUnmuteChatUser(logger, service))),
+		GetDraftPresence:         authMiddleware(makeGetDraftPresence(logger, service)),
+		Heartbeat:                authMiddleware(getUserInfoMiddleWare(makeHeartbeat(logger, service))),
+		LeaveDraftRoom:           authMiddleware(getUserInfoMiddleWare(makeLeaveDraftRoom(logger, service))),
+		RunDraftLottery:          authMiddleware(getUserInfoMiddleWare(makeRunDraftLottery(logger, service))),
+		GetDraftLottery:          authMiddleware(makeGetDraftLottery(logger, service)),
 	}
 
 	return e
@@ -52,6 +125,7 @@ func makeImportDraft(logger log.Logger, service *Service) endpoint.Endpoint {
 type DraftResultResponse struct {
 	League       *entities.League       `json:"league"`
 	DraftResults []entities.DraftResult `json:"draft_results"`
+	Picks        []entities.DraftPick   `json:"picks,omitempty"`
 }
 
 type DraftTeamRostersResponse struct {
@@ -100,7 +174,7 @@ func makeGetDraftInfo(logger log.Logger, service *Service) endpoint.Endpoint {
 			teams[idx] = league.Teams[teamKeyToIdx[teamKey]]
 		}
 		league.TeamDraftOrder = teams
-		return &DraftResultResponse{DraftResults: results, League: league}, err
+		return &DraftResultResponse{DraftResults: results, League: league, Picks: draftPicks(*league)}, err
 	}
 }
 
@@ -201,6 +275,308 @@ func makeShuffle(logger log.Logger, service *Service) endpoint.Endpoint {
 	}
 }
 
+func makeUpdateDraftSettings(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "makeUpdateDraftSettings", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*UpdateDraftSettingsRequest)
+		if !ok {
+			return nil, errors.New("Could not get request")
+		}
+		league, err := service.UpdateDraftSettings(ctx, req.LeagueID, req.Settings)
+		return league, err
+	}
+}
+
+// makeDraftState builds the pause, resume and close endpoints, which only differ by the service method they call
+func makeDraftState(logger log.Logger, transition func(ctx context.Context, leagueKey string) (*entities.League, error)) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "makeDraftState", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*DraftStateRequest)
+		if !ok {
+			return nil, errors.New("Could not get request")
+		}
+		return transition(ctx, req.LeagueID)
+	}
+}
+
+func makeGetDraftGrades(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "GetDraftGrades", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*LeagueDraftRequest)
+		if !ok {
+			level.Error(logger).Log("message", "could not get request")
+			return nil, errors.New("bad request for draft grades")
+		}
+		return service.DraftGrades(ctx, req.LeagueKey)
+	}
+}
+
+func makeGetDraftTimeline(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "GetDraftTimeline", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*LeagueDraftRequest)
+		if !ok {
+			level.Error(logger).Log("message", "could not get request")
+			return nil, errors.New("bad request for draft timeline")
+		}
+		return service.DraftTimeline(ctx, req.LeagueKey)
+	}
+}
+
+func makeReplayDraft(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "ReplayDraft", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*DraftReplayRequest)
+		if !ok {
+			level.Error(logger).Log("message", "could not get request")
+			return nil, errors.New("bad request for draft replay")
+		}
+		return service.ReplayDraft(ctx, req.LeagueKey, req.Pick)
+	}
+}
+
+func makeSyncDraftToYahoo(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "SyncDraftToYahoo", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*LeagueDraftRequest)
+		if !ok {
+			level.Error(logger).Log("message", "could not get request")
+			return nil, errors.New("bad request for yahoo sync")
+		}
+		return service.SyncDraftToYahoo(ctx, req.LeagueKey)
+	}
+}
+
+func makeExportDraft(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "ExportDraft", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*DraftExportRequest)
+		if !ok {
+			level.Error(logger).Log("message", "could not get request")
+			return nil, errors.New("bad request for export draft")
+		}
+		export, err := service.ExportDraft(ctx, req.LeagueKey)
+		if err != nil {
+			return nil, err
+		}
+		return &DraftExportResponse{Format: req.Format, Export: export}, nil
+	}
+}
+
+func makeUndoLastPick(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "makeUndoLastPick", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*UndoLastPickRequest)
+		if !ok {
+			return nil, errors.New("Could not get request")
+		}
+		return service.UndoLastPick(ctx, req.LeagueID)
+	}
+}
+
+func makeReplacePickPlayer(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "makeReplacePickPlayer", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*ReplacePickPlayerRequest)
+		if !ok {
+			return nil, errors.New("Could not get request")
+		}
+		return service.ReplacePickPlayer(ctx, req.LeagueID, req.Pick, req.PlayerKey)
+	}
+}
+
+func makeAssignPick(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "makeAssignPick", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*AssignPickRequest)
+		if !ok {
+			return nil, errors.New("Could not get request")
+		}
+		return service.AssignPick(ctx, req.LeagueID, req.TeamKey, req.PlayerKey, req.Pick)
+	}
+}
+
+func makeGetAuction(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "GetAuction", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*LeagueDraftRequest)
+		if !ok {
+			level.Error(logger).Log("message", "could not get request")
+			return nil, errors.New("bad request for get auction")
+		}
+		return service.GetAuction(ctx, req.LeagueKey)
+	}
+}
+
+func makeNominate(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "Nominate", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*NominateRequest)
+		if !ok {
+			return nil, errors.New("Could not get request")
+		}
+		return service.Nominate(ctx, req.LeagueID, req.TeamKey, req.PlayerKey, req.Amount)
+	}
+}
+
+func makeBid(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "Bid", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*BidRequest)
+		if !ok {
+			return nil, errors.New("Could not get request")
+		}
+		return service.Bid(ctx, req.LeagueID, req.TeamKey, req.Amount)
+	}
+}
+
+func makeGetKeepers(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "GetKeepers", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*LeagueDraftRequest)
+		if !ok {
+			level.Error(logger).Log("message", "could not get request")
+			return nil, errors.New("bad request for get keepers")
+		}
+		return service.GetKeepers(ctx, req.LeagueKey)
+	}
+}
+
+func makeDeclareKeepers(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "DeclareKeepers", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*DeclareKeepersRequest)
+		if !ok {
+			return nil, errors.New("Could not get request")
+		}
+		return service.DeclareKeepers(ctx, req.LeagueID, req.TeamKey, req.PlayerKeys)
+	}
+}
+
+func makeGetPickTrades(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "GetPickTrades", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*LeagueDraftRequest)
+		if !ok {
+			level.Error(logger).Log("message", "could not get request")
+			return nil, errors.New("bad request for get pick trades")
+		}
+		return service.GetPickTrades(ctx, req.LeagueKey)
+	}
+}
+
+func makeProposePickTrade(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "ProposePickTrade", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*ProposePickTradeRequest)
+		if !ok {
+			return nil, errors.New("Could not get request")
+		}
+		return service.ProposePickTrade(ctx, req.LeagueID, req.Picks)
+	}
+}
+
+func makePickTradeDecision(logger log.Logger, decide func(ctx context.Context, leagueKey, tradeID string) (*entities.PickTrade, error)) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "makePickTradeDecision", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*PickTradeDecisionRequest)
+		if !ok {
+			return nil, errors.New("Could not get request")
+		}
+		return decide(ctx, req.LeagueID, req.TradeID)
+	}
+}
+
+func makeStartMockDraft(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "StartMockDraft", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*LeagueDraftRequest)
+		if !ok {
+			level.Error(logger).Log("message", "could not get request")
+			return nil, errors.New("bad request for start mock draft")
+		}
+		return service.StartMockDraft(ctx, req.LeagueKey)
+	}
+}
+
+func makeListMockDrafts(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "ListMockDrafts", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*LeagueDraftRequest)
+		if !ok {
+			level.Error(logger).Log("message", "could not get request")
+			return nil, errors.New("bad request for list mock drafts")
+		}
+		return service.ListMockDrafts(ctx, req.LeagueKey)
+	}
+}
+
+func makeGetMockDraft(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "GetMockDraft", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*MockDraftRequest)
+		if !ok {
+			return nil, errors.New("Could not get request")
+		}
+		return service.GetMockDraft(ctx, req.MockID, req.Through)
+	}
+}
+
+func makeMockDraftPick(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "MockDraftPick", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*MockDraftPickRequest)
+		if !ok {
+			return nil, errors.New("Could not get request")
+		}
+		return service.MockDraftPick(ctx, req.MockID, req.PlayerKey)
+	}
+}
+
 const LeagueKey = "league_key"
 
 func NewUserHasAccessToDraftMiddleware(logger log.Logger, a *auth.AuthService) endpoint.Middleware {
@@ -220,3 +596,157 @@ func NewUserHasAccessToDraftMiddleware(logger log.Logger, a *auth.AuthService) e
 		}
 	}
 }
+
+func makeGetChatHistory(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "GetChatHistory", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*ChatHistoryRequest)
+		if !ok {
+			level.Error(logger).Log("message", "could not get request")
+			return nil, errors.New("bad request for chat history")
+		}
+		return service.ChatHistory(ctx, req.LeagueKey, req.Before, req.Limit)
+	}
+}
+
+func makeSendChatMessage(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "SendChatMessage", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*ChatMessageRequest)
+		if !ok {
+			level.Error(logger).Log("message", "could not get request")
+			return nil, errors.New("bad request for chat message")
+		}
+		return service.SendChatMessage(ctx, req.LeagueKey, req.Text)
+	}
+}
+
+func makeDeleteChatMessage(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "DeleteChatMessage", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*ChatMessageIDRequest)
+		if !ok {
+			level.Error(logger).Log("message", "could not get request")
+			return nil, errors.New("bad request for deleting chat message")
+		}
+		return service.DeleteChatMessage(ctx, req.LeagueKey, req.MessageID)
+	}
+}
+
+func makeReactToChatMessage(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "ReactToChatMessage", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*ChatReactionRequest)
+		if !ok {
+			level.Error(logger).Log("message", "could not get request")
+			return nil, errors.New("bad request for chat reaction")
+		}
+		return service.ReactToChatMessage(ctx, req.LeagueKey, req.MessageID, req.Emoji)
+	}
+}
+
+func makeMuteChatUser(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "MuteChatUser", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*ChatMuteRequest)
+		if !ok {
+			level.Error(logger).Log("message", "could not get request")
+			return nil, errors.New("bad request for chat mute")
+		}
+		return service.MuteChatUser(ctx, req.LeagueKey, req.UserGUID, time.Duration(req.Minutes)*time.Minute)
+	}
+}
+
+func makeUnmuteChatUser(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "UnmuteChatUser", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*ChatMuteRequest)
+		if !ok {
+			level.Error(logger).Log("message", "could not get request")
+			return nil, errors.New("bad request for chat unmute")
+		}
+		return service.UnmuteChatUser(ctx, req.LeagueKey, req.UserGUID)
+	}
+}
+
+func makeHeartbeat(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "Heartbeat", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*LeagueDraftRequest)
+		if !ok {
+			level.Error(logger).Log("message", "could not get request")
+			return nil, errors.New("bad request for heartbeat")
+		}
+		return service.Heartbeat(ctx, req.LeagueKey)
+	}
+}
+
+func makeLeaveDraftRoom(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "LeaveDraftRoom", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*LeagueDraftRequest)
+		if !ok {
+			level.Error(logger).Log("message", "could not get request")
+			return nil, errors.New("bad request for leaving draft room")
+		}
+		return service.LeaveDraftRoom(ctx, req.LeagueKey)
+	}
+}
+
+func makeGetDraftPresence(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "GetDraftPresence", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*LeagueDraftRequest)
+		if !ok {
+			level.Error(logger).Log("message", "could not get request")
+			return nil, errors.New("bad request for draft presence")
+		}
+		return service.DraftPresence(ctx, req.LeagueKey)
+	}
+}
+
+func makeRunDraftLottery(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "RunDraftLottery", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*DraftLotteryRequest)
+		if !ok {
+			level.Error(logger).Log("message", "could not get request")
+			return nil, errors.New("bad request for draft lottery")
+		}
+		return service.RunDraftLottery(ctx, req.LeagueKey, req.Method, time.Duration(req.RevealSeconds)*time.Second)
+	}
+}
+
+func makeGetDraftLottery(logger log.Logger, service *Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		span, ctx := apm.StartSpan(ctx, "GetDraftLottery", "endpoint")
+		defer span.End()
+
+		req, ok := request.(*LeagueDraftRequest)
+		if !ok {
+			level.Error(logger).Log("message", "could not get request")
+			return nil, errors.New("bad request for draft lottery")
+		}
+		return service.GetDraftLottery(ctx, req.LeagueKey)
+	}
+}
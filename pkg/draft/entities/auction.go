@@ -0,0 +1,73 @@
+package entities
+
+import "time"
+
+const (
+	DefaultAuctionBudget     = 200
+	DefaultAuctionMinBid     = 1
+	DefaultAuctionBidSeconds = 30
+)
+
+// AuctionSettings configure a salary cap auction draft. Zero values fall back to the defaults above.
+type AuctionSettings struct {
+	Budget     int `json:"budget" bson:"budget"`
+	MinBid     int `json:"min_bid" bson:"min_bid"`
+	BidSeconds int `json:"bid_seconds" bson:"bid_seconds"`
+}
+
+func (a AuctionSettings) TeamBudget() int {
+	if a.Budget == 0 {
+		return DefaultAuctionBudget
+	}
+	return a.Budget
+}
+
+func (a AuctionSettings) MinimumBid() int {
+	if a.MinBid == 0 {
+		return DefaultAuctionMinBid
+	}
+	return a.MinBid
+}
+
+func (a AuctionSettings) BidDuration() time.Duration {
+	if a.BidSeconds == 0 {
+		return DefaultAuctionBidSeconds * time.Second
+	}
+	return time.Duration(a.BidSeconds) * time.Second
+}
+
+// Bid is a team's offer for the player on the block
+type Bid struct {
+	TeamKey   string    `json:"team_key"`
+	UserGUID  string    `json:"user_guid"`
+	Amount    int       `json:"amount"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// AuctionLot is the player currently up for bid. The nominating team's opening bid is the first HighBid.
+type AuctionLot struct {
+	Pick         int          `json:"pick"`
+	PlayerKey    string       `json:"player_key"`
+	Player       PlayerSeason `json:"player"`
+	NominatedBy  string       `json:"nominated_by"`
+	HighBid      Bid          `json:"high_bid"`
+	BidExpiresAt time.Time    `json:"bid_expires_at"`
+}
+
+// TeamBudget is what a team has left to spend. MaxBid keeps the minimum bid back for every other open roster slot.
+type TeamBudget struct {
+	TeamKey   string `json:"team_key"`
+	Budget    int    `json:"budget"`
+	Spent     int    `json:"spent"`
+	Remaining int    `json:"remaining"`
+	OpenSlots int    `json:"open_slots"`
+	MaxBid    int    `json:"max_bid"`
+}
+
+// Auction is the state of an auction draft room
+type Auction struct {
+	LeagueKey      string       `json:"league_key"`
+	NominatingTeam string       `json:"nominating_team,omitempty"`
+	Lot            *AuctionLot  `json:"lot,omitempty"`
+	Budgets        []TeamBudget `json:"budgets"`
+}
@@ -6,4 +6,47 @@ const (
 	BroadCastTypeDraftOpen BroadcastType = iota
 	BroadCastTypeDraftOrder
 	BroadCastTypePlayerDrafted
+	BroadCastTypeClockExpired
+	BroadCastTypePickSkipped
+	BroadCastTypePickCorrected
+	BroadCastTypeDraftPaused
+	BroadCastTypeDraftResumed
+	BroadCastTypeDraftCompleted
+	BroadCastTypeNomination
+	BroadCastTypeBid
+	BroadCastTypePlayerSold
+	BroadCastTypeKeepersDeclared
+	BroadCastTypePickTradeProposed
+	BroadCastTypePickTraded
+	BroadCastTypePickTradeRejected
+	BroadCastTypeDraftGraded
+	BroadCastTypeChatMessage
+	BroadCastTypeManagerJoined
+	BroadCastTypeManagerLeft
+	BroadCastTypeLotteryDraw
+	BroadCastTypeLotUnsold
 )
+
+// DraftChannel is the channel a league's draft room messages are published on, whatever the backend
+func DraftChannel(leagueKey string) string {
+	return "draft-" + leagueKey
+}
+
+// BroadcastDraftResult is the JSON payload of every message sent to a draft room
+type BroadcastDraftResult struct {
+	Type        BroadcastType
+	Message     string            `json:"message"`
+	DraftOpen   bool              `json:"draft_open"`
+	Team        Team              `json:"team"`
+	League      League            `json:"league"`
+	User        User              `json:"user"`
+	DraftResult DraftResult       `json:"draft_result"`
+	Rosters     map[string]Roster `json:"rosters"`
+	Correction  *DraftCorrection  `json:"correction,omitempty"`
+	Auction     *AuctionLot       `json:"auction,omitempty"`
+	Grades      *DraftReport      `json:"grades,omitempty"`
+	// Chat is a new message, or the whole of a message that was deleted or reacted to
+	Chat *ChatMessage `json:"chat,omitempty"`
+	// Lottery is one slot of the draft order, revealed before the rest of the order is broadcast
+	Lottery *LotteryStep `json:"lottery,omitempty"`
+}
@@ -0,0 +1,52 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrChatMessageNotFound is returned by a repository when a league has no chat message with the id
+var ErrChatMessageNotFound = errors.New("chat message not found")
+
+// ChatMessage is a message in a league's draft room chat. IDs sort in the order messages were sent. A deleted
+// message keeps its place in the history with its text removed.
+type ChatMessage struct {
+	ID        string         `json:"id" bson:"_id"`
+	LeagueKey string         `json:"league_key" bson:"league_key"`
+	UserGUID  string         `json:"user_guid" bson:"user_guid"`
+	Nickname  string         `json:"nickname" bson:"nickname"`
+	TeamKey   string         `json:"team_key,omitempty" bson:"team_key,omitempty"`
+	Text      string         `json:"text" bson:"text"`
+	Reactions []ChatReaction `json:"reactions" bson:"reactions"`
+	SentAt    time.Time      `json:"sent_at" bson:"sent_at"`
+	DeletedAt *time.Time     `json:"deleted_at,omitempty" bson:"deleted_at,omitempty"`
+	DeletedBy string         `json:"deleted_by,omitempty" bson:"deleted_by,omitempty"`
+}
+
+// ChatReaction is one user's emoji on a message
+type ChatReaction struct {
+	Emoji    string `json:"emoji" bson:"emoji"`
+	UserGUID string `json:"user_guid" bson:"user_guid"`
+}
+
+// ChatMute keeps a user from sending messages or reactions to a league's chat until Until, or until a commissioner
+// unmutes them when Until is nil
+type ChatMute struct {
+	UserGUID string     `json:"user_guid" bson:"user_guid"`
+	MutedBy  string     `json:"muted_by" bson:"muted_by"`
+	MutedAt  time.Time  `json:"muted_at" bson:"muted_at"`
+	Until    *time.Time `json:"until,omitempty" bson:"until,omitempty"`
+}
+
+// Active reports whether the mute still applies at now
+func (m ChatMute) Active(now time.Time) bool {
+	return m.Until == nil || now.Before(*m.Until)
+}
+
+// ChatPage is a page of a league's chat history, oldest message first. Before is the cursor of the next, older
+// page and is empty when there are no older messages.
+type ChatPage struct {
+	LeagueKey string        `json:"league_key"`
+	Messages  []ChatMessage `json:"messages"`
+	Before    string        `json:"before,omitempty"`
+}
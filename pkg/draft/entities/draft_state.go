@@ -0,0 +1,40 @@
+package entities
+
+// DraftState is the lifecycle of a league's draft: scheduled, then open, optionally paused, then completed
+type DraftState string
+
+const (
+	DraftStateScheduled DraftState = "scheduled"
+	DraftStateOpen      DraftState = "open"
+	DraftStatePaused    DraftState = "paused"
+	DraftStateCompleted DraftState = "completed"
+)
+
+// draftTransitions are the states each state can move to. A completed draft goes back to
+// paused only when a commissioner undoes a pick and the board is no longer full.
+var draftTransitions = map[DraftState][]DraftState{
+	DraftStateScheduled: {DraftStateOpen},
+	DraftStateOpen:      {DraftStatePaused, DraftStateCompleted},
+	DraftStatePaused:    {DraftStateOpen, DraftStateCompleted},
+	DraftStateCompleted: {DraftStatePaused},
+}
+
+// CanTransitionTo reports whether a draft in state s may move to next
+func (s DraftState) CanTransitionTo(next DraftState) bool {
+	for _, state := range draftTransitions[s] {
+		if state == next {
+			return true
+		}
+	}
+	return false
+}
+
+// CanPick is true only while the draft is open
+func (s DraftState) CanPick() bool {
+	return s == DraftStateOpen
+}
+
+// CanChangeOrder is true until the draft opens
+func (s DraftState) CanChangeOrder() bool {
+	return s == DraftStateScheduled
+}
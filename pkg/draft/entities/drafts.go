@@ -1,6 +1,12 @@
 package entities
 
-import "time"
+import (
+	"errors"
+	"time"
+)
+
+// ErrPickConflict is returned by a repository when another request claimed the pick or the player first
+var ErrPickConflict = errors.New("pick conflicts with a pick that was already saved")
 
 type Draft struct {
 	ID           string        `json:"id" bson:"_id"`
@@ -37,16 +43,48 @@ type RosterSlot struct {
 	DraftResults []DraftResult `json:"draft_results"`
 }
 
+// DraftPick is one overall pick of the draft and the team that owns it
+type DraftPick struct {
+	Pick    int    `json:"pick"`
+	Round   int    `json:"round"`
+	Slot    int    `json:"slot"`
+	TeamKey string `json:"team_key"`
+	// OriginalTeamKey is the team the pick belonged to before it was traded
+	OriginalTeamKey string `json:"original_team_key,omitempty"`
+}
+
+// CorrectionAction is the kind of change a commissioner made to the draft board
+type CorrectionAction string
+
+const (
+	CorrectionUndo    CorrectionAction = "undo"
+	CorrectionReplace CorrectionAction = "replace"
+	CorrectionAssign  CorrectionAction = "assign"
+)
+
+// DraftCorrection describes a commissioner's change to a pick. Previous is the result before the
+// change and Result is the result after it; an undo has no Result and an assign has no Previous.
+type DraftCorrection struct {
+	Action   CorrectionAction `json:"action"`
+	Pick     int              `json:"pick"`
+	Previous *DraftResult     `json:"previous,omitempty"`
+	Result   *DraftResult     `json:"result,omitempty"`
+}
+
 type DraftResult struct {
 	UserGUID  string    `json:"user_guid" bson:"user_guid"`
 	PlayerKey string    `json:"player_key" bson:"player_key"`
-	PlayerID  int       `json:"player_key" bson:"player_id"`
+	PlayerID  int       `json:"player_id" bson:"player_id"`
 	LeagueKey string    `json:"league_key" bson:"league_key"`
 	TeamKey   string    `json:"team_key" bson:"team_key"`
 	Round     int       `json:"round" bson:"round"`
 	Pick      int       `json:"pick" bson:"pick"`
 	Timestamp time.Time `json:"timestamp" bson:"timestamp"`
 	GameID    int       `json:"game_id" bson:"game_id"`
+	// Cost is the winning bid in an auction draft
+	Cost int `json:"cost,omitempty" bson:"cost,omitempty"`
+	// Keeper marks a pick filled by a player kept from last season
+	Keeper bool `json:"keeper,omitempty" bson:"keeper,omitempty"`
 
 	Player []*PlayerSeason `json:"player" bson:"player,omitempty"`
 	League League          `json:"-" bson:"omitempty"`
@@ -4,6 +4,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type User struct {
@@ -38,7 +39,60 @@ type League struct {
 	DraftOrder     []string           `json:"-" bson:"draft_order,omitempty"`
 	TeamDraftOrder []Team             `json:"draft_order,omitempty" bson:"-"`
 	DraftStarted   bool               `json:"draft_started" bson:"draft_started,omitempty"`
+	DraftState     DraftState         `json:"draft_state" bson:"draft_state,omitempty"`
 	DraftedCheck   []string           `json:"drafted_check" bson:"draft_check"`
+	DraftSettings  DraftSettings      `json:"draft_settings" bson:"draft_settings"`
+	CurrentPick    int                `json:"current_pick" bson:"current_pick"`
+	ClockExpiresAt *time.Time         `json:"clock_expires_at,omitempty" bson:"-"`
+	Keepers        []Keeper           `json:"keepers,omitempty" bson:"keepers,omitempty"`
+	PickOwners     []PickOwner        `json:"pick_owners,omitempty" bson:"pick_owners,omitempty"`
+	PickTrades     []PickTrade        `json:"pick_trades,omitempty" bson:"pick_trades,omitempty"`
+	ChatMutes      []ChatMute         `json:"chat_mutes,omitempty" bson:"chat_mutes,omitempty"`
+	DraftLottery   *DraftLottery      `json:"-" bson:"draft_lottery,omitempty"`
+	PreviousDraws  []DraftLottery     `json:"-" bson:"previous_draws,omitempty"`
+}
+
+// State is where the league's draft is in its lifecycle. Leagues saved before draft_state
+// existed only have DraftStarted, which maps to open or scheduled.
+func (l League) State() DraftState {
+	if l.DraftState != "" {
+		return l.DraftState
+	}
+	if l.DraftStarted {
+		return DraftStateOpen
+	}
+	return DraftStateScheduled
+}
+
+// IsAuction reports whether the league drafts by salary cap auction instead of taking turns
+func (l League) IsAuction() bool {
+	return l.Settings != nil && l.Settings.IsAuctionDraft
+}
+
+// IsKeeperPick reports whether pick was filled by a keeper when the draft opened
+func (l League) IsKeeperPick(pick int) bool {
+	for _, keeper := range l.Keepers {
+		if keeper.Pick == pick {
+			return pick != 0
+		}
+	}
+	return false
+}
+
+// PickOwner returns the team that traded for the pick in slot of round, if it changed hands
+func (l League) PickOwner(round, slot int) (string, bool) {
+	for _, owner := range l.PickOwners {
+		if owner.Round == round && owner.Slot == slot {
+			return owner.TeamKey, true
+		}
+	}
+	return "", false
+}
+
+// SetState moves the league to state, keeping DraftStarted in step for older clients
+func (l *League) SetState(state DraftState) {
+	l.DraftState = state
+	l.DraftStarted = state != DraftStateScheduled
 }
 
 func (l *League) GetParentID() *int {
@@ -0,0 +1,13 @@
+package entities
+
+import "time"
+
+// DraftExport is the archive of a league's draft: the board in pick order and every team's roster.
+// Teams are in draft order.
+type DraftExport struct {
+	League     League            `json:"league"`
+	Teams      []Team            `json:"teams"`
+	Results    []DraftResult     `json:"draft_results"`
+	Rosters    map[string]Roster `json:"rosters"`
+	ExportedAt time.Time         `json:"exported_at"`
+}
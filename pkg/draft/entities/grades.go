@@ -0,0 +1,35 @@
+package entities
+
+// DraftReport grades every team of a completed draft, best first
+type DraftReport struct {
+	LeagueKey string      `json:"league_key"`
+	Teams     []TeamGrade `json:"teams"`
+}
+
+// TeamGrade is one team's draft grade and what it was made of. Team carries the grade in HasDraftGrade and DraftGrade,
+// the same fields Yahoo's grades are copied into.
+type TeamGrade struct {
+	Team  Team   `json:"team"`
+	Grade string `json:"grade"`
+	// Score is the weighted league percentile of the team's projection, pick value and balance, from 0 to 1
+	Score float64 `json:"score"`
+	// ProjectedPoints are the fantasy points of the team's starters from their season stats and the league's stat modifiers
+	ProjectedPoints float64 `json:"projected_points"`
+	// PickValue is how many picks after their rank the team's players were drafted on average. Negative is reaching.
+	PickValue float64 `json:"pick_value"`
+	// Balance is the share of starting slots filled and starting positions with a backup on the bench, from 0 to 1
+	Balance    float64      `json:"balance"`
+	Strengths  []string     `json:"strengths"`
+	Weaknesses []string     `json:"weaknesses"`
+	Reaches    []GradedPick `json:"reaches"`
+	Steals     []GradedPick `json:"steals"`
+}
+
+// GradedPick is a pick taken a round or more away from the player's rank. Value is the pick minus the rank.
+type GradedPick struct {
+	Pick      int    `json:"pick"`
+	Round     int    `json:"round"`
+	PlayerKey string `json:"player_key"`
+	Rank      int    `json:"rank"`
+	Value     int    `json:"value"`
+}
@@ -0,0 +1,24 @@
+package entities
+
+// KeeperSettings let teams carry players over from the previous season's draft.
+// A keeper costs the round it was drafted in last season plus RoundCost, so -1 keeps a
+// player a round earlier than they went. Players nobody drafted cost the last round.
+type KeeperSettings struct {
+	// Count is how many players each team may keep. Zero turns keepers off.
+	Count     int `json:"count" bson:"count"`
+	RoundCost int `json:"round_cost" bson:"round_cost"`
+}
+
+func (k KeeperSettings) Enabled() bool {
+	return k.Count > 0
+}
+
+// Keeper is a player a team declared it is keeping. Round is the round the keeper costs
+// this season; Pick is filled in once the draft opens and the draft order is final.
+type Keeper struct {
+	TeamKey       string `json:"team_key" bson:"team_key"`
+	PlayerKey     string `json:"player_key" bson:"player_key"`
+	PreviousRound int    `json:"previous_round,omitempty" bson:"previous_round,omitempty"`
+	Round         int    `json:"round" bson:"round"`
+	Pick          int    `json:"pick,omitempty" bson:"pick,omitempty"`
+}
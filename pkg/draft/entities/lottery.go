@@ -0,0 +1,65 @@
+package entities
+
+import "time"
+
+// LotteryMethod decides how many tickets each team has in a draft lottery
+type LotteryMethod string
+
+const (
+	// LotteryUniform gives every team one ticket
+	LotteryUniform LotteryMethod = "uniform"
+	// LotteryStandings weights the draw by last season's standings: the last place team has a ticket for every
+	// team in the league and the champion has one
+	LotteryStandings LotteryMethod = "standings"
+	// LotteryReverseStandings draws nothing and orders the teams from last place to first
+	LotteryReverseStandings LotteryMethod = "reverse_standings"
+)
+
+func (m LotteryMethod) Valid() bool {
+	switch m {
+	case LotteryUniform, LotteryStandings, LotteryReverseStandings:
+		return true
+	}
+	return false
+}
+
+// UsesStandings is true for the methods that need every team's rank from last season
+func (m LotteryMethod) UsesStandings() bool {
+	return m == LotteryStandings || m == LotteryReverseStandings
+}
+
+// DraftLottery is how a league's draft order was drawn. Every step can be worked out again from the seed, so any
+// manager can check the order was not picked by hand. Draw counts every lottery and shuffle of the league, so a
+// commissioner drawing again until they like the order shows.
+type DraftLottery struct {
+	LeagueKey  string        `json:"league_key" bson:"league_key"`
+	Draw       int           `json:"draw" bson:"draw"`
+	Method     LotteryMethod `json:"method" bson:"method"`
+	Seed       string        `json:"seed" bson:"seed"`
+	Steps      []LotteryStep `json:"steps" bson:"steps"`
+	DraftOrder []string      `json:"draft_order" bson:"draft_order"`
+	DrawnBy    string        `json:"drawn_by" bson:"drawn_by"`
+	DrawnAt    time.Time     `json:"drawn_at" bson:"drawn_at"`
+	// Verified is worked out each time the lottery is read and is not stored
+	Verified bool `json:"verified" bson:"-"`
+	// PreviousDraws are filled from the league when the lottery is read
+	PreviousDraws []DraftLottery `json:"previous_draws,omitempty" bson:"-"`
+}
+
+// LotteryStep draws the team for one slot of the draft order from the teams still left.
+// Hash is the hex SHA-256 of "seed:slot", Ticket is its first eight bytes as a big endian number modulo
+// TotalTickets, and the winner is the candidate whose tickets, counted in order, cover Ticket.
+type LotteryStep struct {
+	Slot         int             `json:"slot" bson:"slot"`
+	Candidates   []LotteryTicket `json:"candidates" bson:"candidates"`
+	TotalTickets int             `json:"total_tickets" bson:"total_tickets"`
+	Hash         string          `json:"hash,omitempty" bson:"hash,omitempty"`
+	Ticket       int             `json:"ticket" bson:"ticket"`
+	TeamKey      string          `json:"team_key" bson:"team_key"`
+}
+
+// LotteryTicket is how many tickets a team holds in a step
+type LotteryTicket struct {
+	TeamKey string `json:"team_key" bson:"team_key"`
+	Tickets int    `json:"tickets" bson:"tickets"`
+}
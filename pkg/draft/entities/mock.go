@@ -0,0 +1,51 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrMockDraftNotFound is returned by a repository when no mock draft has the id
+var ErrMockDraftNotFound = errors.New("mock draft not found")
+
+// ErrMockDraftChanged is returned by a repository when a mock draft was saved by another request first
+var ErrMockDraftChanged = errors.New("mock draft was changed by another request")
+
+// MockDraftStatus is where a mock draft is. A mock draft expires when its user stops picking before it is done.
+type MockDraftStatus string
+
+const (
+	MockDraftDrafting  MockDraftStatus = "drafting"
+	MockDraftCompleted MockDraftStatus = "completed"
+	MockDraftExpired   MockDraftStatus = "expired"
+)
+
+// MockDraft is a rehearsal of a league's draft. League is a copy of the league's settings, roster positions
+// and draft order taken when the mock started, and Results are its picks, so nothing of the real draft is
+// read or written once it runs. One user drafts TeamKey and bots draft every other team.
+type MockDraft struct {
+	ID          string        `json:"id" bson:"_id"`
+	LeagueKey   string        `json:"league_key" bson:"league_key"`
+	UserGUID    string        `json:"user_guid" bson:"user_guid"`
+	TeamKey     string        `json:"team_key" bson:"team_key"`
+	League      League        `json:"league" bson:"league"`
+	Results     []DraftResult `json:"draft_results" bson:"draft_results"`
+	CurrentPick int           `json:"current_pick" bson:"current_pick"`
+	CreatedAt   time.Time     `json:"created_at" bson:"created_at"`
+	// ExpiresAt is when an unfinished mock draft stops taking picks
+	ExpiresAt   time.Time  `json:"expires_at" bson:"expires_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" bson:"completed_at,omitempty"`
+	// Status and Rosters are filled in when a mock draft is returned to a client
+	Status  MockDraftStatus   `json:"status" bson:"-"`
+	Rosters map[string]Roster `json:"rosters,omitempty" bson:"-"`
+}
+
+func (m MockDraft) StatusAt(now time.Time) MockDraftStatus {
+	if m.CompletedAt != nil {
+		return MockDraftCompleted
+	}
+	if now.After(m.ExpiresAt) {
+		return MockDraftExpired
+	}
+	return MockDraftDrafting
+}
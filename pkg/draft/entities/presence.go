@@ -0,0 +1,26 @@
+package entities
+
+import "time"
+
+// DraftPresence is who is connected to a league's draft room, team by team in the order of League.Teams
+type DraftPresence struct {
+	LeagueKey   string         `json:"league_key"`
+	Teams       []TeamPresence `json:"teams"`
+	OnlineTeams int            `json:"online_teams"`
+}
+
+// TeamPresence is online when any of the team's managers is
+type TeamPresence struct {
+	TeamKey  string            `json:"team_key"`
+	Name     string            `json:"name"`
+	Online   bool              `json:"online"`
+	Managers []ManagerPresence `json:"managers"`
+}
+
+// ManagerPresence is one manager of a team and when their client last heartbeat, if it is connected
+type ManagerPresence struct {
+	UserGUID string     `json:"user_guid"`
+	Nickname string     `json:"nickname"`
+	Online   bool       `json:"online"`
+	LastSeen *time.Time `json:"last_seen,omitempty"`
+}
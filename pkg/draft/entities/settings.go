@@ -0,0 +1,34 @@
+package entities
+
+// ClockExpiryAction decides what happens to the team on the clock once its pick clock runs out
+type ClockExpiryAction string
+
+const (
+	ClockExpirySkip     ClockExpiryAction = "skip"
+	ClockExpiryAutoPick ClockExpiryAction = "autopick"
+)
+
+// DraftOrderType decides which direction each round runs through League.DraftOrder
+type DraftOrderType string
+
+const (
+	DraftOrderSnake              DraftOrderType = "snake"
+	DraftOrderLinear             DraftOrderType = "linear"
+	DraftOrderThirdRoundReversal DraftOrderType = "third_round_reversal"
+)
+
+// DraftSettings are the draft room settings a commissioner controls for a league.
+// They are ours, not Yahoo's, and live next to the imported LeagueSettings.
+type DraftSettings struct {
+	// PickSeconds is how long a team has to make its pick. Zero disables the pick clock.
+	PickSeconds  int               `json:"pick_seconds" bson:"pick_seconds"`
+	ExpiryAction ClockExpiryAction `json:"expiry_action" bson:"expiry_action"`
+	// OrderType defaults to a snake draft
+	OrderType DraftOrderType  `json:"order_type" bson:"order_type"`
+	Auction   AuctionSettings `json:"auction" bson:"auction"`
+	Keepers   KeeperSettings  `json:"keepers" bson:"keepers"`
+}
+
+func (d DraftSettings) HasPickClock() bool {
+	return d.PickSeconds > 0
+}
@@ -0,0 +1,90 @@
+package entities
+
+import "time"
+
+// DraftEvent is a draft room message as kept in the league's append-only event log. Sequence numbers a
+// league's events from 1 in the order they were logged.
+type DraftEvent struct {
+	LeagueKey   string           `json:"league_key" bson:"league_key"`
+	Sequence    int              `json:"sequence" bson:"sequence"`
+	Type        BroadcastType    `json:"type" bson:"type"`
+	Message     string           `json:"message" bson:"message"`
+	State       DraftState       `json:"state" bson:"state"`
+	CurrentPick int              `json:"current_pick" bson:"current_pick"`
+	UserGUID    string           `json:"user_guid,omitempty" bson:"user_guid,omitempty"`
+	TeamKey     string           `json:"team_key,omitempty" bson:"team_key,omitempty"`
+	DraftOrder  []string         `json:"draft_order,omitempty" bson:"draft_order,omitempty"`
+	DraftResult *DraftResult     `json:"draft_result,omitempty" bson:"draft_result,omitempty"`
+	Correction  *DraftCorrection `json:"correction,omitempty" bson:"correction,omitempty"`
+	Auction     *AuctionLot      `json:"auction,omitempty" bson:"auction,omitempty"`
+	At          time.Time        `json:"at" bson:"at"`
+}
+
+// NewDraftEvent is the log entry of a draft room message. Rosters and grades are left out since they are rebuilt
+// from the results, and the draft order is only kept by the events that can change it.
+func NewDraftEvent(message BroadcastDraftResult, at time.Time) DraftEvent {
+	event := DraftEvent{
+		LeagueKey:   message.League.LeagueKey,
+		Type:        message.Type,
+		Message:     message.Message,
+		State:       message.League.State(),
+		CurrentPick: message.League.CurrentPick,
+		UserGUID:    message.User.Guid,
+		TeamKey:     message.Team.TeamKey,
+		Auction:     message.Auction,
+		At:          at,
+	}
+	if message.Type == BroadCastTypeDraftOpen || message.Type == BroadCastTypeDraftOrder {
+		event.DraftOrder = message.League.DraftOrder
+	}
+	if message.DraftResult.PlayerKey != "" {
+		event.DraftResult = loggedResult(&message.DraftResult)
+	}
+	if message.Correction != nil {
+		correction := *message.Correction
+		correction.Previous = loggedResult(correction.Previous)
+		correction.Result = loggedResult(correction.Result)
+		event.Correction = &correction
+	}
+	return event
+}
+
+// loggedResult is a copy of result without the league it was saved with
+func loggedResult(result *DraftResult) *DraftResult {
+	if result == nil {
+		return nil
+	}
+	logged := *result
+	logged.League = League{}
+	return &logged
+}
+
+// EndsPick reports whether the event took the team on the clock off it
+func (e DraftEvent) EndsPick() bool {
+	return e.Type == BroadCastTypePlayerDrafted || e.Type == BroadCastTypePlayerSold || e.Type == BroadCastTypePickSkipped
+}
+
+// DraftTimeline is every logged event of a league's draft, oldest first
+type DraftTimeline struct {
+	LeagueKey string          `json:"league_key"`
+	Events    []TimelineEvent `json:"events"`
+}
+
+// TimelineEvent is a logged event. For the events that end a pick, ElapsedSeconds is how long the pick took: the time
+// since the previous pick, or since the draft opened, resumed or was corrected when that was later.
+type TimelineEvent struct {
+	DraftEvent
+	ElapsedSeconds float64 `json:"elapsed_seconds,omitempty"`
+}
+
+// DraftReplay is the draft board as it stood right after Pick was first made, rebuilt from the event log. Sequence is
+// the last event applied, so a client can follow on with the timeline.
+type DraftReplay struct {
+	LeagueKey  string            `json:"league_key"`
+	Pick       int               `json:"pick"`
+	Sequence   int               `json:"sequence"`
+	At         time.Time         `json:"at"`
+	DraftOrder []string          `json:"draft_order"`
+	Results    []DraftResult     `json:"draft_results"`
+	Rosters    map[string]Roster `json:"rosters"`
+}
@@ -0,0 +1,44 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrTradeNotPending is returned by a repository when a trade was approved or rejected by another request first
+var ErrTradeNotPending = errors.New("pick trade is no longer pending")
+
+// PickTradeStatus is where a proposed pick trade is in the commissioner's review
+type PickTradeStatus string
+
+const (
+	PickTradePending  PickTradeStatus = "pending"
+	PickTradeApproved PickTradeStatus = "approved"
+	PickTradeRejected PickTradeStatus = "rejected"
+)
+
+// TradedPick moves the pick in Slot of League.DraftOrder for Round from one team to another
+type TradedPick struct {
+	Round    int    `json:"round" bson:"round"`
+	Slot     int    `json:"slot" bson:"slot"`
+	FromTeam string `json:"from_team_key" bson:"from_team_key"`
+	ToTeam   string `json:"to_team_key" bson:"to_team_key"`
+}
+
+// PickTrade is a set of picks changing hands. It only moves picks once the commissioner approves it.
+type PickTrade struct {
+	ID         string          `json:"id" bson:"id"`
+	Picks      []TradedPick    `json:"picks" bson:"picks"`
+	Status     PickTradeStatus `json:"status" bson:"status"`
+	ProposedBy string          `json:"proposed_by" bson:"proposed_by"`
+	ProposedAt time.Time       `json:"proposed_at" bson:"proposed_at"`
+	DecidedAt  *time.Time      `json:"decided_at,omitempty" bson:"decided_at,omitempty"`
+}
+
+// PickOwner is an entry in a league's pick ownership ledger: the team that owns the pick
+// in Slot of League.DraftOrder for Round after approved trades
+type PickOwner struct {
+	Round   int    `json:"round" bson:"round"`
+	Slot    int    `json:"slot" bson:"slot"`
+	TeamKey string `json:"team_key" bson:"team_key"`
+}
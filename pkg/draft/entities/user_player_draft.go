@@ -7,6 +7,8 @@ type UserPlayerPreference struct {
 	DoNotDraft []string `json:"dnd" bson:"dnd"`
 	Preference []string `json:"pref" bson:"pref"`
 	Available  []string `json:"ap" bson:"ap"`
+	// AutoDraft has the server pick from Preference as soon as the user's team is on the clock
+	AutoDraft bool `json:"autodraft" bson:"autodraft"`
 
 	Positions map[string][]string `json:"positions" bson:"positions"`
 }
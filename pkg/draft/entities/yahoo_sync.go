@@ -0,0 +1,34 @@
+package entities
+
+import "time"
+
+// YahooSyncStatus is what happened to one pick when a draft was sent to Yahoo
+type YahooSyncStatus string
+
+const (
+	// YahooSyncSubmitted picks were entered on Yahoo
+	YahooSyncSubmitted YahooSyncStatus = "submitted"
+	// YahooSyncUnchanged picks already had the same player on Yahoo, so nothing was sent
+	YahooSyncUnchanged YahooSyncStatus = "unchanged"
+	// YahooSyncFailed picks were refused by Yahoo or never reached it. Error says why.
+	YahooSyncFailed YahooSyncStatus = "failed"
+)
+
+// YahooSyncReport is the outcome of sending a completed draft's results to a Yahoo league with an offline draft
+type YahooSyncReport struct {
+	LeagueKey string          `json:"league_key"`
+	Submitted int             `json:"submitted"`
+	Unchanged int             `json:"unchanged"`
+	Failed    int             `json:"failed"`
+	Picks     []YahooSyncPick `json:"picks"`
+	SyncedAt  time.Time       `json:"synced_at"`
+}
+
+type YahooSyncPick struct {
+	Pick      int             `json:"pick"`
+	Round     int             `json:"round"`
+	TeamKey   string          `json:"team_key"`
+	PlayerKey string          `json:"player_key"`
+	Status    YahooSyncStatus `json:"status"`
+	Error     string          `json:"error,omitempty"`
+}
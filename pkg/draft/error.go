@@ -1,6 +1,11 @@
 package draft
 
-import "net/http"
+import (
+	"fmt"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"net/http"
+	"time"
+)
 
 type ErrorUpdateDraft struct {
 	message string
@@ -12,4 +17,557 @@ func (e *ErrorUpdateDraft) Error() string {
 
 func (e *ErrorUpdateDraft) StatusCode() int {
 	return http.StatusConflict
-}
\ No newline at end of file
+}
+
+type ErrorPickNotOnClock struct {
+	pick        int
+	currentPick int
+}
+
+func (e *ErrorPickNotOnClock) Error() string {
+	return fmt.Sprintf("pick %d is not on the clock, current pick is %d", e.pick, e.currentPick)
+}
+
+func (e *ErrorPickNotOnClock) StatusCode() int {
+	return http.StatusConflict
+}
+
+type ErrorInvalidPick struct {
+	pick int
+}
+
+func (e *ErrorInvalidPick) Error() string {
+	return fmt.Sprintf("pick %d is not part of the draft", e.pick)
+}
+
+func (e *ErrorInvalidPick) StatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
+type ErrorPickOutOfTurn struct {
+	pick    int
+	teamKey string
+	owner   string
+}
+
+func (e *ErrorPickOutOfTurn) Error() string {
+	return fmt.Sprintf("pick %d belongs to team %s, not %s", e.pick, e.owner, e.teamKey)
+}
+
+func (e *ErrorPickOutOfTurn) StatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
+type ErrorPickAlreadyMade struct {
+	pick int
+}
+
+func (e *ErrorPickAlreadyMade) Error() string {
+	return fmt.Sprintf("pick %d has already been made", e.pick)
+}
+
+func (e *ErrorPickAlreadyMade) StatusCode() int {
+	return http.StatusConflict
+}
+
+type ErrorPlayerAlreadyDrafted struct {
+	playerKey string
+}
+
+func (e *ErrorPlayerAlreadyDrafted) Error() string {
+	return fmt.Sprintf("player %s has already been drafted", e.playerKey)
+}
+
+func (e *ErrorPlayerAlreadyDrafted) StatusCode() int {
+	return http.StatusConflict
+}
+
+type ErrorUnknownPlayer struct {
+	playerKey string
+}
+
+func (e *ErrorUnknownPlayer) Error() string {
+	return fmt.Sprintf("player %s could not be found", e.playerKey)
+}
+
+func (e *ErrorUnknownPlayer) StatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
+type ErrorNotTeamManager struct {
+	teamKey string
+}
+
+func (e *ErrorNotTeamManager) Error() string {
+	return fmt.Sprintf("user does not manage team %s", e.teamKey)
+}
+
+func (e *ErrorNotTeamManager) StatusCode() int {
+	return http.StatusForbidden
+}
+
+type ErrorPickConflict struct {
+	pick int
+}
+
+func (e *ErrorPickConflict) Error() string {
+	return fmt.Sprintf("pick %d was made by another request", e.pick)
+}
+
+func (e *ErrorPickConflict) StatusCode() int {
+	return http.StatusConflict
+}
+
+type ErrorNotCommissioner struct{}
+
+func (e *ErrorNotCommissioner) Error() string {
+	return "user is not commissioner"
+}
+
+func (e *ErrorNotCommissioner) StatusCode() int {
+	return http.StatusForbidden
+}
+
+type ErrorPickNotMade struct {
+	pick int
+}
+
+func (e *ErrorPickNotMade) Error() string {
+	if e.pick == 0 {
+		return "no picks have been made"
+	}
+	return fmt.Sprintf("pick %d has not been made", e.pick)
+}
+
+func (e *ErrorPickNotMade) StatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
+type ErrorUnknownTeam struct {
+	teamKey string
+}
+
+func (e *ErrorUnknownTeam) Error() string {
+	return fmt.Sprintf("team %s is not in the league", e.teamKey)
+}
+
+func (e *ErrorUnknownTeam) StatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
+type ErrorDraftState struct {
+	state  entities.DraftState
+	action string
+}
+
+func (e *ErrorDraftState) Error() string {
+	return fmt.Sprintf("draft is %s and can not %s", e.state, e.action)
+}
+
+func (e *ErrorDraftState) StatusCode() int {
+	return http.StatusConflict
+}
+
+type ErrorNotAuctionDraft struct{}
+
+func (e *ErrorNotAuctionDraft) Error() string {
+	return "league does not have an auction draft"
+}
+
+func (e *ErrorNotAuctionDraft) StatusCode() int {
+	return http.StatusConflict
+}
+
+type ErrorAuctionDraft struct{}
+
+func (e *ErrorAuctionDraft) Error() string {
+	return "league has an auction draft, players are nominated and bid on"
+}
+
+func (e *ErrorAuctionDraft) StatusCode() int {
+	return http.StatusConflict
+}
+
+type ErrorNotNominatingTeam struct {
+	teamKey        string
+	nominatingTeam string
+}
+
+func (e *ErrorNotNominatingTeam) Error() string {
+	return fmt.Sprintf("team %s is nominating, not %s", e.nominatingTeam, e.teamKey)
+}
+
+func (e *ErrorNotNominatingTeam) StatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
+type ErrorLotInProgress struct{}
+
+func (e *ErrorLotInProgress) Error() string {
+	return "another player is already up for bid"
+}
+
+func (e *ErrorLotInProgress) StatusCode() int {
+	return http.StatusConflict
+}
+
+type ErrorNoLot struct{}
+
+func (e *ErrorNoLot) Error() string {
+	return "no player is up for bid"
+}
+
+func (e *ErrorNoLot) StatusCode() int {
+	return http.StatusConflict
+}
+
+type ErrorBidTooLow struct {
+	amount  int
+	highBid int
+}
+
+func (e *ErrorBidTooLow) Error() string {
+	return fmt.Sprintf("bid of %d does not beat the high bid of %d", e.amount, e.highBid)
+}
+
+func (e *ErrorBidTooLow) StatusCode() int {
+	return http.StatusConflict
+}
+
+type ErrorInvalidBid struct {
+	amount int
+	minBid int
+	maxBid int
+}
+
+func (e *ErrorInvalidBid) Error() string {
+	return fmt.Sprintf("bid of %d is outside of %d to %d", e.amount, e.minBid, e.maxBid)
+}
+
+func (e *ErrorInvalidBid) StatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
+type ErrorKeepersDisabled struct{}
+
+func (e *ErrorKeepersDisabled) Error() string {
+	return "league does not allow keepers"
+}
+
+func (e *ErrorKeepersDisabled) StatusCode() int {
+	return http.StatusConflict
+}
+
+type ErrorNoPreviousSeason struct {
+	leagueKey string
+	action    string
+}
+
+func (e *ErrorNoPreviousSeason) Error() string {
+	return fmt.Sprintf("league %s has no previous season to %s", e.leagueKey, e.action)
+}
+
+func (e *ErrorNoPreviousSeason) StatusCode() int {
+	return http.StatusConflict
+}
+
+type ErrorTooManyKeepers struct {
+	count int
+	max   int
+}
+
+func (e *ErrorTooManyKeepers) Error() string {
+	return fmt.Sprintf("%d keepers declared, teams may keep %d", e.count, e.max)
+}
+
+func (e *ErrorTooManyKeepers) StatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
+type ErrorNotKeeperEligible struct {
+	playerKey string
+	teamKey   string
+}
+
+func (e *ErrorNotKeeperEligible) Error() string {
+	return fmt.Sprintf("player %s was not drafted by %s last season, only the commissioner can make them its keeper", e.playerKey, e.teamKey)
+}
+
+func (e *ErrorNotKeeperEligible) StatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
+type ErrorKeeperWithoutPick struct {
+	playerKey string
+	teamKey   string
+	round     int
+}
+
+func (e *ErrorKeeperWithoutPick) Error() string {
+	if e.round == 0 {
+		return fmt.Sprintf("team %s has no pick left to keep %s with", e.teamKey, e.playerKey)
+	}
+	return fmt.Sprintf("team %s has no pick in round %d for its keeper %s", e.teamKey, e.round, e.playerKey)
+}
+
+func (e *ErrorKeeperWithoutPick) StatusCode() int {
+	return http.StatusConflict
+}
+
+type ErrorInvalidTrade struct {
+	reason string
+}
+
+func (e *ErrorInvalidTrade) Error() string {
+	return "invalid pick trade: " + e.reason
+}
+
+func (e *ErrorInvalidTrade) StatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
+type ErrorPickNotOwned struct {
+	round   int
+	slot    int
+	teamKey string
+	owner   string
+}
+
+func (e *ErrorPickNotOwned) Error() string {
+	return fmt.Sprintf("round %d slot %d belongs to team %s, not %s", e.round, e.slot, e.owner, e.teamKey)
+}
+
+func (e *ErrorPickNotOwned) StatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
+type ErrorTradeNotFound struct {
+	tradeID string
+}
+
+func (e *ErrorTradeNotFound) Error() string {
+	return fmt.Sprintf("pick trade %s not found", e.tradeID)
+}
+
+func (e *ErrorTradeNotFound) StatusCode() int {
+	return http.StatusNotFound
+}
+
+type ErrorTradeNotPending struct {
+	tradeID string
+	status  entities.PickTradeStatus
+}
+
+func (e *ErrorTradeNotPending) Error() string {
+	if e.status == "" {
+		return fmt.Sprintf("pick trade %s was already decided", e.tradeID)
+	}
+	return fmt.Sprintf("pick trade %s is already %s", e.tradeID, e.status)
+}
+
+func (e *ErrorTradeNotPending) StatusCode() int {
+	return http.StatusConflict
+}
+
+type ErrorPicksTraded struct{}
+
+func (e *ErrorPicksTraded) Error() string {
+	return "picks have been traded, so the draft order can no longer change"
+}
+
+func (e *ErrorPicksTraded) StatusCode() int {
+	return http.StatusConflict
+}
+
+type ErrorNotInLeague struct {
+	leagueKey string
+}
+
+func (e *ErrorNotInLeague) Error() string {
+	return fmt.Sprintf("user does not manage a team in league %s", e.leagueKey)
+}
+
+func (e *ErrorNotInLeague) StatusCode() int {
+	return http.StatusForbidden
+}
+
+type ErrorMockDraftNotFound struct {
+	mockID string
+}
+
+func (e *ErrorMockDraftNotFound) Error() string {
+	return fmt.Sprintf("mock draft %s not found", e.mockID)
+}
+
+func (e *ErrorMockDraftNotFound) StatusCode() int {
+	return http.StatusNotFound
+}
+
+type ErrorMockDraftClosed struct {
+	status entities.MockDraftStatus
+}
+
+func (e *ErrorMockDraftClosed) Error() string {
+	return fmt.Sprintf("mock draft is %s and takes no more picks", e.status)
+}
+
+func (e *ErrorMockDraftClosed) StatusCode() int {
+	if e.status == entities.MockDraftExpired {
+		return http.StatusGone
+	}
+	return http.StatusConflict
+}
+
+type ErrorExportFormat struct {
+	format string
+}
+
+func (e *ErrorExportFormat) Error() string {
+	return fmt.Sprintf("%q is not an export format, use csv, grid or json", e.format)
+}
+
+func (e *ErrorExportFormat) StatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
+type ErrorNotOfflineDraft struct {
+	leagueKey string
+}
+
+func (e *ErrorNotOfflineDraft) Error() string {
+	return fmt.Sprintf("league %s does not have an offline draft on yahoo, so yahoo will not take its results", e.leagueKey)
+}
+
+func (e *ErrorNotOfflineDraft) StatusCode() int {
+	return http.StatusConflict
+}
+
+type ErrorYahooSync struct {
+	err error
+}
+
+func (e *ErrorYahooSync) Error() string {
+	if e.err == nil {
+		return "draft results can not be sent to yahoo"
+	}
+	return fmt.Sprintf("draft results can not be sent to yahoo: %s", e.err)
+}
+
+func (e *ErrorYahooSync) StatusCode() int {
+	if e.err == nil {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusBadGateway
+}
+
+type ErrorReplayPick struct {
+	pick int
+}
+
+func (e *ErrorReplayPick) Error() string {
+	if e.pick < 0 {
+		return fmt.Sprintf("%d is not a pick number", e.pick)
+	}
+	return fmt.Sprintf("pick %d has not been made, so the draft can not be replayed to it", e.pick)
+}
+
+func (e *ErrorReplayPick) StatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
+type ErrorChatMessage struct {
+	reason string
+}
+
+func (e *ErrorChatMessage) Error() string {
+	return e.reason
+}
+
+func (e *ErrorChatMessage) StatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
+type ErrorChatMuted struct {
+	until *time.Time
+}
+
+func (e *ErrorChatMuted) Error() string {
+	if e.until == nil {
+		return "you have been muted in this draft room by the commissioner"
+	}
+	return fmt.Sprintf("you have been muted in this draft room until %s", e.until.Format(time.RFC3339))
+}
+
+func (e *ErrorChatMuted) StatusCode() int {
+	return http.StatusForbidden
+}
+
+type ErrorChatRateLimited struct{}
+
+func (e *ErrorChatRateLimited) Error() string {
+	return "you are sending messages too quickly, wait a few seconds"
+}
+
+func (e *ErrorChatRateLimited) StatusCode() int {
+	return http.StatusTooManyRequests
+}
+
+type ErrorChatMessageNotFound struct {
+	messageID string
+}
+
+func (e *ErrorChatMessageNotFound) Error() string {
+	return fmt.Sprintf("chat message %s was not found", e.messageID)
+}
+
+func (e *ErrorChatMessageNotFound) StatusCode() int {
+	return http.StatusNotFound
+}
+
+type ErrorLotteryMethod struct {
+	method entities.LotteryMethod
+}
+
+func (e *ErrorLotteryMethod) Error() string {
+	return fmt.Sprintf("%q is not a lottery method, use uniform, standings or reverse_standings", e.method)
+}
+
+func (e *ErrorLotteryMethod) StatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
+type ErrorLotteryReveal struct {
+	reveal time.Duration
+}
+
+func (e *ErrorLotteryReveal) Error() string {
+	return fmt.Sprintf("each slot can be revealed for between 0 and %s, not %s", maxLotteryReveal, e.reveal)
+}
+
+func (e *ErrorLotteryReveal) StatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
+type ErrorLotteryStandings struct {
+	teamKey string
+}
+
+func (e *ErrorLotteryStandings) Error() string {
+	return fmt.Sprintf("team %s has no standing from last season, so the lottery can not use standings", e.teamKey)
+}
+
+func (e *ErrorLotteryStandings) StatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
+type ErrorNoLottery struct {
+	leagueKey string
+}
+
+func (e *ErrorNoLottery) Error() string {
+	return fmt.Sprintf("league %s has not drawn its draft order", e.leagueKey)
+}
+
+func (e *ErrorNoLottery) StatusCode() int {
+	return http.StatusNotFound
+}
@@ -0,0 +1,225 @@
+package draft
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"go.elastic.co/apm"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExportFormat is a file format a draft can be exported to
+type ExportFormat string
+
+const (
+	// ExportCSV is one row per pick, in pick order, ready to be retyped into Yahoo or a spreadsheet
+	ExportCSV ExportFormat = "csv"
+	// ExportGrid is the draft board: a row per round and a column per team
+	ExportGrid ExportFormat = "grid"
+	// ExportJSON is the machine-readable archive of the whole draft
+	ExportJSON ExportFormat = "json"
+)
+
+// ExportFormats are every format, in the order the export command writes them
+var ExportFormats = []ExportFormat{ExportCSV, ExportGrid, ExportJSON}
+
+func ParseExportFormat(format string) (ExportFormat, error) {
+	for _, exportFormat := range ExportFormats {
+		if string(exportFormat) == strings.ToLower(format) {
+			return exportFormat, nil
+		}
+	}
+	return "", &ErrorExportFormat{format: format}
+}
+
+func (f ExportFormat) ContentType() string {
+	if f == ExportJSON {
+		return "application/json"
+	}
+	return "text/csv"
+}
+
+// FileName is the name a league's export is saved under
+func (f ExportFormat) FileName(leagueKey string) string {
+	switch f {
+	case ExportGrid:
+		return leagueKey + "-board.csv"
+	case ExportJSON:
+		return leagueKey + "-draft.json"
+	}
+	return leagueKey + "-draft.csv"
+}
+
+// ExportDraft gathers a league's draft board and rosters for WriteDraftExport
+func (service *Service) ExportDraft(ctx context.Context, leagueKey string) (*entities.DraftExport, error) {
+	span, ctx := apm.StartSpan(ctx, "ExportDraft", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	defer span.End()
+
+	league, results, err := service.ListDraftResults(ctx, leagueKey)
+	if err != nil {
+		return nil, err
+	}
+	rosters, err := service.GetTeamsDraftResults(ctx, leagueKey)
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := make([]entities.DraftResult, len(results))
+	copy(sorted, results)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Pick < sorted[j].Pick
+	})
+	return &entities.DraftExport{
+		League:     *league,
+		Teams:      teamsInDraftOrder(*league),
+		Results:    sorted,
+		Rosters:    rosters,
+		ExportedAt: time.Now(),
+	}, nil
+}
+
+// WriteDraftExport writes export to w in format
+func WriteDraftExport(w io.Writer, format ExportFormat, export entities.DraftExport) error {
+	switch format {
+	case ExportCSV:
+		return writeDraftCSV(w, export)
+	case ExportGrid:
+		return writeDraftGrid(w, export)
+	case ExportJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(&export)
+	}
+	return &ErrorExportFormat{format: string(format)}
+}
+
+func writeDraftCSV(w io.Writer, export entities.DraftExport) error {
+	teams := make(map[string]entities.Team, len(export.Teams))
+	for _, team := range export.Teams {
+		teams[team.TeamKey] = team
+	}
+
+	writer := csv.NewWriter(w)
+	_ = writer.Write([]string{"Round", "Pick", "Team", "Manager", "Player", "Position", "NFL Team"})
+	for _, result := range export.Results {
+		team := teams[result.TeamKey]
+		manager := ""
+		if len(team.Manager) > 0 {
+			manager = team.Manager[0].Nickname
+		}
+		player, position, proTeam := exportPlayer(result)
+		_ = writer.Write([]string{strconv.Itoa(result.Round), strconv.Itoa(result.Pick), spreadsheetCell(teamName(team, result.TeamKey)), spreadsheetCell(manager), spreadsheetCell(player), spreadsheetCell(position), spreadsheetCell(proTeam)})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// writeDraftGrid writes the board with a column per team in draft order. A result lands in its round's row;
+// auction results have no round and fill the team's column from the top. Traded picks can give a team
+// more than one player in a round, which share the cell.
+func writeDraftGrid(w io.Writer, export entities.DraftExport) error {
+	columns := make(map[string]int, len(export.Teams))
+	header := []string{"Round"}
+	for idx, team := range export.Teams {
+		columns[team.TeamKey] = idx
+		header = append(header, spreadsheetCell(teamName(team, team.TeamKey)))
+	}
+
+	var rows [][]string
+	drafted := make(map[string]int, len(export.Teams))
+	for _, result := range export.Results {
+		column, ok := columns[result.TeamKey]
+		if !ok {
+			continue
+		}
+		drafted[result.TeamKey]++
+		row := result.Round
+		if row <= 0 {
+			row = drafted[result.TeamKey]
+		}
+		for len(rows) < row {
+			rows = append(rows, make([]string, len(export.Teams)))
+		}
+
+		player, position, _ := exportPlayer(result)
+		cell := fmt.Sprintf("%s (%s)", player, position)
+		if rows[row-1][column] != "" {
+			cell = rows[row-1][column] + " / " + cell
+		}
+		rows[row-1][column] = cell
+	}
+
+	writer := csv.NewWriter(w)
+	_ = writer.Write(header)
+	for idx, row := range rows {
+		for column := range row {
+			row[column] = spreadsheetCell(row[column])
+		}
+		_ = writer.Write(append([]string{strconv.Itoa(idx + 1)}, row...))
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// spreadsheetCell keeps a spreadsheet from running a team or manager name as a formula by quoting values
+// that start like one
+func spreadsheetCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// exportPlayer is the name, position and pro team of a result's player, falling back to the player key
+// when the player was not loaded
+func exportPlayer(result entities.DraftResult) (name, position, proTeam string) {
+	player := resultPlayer(result)
+	if player == nil {
+		return result.PlayerKey, "", ""
+	}
+	name = player.Name.Full
+	if name == "" {
+		name = result.PlayerKey
+	}
+	position = player.DisplayPosition
+	if position == "" && len(player.EligiblePositions) > 0 {
+		position = player.EligiblePositions[0]
+	}
+	return name, position, player.EditorialTeamAbbr
+}
+
+func teamName(team entities.Team, teamKey string) string {
+	if team.Name != "" {
+		return team.Name
+	}
+	return teamKey
+}
+
+// teamsInDraftOrder lists the league's teams in draft order, followed by any team missing from it
+func teamsInDraftOrder(league entities.League) []entities.Team {
+	byKey := make(map[string]entities.Team, len(league.Teams))
+	for _, team := range league.Teams {
+		byKey[team.TeamKey] = team
+	}
+
+	teams := make([]entities.Team, 0, len(league.Teams))
+	for _, teamKey := range league.DraftOrder {
+		if team, ok := byKey[teamKey]; ok {
+			teams = append(teams, team)
+			delete(byKey, teamKey)
+		}
+	}
+	for _, team := range league.Teams {
+		if _, ok := byKey[team.TeamKey]; ok {
+			teams = append(teams, team)
+		}
+	}
+	return teams
+}
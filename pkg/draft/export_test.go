@@ -0,0 +1,136 @@
+package draft
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"testing"
+)
+
+func exportResult(teamKey, playerKey, name, position, proTeam string, round, pick int) entities.DraftResult {
+	player := testPlayer(playerKey, position)
+	player.Name.Full = name
+	player.DisplayPosition = position
+	player.EditorialTeamAbbr = proTeam
+	return entities.DraftResult{TeamKey: teamKey, PlayerKey: playerKey, PlayerID: pick, Round: round, Pick: pick, Player: []*entities.PlayerSeason{&player}}
+}
+
+func testExport() entities.DraftExport {
+	league := testLeague()
+	league.Teams = league.Teams[:2]
+	league.DraftOrder = []string{"399.l.1.t.2", "399.l.1.t.1"}
+	league.Teams[0].Name = "Commish, Inc."
+	league.Teams[0].Manager[0].Nickname = "Commish"
+	league.Teams[1].Name = "Second"
+	league.Teams[1].Manager[0].Nickname = "Manager Two"
+
+	return entities.DraftExport{
+		League: league,
+		Teams:  teamsInDraftOrder(league),
+		Results: []entities.DraftResult{
+			exportResult("399.l.1.t.2", "399.p.1", "Patrick Mahomes", "QB", "KC", 1, 1),
+			exportResult("399.l.1.t.1", "399.p.2", "Derrick Henry", "RB", "Ten", 1, 2),
+			exportResult("399.l.1.t.1", "399.p.3", "Davante Adams", "WR", "LV", 2, 3),
+			{TeamKey: "399.l.1.t.1", PlayerKey: "399.p.4", Round: 2, Pick: 4},
+		},
+	}
+}
+
+func TestWriteDraftExport_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Nil(t, WriteDraftExport(&buf, ExportCSV, testExport()))
+	assert.Equal(t, `Round,Pick,Team,Manager,Player,Position,NFL Team
+1,1,Second,Manager Two,Patrick Mahomes,QB,KC
+1,2,"Commish, Inc.",Commish,Derrick Henry,RB,Ten
+2,3,"Commish, Inc.",Commish,Davante Adams,WR,LV
+2,4,"Commish, Inc.",Commish,399.p.4,,
+`, buf.String())
+}
+
+func TestWriteDraftExport_Grid(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Nil(t, WriteDraftExport(&buf, ExportGrid, testExport()))
+	assert.Equal(t, `Round,Second,"Commish, Inc."
+1,Patrick Mahomes (QB),Derrick Henry (RB)
+2,,Davante Adams (WR) / 399.p.4 ()
+`, buf.String(), "the traded pick shares the round's cell")
+
+	auction := testExport()
+	for idx := range auction.Results {
+		auction.Results[idx].Round = 0
+	}
+	buf.Reset()
+	assert.Nil(t, WriteDraftExport(&buf, ExportGrid, auction))
+	assert.Equal(t, `Round,Second,"Commish, Inc."
+1,Patrick Mahomes (QB),Derrick Henry (RB)
+2,,Davante Adams (WR)
+3,,399.p.4 ()
+`, buf.String(), "auction results fill each column from the top")
+}
+
+func TestWriteDraftExport_Formulas(t *testing.T) {
+	export := testExport()
+	export.Teams[0].Name = "=HYPERLINK(\"http://example.com\")"
+	export.Teams[1].Manager[0].Nickname = "@SUM(A1)"
+	export.Results[1].Player[0].Name.Full = "-2+3"
+	export.Results[1].Player[0].EditorialTeamAbbr = "+Ten"
+
+	var buf bytes.Buffer
+	assert.Nil(t, WriteDraftExport(&buf, ExportCSV, export))
+	assert.Equal(t, `Round,Pick,Team,Manager,Player,Position,NFL Team
+1,1,"'=HYPERLINK(""http://example.com"")",Manager Two,Patrick Mahomes,QB,KC
+1,2,"Commish, Inc.",'@SUM(A1),'-2+3,RB,'+Ten
+2,3,"Commish, Inc.",'@SUM(A1),Davante Adams,WR,LV
+2,4,"Commish, Inc.",'@SUM(A1),399.p.4,,
+`, buf.String())
+
+	buf.Reset()
+	assert.Nil(t, WriteDraftExport(&buf, ExportGrid, export))
+	assert.Equal(t, `Round,"'=HYPERLINK(""http://example.com"")","Commish, Inc."
+1,Patrick Mahomes (QB),'-2+3 (RB)
+2,,Davante Adams (WR) / 399.p.4 ()
+`, buf.String())
+}
+
+func TestWriteDraftExport_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Nil(t, WriteDraftExport(&buf, ExportJSON, testExport()))
+
+	var archive entities.DraftExport
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &archive))
+	assert.Equal(t, "399.l.1", archive.League.LeagueKey)
+	assert.Equal(t, "399.l.1.t.2", archive.Teams[0].TeamKey)
+	if assert.Len(t, archive.Results, 4) {
+		assert.Equal(t, "399.p.2", archive.Results[1].PlayerKey)
+		assert.Equal(t, 2, archive.Results[1].PlayerID)
+	}
+}
+
+func TestParseExportFormat(t *testing.T) {
+	format, err := ParseExportFormat("GRID")
+	assert.Nil(t, err)
+	assert.Equal(t, ExportGrid, format)
+	assert.Equal(t, "399.l.1-board.csv", format.FileName("399.l.1"))
+
+	_, err = ParseExportFormat("xlsx")
+	assert.IsType(t, &ErrorExportFormat{}, err)
+}
+
+func TestService_ExportDraft(t *testing.T) {
+	league := testLeague()
+	repo := newFakeDraftRepository(league)
+	export := testExport()
+	repo.results[league.LeagueKey] = []entities.DraftResult{export.Results[2], export.Results[0], export.Results[1]}
+	service := NewService(log.NewNopLogger(), repo, newFakeBroadcaster())
+
+	got, err := service.ExportDraft(context.Background(), league.LeagueKey)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, []int{1, 2, 3}, []int{got.Results[0].Pick, got.Results[1].Pick, got.Results[2].Pick}, "results are in pick order")
+	assert.Len(t, got.Teams, 4)
+	assert.Equal(t, "399.p.2", got.Rosters["399.l.1.t.1"].Roster["RB"].DraftResults[0].PlayerKey)
+}
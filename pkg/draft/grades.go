@@ -0,0 +1,246 @@
+package draft
+
+import (
+	"context"
+	"github.com/go-kit/kit/log/level"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"go.elastic.co/apm"
+	"sort"
+)
+
+// weights of the parts of a draft grade
+const (
+	projectionWeight = 0.5
+	pickValueWeight  = 0.3
+	balanceWeight    = 0.2
+)
+
+// draftGrades maps the lowest score of a grade to the grade, best first
+var draftGrades = []struct {
+	score float64
+	grade string
+}{
+	{0.9, "A"}, {0.8, "A-"}, {0.7, "B+"}, {0.6, "B"}, {0.5, "B-"},
+	{0.4, "C+"}, {0.3, "C"}, {0.2, "C-"}, {0.1, "D"}, {0, "F"},
+}
+
+// DraftGrades grades every team of a completed draft from its drafted roster
+func (service *Service) DraftGrades(ctx context.Context, leagueKey string) (*entities.DraftReport, error) {
+	span, ctx := apm.StartSpan(ctx, "DraftGrades", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	defer span.End()
+
+	league, err := service.draftRepo.GetLeague(ctx, leagueKey)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not get league", "error", err, "league_key", leagueKey)
+		return nil, err
+	}
+	if league.State() != entities.DraftStateCompleted {
+		return nil, &ErrorDraftState{state: league.State(), action: "be graded"}
+	}
+	return service.gradeDraft(ctx, league)
+}
+
+func (service *Service) gradeDraft(ctx context.Context, league entities.League) (*entities.DraftReport, error) {
+	results, err := service.draftRepo.GetTeamDraftResultsByTeam(ctx, league.LeagueKey)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not get draft results", "error", err, "league_key", league.LeagueKey)
+		return nil, err
+	}
+	return gradeTeams(league, results), nil
+}
+
+// broadcastGrades sends the grades of a draft that just completed to the draft room
+func (service *Service) broadcastGrades(ctx context.Context, league entities.League) {
+	report, err := service.gradeDraft(ctx, league)
+	if err != nil {
+		return
+	}
+	err = service.broadCastRepo.BroadCastDraftGrades(ctx, league, *report)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not broadcast draft grades", "error", err, "league_key", league.LeagueKey)
+	}
+}
+
+// gradeTeams grades each team on the projected points of its starters, the value of its picks against the players'
+// ranks and the balance of its roster. Each part is the team's percentile in the league, so grades are on a curve.
+func gradeTeams(league entities.League, results map[string][]entities.DraftResult) *entities.DraftReport {
+	teams := len(league.DraftOrder)
+	if teams == 0 {
+		teams = len(league.Teams)
+	}
+	grades := make([]entities.TeamGrade, len(league.Teams))
+	positionPoints := make([]map[string]float64, len(league.Teams))
+	for idx, team := range league.Teams {
+		grades[idx], positionPoints[idx] = gradeTeam(league, team, results[team.TeamKey], teams)
+	}
+
+	projections := percentiles(grades, func(grade entities.TeamGrade) float64 { return grade.ProjectedPoints })
+	values := percentiles(grades, func(grade entities.TeamGrade) float64 { return grade.PickValue })
+	balances := percentiles(grades, func(grade entities.TeamGrade) float64 { return grade.Balance })
+	for idx := range grades {
+		grades[idx].Score = projectionWeight*projections[idx] + pickValueWeight*values[idx] + balanceWeight*balances[idx]
+		grades[idx].Grade = letterGrade(grades[idx].Score)
+		grades[idx].Team.HasDraftGrade = true
+		grades[idx].Team.DraftGrade = grades[idx].Grade
+		grades[idx].Strengths = positionRanks(positionPoints, idx, true)
+		grades[idx].Weaknesses = append(grades[idx].Weaknesses, positionRanks(positionPoints, idx, false)...)
+	}
+
+	sort.SliceStable(grades, func(i, j int) bool {
+		return grades[i].Score > grades[j].Score
+	})
+	return &entities.DraftReport{LeagueKey: league.LeagueKey, Teams: grades}
+}
+
+// gradeTeam works out a team's projection, pick value and balance, and the projected points of its starters by slot
+func gradeTeam(league entities.League, team entities.Team, results []entities.DraftResult, teams int) (entities.TeamGrade, map[string]float64) {
+	grade := entities.TeamGrade{
+		Team:       team,
+		Strengths:  []string{},
+		Weaknesses: []string{},
+		Reaches:    []entities.GradedPick{},
+		Steals:     []entities.GradedPick{},
+	}
+	roster := buildTeamRoster(results, makeRoster(league))
+	seats := starterSeats(roster)
+
+	positionPoints := make(map[string]float64)
+	starters := 0
+	for slot, rosterSlot := range roster.Roster {
+		if slot == benchSlot {
+			continue
+		}
+		for _, result := range rosterSlot.DraftResults {
+			points := projectedPoints(resultPlayer(result), league)
+			grade.ProjectedPoints += points
+			positionPoints[slot] += points
+			starters++
+		}
+		if len(rosterSlot.DraftResults) < rosterSlot.Count && !reserveSlots[slot] {
+			grade.Weaknesses = append(grade.Weaknesses, "empty "+slot)
+		}
+	}
+	sort.Strings(grade.Weaknesses)
+
+	// every single position starting slot is covered when someone on the bench can play it
+	positions, covered := 0, 0
+	for slot := range positionPoints {
+		if _, flex := flexSlots[slot]; flex || slot == utilitySlot {
+			continue
+		}
+		positions++
+		for _, result := range roster.Roster[benchSlot].DraftResults {
+			if player := resultPlayer(result); player != nil && slotAccepts(slot, player.EligiblePositions) {
+				covered++
+				break
+			}
+		}
+	}
+	if len(seats)+positions > 0 {
+		grade.Balance = float64(starters+covered) / float64(len(seats)+positions)
+	}
+
+	ranked := 0
+	for _, result := range results {
+		rank := pickRank(resultPlayer(result))
+		if result.Keeper || rank == 0 {
+			continue
+		}
+		value := result.Pick - rank
+		grade.PickValue += float64(value)
+		ranked++
+
+		graded := entities.GradedPick{Pick: result.Pick, Round: result.Round, PlayerKey: result.PlayerKey, Rank: rank, Value: value}
+		switch {
+		case value <= -teams:
+			grade.Reaches = append(grade.Reaches, graded)
+		case value >= teams:
+			grade.Steals = append(grade.Steals, graded)
+		}
+	}
+	if ranked > 0 {
+		grade.PickValue /= float64(ranked)
+	}
+	return grade, positionPoints
+}
+
+// projectedPoints scores a player's season stats with the league's stat modifiers. Bonuses are per game,
+// which season totals can not tell, so they are left out.
+func projectedPoints(player *entities.PlayerSeason, league entities.League) float64 {
+	if player == nil || league.Settings == nil {
+		return 0
+	}
+	modifiers := make(map[int]float64, len(league.Settings.StatModifiers))
+	for _, modifier := range league.Settings.StatModifiers {
+		modifiers[modifier.StatID] = float64(modifier.Value)
+	}
+	points := 0.0
+	for _, stat := range player.SeasonStats {
+		points += stat.Value * modifiers[stat.StatID]
+	}
+	return points
+}
+
+// pickRank is where a player was expected to go, their ADP as the bots read it
+func pickRank(player *entities.PlayerSeason) int {
+	if player == nil {
+		return 0
+	}
+	return playerADP(*player)
+}
+
+// percentiles ranks every team on value, from 1 for the best to 0 for the worst. Ties share the better rank.
+func percentiles(grades []entities.TeamGrade, value func(entities.TeamGrade) float64) []float64 {
+	ranks := make([]float64, len(grades))
+	if len(grades) < 2 {
+		for idx := range ranks {
+			ranks[idx] = 1
+		}
+		return ranks
+	}
+	for idx := range grades {
+		better := 0
+		for other := range grades {
+			if value(grades[other]) > value(grades[idx]) {
+				better++
+			}
+		}
+		ranks[idx] = 1 - float64(better)/float64(len(grades)-1)
+	}
+	return ranks
+}
+
+// positionRanks lists the starting slots where team's starters project the most points in the league, or the fewest
+func positionRanks(positionPoints []map[string]float64, team int, best bool) []string {
+	slots := []string{}
+	if len(positionPoints) < 2 {
+		return slots
+	}
+	for slot, points := range positionPoints[team] {
+		leads := true
+		for other := range positionPoints {
+			if other == team {
+				continue
+			}
+			if best && positionPoints[other][slot] >= points || !best && positionPoints[other][slot] <= points {
+				leads = false
+				break
+			}
+		}
+		if leads && (!best || points > 0) {
+			slots = append(slots, slot)
+		}
+	}
+	sort.Strings(slots)
+	return slots
+}
+
+func letterGrade(score float64) string {
+	for _, grade := range draftGrades {
+		if score >= grade.score {
+			return grade.grade
+		}
+	}
+	return draftGrades[len(draftGrades)-1].grade
+}
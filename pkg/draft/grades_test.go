@@ -0,0 +1,126 @@
+package draft
+
+import (
+	"context"
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"net/http"
+	"testing"
+)
+
+const (
+	passingYards = 4
+	rushingYards = 9
+)
+
+// gradesLeague is testLeague cut down to two teams, scoring passing and rushing yards
+func gradesLeague() entities.League {
+	league := testLeague()
+	league.Teams = league.Teams[:2]
+	league.DraftOrder = league.DraftOrder[:2]
+	league.Settings.StatModifiers = []entities.StatModifier{{StatID: passingYards, Value: 0.04}, {StatID: rushingYards, Value: 0.1}}
+	return league
+}
+
+func gradedResult(teamKey string, pick, rank, statID int, yards float64, positions ...string) entities.DraftResult {
+	player := rankedPlayer(teamKey+"-"+positions[0], rank, positions...)
+	player.SeasonStats = []entities.PlayerStat{{StatID: statID, Value: yards}}
+	return entities.DraftResult{TeamKey: teamKey, PlayerKey: player.PlayerKey, Pick: pick, Player: []*entities.PlayerSeason{&player}}
+}
+
+func gradesResults() map[string][]entities.DraftResult {
+	return map[string][]entities.DraftResult{
+		"399.l.1.t.1": {
+			gradedResult("399.l.1.t.1", 1, 1, passingYards, 4000, "QB"),
+			gradedResult("399.l.1.t.1", 4, 2, rushingYards, 1000, "RB"),
+			gradedResult("399.l.1.t.1", 5, 10, rushingYards, 800, "RB", "WR"),
+		},
+		"399.l.1.t.2": {
+			gradedResult("399.l.1.t.2", 2, 3, passingYards, 3000, "QB"),
+			gradedResult("399.l.1.t.2", 3, 20, rushingYards, 500, "RB"),
+			gradedResult("399.l.1.t.2", 6, 6, passingYards, 2000, "QB"),
+		},
+	}
+}
+
+func Test_pickRank(t *testing.T) {
+	player := rankedPlayer("399.p.1", 10, "RB")
+	assert.Equal(t, 10, pickRank(&player), "without an ADP the player is expected at yahoo's rank")
+
+	player.Ranks[botADPRank] = 14
+	assert.Equal(t, 14, pickRank(&player))
+	assert.Equal(t, 0, pickRank(nil))
+}
+
+func Test_gradeTeams(t *testing.T) {
+	report := gradeTeams(gradesLeague(), gradesResults())
+	if !assert.Len(t, report.Teams, 2) {
+		return
+	}
+	best, worst := report.Teams[0], report.Teams[1]
+
+	assert.Equal(t, "399.l.1.t.1", best.Team.TeamKey)
+	assert.InDelta(t, 340, best.ProjectedPoints, 0.001, "starters only: 160 passing, 100 and 80 rushing")
+	assert.InDelta(t, -1, best.PickValue, 0.001)
+	assert.InDelta(t, 1, best.Score, 0.001)
+	assert.Equal(t, "A", best.Grade)
+	assert.True(t, best.Team.HasDraftGrade)
+	assert.Equal(t, "A", best.Team.DraftGrade)
+	assert.Equal(t, []string{"QB", "RB"}, best.Strengths)
+	assert.Equal(t, []string{"empty W/R/T", "empty WR"}, best.Weaknesses)
+	assert.Equal(t, []entities.GradedPick{{Pick: 4, PlayerKey: "399.l.1.t.1-RB", Rank: 2, Value: 2}}, best.Steals)
+	if assert.Len(t, best.Reaches, 1) {
+		assert.Equal(t, 5, best.Reaches[0].Pick)
+	}
+
+	assert.Equal(t, "399.l.1.t.2", worst.Team.TeamKey)
+	assert.InDelta(t, 170, worst.ProjectedPoints, 0.001, "the bench quarterback does not count")
+	assert.InDelta(t, -6, worst.PickValue, 0.001)
+	assert.Equal(t, best.Balance, worst.Balance, "a backup quarterback makes up for an empty RB slot")
+	assert.InDelta(t, 0.2, worst.Score, 0.001)
+	assert.Equal(t, "C-", worst.Grade)
+	assert.Empty(t, worst.Strengths)
+	assert.Equal(t, []string{"empty RB", "empty W/R/T", "empty WR", "QB", "RB"}, worst.Weaknesses)
+	assert.Empty(t, worst.Steals)
+	if assert.Len(t, worst.Reaches, 1) {
+		assert.Equal(t, -17, worst.Reaches[0].Value)
+	}
+}
+
+func Test_gradeTeams_KeepersHaveNoPickValue(t *testing.T) {
+	results := gradesResults()
+	results["399.l.1.t.2"][1].Keeper = true
+
+	report := gradeTeams(gradesLeague(), results)
+	assert.InDelta(t, -0.5, report.Teams[1].PickValue, 0.001)
+	assert.Empty(t, report.Teams[1].Reaches)
+}
+
+func TestService_DraftGrades(t *testing.T) {
+	league := gradesLeague()
+	league.SetState(entities.DraftStateOpen)
+	repo := newFakeDraftRepository(league)
+	for _, results := range gradesResults() {
+		repo.results[league.LeagueKey] = append(repo.results[league.LeagueKey], results...)
+	}
+	broadcaster := newFakeBroadcaster()
+	service := NewService(log.NewNopLogger(), repo, broadcaster)
+
+	_, err := service.DraftGrades(context.Background(), league.LeagueKey)
+	if assert.IsType(t, &ErrorDraftState{}, err) {
+		assert.Equal(t, http.StatusConflict, err.(*ErrorDraftState).StatusCode())
+	}
+
+	_, err = service.CloseDraft(commissionerContext(), league.LeagueKey)
+	assert.Nil(t, err)
+	graded := broadcaster.last()
+	assert.Equal(t, entities.BroadCastTypeDraftGraded, graded.Type)
+	if assert.NotNil(t, graded.Grades) {
+		assert.Len(t, graded.Grades.Teams, 2)
+	}
+
+	report, err := service.DraftGrades(context.Background(), league.LeagueKey)
+	assert.Nil(t, err)
+	assert.Equal(t, graded.Grades, report)
+}
@@ -0,0 +1,256 @@
+package draft
+
+import (
+	"context"
+	"errors"
+	"github.com/go-kit/kit/log/level"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"go.elastic.co/apm"
+	"strings"
+)
+
+// DeclareKeepers replaces the players a team keeps from last season's draft. Managers declare their own
+// keepers until the draft opens, and only players their team drafted last season. A commissioner can
+// declare for any team, including players the team picked up during last season or off waivers.
+func (service *Service) DeclareKeepers(ctx context.Context, leagueKey, teamKey string, playerKeys []string) ([]entities.Keeper, error) {
+	span, ctx := apm.StartSpan(ctx, "DeclareKeepers", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	span.Context.SetLabel("team_key", teamKey)
+	defer span.End()
+
+	league, err := service.draftRepo.GetLeague(ctx, leagueKey)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not get league", "error", err, "league_key", leagueKey)
+		return nil, err
+	}
+	settings := league.DraftSettings.Keepers
+	if !settings.Enabled() {
+		return nil, &ErrorKeepersDisabled{}
+	}
+	if league.IsAuction() {
+		return nil, &ErrorAuctionDraft{}
+	}
+	if league.State() != entities.DraftStateScheduled {
+		return nil, &ErrorDraftState{state: league.State(), action: "declare keepers"}
+	}
+	if _, ok := leagueTeam(league, teamKey); !ok {
+		return nil, &ErrorUnknownTeam{teamKey: teamKey}
+	}
+	commissioner := isUserCommissioner(ctx, league)
+	if !commissioner && !isUserTeamManager(ctx, league, teamKey) {
+		return nil, &ErrorNotTeamManager{teamKey: teamKey}
+	}
+	rounds := numberOfRounds(league)
+	maxKeepers := settings.Count
+	if rounds < maxKeepers {
+		maxKeepers = rounds
+	}
+	if len(playerKeys) > maxKeepers {
+		return nil, &ErrorTooManyKeepers{count: len(playerKeys), max: maxKeepers}
+	}
+	if league.PreviousLeague == nil || *league.PreviousLeague == "" {
+		return nil, &ErrorNoPreviousSeason{leagueKey: leagueKey, action: "keep players from"}
+	}
+
+	previous, err := service.draftRepo.GetDraftResults(ctx, *league.PreviousLeague)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not get previous season's draft results", "error", err, "league_key", *league.PreviousLeague)
+		return nil, err
+	}
+	previousByPlayer := make(map[string]entities.DraftResult, len(previous))
+	for _, result := range previous {
+		previousByPlayer[playerNumber(result.PlayerKey)] = result
+	}
+	kept := make(map[string]bool, len(league.Keepers))
+	for _, keeper := range league.Keepers {
+		if keeper.TeamKey != teamKey {
+			kept[keeper.PlayerKey] = true
+		}
+	}
+
+	keepers := make([]entities.Keeper, 0, len(playerKeys))
+	// a round the team traded its pick away in has no pick to keep a player with
+	takenRounds := make(map[int]bool, rounds)
+	for round := 1; round <= rounds; round++ {
+		if keeperPick(league, teamKey, round) == 0 {
+			takenRounds[round] = true
+		}
+	}
+	for _, playerKey := range playerKeys {
+		if kept[playerKey] {
+			return nil, &ErrorPlayerAlreadyDrafted{playerKey: playerKey}
+		}
+		kept[playerKey] = true
+
+		if _, err = service.getPlayer(ctx, playerKey); err != nil {
+			return nil, err
+		}
+
+		keeper := entities.Keeper{TeamKey: teamKey, PlayerKey: playerKey}
+		result, ok := previousByPlayer[playerNumber(playerKey)]
+		if !commissioner && (!ok || teamNumber(result.TeamKey) != teamNumber(teamKey)) {
+			return nil, &ErrorNotKeeperEligible{playerKey: playerKey, teamKey: teamKey}
+		}
+		if ok {
+			keeper.PreviousRound = result.Round
+		}
+		keeper.Round = keeperRound(settings, keeper.PreviousRound, rounds, takenRounds)
+		if keeper.Round == 0 {
+			return nil, &ErrorKeeperWithoutPick{playerKey: playerKey, teamKey: teamKey}
+		}
+		takenRounds[keeper.Round] = true
+		keepers = append(keepers, keeper)
+	}
+
+	err = service.draftRepo.SaveTeamKeepers(ctx, leagueKey, teamKey, keepers)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not save keepers", "error", err, "league_key", leagueKey, "team_key", teamKey)
+		return nil, &ErrorUpdateDraft{}
+	}
+
+	err = service.broadCastRepo.BroadCastLeagueInformation(ctx, league, "keepers declared for "+teamKey, entities.BroadCastTypeKeepersDeclared)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not broadcast keepers", "error", err, "league_key", leagueKey, "team_key", teamKey)
+	}
+	return keepers, nil
+}
+
+// GetKeepers lists every declared keeper with the pick it takes under the current draft order
+func (service *Service) GetKeepers(ctx context.Context, leagueKey string) ([]entities.Keeper, error) {
+	span, ctx := apm.StartSpan(ctx, "GetKeepers", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	defer span.End()
+
+	league, err := service.draftRepo.GetLeague(ctx, leagueKey)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not get league", "error", err, "league_key", leagueKey)
+		return nil, err
+	}
+
+	keepers := make([]entities.Keeper, len(league.Keepers))
+	for idx, keeper := range league.Keepers {
+		if keeper.Pick == 0 {
+			keeper.Pick = keeperPick(league, keeper.TeamKey, keeper.Round)
+		}
+		keepers[idx] = keeper
+	}
+	return keepers, nil
+}
+
+// fillKeeperPicks puts every keeper on the draft board as the draft opens, so kept players are on their
+// team's roster and out of the player pool before the first pick. A keeper that is already on the
+// board from an earlier attempt to open the draft is left as it is. The draft does not open while a
+// keeper's team has no pick in the keeper's round, the commissioner has to change the keeper first.
+func (service *Service) fillKeeperPicks(ctx context.Context, league *entities.League) error {
+	span, ctx := apm.StartSpan(ctx, "fillKeeperPicks", "service")
+	defer span.End()
+
+	for idx := range league.Keepers {
+		keeper := &league.Keepers[idx]
+		keeper.Pick = keeperPick(*league, keeper.TeamKey, keeper.Round)
+		if keeper.Pick == 0 {
+			return &ErrorKeeperWithoutPick{playerKey: keeper.PlayerKey, teamKey: keeper.TeamKey, round: keeper.Round}
+		}
+	}
+
+	for idx := range league.Keepers {
+		keeper := &league.Keepers[idx]
+		player, err := service.getPlayer(ctx, keeper.PlayerKey)
+		if err != nil {
+			return err
+		}
+		var manager entities.User
+		if team, ok := leagueTeam(*league, keeper.TeamKey); ok && len(team.Manager) > 0 {
+			manager = team.Manager[0]
+		}
+
+		_, err = service.draftRepo.SaveKeeperResult(ctx, *league, manager, *keeper, player)
+		if errors.Is(err, entities.ErrPickConflict) {
+			level.Debug(service.logger).Log("message", "keeper already on the draft board", "league_key", league.LeagueKey, "pick", keeper.Pick, "player_key", keeper.PlayerKey)
+			continue
+		}
+		if err != nil {
+			level.Error(service.logger).Log("message", "could not save keeper", "error", err, "league_key", league.LeagueKey, "pick", keeper.Pick, "player_key", keeper.PlayerKey)
+			return &ErrorUpdateDraft{}
+		}
+	}
+	return nil
+}
+
+// keeperRound is the round a keeper costs. Two keepers of the same team can not share a round,
+// so a keeper that lands on a taken round moves up a round at a time, then down when none is left.
+func keeperRound(settings entities.KeeperSettings, previousRound, rounds int, taken map[int]bool) int {
+	round := rounds
+	if previousRound > 0 {
+		round = previousRound + settings.RoundCost
+	}
+	if round < 1 {
+		round = 1
+	}
+	if round > rounds {
+		round = rounds
+	}
+
+	for earlier := round; earlier >= 1; earlier-- {
+		if !taken[earlier] {
+			return earlier
+		}
+	}
+	for later := round + 1; later <= rounds; later++ {
+		if !taken[later] {
+			return later
+		}
+	}
+	return 0
+}
+
+// keeperOnPick returns the keeper of teamKey that takes the overall pick, if any does
+func keeperOnPick(league entities.League, teamKey string, pick int) (entities.Keeper, bool) {
+	for _, keeper := range league.Keepers {
+		if keeper.TeamKey != teamKey {
+			continue
+		}
+		keeperAt := keeper.Pick
+		if keeperAt == 0 {
+			keeperAt = keeperPick(league, keeper.TeamKey, keeper.Round)
+		}
+		if keeperAt == pick {
+			return keeper, true
+		}
+	}
+	return entities.Keeper{}, false
+}
+
+// keeperPick is the overall pick teamKey owns in round, or zero when the team has no pick in it
+func keeperPick(league entities.League, teamKey string, round int) int {
+	teams := len(league.DraftOrder)
+	if round < 1 {
+		return 0
+	}
+	for pick := (round-1)*teams + 1; pick <= round*teams; pick++ {
+		if owner, _ := teamKeyForPick(league, pick); owner == teamKey {
+			return pick
+		}
+	}
+	return 0
+}
+
+// teamNumber is the team's id within its league. Yahoo keeps it when a league renews, so it
+// matches a team to itself across seasons even though the league part of the key changes.
+func teamNumber(teamKey string) string {
+	idx := strings.LastIndex(teamKey, ".t.")
+	if idx < 0 {
+		return teamKey
+	}
+	return teamKey[idx+len(".t."):]
+}
+
+// playerNumber is the player's id within Yahoo's games. The game part of the key changes every
+// season, so last season's draft results are matched on the id alone.
+func playerNumber(playerKey string) string {
+	idx := strings.LastIndex(playerKey, ".p.")
+	if idx < 0 {
+		return playerKey
+	}
+	return playerKey[idx+len(".p."):]
+}
@@ -0,0 +1,210 @@
+package draft
+
+import (
+	"context"
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/thethan/fdr-users/pkg/auth"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	userEntities "github.com/thethan/fdr-users/pkg/users/entities"
+	"testing"
+)
+
+func Test_keeperRound(t *testing.T) {
+	settings := entities.KeeperSettings{Count: 3, RoundCost: -1}
+	tests := []struct {
+		name          string
+		previousRound int
+		taken         map[int]bool
+		want          int
+	}{
+		{name: "costs last season's round plus the round cost", previousRound: 4, want: 3},
+		{name: "never earlier than the first round", previousRound: 1, want: 1},
+		{name: "undrafted players cost the last round", previousRound: 0, want: 7},
+		{name: "moves up when the round is taken", previousRound: 4, taken: map[int]bool{3: true}, want: 2},
+		{name: "keeps its round when a later round is taken", previousRound: 2, taken: map[int]bool{3: true}, want: 1},
+		{name: "moves down when every earlier round is taken", previousRound: 2, taken: map[int]bool{1: true}, want: 2},
+		{name: "moves down past the round it lands on", previousRound: 3, taken: map[int]bool{1: true, 2: true}, want: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, keeperRound(settings, tt.previousRound, 7, tt.taken))
+		})
+	}
+}
+
+func Test_keeperPick(t *testing.T) {
+	league := testLeague()
+	assert.Equal(t, 1, keeperPick(league, "399.l.1.t.1", 1))
+	assert.Equal(t, 7, keeperPick(league, "399.l.1.t.2", 2), "round two of a snake draft runs backwards")
+	assert.Equal(t, 0, keeperPick(league, "399.l.1.t.9", 1))
+	assert.Equal(t, "3", teamNumber("390.l.9.t.3"))
+	assert.Equal(t, "30123", playerNumber("390.p.30123"))
+}
+
+// keeperLeague lets each team keep two players a round earlier than they went in league 390.l.9
+func keeperLeague() entities.League {
+	league := testLeague()
+	previous := "390.l.9"
+	league.PreviousLeague = &previous
+	league.DraftSettings.Keepers = entities.KeeperSettings{Count: 2, RoundCost: -1}
+	return league
+}
+
+func TestService_DeclareKeepers(t *testing.T) {
+	league := keeperLeague()
+	repo := newFakeDraftRepository(league)
+	repo.players = []entities.PlayerSeason{testPlayer("399.p.1", "RB"), testPlayer("399.p.2", "WR"), testPlayer("399.p.3", "QB")}
+	repo.results["390.l.9"] = []entities.DraftResult{
+		{PlayerKey: "390.p.1", TeamKey: "390.l.9.t.2", Round: 3, Pick: 10},
+		{PlayerKey: "390.p.2", TeamKey: "390.l.9.t.3", Round: 5, Pick: 18},
+	}
+	broadcaster := newFakeBroadcaster()
+	service := NewService(log.NewNopLogger(), repo, broadcaster)
+	managerContext := context.WithValue(context.Background(), auth.User, &userEntities.User{GUID: "manager-2"})
+
+	keepers, err := service.DeclareKeepers(managerContext, league.LeagueKey, "399.l.1.t.2", []string{"399.p.1"})
+	assert.Nil(t, err)
+	assert.Equal(t, []entities.Keeper{{TeamKey: "399.l.1.t.2", PlayerKey: "399.p.1", PreviousRound: 3, Round: 2}}, keepers)
+	assert.Equal(t, entities.BroadCastTypeKeepersDeclared, broadcaster.last().Type)
+
+	_, err = service.DeclareKeepers(managerContext, league.LeagueKey, "399.l.1.t.2", []string{"399.p.2"})
+	assert.IsType(t, &ErrorNotKeeperEligible{}, err)
+	_, err = service.DeclareKeepers(managerContext, league.LeagueKey, "399.l.1.t.3", []string{"399.p.2"})
+	assert.IsType(t, &ErrorNotTeamManager{}, err)
+	_, err = service.DeclareKeepers(managerContext, league.LeagueKey, "399.l.1.t.2", []string{"399.p.1", "399.p.2", "399.p.3"})
+	assert.IsType(t, &ErrorTooManyKeepers{}, err)
+	_, err = service.DeclareKeepers(commissionerContext(), league.LeagueKey, "399.l.1.t.1", []string{"399.p.1"})
+	assert.IsType(t, &ErrorPlayerAlreadyDrafted{}, err, "a player is kept by one team")
+
+	// a commissioner can record a keeper a team traded for
+	_, err = service.DeclareKeepers(commissionerContext(), league.LeagueKey, "399.l.1.t.2", []string{"399.p.2"})
+	assert.Nil(t, err)
+
+	keepers, err = service.GetKeepers(context.Background(), league.LeagueKey)
+	assert.Nil(t, err)
+	assert.Equal(t, []entities.Keeper{{TeamKey: "399.l.1.t.2", PlayerKey: "399.p.2", PreviousRound: 5, Round: 4, Pick: 15}}, keepers)
+}
+
+func TestService_DeclareKeepers_Undrafted(t *testing.T) {
+	league := keeperLeague()
+	repo := newFakeDraftRepository(league)
+	repo.players = []entities.PlayerSeason{testPlayer("399.p.1", "RB"), testPlayer("399.p.3", "QB")}
+	repo.results["390.l.9"] = []entities.DraftResult{{PlayerKey: "390.p.1", TeamKey: "390.l.9.t.2", Round: 3, Pick: 10}}
+	service := NewService(log.NewNopLogger(), repo, newFakeBroadcaster())
+	managerContext := context.WithValue(context.Background(), auth.User, &userEntities.User{GUID: "manager-2"})
+
+	_, err := service.DeclareKeepers(managerContext, league.LeagueKey, "399.l.1.t.2", []string{"399.p.1", "399.p.3"})
+	assert.IsType(t, &ErrorNotKeeperEligible{}, err, "nobody drafted 399.p.3 last season")
+	saved, _ := repo.GetLeague(context.Background(), league.LeagueKey)
+	assert.Empty(t, saved.Keepers)
+
+	keepers, err := service.DeclareKeepers(commissionerContext(), league.LeagueKey, "399.l.1.t.2", []string{"399.p.3"})
+	assert.Nil(t, err, "the commissioner can record a player the team picked up")
+	assert.Equal(t, []entities.Keeper{{TeamKey: "399.l.1.t.2", PlayerKey: "399.p.3", Round: 7}}, keepers, "without a draft round the keeper costs the last round")
+}
+
+func TestService_Keepers_TradedPicks(t *testing.T) {
+	league := keeperLeague()
+	// t.2 traded its round two pick to t.3
+	league.PickOwners = []entities.PickOwner{{Round: 2, Slot: 2, TeamKey: "399.l.1.t.3"}}
+	repo := newFakeDraftRepository(league)
+	repo.players = []entities.PlayerSeason{testPlayer("399.p.1", "RB"), testPlayer("399.p.2", "WR")}
+	repo.results["390.l.9"] = []entities.DraftResult{{PlayerKey: "390.p.1", TeamKey: "390.l.9.t.2", Round: 3, Pick: 10}}
+	service := NewService(log.NewNopLogger(), repo, newFakeBroadcaster())
+	managerContext := context.WithValue(context.Background(), auth.User, &userEntities.User{GUID: "manager-2"})
+
+	keepers, err := service.DeclareKeepers(managerContext, league.LeagueKey, "399.l.1.t.2", []string{"399.p.1"})
+	if assert.Nil(t, err) {
+		assert.Equal(t, 1, keepers[0].Round, "round two has no pick left, so the keeper moves up a round")
+	}
+
+	_, err = service.ProposePickTrade(managerContext, league.LeagueKey, []entities.TradedPick{{Round: 1, Slot: 2, FromTeam: "399.l.1.t.2", ToTeam: "399.l.1.t.4"}})
+	assert.IsType(t, &ErrorInvalidTrade{}, err, "the keeper needs the round one pick")
+	_, err = service.ProposePickTrade(managerContext, league.LeagueKey, []entities.TradedPick{{Round: 3, Slot: 2, FromTeam: "399.l.1.t.2", ToTeam: "399.l.1.t.4"}})
+	assert.Nil(t, err)
+}
+
+func TestService_OpenDraft_KeeperWithoutPick(t *testing.T) {
+	league := keeperLeague()
+	league.Keepers = []entities.Keeper{
+		{TeamKey: "399.l.1.t.1", PlayerKey: "399.p.1", Round: 1},
+		{TeamKey: "399.l.1.t.2", PlayerKey: "399.p.2", Round: 2},
+	}
+	league.PickOwners = []entities.PickOwner{{Round: 2, Slot: 2, TeamKey: "399.l.1.t.3"}}
+	repo := newFakeDraftRepository(league)
+	repo.players = []entities.PlayerSeason{testPlayer("399.p.1", "RB"), testPlayer("399.p.2", "WR")}
+	service := NewService(log.NewNopLogger(), repo, newFakeBroadcaster())
+
+	_, err := service.OpenDraft(commissionerContext(), league.LeagueKey)
+	assert.IsType(t, &ErrorKeeperWithoutPick{}, err)
+	assert.Contains(t, err.Error(), "399.p.2")
+
+	saved, _ := repo.GetLeague(context.Background(), league.LeagueKey)
+	assert.Equal(t, entities.DraftStateScheduled, saved.State())
+	results, _ := repo.GetDraftResults(context.Background(), league.LeagueKey)
+	assert.Empty(t, results, "no keeper goes on the board while one of them has no pick")
+}
+
+func TestService_DeclareKeepers_Rejected(t *testing.T) {
+	noKeepers := testLeague()
+	noPrevious := keeperLeague()
+	noPrevious.LeagueKey, noPrevious.PreviousLeague = "399.l.2", nil
+	open := keeperLeague()
+	open.LeagueKey = "399.l.3"
+	open.SetState(entities.DraftStateOpen)
+	manyKeepers := keeperLeague()
+	manyKeepers.LeagueKey = "399.l.4"
+	manyKeepers.DraftSettings.Keepers.Count = 10
+
+	repo := newFakeDraftRepository(noKeepers, noPrevious, open, manyKeepers)
+	service := NewService(log.NewNopLogger(), repo, newFakeBroadcaster())
+
+	_, err := service.DeclareKeepers(commissionerContext(), noKeepers.LeagueKey, "399.l.1.t.1", nil)
+	assert.IsType(t, &ErrorKeepersDisabled{}, err)
+	_, err = service.DeclareKeepers(commissionerContext(), noPrevious.LeagueKey, "399.l.1.t.1", nil)
+	assert.IsType(t, &ErrorNoPreviousSeason{}, err)
+	_, err = service.DeclareKeepers(commissionerContext(), open.LeagueKey, "399.l.1.t.1", nil)
+	assert.IsType(t, &ErrorDraftState{}, err)
+	_, err = service.DeclareKeepers(commissionerContext(), manyKeepers.LeagueKey, "399.l.1.t.1", make([]string, 8))
+	if assert.IsType(t, &ErrorTooManyKeepers{}, err) {
+		assert.Equal(t, "8 keepers declared, teams may keep 7", err.Error(), "the draft only has seven rounds")
+	}
+}
+
+func TestService_OpenDraft_FillsKeeperPicks(t *testing.T) {
+	league := keeperLeague()
+	league.Keepers = []entities.Keeper{
+		{TeamKey: "399.l.1.t.1", PlayerKey: "399.p.1", Round: 1},
+		{TeamKey: "399.l.1.t.2", PlayerKey: "399.p.2", Round: 2},
+	}
+	repo := newFakeDraftRepository(league)
+	repo.players = []entities.PlayerSeason{testPlayer("399.p.1", "RB"), testPlayer("399.p.2", "WR"), testPlayer("399.p.3", "QB")}
+	service := NewService(log.NewNopLogger(), repo, newFakeBroadcaster())
+
+	opened, err := service.OpenDraft(commissionerContext(), league.LeagueKey)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, opened.CurrentPick, "the first pick is a keeper")
+	assert.True(t, opened.IsKeeperPick(7))
+
+	results, _ := repo.GetDraftResults(context.Background(), league.LeagueKey)
+	if assert.Len(t, results, 2) {
+		assert.True(t, results[1].Keeper)
+		assert.Equal(t, "399.l.1.t.2", results[1].TeamKey)
+		assert.Equal(t, "manager-2", results[1].UserGUID)
+		assert.Equal(t, 7, results[1].Pick)
+		assert.Equal(t, 2, results[1].Round)
+	}
+	rosters, _ := service.GetTeamsDraftResults(context.Background(), league.LeagueKey)
+	assert.Len(t, rosters["399.l.1.t.1"].Roster["RB"].DraftResults, 1)
+
+	_ = repo.SaveCurrentPick(context.Background(), league.LeagueKey, 6)
+	_, err = service.SaveDraftRequest(commissionerContext(), entities.User{Guid: "commish"}, league, league.Teams[2], testPlayer("399.p.3"), 6)
+	assert.Nil(t, err)
+	saved, _ := repo.GetLeague(context.Background(), league.LeagueKey)
+	assert.Equal(t, 8, saved.CurrentPick, "the clock passes over the keeper at pick 7")
+
+	correction, err := service.UndoLastPick(commissionerContext(), league.LeagueKey)
+	assert.Nil(t, err)
+	assert.Equal(t, 6, correction.Pick, "keepers are not undone")
+}
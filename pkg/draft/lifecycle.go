@@ -0,0 +1,137 @@
+package draft
+
+import (
+	"context"
+	"github.com/go-kit/kit/log/level"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"go.elastic.co/apm"
+)
+
+// PauseDraft stops the pick clock and the bid timer and holds all picks until the commissioner resumes
+func (service *Service) PauseDraft(ctx context.Context, leagueKey string) (*entities.League, error) {
+	span, ctx := apm.StartSpan(ctx, "PauseDraft", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	defer span.End()
+
+	league, err := service.commissionerLeague(ctx, leagueKey)
+	if err != nil {
+		return nil, err
+	}
+	if league.State() != entities.DraftStateOpen {
+		return nil, &ErrorDraftState{state: league.State(), action: "pause"}
+	}
+	err = service.transitionDraft(ctx, &league, entities.DraftStatePaused, "pause")
+	if err != nil {
+		return nil, err
+	}
+	service.clock.stop(leagueKey)
+	service.auctions.freeze(leagueKey)
+
+	return &league, service.broadcastState(ctx, league, "draft is paused", entities.BroadCastTypeDraftPaused)
+}
+
+// ResumeDraft reopens a paused draft and gives the team on the clock a fresh pick clock. A player on the
+// auction block gets the bid time it had left.
+func (service *Service) ResumeDraft(ctx context.Context, leagueKey string) (*entities.League, error) {
+	span, ctx := apm.StartSpan(ctx, "ResumeDraft", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	defer span.End()
+
+	league, err := service.commissionerLeague(ctx, leagueKey)
+	if err != nil {
+		return nil, err
+	}
+	if league.State() != entities.DraftStatePaused {
+		return nil, &ErrorDraftState{state: league.State(), action: "resume"}
+	}
+	err = service.transitionDraft(ctx, &league, entities.DraftStateOpen, "resume")
+	if err != nil {
+		return nil, err
+	}
+	if league.CurrentPick <= totalPicks(league) {
+		service.startPickClock(&league)
+	}
+	service.auctions.thaw(leagueKey)
+
+	err = service.broadcastState(ctx, league, "draft is resumed", entities.BroadCastTypeDraftResumed)
+	if err != nil {
+		return nil, err
+	}
+	go service.autoDraftIfEnabled(league.LeagueKey, league.CurrentPick)
+	return &league, nil
+}
+
+// CloseDraft completes the draft, even when some picks were never made. A player on the auction block goes unsold.
+func (service *Service) CloseDraft(ctx context.Context, leagueKey string) (*entities.League, error) {
+	span, ctx := apm.StartSpan(ctx, "CloseDraft", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	defer span.End()
+
+	league, err := service.commissionerLeague(ctx, leagueKey)
+	if err != nil {
+		return nil, err
+	}
+	err = service.transitionDraft(ctx, &league, entities.DraftStateCompleted, "close")
+	if err != nil {
+		return nil, err
+	}
+	service.clock.stop(leagueKey)
+	service.auctions.cancel(leagueKey)
+
+	err = service.broadcastState(ctx, league, "draft is completed", entities.BroadCastTypeDraftCompleted)
+	if err != nil {
+		return nil, err
+	}
+	service.broadcastGrades(ctx, league)
+	service.syncToYahoo(league)
+	return &league, nil
+}
+
+// transitionDraft validates and saves a move to the next state. action names what was attempted for the error.
+func (service *Service) transitionDraft(ctx context.Context, league *entities.League, next entities.DraftState, action string) error {
+	state := league.State()
+	if !state.CanTransitionTo(next) {
+		return &ErrorDraftState{state: state, action: action}
+	}
+
+	err := service.draftRepo.SaveDraftState(ctx, league.LeagueKey, next)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not save draft state", "error", err, "league_key", league.LeagueKey, "state", next)
+		return &ErrorUpdateDraft{}
+	}
+	league.SetState(next)
+	league.ClockExpiresAt = nil
+	return nil
+}
+
+// completeIfFull completes the draft once every roster slot of every team has been drafted
+func (service *Service) completeIfFull(ctx context.Context, league *entities.League) {
+	if !league.State().CanTransitionTo(entities.DraftStateCompleted) {
+		return
+	}
+	results, err := service.draftRepo.GetDraftResults(ctx, league.LeagueKey)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not get draft results", "error", err, "league_key", league.LeagueKey)
+		return
+	}
+	if len(results) < totalPicks(*league) {
+		return
+	}
+
+	err = service.transitionDraft(ctx, league, entities.DraftStateCompleted, "complete")
+	if err != nil {
+		return
+	}
+	service.clock.stop(league.LeagueKey)
+	_ = service.broadcastState(ctx, *league, "draft is completed", entities.BroadCastTypeDraftCompleted)
+	service.broadcastGrades(ctx, *league)
+	service.syncToYahoo(*league)
+}
+
+func (service *Service) broadcastState(ctx context.Context, league entities.League, message string, broadcastType entities.BroadcastType) error {
+	err := service.broadCastRepo.BroadCastLeagueInformation(ctx, league, message, broadcastType)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not broadcast draft state", "error", err, "league_key", league.LeagueKey, "state", league.State())
+	}
+	return err
+}
@@ -0,0 +1,116 @@
+package draft
+
+import (
+	"context"
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"testing"
+)
+
+func TestDraftState_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from entities.DraftState
+		to   entities.DraftState
+		want bool
+	}{
+		{entities.DraftStateScheduled, entities.DraftStateOpen, true},
+		{entities.DraftStateScheduled, entities.DraftStatePaused, false},
+		{entities.DraftStateScheduled, entities.DraftStateCompleted, false},
+		{entities.DraftStateOpen, entities.DraftStatePaused, true},
+		{entities.DraftStateOpen, entities.DraftStateCompleted, true},
+		{entities.DraftStateOpen, entities.DraftStateScheduled, false},
+		{entities.DraftStatePaused, entities.DraftStateOpen, true},
+		{entities.DraftStatePaused, entities.DraftStateCompleted, true},
+		{entities.DraftStateCompleted, entities.DraftStateOpen, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.from)+" to "+string(tt.to), func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.from.CanTransitionTo(tt.to))
+		})
+	}
+}
+
+func TestLeague_State(t *testing.T) {
+	assert.Equal(t, entities.DraftStateScheduled, entities.League{}.State())
+	assert.Equal(t, entities.DraftStateOpen, entities.League{DraftStarted: true}.State(), "leagues saved before draft_state keep working")
+
+	league := entities.League{}
+	league.SetState(entities.DraftStatePaused)
+	assert.Equal(t, entities.DraftStatePaused, league.State())
+	assert.True(t, league.DraftStarted)
+}
+
+func TestService_DraftLifecycle(t *testing.T) {
+	league := testLeague()
+	league.DraftSettings = entities.DraftSettings{PickSeconds: 90, ExpiryAction: entities.ClockExpirySkip}
+	repo := newFakeDraftRepository(league)
+	broadcaster := newFakeBroadcaster()
+	service := NewService(log.NewNopLogger(), repo, broadcaster)
+	defer service.clock.stop(league.LeagueKey)
+
+	_, err := service.PauseDraft(commissionerContext(), league.LeagueKey)
+	assert.IsType(t, &ErrorDraftState{}, err, "a scheduled draft can not be paused")
+
+	_, err = service.OpenDraft(commissionerContext(), league.LeagueKey)
+	assert.Nil(t, err)
+	_, err = service.ShuffleOrder(commissionerContext(), league.LeagueKey)
+	assert.IsType(t, &ErrorDraftState{}, err, "the order is fixed once the draft opens")
+	_, err = service.OpenDraft(commissionerContext(), league.LeagueKey)
+	assert.IsType(t, &ErrorDraftState{}, err)
+
+	paused, err := service.PauseDraft(commissionerContext(), league.LeagueKey)
+	assert.Nil(t, err)
+	assert.Equal(t, entities.DraftStatePaused, paused.State())
+	_, _, running := service.clock.current(league.LeagueKey)
+	assert.False(t, running, "pausing stops the pick clock")
+	assert.Equal(t, entities.BroadCastTypeDraftPaused, broadcaster.last().Type)
+
+	resumed, err := service.ResumeDraft(commissionerContext(), league.LeagueKey)
+	assert.Nil(t, err)
+	assert.Equal(t, entities.DraftStateOpen, resumed.State())
+	pick, _, running := service.clock.current(league.LeagueKey)
+	assert.True(t, running)
+	assert.Equal(t, 1, pick)
+
+	closed, err := service.CloseDraft(commissionerContext(), league.LeagueKey)
+	assert.Nil(t, err)
+	assert.Equal(t, entities.DraftStateCompleted, closed.State())
+	_, _, running = service.clock.current(league.LeagueKey)
+	assert.False(t, running)
+
+	_, err = service.ResumeDraft(commissionerContext(), league.LeagueKey)
+	assert.IsType(t, &ErrorDraftState{}, err)
+	_, err = service.PauseDraft(commissionerContext(), league.LeagueKey)
+	assert.IsType(t, &ErrorDraftState{}, err)
+}
+
+func TestService_SaveDraftRequest_CompletesDraft(t *testing.T) {
+	league := testLeague()
+	league.SetState(entities.DraftStateOpen)
+	league.DraftOrder = league.DraftOrder[:1]
+	league.Settings.RosterPositions = []entities.RosterPosition{{Position: "QB", Count: 1}, {Position: "BN", Count: 1}}
+	league.CurrentPick = 1
+	repo := newFakeDraftRepository(league)
+	repo.players = []entities.PlayerSeason{testPlayer("399.p.1", "QB"), testPlayer("399.p.2", "QB")}
+	broadcaster := newFakeBroadcaster()
+	service := NewService(log.NewNopLogger(), repo, broadcaster)
+
+	_, err := service.SaveDraftRequest(commissionerContext(), entities.User{Guid: "commish"}, league, league.Teams[0], testPlayer("399.p.1"), 1)
+	assert.Nil(t, err)
+	saved, _ := repo.GetLeague(context.Background(), league.LeagueKey)
+	assert.Equal(t, entities.DraftStateOpen, saved.State())
+
+	_, err = service.SaveDraftRequest(commissionerContext(), entities.User{Guid: "commish"}, league, league.Teams[0], testPlayer("399.p.2"), 2)
+	assert.Nil(t, err)
+	saved, _ = repo.GetLeague(context.Background(), league.LeagueKey)
+	assert.Equal(t, entities.DraftStateCompleted, saved.State(), "filling the last roster slot completes the draft")
+	types := broadcaster.types()
+	assert.Equal(t, []entities.BroadcastType{entities.BroadCastTypeDraftCompleted, entities.BroadCastTypeDraftGraded}, types[len(types)-2:])
+
+	// undoing a pick of a completed draft leaves it paused for the commissioner
+	_, err = service.UndoLastPick(commissionerContext(), league.LeagueKey)
+	assert.Nil(t, err)
+	saved, _ = repo.GetLeague(context.Background(), league.LeagueKey)
+	assert.Equal(t, entities.DraftStatePaused, saved.State())
+}
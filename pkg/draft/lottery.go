@@ -0,0 +1,254 @@
+package draft
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"github.com/go-kit/kit/log/level"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"go.elastic.co/apm"
+	"reflect"
+	"sort"
+	"time"
+)
+
+const (
+	// maxLotteryReveal is the longest a commissioner can hold each slot of the reveal for
+	maxLotteryReveal = 30 * time.Second
+)
+
+// RunDraftLottery draws the league's draft order from a random seed and saves how it was drawn, keeping every
+// earlier draw. The order is revealed to the draft room one slot at a time, last slot first, reveal apart.
+func (service *Service) RunDraftLottery(ctx context.Context, leagueKey string, method entities.LotteryMethod, reveal time.Duration) (*entities.DraftLottery, error) {
+	span, ctx := apm.StartSpan(ctx, "RunDraftLottery", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	defer span.End()
+
+	league, err := service.commissionerLeague(ctx, leagueKey)
+	if err != nil {
+		return nil, err
+	}
+	if !method.Valid() {
+		return nil, &ErrorLotteryMethod{method: method}
+	}
+	if reveal < 0 || reveal > maxLotteryReveal {
+		return nil, &ErrorLotteryReveal{reveal: reveal}
+	}
+	err = checkCanChangeOrder(league)
+	if err != nil {
+		return nil, err
+	}
+
+	var standings map[string]int
+	if method.UsesStandings() {
+		standings, err = service.previousStandings(ctx, league)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	lottery, err := drawLottery(league, standings, method, "")
+	if err != nil {
+		return nil, err
+	}
+	lottery.DrawnBy = commissionerUser(ctx).Guid
+	keepLottery(&league, &lottery)
+	league, err = service.draftRepo.SaveLeague(ctx, league)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not save draft lottery", "error", err, "league_key", leagueKey)
+		return nil, &ErrorUpdateDraft{}
+	}
+
+	go service.revealLottery(league, lottery, reveal)
+	lottery.Verified = true
+	return &lottery, nil
+}
+
+// GetDraftLottery returns how the league's draft order was drawn and every draw before it. The lottery is only
+// verified while it checks out against its seed and the league still drafts in the order it drew.
+func (service *Service) GetDraftLottery(ctx context.Context, leagueKey string) (*entities.DraftLottery, error) {
+	span, ctx := apm.StartSpan(ctx, "GetDraftLottery", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	defer span.End()
+
+	league, err := service.draftRepo.GetLeague(ctx, leagueKey)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not get league", "error", err, "league_key", leagueKey)
+		return nil, err
+	}
+	if league.DraftLottery == nil {
+		return nil, &ErrorNoLottery{leagueKey: leagueKey}
+	}
+	lottery := *league.DraftLottery
+	lottery.Verified = VerifyLottery(lottery) && reflect.DeepEqual(lottery.DraftOrder, league.DraftOrder)
+	for _, previous := range league.PreviousDraws {
+		previous.Verified = VerifyLottery(previous)
+		lottery.PreviousDraws = append(lottery.PreviousDraws, previous)
+	}
+	return &lottery, nil
+}
+
+// keepLottery makes lottery the league's draft order and moves the lottery it replaces to its previous draws
+func keepLottery(league *entities.League, lottery *entities.DraftLottery) {
+	if league.DraftLottery != nil {
+		league.PreviousDraws = append(league.PreviousDraws, *league.DraftLottery)
+	}
+	lottery.Draw = len(league.PreviousDraws) + 1
+	league.DraftOrder = lottery.DraftOrder
+	league.DraftLottery = lottery
+}
+
+// VerifyLottery draws the lottery again from its seed and the tickets of its first step, and reports whether
+// every step and the draft order come out the same
+func VerifyLottery(lottery entities.DraftLottery) bool {
+	if len(lottery.Steps) == 0 {
+		return false
+	}
+	steps, draftOrder := runLottery(lottery.Method, lottery.Seed, lottery.Steps[0].Candidates)
+	return reflect.DeepEqual(steps, lottery.Steps) && reflect.DeepEqual(draftOrder, lottery.DraftOrder)
+}
+
+// checkCanChangeOrder is true until the draft opens and while no picks have been traded, because traded picks
+// are tied to a slot and a new order would hand them to other teams
+func checkCanChangeOrder(league entities.League) error {
+	if !league.State().CanChangeOrder() {
+		return &ErrorDraftState{state: league.State(), action: "change the draft order"}
+	}
+	if len(league.PickOwners) > 0 {
+		return &ErrorPicksTraded{}
+	}
+	return nil
+}
+
+// previousStandings are the final ranks of last season's league by team number, see teamNumber
+func (service *Service) previousStandings(ctx context.Context, league entities.League) (map[string]int, error) {
+	if league.PreviousLeague == nil || *league.PreviousLeague == "" {
+		return nil, &ErrorNoPreviousSeason{leagueKey: league.LeagueKey, action: "take standings from"}
+	}
+	previous, err := service.draftRepo.GetLeague(ctx, *league.PreviousLeague)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not get previous season's league", "error", err, "league_key", *league.PreviousLeague)
+		return nil, err
+	}
+	standings := make(map[string]int, len(previous.Teams))
+	for _, team := range previous.Teams {
+		standings[teamNumber(team.TeamKey)] = team.Standing.Rank
+	}
+	return standings, nil
+}
+
+// drawLottery draws an order for the teams in the league's draft order, or all of its teams before it has one.
+// Methods that use standings give every team the tickets of its rank in standings, which are by team number.
+func drawLottery(league entities.League, standings map[string]int, method entities.LotteryMethod, seed string) (entities.DraftLottery, error) {
+	teamKeys := league.DraftOrder
+	if len(teamKeys) == 0 {
+		for _, team := range league.Teams {
+			teamKeys = append(teamKeys, team.TeamKey)
+		}
+	}
+
+	tickets := make([]entities.LotteryTicket, len(teamKeys))
+	for idx, teamKey := range teamKeys {
+		tickets[idx] = entities.LotteryTicket{TeamKey: teamKey, Tickets: 1}
+		if !method.UsesStandings() {
+			continue
+		}
+		rank := standings[teamNumber(teamKey)]
+		if rank < 1 || rank > len(teamKeys) {
+			return entities.DraftLottery{}, &ErrorLotteryStandings{teamKey: teamKey}
+		}
+		tickets[idx].Tickets = rank
+	}
+	sort.Slice(tickets, func(i, j int) bool { return tickets[i].TeamKey < tickets[j].TeamKey })
+
+	if seed == "" {
+		var err error
+		seed, err = newLotterySeed()
+		if err != nil {
+			return entities.DraftLottery{}, err
+		}
+	}
+	steps, draftOrder := runLottery(method, seed, tickets)
+	return entities.DraftLottery{
+		LeagueKey:  league.LeagueKey,
+		Method:     method,
+		Seed:       seed,
+		Steps:      steps,
+		DraftOrder: draftOrder,
+		DrawnAt:    time.Now(),
+	}, nil
+}
+
+// runLottery draws one slot at a time from the teams left. Every draw only depends on the seed and the slot,
+// see entities.LotteryStep, so the same seed and tickets always give the same order.
+func runLottery(method entities.LotteryMethod, seed string, tickets []entities.LotteryTicket) ([]entities.LotteryStep, []string) {
+	left := append([]entities.LotteryTicket{}, tickets...)
+	steps := make([]entities.LotteryStep, 0, len(tickets))
+	draftOrder := make([]string, 0, len(tickets))
+
+	for slot := 1; len(left) > 0; slot++ {
+		step := entities.LotteryStep{Slot: slot, Candidates: append([]entities.LotteryTicket{}, left...)}
+		winner := 0
+		if method == entities.LotteryReverseStandings {
+			// last place has the most tickets and picks first, with nothing to draw
+			for idx, candidate := range left {
+				if candidate.Tickets > left[winner].Tickets {
+					winner = idx
+				}
+			}
+		} else {
+			for _, candidate := range left {
+				step.TotalTickets += candidate.Tickets
+			}
+			hash := sha256.Sum256([]byte(fmt.Sprintf("%s:%d", seed, slot)))
+			step.Hash = hex.EncodeToString(hash[:])
+			step.Ticket = int(binary.BigEndian.Uint64(hash[:8]) % uint64(step.TotalTickets))
+			for covered := left[0].Tickets; covered <= step.Ticket; covered += left[winner].Tickets {
+				winner++
+			}
+		}
+
+		step.TeamKey = left[winner].TeamKey
+		steps = append(steps, step)
+		draftOrder = append(draftOrder, step.TeamKey)
+		left = append(left[:winner], left[winner+1:]...)
+	}
+	return steps, draftOrder
+}
+
+func newLotterySeed() (string, error) {
+	seed := make([]byte, 16)
+	_, err := rand.Read(seed)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(seed), nil
+}
+
+// revealLottery broadcasts the draw one slot at a time, last slot first, then the whole order.
+// The draw messages leave the order off the league so the reveal is not spoiled.
+func (service *Service) revealLottery(league entities.League, lottery entities.DraftLottery, reveal time.Duration) {
+	tx := apm.DefaultTracer.StartTransaction("RevealLottery", "lottery")
+	defer tx.End()
+	ctx := apm.ContextWithTransaction(context.Background(), tx)
+
+	hidden := league
+	hidden.DraftOrder = nil
+	hidden.TeamDraftOrder = nil
+	hidden.DraftLottery = nil
+	for idx := len(lottery.Steps) - 1; idx >= 0; idx-- {
+		err := service.broadCastRepo.BroadCastLotteryDraw(ctx, hidden, lottery.Steps[idx])
+		if err != nil {
+			level.Error(service.logger).Log("message", "could not broadcast lottery draw", "error", err, "league_key", league.LeagueKey, "slot", lottery.Steps[idx].Slot)
+		}
+		time.Sleep(reveal)
+	}
+
+	err := service.broadCastRepo.BroadCastLeagueInformation(ctx, league, "draft order drawn by lottery", entities.BroadCastTypeDraftOrder)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not broadcast draft order", "error", err, "league_key", league.LeagueKey)
+	}
+}
@@ -0,0 +1,193 @@
+package draft
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"testing"
+	"time"
+)
+
+// lotteryLeague renews league 390.l.9, which finished with t.1 in last place and t.4 as champion
+func lotteryLeague() (entities.League, entities.League) {
+	league := testLeague()
+	previousKey := "390.l.9"
+	league.PreviousLeague = &previousKey
+
+	previous := entities.League{LeagueKey: previousKey}
+	for idx := range league.Teams {
+		team := entities.Team{TeamKey: fmt.Sprintf("390.l.9.t.%d", idx+1)}
+		team.Standing.Rank = len(league.Teams) - idx
+		previous.Teams = append(previous.Teams, team)
+	}
+	return league, previous
+}
+
+// lotteryStandings are the standings of lotteryLeague's previous season
+func lotteryStandings() map[string]int {
+	return map[string]int{"1": 4, "2": 3, "3": 2, "4": 1}
+}
+
+func TestService_RunDraftLottery(t *testing.T) {
+	league, previous := lotteryLeague()
+	repo := newFakeDraftRepository(league, previous)
+	broadcaster := newFakeBroadcaster()
+	service := NewService(log.NewNopLogger(), repo, broadcaster)
+
+	lottery, err := service.RunDraftLottery(commissionerContext(), "399.l.1", entities.LotteryStandings, 0)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Len(t, lottery.Seed, 32, "the seed is drawn by the server")
+	assert.Equal(t, "commish", lottery.DrawnBy)
+	assert.Equal(t, 1, lottery.Draw)
+	assert.True(t, lottery.Verified)
+	assert.ElementsMatch(t, []string{"399.l.1.t.1", "399.l.1.t.2", "399.l.1.t.3", "399.l.1.t.4"}, lottery.DraftOrder)
+	assert.Equal(t, lottery.DraftOrder, repo.leagues["399.l.1"].DraftOrder)
+	if assert.Len(t, lottery.Steps, 4) {
+		assert.Equal(t, 10, lottery.Steps[0].TotalTickets, "last place holds four tickets down to one for the champion")
+		assert.Len(t, lottery.Steps[3].Candidates, 1)
+	}
+
+	again, err := service.GetDraftLottery(context.Background(), "399.l.1")
+	if assert.Nil(t, err) {
+		assert.True(t, again.Verified)
+	}
+
+	assert.Eventually(t, func() bool {
+		return broadcaster.last().Type == entities.BroadCastTypeDraftOrder
+	}, time.Second, 5*time.Millisecond)
+	if assert.Len(t, broadcaster.broadcasts, 5) {
+		reveal := broadcaster.broadcasts[0]
+		assert.Equal(t, entities.BroadCastTypeLotteryDraw, reveal.Type)
+		assert.Equal(t, 4, reveal.Lottery.Slot, "the last slot is revealed first")
+		assert.Nil(t, reveal.League.DraftOrder, "the draw does not give away the order")
+		assert.Equal(t, 1, broadcaster.broadcasts[3].Lottery.Slot)
+	}
+}
+
+func TestService_RunDraftLottery_Errors(t *testing.T) {
+	service := NewService(log.NewNopLogger(), newFakeDraftRepository(testLeague()), newFakeBroadcaster())
+
+	_, err := service.RunDraftLottery(commissionerContext(), "399.l.1", entities.LotteryStandings, 0)
+	assert.IsType(t, &ErrorNoPreviousSeason{}, err, "the test league has no previous season")
+	_, err = service.RunDraftLottery(commissionerContext(), "399.l.1", "fair", 0)
+	assert.IsType(t, &ErrorLotteryMethod{}, err)
+	_, err = service.RunDraftLottery(commissionerContext(), "399.l.1", entities.LotteryUniform, time.Minute)
+	assert.IsType(t, &ErrorLotteryReveal{}, err)
+	_, err = service.RunDraftLottery(chatContext("manager-2"), "399.l.1", entities.LotteryUniform, 0)
+	assert.IsType(t, &ErrorNotCommissioner{}, err)
+	_, err = service.GetDraftLottery(context.Background(), "399.l.1")
+	assert.IsType(t, &ErrorNoLottery{}, err)
+}
+
+func TestService_RunDraftLottery_PreviousStandings(t *testing.T) {
+	league, previous := lotteryLeague()
+	previous.Teams[2].Standing.Rank = 0
+	service := NewService(log.NewNopLogger(), newFakeDraftRepository(league, previous), newFakeBroadcaster())
+
+	_, err := service.RunDraftLottery(commissionerContext(), "399.l.1", entities.LotteryStandings, 0)
+	assert.IsType(t, &ErrorLotteryStandings{}, err, "t.3 has no rank last season")
+	assert.Contains(t, err.Error(), "399.l.1.t.3")
+
+	lottery, err := service.RunDraftLottery(commissionerContext(), "399.l.1", entities.LotteryUniform, 0)
+	if assert.Nil(t, err, "a uniform lottery does not need standings") {
+		assert.Len(t, lottery.DraftOrder, 4)
+	}
+}
+
+func TestService_RunDraftLottery_DrawAgain(t *testing.T) {
+	league, previous := lotteryLeague()
+	repo := newFakeDraftRepository(league, previous)
+	service := NewService(log.NewNopLogger(), repo, newFakeBroadcaster())
+
+	first, err := service.RunDraftLottery(commissionerContext(), "399.l.1", entities.LotteryStandings, 0)
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, err = service.ShuffleOrder(commissionerContext(), "399.l.1")
+	assert.Nil(t, err)
+	second, err := service.RunDraftLottery(commissionerContext(), "399.l.1", entities.LotteryUniform, 0)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, 3, second.Draw, "the shuffle counts as a draw")
+
+	current, err := service.GetDraftLottery(context.Background(), "399.l.1")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, second.Seed, current.Seed)
+	if assert.Len(t, current.PreviousDraws, 2, "earlier draws are kept") {
+		assert.Equal(t, first.Seed, current.PreviousDraws[0].Seed)
+		assert.Equal(t, entities.LotteryUniform, current.PreviousDraws[1].Method)
+		assert.True(t, current.PreviousDraws[0].Verified)
+	}
+}
+
+func TestService_GetDraftLottery_OrderChanged(t *testing.T) {
+	league, previous := lotteryLeague()
+	repo := newFakeDraftRepository(league, previous)
+	service := NewService(log.NewNopLogger(), repo, newFakeBroadcaster())
+
+	lottery, err := service.RunDraftLottery(commissionerContext(), "399.l.1", entities.LotteryUniform, 0)
+	if !assert.Nil(t, err) {
+		return
+	}
+	saved := repo.leagues["399.l.1"]
+	saved.DraftOrder = []string{lottery.DraftOrder[3], lottery.DraftOrder[2], lottery.DraftOrder[1], lottery.DraftOrder[0]}
+	repo.leagues["399.l.1"] = saved
+
+	stale, err := service.GetDraftLottery(context.Background(), "399.l.1")
+	if assert.Nil(t, err) {
+		assert.False(t, stale.Verified, "the league no longer drafts in the order the lottery drew")
+	}
+}
+
+func Test_drawLottery_ReverseStandings(t *testing.T) {
+	lottery, err := drawLottery(testLeague(), lotteryStandings(), entities.LotteryReverseStandings, "seed")
+	if assert.Nil(t, err) {
+		assert.Equal(t, []string{"399.l.1.t.1", "399.l.1.t.2", "399.l.1.t.3", "399.l.1.t.4"}, lottery.DraftOrder)
+		assert.Empty(t, lottery.Steps[0].Hash, "nothing is drawn")
+		assert.True(t, VerifyLottery(lottery))
+	}
+}
+
+func Test_drawLottery_SameSeedSameOrder(t *testing.T) {
+	first, _ := drawLottery(testLeague(), nil, entities.LotteryUniform, "public seed")
+	second, _ := drawLottery(testLeague(), nil, entities.LotteryUniform, "public seed")
+	assert.Equal(t, first.Steps, second.Steps)
+	assert.Equal(t, first.DraftOrder, second.DraftOrder)
+
+	random, _ := drawLottery(testLeague(), nil, entities.LotteryUniform, "")
+	assert.Len(t, random.Seed, 32, "a random seed is drawn when none is given")
+	assert.Len(t, random.DraftOrder, 4)
+}
+
+func TestVerifyLottery_Tampered(t *testing.T) {
+	lottery, _ := drawLottery(testLeague(), lotteryStandings(), entities.LotteryStandings, "seed")
+	assert.True(t, VerifyLottery(lottery))
+
+	swapped := lottery
+	swapped.DraftOrder = []string{lottery.DraftOrder[1], lottery.DraftOrder[0], lottery.DraftOrder[2], lottery.DraftOrder[3]}
+	assert.False(t, VerifyLottery(swapped), "the order has to come from the steps")
+
+	reseeded := lottery
+	reseeded.Seed = "another seed"
+	assert.False(t, VerifyLottery(reseeded))
+}
+
+func Test_runLottery_TicketsCoverTheDraw(t *testing.T) {
+	tickets := []entities.LotteryTicket{{TeamKey: "a", Tickets: 1}, {TeamKey: "b", Tickets: 3}}
+	for _, seed := range []string{"1", "2", "3", "4", "5", "6", "7", "8"} {
+		steps, _ := runLottery(entities.LotteryStandings, seed, tickets)
+		first := steps[0]
+		if first.Ticket < 1 {
+			assert.Equal(t, "a", first.TeamKey, "seed %s ticket %d", seed, first.Ticket)
+		} else {
+			assert.Equal(t, "b", first.TeamKey, "seed %s ticket %d", seed, first.Ticket)
+		}
+	}
+}
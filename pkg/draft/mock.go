@@ -0,0 +1,341 @@
+package draft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-kit/kit/log/level"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"go.elastic.co/apm"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// mockDraftDuration is how long a user has to finish a mock draft before it expires
+const mockDraftDuration = 2 * time.Hour
+
+// botADPRank is the rank source bots read average draft position from, next to autoPickRank
+const botADPRank = "adp"
+
+// playerADP is the player's average draft position. The importers only store Yahoo's rank so far,
+// so a player without an "adp" rank falls back to autoPickRank, the rank Yahoo expects them to go at.
+func playerADP(player entities.PlayerSeason) int {
+	if adp := player.Ranks[botADPRank]; adp > 0 {
+		return adp
+	}
+	return player.Ranks[autoPickRank]
+}
+
+// StartMockDraft starts a rehearsal of the league's draft for the logged in user's team. The league's
+// settings, roster positions and draft order are copied into the mock draft, and bots draft every other
+// team until the user is on the clock.
+func (service *Service) StartMockDraft(ctx context.Context, leagueKey string) (*entities.MockDraft, error) {
+	span, ctx := apm.StartSpan(ctx, "StartMockDraft", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	defer span.End()
+
+	league, err := service.draftRepo.GetLeague(ctx, leagueKey)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not get league", "error", err, "league_key", leagueKey)
+		return nil, err
+	}
+	if league.IsAuction() {
+		return nil, &ErrorAuctionDraft{}
+	}
+	user, _ := userFromContext(ctx)
+	team, ok := userTeam(ctx, league)
+	if !ok {
+		return nil, &ErrorNotInLeague{leagueKey: leagueKey}
+	}
+
+	now := time.Now()
+	mock := entities.MockDraft{
+		ID:          primitive.NewObjectID().Hex(),
+		LeagueKey:   leagueKey,
+		UserGUID:    user.GUID,
+		TeamKey:     team.TeamKey,
+		League:      mockLeague(league),
+		Results:     []entities.DraftResult{},
+		CurrentPick: 1,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(mockDraftDuration),
+	}
+	err = service.runMockBots(ctx, &mock)
+	if err != nil {
+		return nil, err
+	}
+
+	err = service.draftRepo.CreateMockDraft(ctx, mock)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not create mock draft", "error", err, "league_key", leagueKey)
+		return nil, &ErrorUpdateDraft{}
+	}
+	return mockDraftResponse(mock, 0), nil
+}
+
+// MockDraftPick makes the user's pick in a mock draft, then lets the bots draft until the user is on the clock again
+func (service *Service) MockDraftPick(ctx context.Context, mockID, playerKey string) (*entities.MockDraft, error) {
+	span, ctx := apm.StartSpan(ctx, "MockDraftPick", "service")
+	span.Context.SetLabel("mock_draft_id", mockID)
+	defer span.End()
+
+	mock, err := service.userMockDraft(ctx, mockID)
+	if err != nil {
+		return nil, err
+	}
+	if status := mock.StatusAt(time.Now()); status != entities.MockDraftDrafting {
+		return nil, &ErrorMockDraftClosed{status: status}
+	}
+	err = validatePickAvailable(mock.League, mock.Results, playerKey, mock.CurrentPick)
+	if err != nil {
+		return nil, err
+	}
+	player, err := service.getPlayer(ctx, playerKey)
+	if err != nil {
+		return nil, err
+	}
+
+	fromPick := mock.CurrentPick
+	addMockPick(&mock, mock.UserGUID, mock.TeamKey, player)
+	err = service.runMockBots(ctx, &mock)
+	if err != nil {
+		return nil, err
+	}
+
+	err = service.draftRepo.SaveMockDraftPicks(ctx, mock, fromPick)
+	if errors.Is(err, entities.ErrMockDraftChanged) {
+		return nil, &ErrorPickConflict{pick: fromPick}
+	}
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not save mock draft", "error", err, "mock_draft_id", mockID)
+		return nil, &ErrorUpdateDraft{}
+	}
+	return mockDraftResponse(mock, 0), nil
+}
+
+// GetMockDraft returns a mock draft with its rosters. A mock draft can be replayed pick by pick after it
+// completed or expired: through is the last pick to include, or zero for the whole draft.
+func (service *Service) GetMockDraft(ctx context.Context, mockID string, through int) (*entities.MockDraft, error) {
+	span, ctx := apm.StartSpan(ctx, "GetMockDraft", "service")
+	span.Context.SetLabel("mock_draft_id", mockID)
+	defer span.End()
+
+	if through < 0 {
+		return nil, &ErrorInvalidPick{pick: through}
+	}
+	mock, err := service.userMockDraft(ctx, mockID)
+	if err != nil {
+		return nil, err
+	}
+	return mockDraftResponse(mock, through), nil
+}
+
+// ListMockDrafts lists the logged in user's mock drafts of a league, newest first
+func (service *Service) ListMockDrafts(ctx context.Context, leagueKey string) ([]entities.MockDraft, error) {
+	span, ctx := apm.StartSpan(ctx, "ListMockDrafts", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	defer span.End()
+
+	user, ok := userFromContext(ctx)
+	if !ok {
+		return nil, &ErrorNotInLeague{leagueKey: leagueKey}
+	}
+	mocks, err := service.draftRepo.ListMockDrafts(ctx, leagueKey, user.GUID)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not list mock drafts", "error", err, "league_key", leagueKey)
+		return nil, err
+	}
+
+	now := time.Now()
+	for idx := range mocks {
+		mocks[idx].Status = mocks[idx].StatusAt(now)
+	}
+	return mocks, nil
+}
+
+// userMockDraft loads a mock draft of the logged in user. Another user's mock draft is reported as not found.
+func (service *Service) userMockDraft(ctx context.Context, mockID string) (entities.MockDraft, error) {
+	mock, err := service.draftRepo.GetMockDraft(ctx, mockID)
+	if errors.Is(err, entities.ErrMockDraftNotFound) {
+		return mock, &ErrorMockDraftNotFound{mockID: mockID}
+	}
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not get mock draft", "error", err, "mock_draft_id", mockID)
+		return mock, err
+	}
+	user, ok := userFromContext(ctx)
+	if !ok || user.GUID != mock.UserGUID {
+		return entities.MockDraft{}, &ErrorMockDraftNotFound{mockID: mockID}
+	}
+	return mock, nil
+}
+
+// runMockBots drafts for the bots until the user's team is on the clock or the mock draft is complete.
+// Bots draft from the game's ranked players, not the league's player pool, which belongs to the real draft.
+func (service *Service) runMockBots(ctx context.Context, mock *entities.MockDraft) error {
+	total := totalPicks(mock.League)
+	var pool []entities.PlayerSeason
+	exhausted := false
+
+	for mock.CurrentPick <= total {
+		teamKey, ok := teamKeyForPick(mock.League, mock.CurrentPick)
+		if !ok {
+			return &ErrorInvalidPick{pick: mock.CurrentPick}
+		}
+		if teamKey == mock.TeamKey {
+			return nil
+		}
+
+		drafted := make(map[string]bool, len(mock.Results))
+		for _, result := range mock.Results {
+			drafted[result.PlayerKey] = true
+		}
+		roster := mockRosters(*mock)[teamKey]
+		teams := len(mock.League.DraftOrder)
+
+		player, ok := chooseBotPick(pool, drafted, roster, teams)
+		for page := len(pool) / autoPickPageSize; !ok && !exhausted; page++ {
+			ranked, err := service.draftRepo.GetPlayersByGameRank(ctx, mock.League.Game.GameID, autoPickPageSize, page)
+			if err != nil {
+				level.Error(service.logger).Log("message", "could not get ranked players for mock draft", "error", err, "mock_draft_id", mock.ID)
+				return err
+			}
+			exhausted = len(ranked) < autoPickPageSize
+			pool = append(pool, ranked...)
+			player, ok = chooseBotPick(pool, drafted, roster, teams)
+		}
+		if !ok {
+			return fmt.Errorf("no available player fits the roster of team %s", teamKey)
+		}
+
+		addMockPick(mock, "", teamKey, player)
+	}
+
+	completedAt := time.Now()
+	mock.CompletedAt = &completedAt
+	return nil
+}
+
+// chooseBotPick returns the candidate a bot drafts: the undrafted player with the best botScore
+// that still has an open roster slot
+func chooseBotPick(candidates []entities.PlayerSeason, drafted map[string]bool, roster entities.Roster, teams int) (entities.PlayerSeason, bool) {
+	best, found := 0, false
+	bestScore := 0.0
+	for idx := range candidates {
+		player := candidates[idx]
+		if player.PlayerKey == "" || drafted[player.PlayerKey] || len(player.EligiblePositions) == 0 {
+			continue
+		}
+		slot, open := rosterSlotFor(roster, &player)
+		if !open {
+			continue
+		}
+		score := botScore(player, slot, teams)
+		if !found || score < bestScore {
+			best, bestScore, found = idx, score, true
+		}
+	}
+	if !found {
+		return entities.PlayerSeason{}, false
+	}
+	return candidates[best], true
+}
+
+// botScore is how early a bot wants a player, lower first: the average of the player's rank and ADP,
+// pushed back two rounds when the player would only fill the bench so bots fill their starters first
+func botScore(player entities.PlayerSeason, slot string, teams int) float64 {
+	rank, adp := player.Ranks[autoPickRank], playerADP(player)
+	var score float64
+	switch {
+	case rank > 0 && adp > 0:
+		score = float64(rank+adp) / 2
+	case rank > 0:
+		score = float64(rank)
+	case adp > 0:
+		score = float64(adp)
+	default:
+		// unranked players only go once nobody ranked fits
+		score = 1 << 20
+	}
+	if slot == "BN" {
+		score += float64(2 * teams)
+	}
+	return score
+}
+
+// addMockPick drafts player to teamKey with the mock draft's current pick
+func addMockPick(mock *entities.MockDraft, userGUID, teamKey string, player entities.PlayerSeason) {
+	mock.Results = append(mock.Results, entities.DraftResult{
+		UserGUID:  userGUID,
+		PlayerKey: player.PlayerKey,
+		PlayerID:  player.PlayerID,
+		LeagueKey: mock.LeagueKey,
+		TeamKey:   teamKey,
+		Round:     getRound(mock.CurrentPick, mock.League),
+		Pick:      mock.CurrentPick,
+		Timestamp: time.Now(),
+		GameID:    mock.League.Game.GameID,
+		Player:    []*entities.PlayerSeason{&player},
+	})
+	mock.CurrentPick++
+}
+
+// mockLeague copies what a mock draft needs from the league: settings, roster positions, teams, draft order
+// and traded picks. Keepers and the real draft's progress are left behind.
+func mockLeague(league entities.League) entities.League {
+	mock := entities.League{
+		LeagueKey:     league.LeagueKey,
+		Name:          league.Name,
+		LeagueID:      league.LeagueID,
+		Settings:      league.Settings,
+		Teams:         league.Teams,
+		Game:          league.Game,
+		DraftOrder:    league.DraftOrder,
+		DraftSettings: league.DraftSettings,
+		PickOwners:    league.PickOwners,
+		CurrentPick:   1,
+	}
+	mock.SetState(entities.DraftStateOpen)
+	return mock
+}
+
+// mockRosters builds every team's roster from the mock draft's picks
+func mockRosters(mock entities.MockDraft) map[string]entities.Roster {
+	byTeam := make(map[string][]entities.DraftResult, len(mock.League.Teams))
+	for _, result := range mock.Results {
+		byTeam[result.TeamKey] = append(byTeam[result.TeamKey], result)
+	}
+
+	rosters := make(map[string]entities.Roster, len(mock.League.DraftOrder))
+	for _, teamKey := range mock.League.DraftOrder {
+		rosters[teamKey] = buildTeamRoster(byTeam[teamKey], makeRoster(mock.League))
+	}
+	return rosters
+}
+
+// mockDraftResponse fills in the status and rosters of a mock draft for a client, as of pick through when it is not zero
+func mockDraftResponse(mock entities.MockDraft, through int) *entities.MockDraft {
+	mock.Status = mock.StatusAt(time.Now())
+	if through > 0 && through < mock.CurrentPick {
+		results := make([]entities.DraftResult, 0, through)
+		for _, result := range mock.Results {
+			if result.Pick <= through {
+				results = append(results, result)
+			}
+		}
+		mock.Results = results
+		mock.CurrentPick = through + 1
+	}
+	mock.Rosters = mockRosters(mock)
+	return &mock
+}
+
+// userTeam returns the team in the league the logged in user manages
+func userTeam(ctx context.Context, league entities.League) (entities.Team, bool) {
+	for _, team := range league.Teams {
+		if isUserTeamManager(ctx, league, team.TeamKey) {
+			return team, true
+		}
+	}
+	return entities.Team{}, false
+}
@@ -0,0 +1,174 @@
+package draft
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/thethan/fdr-users/pkg/auth"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	userEntities "github.com/thethan/fdr-users/pkg/users/entities"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// mockPlayers is enough ranked players for every team in testLeague to fill its roster
+func mockPlayers() []entities.PlayerSeason {
+	var players []entities.PlayerSeason
+	positions := []string{"RB", "WR", "QB", "RB", "WR", "TE"}
+	for idx := 1; idx <= 48; idx++ {
+		players = append(players, rankedPlayer(fmt.Sprintf("399.p.%d", idx), idx, positions[idx%len(positions)]))
+	}
+	return players
+}
+
+func Test_playerADP(t *testing.T) {
+	player := rankedPlayer("399.p.1", 10, "RB")
+	assert.Equal(t, 10, playerADP(player), "yahoo's rank stands in for ADP")
+
+	player.Ranks[botADPRank] = 20
+	assert.Equal(t, 20, playerADP(player))
+	assert.Equal(t, 0, playerADP(testPlayer("399.p.2", "RB")))
+}
+
+func Test_botScore(t *testing.T) {
+	player := rankedPlayer("399.p.1", 10, "RB")
+	assert.Equal(t, 10.0, botScore(player, "RB", 4), "rank alone")
+
+	player.Ranks[botADPRank] = 20
+	assert.Equal(t, 15.0, botScore(player, "RB", 4), "rank and ADP are averaged")
+	assert.Equal(t, 23.0, botScore(player, "BN", 4), "a bench player is pushed back two rounds")
+
+	unranked := testPlayer("399.p.2", "RB")
+	assert.True(t, botScore(unranked, "RB", 4) > botScore(player, "BN", 4))
+}
+
+func Test_chooseBotPick(t *testing.T) {
+	league := testLeague()
+	roster := makeRoster(league)
+	qb := rankedPlayer("399.p.qb", 1, "QB")
+	roster.AddResult("QB", entities.DraftResult{Player: []*entities.PlayerSeason{&qb}})
+
+	secondQB := rankedPlayer("399.p.qb2", 2, "QB")
+	rb := rankedPlayer("399.p.rb", 5, "RB")
+	drafted := rankedPlayer("399.p.wr", 3, "WR")
+
+	player, ok := chooseBotPick([]entities.PlayerSeason{qb, secondQB, drafted, rb}, map[string]bool{qb.PlayerKey: true, drafted.PlayerKey: true}, roster, 4)
+	assert.True(t, ok)
+	assert.Equal(t, rb.PlayerKey, player.PlayerKey, "a starter slot beats a better ranked bench player")
+
+	rb.Ranks[botADPRank] = 40
+	player, _ = chooseBotPick([]entities.PlayerSeason{secondQB, rb}, map[string]bool{}, roster, 4)
+	assert.Equal(t, secondQB.PlayerKey, player.PlayerKey, "a late ADP lets the bench player go first")
+
+	_, ok = chooseBotPick([]entities.PlayerSeason{qb}, map[string]bool{qb.PlayerKey: true}, roster, 4)
+	assert.False(t, ok)
+}
+
+func TestService_MockDraft(t *testing.T) {
+	league := testLeague()
+	repo := newFakeDraftRepository(league)
+	repo.players = mockPlayers()
+	broadcaster := newFakeBroadcaster()
+	service := NewService(log.NewNopLogger(), repo, broadcaster)
+	managerContext := context.WithValue(context.Background(), auth.User, &userEntities.User{GUID: "manager-3"})
+
+	mock, err := service.StartMockDraft(managerContext, league.LeagueKey)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "399.l.1.t.3", mock.TeamKey)
+	assert.Equal(t, entities.MockDraftDrafting, mock.Status)
+	assert.Equal(t, 3, mock.CurrentPick, "bots drafted until the user is on the clock")
+	if assert.Len(t, mock.Results, 2) {
+		assert.Equal(t, "399.l.1.t.1", mock.Results[0].TeamKey)
+		assert.Equal(t, "399.p.1", mock.Results[0].PlayerKey, "the best ranked player goes first")
+		assert.Equal(t, "399.l.1.t.2", mock.Results[1].TeamKey)
+	}
+
+	_, err = service.MockDraftPick(managerContext, mock.ID, "399.p.1")
+	assert.IsType(t, &ErrorPlayerAlreadyDrafted{}, err)
+	_, err = service.MockDraftPick(commissionerContext(), mock.ID, "399.p.3")
+	assert.IsType(t, &ErrorMockDraftNotFound{}, err, "another user's mock draft is not theirs to pick in")
+
+	mock, err = service.MockDraftPick(managerContext, mock.ID, "399.p.3")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "399.l.1.t.3", mock.Results[2].TeamKey)
+	assert.Equal(t, 6, mock.CurrentPick, "the snake comes back to the user after team 4 picks twice")
+
+	for mock.Status == entities.MockDraftDrafting {
+		player, ok := chooseBotPick(repo.players, mockDrafted(mock), mock.Rosters[mock.TeamKey], 4)
+		if !assert.True(t, ok) {
+			return
+		}
+		mock, err = service.MockDraftPick(managerContext, mock.ID, player.PlayerKey)
+		if !assert.Nil(t, err) {
+			return
+		}
+	}
+	assert.Equal(t, entities.MockDraftCompleted, mock.Status)
+	assert.Len(t, mock.Results, totalPicks(league))
+	for teamKey, roster := range mock.Rosters {
+		for position, slot := range roster.Roster {
+			assert.Equal(t, slot.Count, len(slot.DraftResults), "%s %s is full", teamKey, position)
+		}
+	}
+
+	_, err = service.MockDraftPick(managerContext, mock.ID, "399.p.48")
+	if assert.IsType(t, &ErrorMockDraftClosed{}, err) {
+		assert.Equal(t, http.StatusConflict, err.(*ErrorMockDraftClosed).StatusCode())
+	}
+
+	replay, err := service.GetMockDraft(managerContext, mock.ID, 5)
+	assert.Nil(t, err)
+	assert.Len(t, replay.Results, 5)
+	assert.Equal(t, 6, replay.CurrentPick)
+	assert.Len(t, replay.Rosters["399.l.1.t.4"].Roster["BN"].DraftResults, 0)
+
+	mocks, err := service.ListMockDrafts(managerContext, league.LeagueKey)
+	assert.Nil(t, err)
+	assert.Len(t, mocks, 1)
+
+	assert.Empty(t, repo.results[league.LeagueKey], "the real draft has no results")
+	real, _ := repo.GetLeague(context.Background(), league.LeagueKey)
+	assert.Equal(t, entities.DraftStateScheduled, real.State())
+	assert.Empty(t, broadcaster.types(), "nothing is broadcast to the real draft room")
+}
+
+func TestService_MockDraftPick_Expired(t *testing.T) {
+	league := testLeague()
+	repo := newFakeDraftRepository(league)
+	repo.players = mockPlayers()
+	service := NewService(log.NewNopLogger(), repo, newFakeBroadcaster())
+	managerContext := context.WithValue(context.Background(), auth.User, &userEntities.User{GUID: "manager-2"})
+
+	mock, err := service.StartMockDraft(managerContext, league.LeagueKey)
+	if !assert.Nil(t, err) {
+		return
+	}
+	expired := repo.mocks[mock.ID]
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+	repo.mocks[mock.ID] = expired
+
+	_, err = service.MockDraftPick(managerContext, mock.ID, "399.p.10")
+	if assert.IsType(t, &ErrorMockDraftClosed{}, err) {
+		assert.Equal(t, http.StatusGone, err.(*ErrorMockDraftClosed).StatusCode())
+	}
+	replay, err := service.GetMockDraft(managerContext, mock.ID, 0)
+	assert.Nil(t, err, "an expired mock draft can still be replayed")
+	assert.Equal(t, entities.MockDraftExpired, replay.Status)
+
+	_, err = service.StartMockDraft(context.WithValue(context.Background(), auth.User, &userEntities.User{GUID: "stranger"}), league.LeagueKey)
+	assert.IsType(t, &ErrorNotInLeague{}, err)
+}
+
+func mockDrafted(mock *entities.MockDraft) map[string]bool {
+	drafted := make(map[string]bool, len(mock.Results))
+	for _, result := range mock.Results {
+		drafted[result.PlayerKey] = true
+	}
+	return drafted
+}
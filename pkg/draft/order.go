@@ -0,0 +1,129 @@
+package draft
+
+import (
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+)
+
+// numberOfRounds is one round per roster slot
+func numberOfRounds(league entities.League) int {
+	if league.Settings == nil {
+		return 0
+	}
+	count := 0
+	for _, pos := range league.Settings.RosterPositions {
+		count += pos.Count
+	}
+	return count
+}
+
+// totalPicks is the number of picks in the draft, one per roster slot for every team
+func totalPicks(league entities.League) int {
+	return numberOfRounds(league) * len(league.DraftOrder)
+}
+
+func getRound(pick int, league entities.League) int {
+	if len(league.DraftOrder) == 0 || pick < 1 {
+		return 0
+	}
+	return (pick-1)/len(league.DraftOrder) + 1
+}
+
+// isRoundReversed reports whether a round runs from the last slot of DraftOrder to the first
+func isRoundReversed(orderType entities.DraftOrderType, round int) bool {
+	switch orderType {
+	case entities.DraftOrderLinear:
+		return false
+	case entities.DraftOrderThirdRoundReversal:
+		// rounds two and three both run backwards, then the draft snakes from round four on
+		return round == 2 || (round > 2 && round%2 == 1)
+	default:
+		return round%2 == 0
+	}
+}
+
+// draftSlot is the 1 based position in DraftOrder that owns pick
+func draftSlot(league entities.League, pick int) int {
+	teams := len(league.DraftOrder)
+	slot := (pick-1)%teams + 1
+	if isRoundReversed(league.DraftSettings.OrderType, getRound(pick, league)) {
+		slot = teams - slot + 1
+	}
+	return slot
+}
+
+// teamKeyForPick returns the team that owns the overall pick, honoring traded picks
+func teamKeyForPick(league entities.League, pick int) (string, bool) {
+	if pick < 1 || len(league.DraftOrder) == 0 {
+		return "", false
+	}
+	return slotOwner(league, getRound(pick, league), draftSlot(league, pick))
+}
+
+// slotOwner returns the team that owns the pick in slot of round: whoever traded for it, otherwise the team in that slot
+func slotOwner(league entities.League, round, slot int) (string, bool) {
+	if slot < 1 || slot > len(league.DraftOrder) {
+		return "", false
+	}
+	if teamKey, ok := league.PickOwner(round, slot); ok {
+		return teamKey, true
+	}
+	return league.DraftOrder[slot-1], true
+}
+
+// draftPicks lays out every pick of the draft in order
+func draftPicks(league entities.League) []entities.DraftPick {
+	picks := make([]entities.DraftPick, totalPicks(league))
+	for idx := range picks {
+		pick := idx + 1
+		teamKey, _ := teamKeyForPick(league, pick)
+		picks[idx] = entities.DraftPick{
+			Pick:    pick,
+			Round:   getRound(pick, league),
+			Slot:    draftSlot(league, pick),
+			TeamKey: teamKey,
+		}
+		if original := league.DraftOrder[picks[idx].Slot-1]; original != teamKey {
+			picks[idx].OriginalTeamKey = original
+		}
+	}
+	return picks
+}
+
+// validatePick checks a requested pick against the draft order and what has already been drafted
+func validatePick(league entities.League, results []entities.DraftResult, teamKey, playerKey string, pick int) error {
+	if league.IsAuction() {
+		return &ErrorAuctionDraft{}
+	}
+	if !league.State().CanPick() {
+		return &ErrorDraftState{state: league.State(), action: "make picks"}
+	}
+	err := validatePickAvailable(league, results, playerKey, pick)
+	if err != nil {
+		return err
+	}
+	if league.CurrentPick != 0 && pick != league.CurrentPick {
+		return &ErrorPickNotOnClock{pick: pick, currentPick: league.CurrentPick}
+	}
+
+	owner, _ := teamKeyForPick(league, pick)
+	if owner != teamKey {
+		return &ErrorPickOutOfTurn{pick: pick, teamKey: teamKey, owner: owner}
+	}
+	return nil
+}
+
+// validatePickAvailable checks that pick is part of the draft, has not been made and that the player is still undrafted
+func validatePickAvailable(league entities.League, results []entities.DraftResult, playerKey string, pick int) error {
+	if pick < 1 || pick > totalPicks(league) {
+		return &ErrorInvalidPick{pick: pick}
+	}
+	for _, result := range results {
+		if result.Pick == pick {
+			return &ErrorPickAlreadyMade{pick: pick}
+		}
+		if result.PlayerKey == playerKey {
+			return &ErrorPlayerAlreadyDrafted{playerKey: playerKey}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package draft
+
+import (
+	"context"
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/thethan/fdr-users/pkg/auth"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	userEntities "github.com/thethan/fdr-users/pkg/users/entities"
+	"testing"
+)
+
+func Test_teamKeyForPick(t *testing.T) {
+	// four teams, picks 1 through 16 are the first four rounds
+	tests := []struct {
+		name      string
+		orderType entities.DraftOrderType
+		want      []string
+	}{
+		{
+			name:      "linear",
+			orderType: entities.DraftOrderLinear,
+			want: []string{
+				"t.1", "t.2", "t.3", "t.4",
+				"t.1", "t.2", "t.3", "t.4",
+				"t.1", "t.2", "t.3", "t.4",
+				"t.1", "t.2", "t.3", "t.4",
+			},
+		},
+		{
+			name:      "snake",
+			orderType: entities.DraftOrderSnake,
+			want: []string{
+				"t.1", "t.2", "t.3", "t.4",
+				"t.4", "t.3", "t.2", "t.1",
+				"t.1", "t.2", "t.3", "t.4",
+				"t.4", "t.3", "t.2", "t.1",
+			},
+		},
+		{
+			name: "defaults to snake",
+			want: []string{
+				"t.1", "t.2", "t.3", "t.4",
+				"t.4", "t.3", "t.2", "t.1",
+				"t.1", "t.2", "t.3", "t.4",
+				"t.4", "t.3", "t.2", "t.1",
+			},
+		},
+		{
+			name:      "third round reversal",
+			orderType: entities.DraftOrderThirdRoundReversal,
+			want: []string{
+				"t.1", "t.2", "t.3", "t.4",
+				"t.4", "t.3", "t.2", "t.1",
+				"t.4", "t.3", "t.2", "t.1",
+				"t.1", "t.2", "t.3", "t.4",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			league := entities.League{
+				DraftOrder:    []string{"t.1", "t.2", "t.3", "t.4"},
+				DraftSettings: entities.DraftSettings{OrderType: tt.orderType},
+			}
+			got := make([]string, len(tt.want))
+			for idx := range tt.want {
+				teamKey, ok := teamKeyForPick(league, idx+1)
+				assert.True(t, ok)
+				got[idx] = teamKey
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_draftPicks(t *testing.T) {
+	league := testLeague()
+	picks := draftPicks(league)
+
+	assert.Len(t, picks, 28)
+	assert.Equal(t, entities.DraftPick{Pick: 1, Round: 1, Slot: 1, TeamKey: "399.l.1.t.1"}, picks[0])
+	assert.Equal(t, entities.DraftPick{Pick: 5, Round: 2, Slot: 4, TeamKey: "399.l.1.t.4"}, picks[4])
+	assert.Equal(t, entities.DraftPick{Pick: 28, Round: 7, Slot: 4, TeamKey: "399.l.1.t.4"}, picks[27])
+}
+
+func Test_validatePick(t *testing.T) {
+	league := testLeague()
+	league.SetState(entities.DraftStateOpen)
+	league.CurrentPick = 2
+	results := []entities.DraftResult{{Pick: 1, PlayerKey: "399.p.1", TeamKey: "399.l.1.t.1"}}
+
+	tests := []struct {
+		name      string
+		teamKey   string
+		playerKey string
+		pick      int
+		wantErr   error
+		wantCode  int
+	}{
+		{name: "team on the clock", teamKey: "399.l.1.t.2", playerKey: "399.p.2", pick: 2},
+		{name: "pick before the draft", teamKey: "399.l.1.t.2", playerKey: "399.p.2", pick: 0, wantErr: &ErrorInvalidPick{}, wantCode: 422},
+		{name: "pick after the draft", teamKey: "399.l.1.t.2", playerKey: "399.p.2", pick: 29, wantErr: &ErrorInvalidPick{}, wantCode: 422},
+		{name: "pick already made", teamKey: "399.l.1.t.1", playerKey: "399.p.2", pick: 1, wantErr: &ErrorPickAlreadyMade{}, wantCode: 409},
+		{name: "player already drafted", teamKey: "399.l.1.t.2", playerKey: "399.p.1", pick: 2, wantErr: &ErrorPlayerAlreadyDrafted{}, wantCode: 409},
+		{name: "pick not on the clock", teamKey: "399.l.1.t.3", playerKey: "399.p.2", pick: 3, wantErr: &ErrorPickNotOnClock{}, wantCode: 409},
+		{name: "pick out of turn", teamKey: "399.l.1.t.3", playerKey: "399.p.2", pick: 2, wantErr: &ErrorPickOutOfTurn{}, wantCode: 422},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePick(league, results, tt.teamKey, tt.playerKey, tt.pick)
+			if tt.wantErr == nil {
+				assert.Nil(t, err)
+				return
+			}
+			assert.IsType(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantCode, err.(interface{ StatusCode() int }).StatusCode())
+		})
+	}
+
+	for _, state := range []entities.DraftState{entities.DraftStateScheduled, entities.DraftStatePaused, entities.DraftStateCompleted} {
+		league.SetState(state)
+		err := validatePick(league, results, "399.l.1.t.2", "399.p.2", 2)
+		assert.IsType(t, &ErrorDraftState{}, err, string(state))
+	}
+}
+
+func TestService_SaveDraftRequest_ValidatesPick(t *testing.T) {
+	league := testLeague()
+	league.DraftStarted = true
+	league.CurrentPick = 1
+	repo := newFakeDraftRepository(league)
+	repo.players = []entities.PlayerSeason{testPlayer("399.p.1", "QB"), testPlayer("399.p.2", "RB")}
+	service := NewService(log.NewNopLogger(), repo, newFakeBroadcaster())
+	managerContext := context.WithValue(context.Background(), auth.User, &userEntities.User{GUID: "manager-2"})
+
+	_, err := service.SaveDraftRequest(managerContext, entities.User{Guid: "manager-2"}, league, league.Teams[0], testPlayer("399.p.1"), 1)
+	assert.IsType(t, &ErrorNotTeamManager{}, err)
+
+	_, err = service.SaveDraftRequest(managerContext, entities.User{Guid: "manager-2"}, league, league.Teams[1], testPlayer("399.p.1"), 1)
+	assert.IsType(t, &ErrorPickOutOfTurn{}, err)
+
+	_, err = service.SaveDraftRequest(commissionerContext(), entities.User{Guid: "commish"}, league, league.Teams[0], testPlayer("399.p.9"), 1)
+	assert.IsType(t, &ErrorUnknownPlayer{}, err)
+
+	result, err := service.SaveDraftRequest(commissionerContext(), entities.User{Guid: "commish"}, league, league.Teams[0], testPlayer("399.p.1"), 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"QB"}, result.Player[0].EligiblePositions, "the stored player should be saved, not the client copy")
+
+	_, err = service.SaveDraftRequest(managerContext, entities.User{Guid: "manager-2"}, league, league.Teams[1], testPlayer("399.p.1"), 2)
+	assert.IsType(t, &ErrorPlayerAlreadyDrafted{}, err)
+
+	_, err = service.SaveDraftRequest(managerContext, entities.User{Guid: "manager-2"}, league, league.Teams[1], testPlayer("399.p.2"), 2)
+	assert.Nil(t, err)
+}
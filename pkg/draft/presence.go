@@ -0,0 +1,241 @@
+package draft
+
+import (
+	"context"
+	"github.com/go-kit/kit/log/level"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"go.elastic.co/apm"
+	"sync"
+	"time"
+)
+
+// presenceTimeout is how long a manager stays in the draft room after their client's last heartbeat.
+// Clients heartbeat a few times within it so one slow request does not drop them.
+const presenceTimeout = 30 * time.Second
+
+// presenceTracker keeps a timer per manager connected to a league's draft room, reset by every heartbeat.
+// Like the pick clock it lives in memory, so each instance knows about the clients it serves.
+type presenceTracker struct {
+	mu      *sync.Mutex
+	timeout time.Duration
+	leagues map[string]map[string]*presenceTimer
+}
+
+type presenceTimer struct {
+	teamKey  string
+	lastSeen time.Time
+	timer    *time.Timer
+}
+
+func newPresenceTracker(timeout time.Duration) *presenceTracker {
+	return &presenceTracker{
+		mu:      &sync.Mutex{},
+		timeout: timeout,
+		leagues: make(map[string]map[string]*presenceTimer),
+	}
+}
+
+// beat marks the manager present until the timeout runs out without another beat, then calls onTimeout.
+// It returns true when the manager was not present before.
+func (p *presenceTracker) beat(leagueKey, userGUID, teamKey string, onTimeout func(leagueKey, userGUID string)) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	managers, ok := p.leagues[leagueKey]
+	if !ok {
+		managers = make(map[string]*presenceTimer)
+		p.leagues[leagueKey] = managers
+	}
+	running, joined := managers[userGUID]
+	if joined {
+		running.timer.Stop()
+	}
+
+	present := &presenceTimer{teamKey: teamKey, lastSeen: time.Now()}
+	present.timer = time.AfterFunc(p.timeout, func() {
+		if p.expire(leagueKey, userGUID, present) {
+			onTimeout(leagueKey, userGUID)
+		}
+	})
+	managers[userGUID] = present
+	return !joined
+}
+
+// leave takes the manager out of the draft room. It returns false when they were not in it.
+func (p *presenceTracker) leave(leagueKey, userGUID string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	running, ok := p.leagues[leagueKey][userGUID]
+	if !ok {
+		return false
+	}
+	running.timer.Stop()
+	p.remove(leagueKey, userGUID)
+	return true
+}
+
+// expire takes the manager out if present is still their latest heartbeat.
+// It returns false when a heartbeat or a leave got in before the timer fired.
+func (p *presenceTracker) expire(leagueKey, userGUID string, present *presenceTimer) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.leagues[leagueKey][userGUID] != present {
+		return false
+	}
+	p.remove(leagueKey, userGUID)
+	return true
+}
+
+func (p *presenceTracker) remove(leagueKey, userGUID string) {
+	delete(p.leagues[leagueKey], userGUID)
+	if len(p.leagues[leagueKey]) == 0 {
+		delete(p.leagues, leagueKey)
+	}
+}
+
+// lastSeen returns when each manager present in the league last heartbeat
+func (p *presenceTracker) lastSeen(leagueKey string) map[string]time.Time {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	seen := make(map[string]time.Time, len(p.leagues[leagueKey]))
+	for userGUID, present := range p.leagues[leagueKey] {
+		seen[userGUID] = present.lastSeen
+	}
+	return seen
+}
+
+// teamPresent reports whether any manager of the team is in the draft room
+func (p *presenceTracker) teamPresent(leagueKey, teamKey string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, present := range p.leagues[leagueKey] {
+		if present.teamKey == teamKey {
+			return true
+		}
+	}
+	return false
+}
+
+// Heartbeat keeps the logged in manager in the league's draft room. The first heartbeat tells the room they joined.
+func (service *Service) Heartbeat(ctx context.Context, leagueKey string) (*entities.DraftPresence, error) {
+	span, ctx := apm.StartSpan(ctx, "Heartbeat", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	defer span.End()
+
+	league, team, manager, err := service.presenceLeague(ctx, leagueKey)
+	if err != nil {
+		return nil, err
+	}
+	if service.presence.beat(leagueKey, manager.Guid, team.TeamKey, service.presenceTimedOut) {
+		service.broadcastPresence(ctx, league, entities.BroadCastTypeManagerJoined, manager, team)
+	}
+	presence := service.draftPresence(league)
+	return &presence, nil
+}
+
+// LeaveDraftRoom takes the logged in manager out of the league's draft room without waiting for their heartbeat
+// to time out
+func (service *Service) LeaveDraftRoom(ctx context.Context, leagueKey string) (*entities.DraftPresence, error) {
+	span, ctx := apm.StartSpan(ctx, "LeaveDraftRoom", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	defer span.End()
+
+	league, team, manager, err := service.presenceLeague(ctx, leagueKey)
+	if err != nil {
+		return nil, err
+	}
+	if service.presence.leave(leagueKey, manager.Guid) {
+		service.broadcastPresence(ctx, league, entities.BroadCastTypeManagerLeft, manager, team)
+	}
+	presence := service.draftPresence(league)
+	return &presence, nil
+}
+
+// DraftPresence returns which teams have a manager in the league's draft room
+func (service *Service) DraftPresence(ctx context.Context, leagueKey string) (*entities.DraftPresence, error) {
+	span, ctx := apm.StartSpan(ctx, "DraftPresence", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	defer span.End()
+
+	league, err := service.draftRepo.GetLeague(ctx, leagueKey)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not get league", "error", err, "league_key", leagueKey)
+		return nil, err
+	}
+	presence := service.draftPresence(league)
+	return &presence, nil
+}
+
+// isTeamPresent reports whether a manager of the team is in the draft room, for the pick clock and autopick to
+// tell an absent manager from a slow one
+func (service *Service) isTeamPresent(leagueKey, teamKey string) bool {
+	return service.presence.teamPresent(leagueKey, teamKey)
+}
+
+func (service *Service) presenceLeague(ctx context.Context, leagueKey string) (entities.League, entities.Team, entities.User, error) {
+	league, err := service.draftRepo.GetLeague(ctx, leagueKey)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not get league", "error", err, "league_key", leagueKey)
+		return league, entities.Team{}, entities.User{}, err
+	}
+	user, ok := userFromContext(ctx)
+	if !ok {
+		return league, entities.Team{}, entities.User{}, &ErrorNotInLeague{leagueKey: leagueKey}
+	}
+	team, manager, ok := leagueManager(league, user.GUID)
+	if !ok {
+		return league, entities.Team{}, entities.User{}, &ErrorNotInLeague{leagueKey: leagueKey}
+	}
+	if manager.Nickname == "" {
+		manager.Nickname = user.NickName
+	}
+	return league, team, manager, nil
+}
+
+// presenceTimedOut runs on the timer's goroutine when a manager's heartbeats stop
+func (service *Service) presenceTimedOut(leagueKey, userGUID string) {
+	tx := apm.DefaultTracer.StartTransaction("PresenceTimedOut", "presence")
+	defer tx.End()
+	ctx := apm.ContextWithTransaction(context.Background(), tx)
+
+	league, err := service.draftRepo.GetLeague(ctx, leagueKey)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not get league for timed out manager", "error", err, "league_key", leagueKey, "user_guid", userGUID)
+		return
+	}
+	team, manager, _ := leagueManager(league, userGUID)
+	service.broadcastPresence(ctx, league, entities.BroadCastTypeManagerLeft, manager, team)
+}
+
+func (service *Service) broadcastPresence(ctx context.Context, league entities.League, broadcastType entities.BroadcastType, manager entities.User, team entities.Team) {
+	err := service.broadCastRepo.BroadCastPresence(ctx, league, broadcastType, manager, team)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not broadcast presence", "error", err, "league_key", league.LeagueKey, "user_guid", manager.Guid, "type", broadcastType)
+	}
+}
+
+func (service *Service) draftPresence(league entities.League) entities.DraftPresence {
+	seen := service.presence.lastSeen(league.LeagueKey)
+	presence := entities.DraftPresence{LeagueKey: league.LeagueKey, Teams: make([]entities.TeamPresence, len(league.Teams))}
+	for idx, team := range league.Teams {
+		teamPresence := entities.TeamPresence{TeamKey: team.TeamKey, Name: team.Name, Managers: make([]entities.ManagerPresence, len(team.Manager))}
+		for managerIdx, manager := range team.Manager {
+			managerPresence := entities.ManagerPresence{UserGUID: manager.Guid, Nickname: manager.Nickname}
+			if lastSeen, ok := seen[manager.Guid]; ok {
+				managerPresence.Online = true
+				managerPresence.LastSeen = &lastSeen
+				teamPresence.Online = true
+			}
+			teamPresence.Managers[managerIdx] = managerPresence
+		}
+		if teamPresence.Online {
+			presence.OnlineTeams++
+		}
+		presence.Teams[idx] = teamPresence
+	}
+	return presence
+}
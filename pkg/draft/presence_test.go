@@ -0,0 +1,98 @@
+package draft
+
+import (
+	"context"
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"testing"
+	"time"
+)
+
+func presenceService(timeout time.Duration) (*Service, *fakeBroadcaster) {
+	broadcaster := newFakeBroadcaster()
+	service := NewService(log.NewNopLogger(), newFakeDraftRepository(testLeague()), broadcaster)
+	service.presence = newPresenceTracker(timeout)
+	return &service, broadcaster
+}
+
+func TestService_Heartbeat(t *testing.T) {
+	service, broadcaster := presenceService(time.Minute)
+
+	presence, err := service.Heartbeat(chatContext("manager-2"), "399.l.1")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, 1, presence.OnlineTeams)
+	assert.True(t, presence.Teams[1].Online)
+	assert.True(t, presence.Teams[1].Managers[0].Online)
+	assert.NotNil(t, presence.Teams[1].Managers[0].LastSeen)
+	assert.False(t, presence.Teams[0].Online)
+
+	_, err = service.Heartbeat(chatContext("manager-2"), "399.l.1")
+	assert.Nil(t, err)
+	if assert.Len(t, broadcaster.broadcasts, 1, "only the first heartbeat is a join") {
+		assert.Equal(t, entities.BroadCastTypeManagerJoined, broadcaster.broadcasts[0].Type)
+		assert.Equal(t, "399.l.1.t.2", broadcaster.broadcasts[0].Team.TeamKey)
+		assert.Equal(t, "manager-2 nickname", broadcaster.broadcasts[0].User.Nickname)
+	}
+	assert.True(t, service.isTeamPresent("399.l.1", "399.l.1.t.2"))
+	assert.False(t, service.isTeamPresent("399.l.1", "399.l.1.t.3"))
+
+	_, err = service.Heartbeat(chatContext("stranger"), "399.l.1")
+	assert.IsType(t, &ErrorNotInLeague{}, err)
+}
+
+func TestService_LeaveDraftRoom(t *testing.T) {
+	service, broadcaster := presenceService(time.Minute)
+	_, _ = service.Heartbeat(chatContext("manager-3"), "399.l.1")
+
+	presence, err := service.LeaveDraftRoom(chatContext("manager-3"), "399.l.1")
+	if assert.Nil(t, err) {
+		assert.Equal(t, 0, presence.OnlineTeams)
+	}
+	assert.Equal(t, entities.BroadCastTypeManagerLeft, broadcaster.last().Type)
+
+	_, err = service.LeaveDraftRoom(chatContext("manager-3"), "399.l.1")
+	assert.Nil(t, err)
+	assert.Len(t, broadcaster.broadcasts, 2, "leaving twice is one leave")
+}
+
+func TestService_Heartbeat_TimesOut(t *testing.T) {
+	service, broadcaster := presenceService(20 * time.Millisecond)
+	_, _ = service.Heartbeat(chatContext("manager-4"), "399.l.1")
+
+	assert.Eventually(t, func() bool {
+		return !service.isTeamPresent("399.l.1", "399.l.1.t.4")
+	}, time.Second, 5*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return broadcaster.last().Type == entities.BroadCastTypeManagerLeft
+	}, time.Second, 5*time.Millisecond)
+
+	presence, err := service.DraftPresence(context.Background(), "399.l.1")
+	if assert.Nil(t, err) {
+		assert.Equal(t, 0, presence.OnlineTeams)
+		assert.Len(t, presence.Teams, 4)
+	}
+}
+
+func Test_presenceTracker_HeartbeatKeepsManagerIn(t *testing.T) {
+	tracker := newPresenceTracker(30 * time.Millisecond)
+	timedOut := make(chan string, 1)
+	onTimeout := func(leagueKey, userGUID string) { timedOut <- userGUID }
+
+	assert.True(t, tracker.beat("399.l.1", "manager-2", "399.l.1.t.2", onTimeout))
+	for idx := 0; idx < 4; idx++ {
+		time.Sleep(10 * time.Millisecond)
+		assert.False(t, tracker.beat("399.l.1", "manager-2", "399.l.1.t.2", onTimeout))
+	}
+	assert.True(t, tracker.teamPresent("399.l.1", "399.l.1.t.2"), "heartbeats inside the timeout keep the manager in")
+
+	select {
+	case userGUID := <-timedOut:
+		assert.Equal(t, "manager-2", userGUID)
+	case <-time.After(time.Second):
+		t.Fatal("manager did not time out")
+	}
+	assert.Empty(t, tracker.lastSeen("399.l.1"))
+}
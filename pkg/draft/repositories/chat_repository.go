@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"github.com/go-kit/kit/log/level"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"go.elastic.co/apm"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+const chatMessagesCollection string = "draft_chat"
+
+func (m MongoRepository) SaveChatMessage(ctx context.Context, message entities.ChatMessage) error {
+	span, ctx := apm.StartSpan(ctx, "SaveChatMessage", "repository.Mongo")
+	defer span.End()
+
+	collection := m.client.Database(database).Collection(chatMessagesCollection)
+	_, err := collection.InsertOne(ctx, message)
+	if err != nil {
+		level.Error(m.logger).Log("error", err, "message", "could not save chat message", "league_key", message.LeagueKey)
+	}
+	return err
+}
+
+func (m MongoRepository) GetChatMessage(ctx context.Context, leagueKey, messageID string) (entities.ChatMessage, error) {
+	span, ctx := apm.StartSpan(ctx, "GetChatMessage", "repository.Mongo")
+	defer span.End()
+
+	collection := m.client.Database(database).Collection(chatMessagesCollection)
+	var message entities.ChatMessage
+	err := collection.FindOne(ctx, bson.M{"_id": messageID, "league_key": leagueKey}).Decode(&message)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return message, entities.ErrChatMessageNotFound
+	}
+	return message, err
+}
+
+// GetChatMessages returns up to limit of a league's messages sent before the message with id before, newest first.
+// An empty before starts from the newest message.
+func (m MongoRepository) GetChatMessages(ctx context.Context, leagueKey, before string, limit int) ([]entities.ChatMessage, error) {
+	span, ctx := apm.StartSpan(ctx, "GetChatMessages", "repository.Mongo")
+	defer span.End()
+
+	filter := bson.M{"league_key": leagueKey}
+	if before != "" {
+		filter["_id"] = bson.M{"$lt": before}
+	}
+	collection := m.client.Database(database).Collection(chatMessagesCollection)
+	findOptions := options.Find().SetSort(bson.D{{Key: "_id", Value: -1}}).SetLimit(int64(limit))
+	cursor, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		level.Error(m.logger).Log("error", err, "message", "could not get chat messages", "league_key", leagueKey)
+		return nil, err
+	}
+
+	messages := make([]entities.ChatMessage, 0, limit)
+	err = cursor.All(ctx, &messages)
+	return messages, err
+}
+
+// DeleteChatMessage removes the text of a message and records who deleted it
+func (m MongoRepository) DeleteChatMessage(ctx context.Context, leagueKey, messageID, deletedBy string, deletedAt time.Time) (entities.ChatMessage, error) {
+	span, ctx := apm.StartSpan(ctx, "DeleteChatMessage", "repository.Mongo")
+	defer span.End()
+
+	update := bson.M{"$set": bson.M{"text": "", "deleted_at": deletedAt, "deleted_by": deletedBy}}
+	return m.updateChatMessage(ctx, leagueKey, messageID, update)
+}
+
+// SaveChatReaction adds a user's reaction to a message, or takes it off when add is false
+func (m MongoRepository) SaveChatReaction(ctx context.Context, leagueKey, messageID string, reaction entities.ChatReaction, add bool) (entities.ChatMessage, error) {
+	span, ctx := apm.StartSpan(ctx, "SaveChatReaction", "repository.Mongo")
+	defer span.End()
+
+	update := bson.M{"$pull": bson.M{"reactions": reaction}}
+	if add {
+		update = bson.M{"$addToSet": bson.M{"reactions": reaction}}
+	}
+	return m.updateChatMessage(ctx, leagueKey, messageID, update)
+}
+
+func (m MongoRepository) updateChatMessage(ctx context.Context, leagueKey, messageID string, update bson.M) (entities.ChatMessage, error) {
+	collection := m.client.Database(database).Collection(chatMessagesCollection)
+	var message entities.ChatMessage
+	updateOptions := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := collection.FindOneAndUpdate(ctx, bson.M{"_id": messageID, "league_key": leagueKey}, update, updateOptions).Decode(&message)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return message, entities.ErrChatMessageNotFound
+	}
+	if err != nil {
+		level.Error(m.logger).Log("error", err, "message", "could not update chat message", "league_key", leagueKey, "chat_message_id", messageID)
+	}
+	return message, err
+}
+
+// SaveChatMutes replaces the league's chat mutes
+func (m MongoRepository) SaveChatMutes(ctx context.Context, leagueKey string, mutes []entities.ChatMute) error {
+	span, ctx := apm.StartSpan(ctx, "SaveChatMutes", "repository.Mongo")
+	defer span.End()
+
+	collection := m.client.Database(database).Collection(leaguesCollection)
+	_, err := collection.UpdateOne(ctx, bson.M{"league_key": leagueKey}, bson.M{"$set": bson.M{"chat_mutes": mutes}})
+	if err != nil {
+		level.Error(m.logger).Log("error", err, "message", "could not save chat mutes", "league_key", leagueKey)
+	}
+	return err
+}
+
+// EnsureChatIndexes lets a league's chat history be paged newest first
+func (m MongoRepository) EnsureChatIndexes(ctx context.Context) error {
+	span, ctx := apm.StartSpan(ctx, "EnsureChatIndexes", "repository.Mongo")
+	defer span.End()
+
+	collection := m.client.Database(database).Collection(chatMessagesCollection)
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "league_key", Value: 1}, {Key: "_id", Value: -1}},
+		Options: options.Index().SetName("league_key_id"),
+	})
+	if err != nil {
+		level.Error(m.logger).Log("message", "could not create chat indexes", "error", err)
+	}
+	return err
+}
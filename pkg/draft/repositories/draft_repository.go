@@ -203,6 +203,39 @@ func (m MongoRepository) GetAvailablePlayersForDraft(ctx context.Context, gameID
 	return players, nil
 }
 
+// GetAvailablePlayersByRank returns the league's undrafted players ordered by their yahoo rank. Offset is a page number.
+func (m MongoRepository) GetAvailablePlayersByRank(ctx context.Context, leagueKey string, limit, offset int) ([]entities.PlayerSeason, error) {
+	span, ctx := apm.StartSpan(ctx, "GetAvailablePlayersByRank", "repository.Mongo")
+	defer span.End()
+
+	collection := m.client.Database(database).Collection(getPlayerLeagueCollection(leagueKey))
+
+	pipeline := mongo.Pipeline{
+		bson.D{{"$match", bson.M{"league_key": leagueKey, "draft_results": bson.M{"$exists": false}, "player.ranks.yahoo": bson.M{"$gt": 0}}}},
+		bson.D{{"$sort", bson.D{{"player.ranks.yahoo", 1}}}},
+		bson.D{{"$skip", offset * limit}},
+		bson.D{{"$limit", limit}},
+	}
+
+	cursor, err := collection.Aggregate(ctx, pipeline, &options.AggregateOptions{})
+	if err != nil {
+		level.Error(m.logger).Log("message", "could not get ranked players", "error", err, "league_key", leagueKey)
+		return nil, err
+	}
+
+	leaguePlayers := make([]entities.LeaguePlayer, 0, limit)
+	err = cursor.All(ctx, &leaguePlayers)
+	if err != nil {
+		return nil, err
+	}
+
+	players := make([]entities.PlayerSeason, len(leaguePlayers))
+	for idx := range leaguePlayers {
+		players[idx] = leaguePlayers[idx].Player
+	}
+	return players, nil
+}
+
 func (m MongoRepository) GetUserPlayerPreference(ctx context.Context, userGUID, leagueKey string) (entities.UserPlayerPreference, error) {
 	span, ctx := apm.StartSpan(ctx, "GetUserPlayerPreference", "repository.Mongo")
 	defer span.End()
@@ -298,7 +331,9 @@ func newPlayerSeasonSlice() []entities.DraftResult {
 	return make([]entities.DraftResult, 0)
 }
 
-// {"teams.manager":{ $elemMatch: {"guid":"DPPQCXCRV75Z2LKJW5YRC7RAYM"}}}
+// SaveDraftResultFromUser persists a pick. When pick is the league's current pick it is claimed first by moving
+// current_pick on only if it still points at pick, and the unique indexes on draft_results stop the same pick
+// or player being saved twice. The loser of a race gets entities.ErrPickConflict.
 func (m MongoRepository) SaveDraftResultFromUser(ctx context.Context, league entities.League, user entities.User, team entities.Team, player entities.PlayerSeason, pick, round int) (*entities.DraftResult, error) {
 	span, ctx := apm.StartSpan(ctx, "SaveDraftResult", "repository.Mongo")
 	defer span.End()
@@ -315,10 +350,71 @@ func (m MongoRepository) SaveDraftResultFromUser(ctx context.Context, league ent
 		GameID:    league.Game.GameID,
 		Player:    []*entities.PlayerSeason{&player},
 	}
+	return m.saveDraftResult(ctx, league, draftResult)
+}
+
+// SaveAuctionResult persists a player sold in an auction draft with the winning bid as its cost.
+// pick counts sales and is claimed the same way as a turn based pick.
+func (m MongoRepository) SaveAuctionResult(ctx context.Context, league entities.League, user entities.User, team entities.Team, player entities.PlayerSeason, pick, cost int) (*entities.DraftResult, error) {
+	span, ctx := apm.StartSpan(ctx, "SaveAuctionResult", "repository.Mongo")
+	defer span.End()
+
+	draftResult := entities.DraftResult{
+		UserGUID:  user.Guid,
+		PlayerKey: player.PlayerKey,
+		PlayerID:  player.PlayerID,
+		LeagueKey: league.LeagueKey,
+		TeamKey:   team.TeamKey,
+		Pick:      pick,
+		Cost:      cost,
+		Timestamp: time.Now(),
+		GameID:    league.Game.GameID,
+		Player:    []*entities.PlayerSeason{&player},
+	}
+	return m.saveDraftResult(ctx, league, draftResult)
+}
+
+// SaveKeeperResult places a kept player on the draft board before the draft opens. The pick is not
+// claimed through current_pick since nobody is on the clock yet.
+func (m MongoRepository) SaveKeeperResult(ctx context.Context, league entities.League, user entities.User, keeper entities.Keeper, player entities.PlayerSeason) (*entities.DraftResult, error) {
+	span, ctx := apm.StartSpan(ctx, "SaveKeeperResult", "repository.Mongo")
+	defer span.End()
+
+	draftResult := entities.DraftResult{
+		UserGUID:  user.Guid,
+		PlayerKey: player.PlayerKey,
+		PlayerID:  player.PlayerID,
+		LeagueKey: league.LeagueKey,
+		TeamKey:   keeper.TeamKey,
+		Round:     keeper.Round,
+		Pick:      keeper.Pick,
+		Timestamp: time.Now(),
+		GameID:    league.Game.GameID,
+		Keeper:    true,
+		Player:    []*entities.PlayerSeason{&player},
+	}
+	league.CurrentPick = 0
+	return m.saveDraftResult(ctx, league, draftResult)
+}
+
+func (m MongoRepository) saveDraftResult(ctx context.Context, league entities.League, draftResult entities.DraftResult) (*entities.DraftResult, error) {
+	pick := draftResult.Pick
+
+	claimed := league.CurrentPick == pick
+	if claimed {
+		err := m.AdvanceCurrentPick(ctx, league.LeagueKey, pick)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	err := m.SaveDraftResult(ctx, draftResult)
 	if err != nil {
 		level.Error(m.logger).Log("message", "error in saving draft result", "err", err)
+		m.releasePick(ctx, league.LeagueKey, pick, claimed, false)
+		if isDuplicateKeyError(err) {
+			return nil, entities.ErrPickConflict
+		}
 		return nil, err
 	}
 
@@ -327,20 +423,162 @@ func (m MongoRepository) SaveDraftResultFromUser(ctx context.Context, league ent
 	collection := m.client.Database(database).Collection(newTable)
 
 	res := bson.M{"$set": bson.M{"draft_results": draftResult}}
-	filter := bson.M{"player._id": player.PlayerKey}
+	filter := bson.M{"player._id": draftResult.PlayerKey}
 	insertResult, err := collection.UpdateOne(ctx, filter, res)
 	if err != nil {
 		level.Error(m.logger).Log("error", err, "message", "could not execute query", "guid", draftResult.UserGUID, "league_key", league.LeagueKey)
+		m.releasePick(ctx, league.LeagueKey, pick, claimed, true)
 		return nil, err
 	}
 
 	level.Debug(m.logger).Log("message", "insert draft result", "upsert_count", insertResult.UpsertedCount, "upsert_id", insertResult.UpsertedID, "player_key", draftResult.PlayerKey)
 	if insertResult.ModifiedCount == 0 {
+		m.releasePick(ctx, league.LeagueKey, pick, claimed, true)
 		return nil, errors.New("did not update")
 	}
 	return &draftResult, nil
 }
 
+// releasePick undoes a pick that could not be saved completely so the team stays on the clock
+func (m MongoRepository) releasePick(ctx context.Context, leagueKey string, pick int, claimed, saved bool) {
+	if saved {
+		_, err := m.client.Database(database).Collection(draftsCollection).DeleteOne(ctx, bson.M{"league_key": leagueKey, "pick": pick})
+		if err != nil {
+			level.Error(m.logger).Log("message", "could not remove draft result", "error", err, "league_key", leagueKey, "pick", pick)
+		}
+	}
+	if !claimed {
+		return
+	}
+	collection := m.client.Database(database).Collection(leaguesCollection)
+	_, err := collection.UpdateOne(ctx, bson.M{"league_key": leagueKey, "current_pick": pick + 1}, bson.M{"$set": bson.M{"current_pick": pick}})
+	if err != nil {
+		level.Error(m.logger).Log("message", "could not release current pick", "error", err, "league_key", leagueKey, "pick", pick)
+	}
+}
+
+// DeleteDraftResult removes a pick from draft_results and puts its player back into the league's player pool
+func (m MongoRepository) DeleteDraftResult(ctx context.Context, leagueKey string, pick int) error {
+	span, ctx := apm.StartSpan(ctx, "DeleteDraftResult", "repository.Mongo")
+	defer span.End()
+
+	collection := m.client.Database(database).Collection(draftsCollection)
+
+	var draftResult entities.DraftResult
+	err := collection.FindOneAndDelete(ctx, bson.M{"league_key": leagueKey, "pick": pick}).Decode(&draftResult)
+	if err != nil {
+		level.Error(m.logger).Log("message", "could not delete draft result", "error", err, "league_key", leagueKey, "pick", pick)
+		return err
+	}
+
+	return m.setPlayerDraftResult(ctx, leagueKey, draftResult.PlayerKey, nil)
+}
+
+// ReplaceDraftResultPlayer swaps the player on a pick that has already been made. The unique index on
+// player_key turns a player that was drafted in the meantime into entities.ErrPickConflict.
+func (m MongoRepository) ReplaceDraftResultPlayer(ctx context.Context, leagueKey string, pick int, player entities.PlayerSeason) (*entities.DraftResult, error) {
+	span, ctx := apm.StartSpan(ctx, "ReplaceDraftResultPlayer", "repository.Mongo")
+	defer span.End()
+
+	collection := m.client.Database(database).Collection(draftsCollection)
+
+	update := bson.M{"$set": bson.M{"player_key": player.PlayerKey, "player_id": player.PlayerID, "player": []*entities.PlayerSeason{&player}}}
+	var previous entities.DraftResult
+	err := collection.FindOneAndUpdate(ctx, bson.M{"league_key": leagueKey, "pick": pick}, update).Decode(&previous)
+	if err != nil {
+		level.Error(m.logger).Log("message", "could not replace draft result player", "error", err, "league_key", leagueKey, "pick", pick)
+		if isDuplicateKeyError(err) {
+			return nil, entities.ErrPickConflict
+		}
+		return nil, err
+	}
+
+	draftResult := previous
+	draftResult.PlayerKey = player.PlayerKey
+	draftResult.PlayerID = player.PlayerID
+	draftResult.Player = []*entities.PlayerSeason{&player}
+
+	err = m.setPlayerDraftResult(ctx, leagueKey, previous.PlayerKey, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = m.setPlayerDraftResult(ctx, leagueKey, player.PlayerKey, &draftResult)
+	if err != nil {
+		return nil, err
+	}
+	return &draftResult, nil
+}
+
+// setPlayerDraftResult marks a player in the league's player pool as drafted, or available again when draftResult is nil
+func (m MongoRepository) setPlayerDraftResult(ctx context.Context, leagueKey, playerKey string, draftResult *entities.DraftResult) error {
+	collection := m.client.Database(database).Collection(getPlayerLeagueCollection(leagueKey))
+
+	update := bson.M{"$unset": bson.M{"draft_results": ""}}
+	if draftResult != nil {
+		update = bson.M{"$set": bson.M{"draft_results": *draftResult}}
+	}
+	_, err := collection.UpdateOne(ctx, bson.M{"player._id": playerKey}, update)
+	if err != nil {
+		level.Error(m.logger).Log("message", "could not update league player", "error", err, "league_key", leagueKey, "player_key", playerKey)
+	}
+	return err
+}
+
+// AdvanceCurrentPick moves current_pick from pick to the next pick. It fails with entities.ErrPickConflict
+// when current_pick has already moved on, so only one request can ever claim a pick.
+func (m MongoRepository) AdvanceCurrentPick(ctx context.Context, leagueKey string, pick int) error {
+	span, ctx := apm.StartSpan(ctx, "AdvanceCurrentPick", "repository.Mongo")
+	defer span.End()
+
+	collection := m.client.Database(database).Collection(leaguesCollection)
+
+	res, err := collection.UpdateOne(ctx, bson.M{"league_key": leagueKey, "current_pick": pick}, bson.M{"$set": bson.M{"current_pick": pick + 1}})
+	if err != nil {
+		level.Error(m.logger).Log("error", err, "could not make query", "league_key", leagueKey)
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return entities.ErrPickConflict
+	}
+	return nil
+}
+
+// EnsureDraftResultIndexes makes the database reject a second result for the same pick or the same player in a league
+func (m MongoRepository) EnsureDraftResultIndexes(ctx context.Context) error {
+	span, ctx := apm.StartSpan(ctx, "EnsureDraftResultIndexes", "repository.Mongo")
+	defer span.End()
+
+	collection := m.client.Database(database).Collection(draftsCollection)
+	_, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "league_key", Value: 1}, {Key: "pick", Value: 1}},
+			Options: options.Index().SetUnique(true).SetName("league_key_pick"),
+		},
+		{
+			Keys:    bson.D{{Key: "league_key", Value: 1}, {Key: "player_key", Value: 1}},
+			Options: options.Index().SetUnique(true).SetName("league_key_player_key"),
+		},
+	})
+	if err != nil {
+		level.Error(m.logger).Log("message", "could not create draft result indexes", "error", err)
+	}
+	return err
+}
+
+func isDuplicateKeyError(err error) bool {
+	const duplicateKey = 11000
+	var writeException mongo.WriteException
+	if errors.As(err, &writeException) {
+		for _, writeError := range writeException.WriteErrors {
+			if writeError.Code == duplicateKey {
+				return true
+			}
+		}
+	}
+	var commandError mongo.CommandError
+	return errors.As(err, &commandError) && commandError.Code == duplicateKey
+}
+
 // {"teams.manager":{ $elemMatch: {"guid":"DPPQCXCRV75Z2LKJW5YRC7RAYM"}}}
 func (m MongoRepository) SaveDraftResult(ctx context.Context, draftResult entities.DraftResult) error {
 	span, ctx := apm.StartSpan(ctx, "SaveDraftResult", "repository.Mongo")
@@ -380,6 +618,125 @@ func (m MongoRepository) SaveDraftOrder(ctx context.Context, leagueKey string, t
 	return err
 }
 
+func (m MongoRepository) SaveDraftSettings(ctx context.Context, leagueKey string, settings entities.DraftSettings) error {
+	span, ctx := apm.StartSpan(ctx, "SaveDraftSettings", "repository.Mongo")
+	defer span.End()
+
+	collection := m.client.Database(database).Collection(leaguesCollection)
+
+	res, err := collection.UpdateOne(ctx, bson.M{"league_key": leagueKey}, bson.M{"$set": bson.M{"draft_settings": settings}})
+	if err != nil {
+		level.Error(m.logger).Log("error", err, "could not make query", "league_key", leagueKey)
+		return err
+	}
+
+	level.Debug(m.logger).Log("message", "updated matched count", "league_key", leagueKey, "matched", res.MatchedCount, "modified", res.ModifiedCount)
+	return err
+}
+
+func (m MongoRepository) SaveDraftState(ctx context.Context, leagueKey string, state entities.DraftState) error {
+	span, ctx := apm.StartSpan(ctx, "SaveDraftState", "repository.Mongo")
+	defer span.End()
+
+	collection := m.client.Database(database).Collection(leaguesCollection)
+
+	update := bson.M{"draft_state": state, "draft_started": state != entities.DraftStateScheduled}
+	res, err := collection.UpdateOne(ctx, bson.M{"league_key": leagueKey}, bson.M{"$set": update})
+	if err != nil {
+		level.Error(m.logger).Log("error", err, "could not make query", "league_key", leagueKey)
+		return err
+	}
+
+	level.Debug(m.logger).Log("message", "updated matched count", "league_key", leagueKey, "matched", res.MatchedCount, "modified", res.ModifiedCount)
+	return err
+}
+
+// SaveTeamKeepers replaces the keepers a team has declared for the league
+func (m MongoRepository) SaveTeamKeepers(ctx context.Context, leagueKey, teamKey string, keepers []entities.Keeper) error {
+	span, ctx := apm.StartSpan(ctx, "SaveTeamKeepers", "repository.Mongo")
+	defer span.End()
+
+	collection := m.client.Database(database).Collection(leaguesCollection)
+
+	_, err := collection.UpdateOne(ctx, bson.M{"league_key": leagueKey}, bson.M{"$pull": bson.M{"keepers": bson.M{"team_key": teamKey}}})
+	if err != nil {
+		level.Error(m.logger).Log("error", err, "could not make query", "league_key", leagueKey)
+		return err
+	}
+	if len(keepers) == 0 {
+		return nil
+	}
+
+	res, err := collection.UpdateOne(ctx, bson.M{"league_key": leagueKey}, bson.M{"$push": bson.M{"keepers": bson.M{"$each": keepers}}})
+	if err != nil {
+		level.Error(m.logger).Log("error", err, "could not make query", "league_key", leagueKey)
+		return err
+	}
+
+	level.Debug(m.logger).Log("message", "updated matched count", "league_key", leagueKey, "matched", res.MatchedCount, "modified", res.ModifiedCount)
+	return err
+}
+
+// SavePickTrade adds a proposed pick trade to the league
+func (m MongoRepository) SavePickTrade(ctx context.Context, leagueKey string, trade entities.PickTrade) error {
+	span, ctx := apm.StartSpan(ctx, "SavePickTrade", "repository.Mongo")
+	defer span.End()
+
+	collection := m.client.Database(database).Collection(leaguesCollection)
+
+	res, err := collection.UpdateOne(ctx, bson.M{"league_key": leagueKey}, bson.M{"$push": bson.M{"pick_trades": trade}})
+	if err != nil {
+		level.Error(m.logger).Log("error", err, "could not make query", "league_key", leagueKey)
+		return err
+	}
+
+	level.Debug(m.logger).Log("message", "updated matched count", "league_key", leagueKey, "matched", res.MatchedCount, "modified", res.ModifiedCount)
+	return err
+}
+
+// DecidePickTrade saves the commissioner's decision on a pending trade, and the pick ownership ledger
+// when owners is not nil. It fails with entities.ErrTradeNotPending when the trade was decided already.
+func (m MongoRepository) DecidePickTrade(ctx context.Context, leagueKey string, trade entities.PickTrade, owners []entities.PickOwner) error {
+	span, ctx := apm.StartSpan(ctx, "DecidePickTrade", "repository.Mongo")
+	defer span.End()
+
+	collection := m.client.Database(database).Collection(leaguesCollection)
+
+	filter := bson.M{
+		"league_key":  leagueKey,
+		"pick_trades": bson.M{"$elemMatch": bson.M{"id": trade.ID, "status": entities.PickTradePending}},
+	}
+	update := bson.M{"pick_trades.$": trade}
+	if owners != nil {
+		update["pick_owners"] = owners
+	}
+	res, err := collection.UpdateOne(ctx, filter, bson.M{"$set": update})
+	if err != nil {
+		level.Error(m.logger).Log("error", err, "could not make query", "league_key", leagueKey)
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return entities.ErrTradeNotPending
+	}
+	return nil
+}
+
+func (m MongoRepository) SaveCurrentPick(ctx context.Context, leagueKey string, pick int) error {
+	span, ctx := apm.StartSpan(ctx, "SaveCurrentPick", "repository.Mongo")
+	defer span.End()
+
+	collection := m.client.Database(database).Collection(leaguesCollection)
+
+	res, err := collection.UpdateOne(ctx, bson.M{"league_key": leagueKey}, bson.M{"$set": bson.M{"current_pick": pick}})
+	if err != nil {
+		level.Error(m.logger).Log("error", err, "could not make query", "league_key", leagueKey)
+		return err
+	}
+
+	level.Debug(m.logger).Log("message", "updated matched count", "league_key", leagueKey, "matched", res.MatchedCount, "modified", res.ModifiedCount)
+	return err
+}
+
 // {"teams.manager":{ $elemMatch: {"guid":"DPPQCXCRV75Z2LKJW5YRC7RAYM"}}}
 func (m MongoRepository) SaveLeagueLeague(ctx context.Context, league entities.League) (entities.League, error) {
 	span, ctx := apm.StartSpan(ctx, "SaveLeagueLeague", "repository.Mongo")
@@ -442,7 +799,7 @@ func (m MongoRepository) SaveLeague(ctx context.Context, league entities.League)
 		return entities.League{}, err
 	}
 
-	_, err = collection.UpdateOne(ctx, findQuert, league)
+	_, err = collection.UpdateOne(ctx, findQuert, bson.M{"$set": league})
 	if err != nil {
 		level.Error(m.logger).Log("error", err, "could not make query", "league_key", league.LeagueKey, "query", queryString)
 		return entities.League{}, err
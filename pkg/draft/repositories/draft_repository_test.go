@@ -7,7 +7,9 @@ import (
 	"github.com/thethan/fdr-users/internal/test_helpers"
 	"github.com/thethan/fdr-users/pkg/draft/entities"
 	"github.com/thethan/fdr-users/pkg/mongo"
+	"go.mongodb.org/mongo-driver/bson"
 	"os"
+	"sync"
 	"testing"
 )
 
@@ -293,3 +295,63 @@ func TestMongoRepository_SaveDraftResults2(t *testing.T) {
 	//}
 
 }
+
+func TestMongoRepository_SaveDraftResultFromUser_Concurrent(t *testing.T) {
+	logger := test_helpers.LogrusLogger(t)
+	client, err := mongo.NewMongoDBClient(context.TODO(), os.Getenv("MONGO_USERNAME"), os.Getenv("MONGO_PASSWORD"), os.Getenv("MONGO_HOST"), os.Getenv("MONGO_PORT"))
+	assert.Nil(t, err)
+	if t.Failed() {
+		t.FailNow()
+	}
+	const requests = 20
+	league := entities.League{LeagueKey: "0.l.concurrent", CurrentPick: 1}
+	team := entities.Team{TeamKey: "0.l.concurrent.t.1"}
+
+	mongoRepo := NewMongoRepository(logger, client, "fdr", "draft", "fdr_user", "roster")
+	assert.Nil(t, mongoRepo.EnsureDraftResultIndexes(context.TODO()))
+
+	db := client.Database(database)
+	playersTable := db.Collection(getPlayerLeagueCollection(league.LeagueKey))
+	defer func() {
+		_, _ = db.Collection(leaguesCollection).DeleteOne(context.TODO(), bson.M{"league_key": league.LeagueKey})
+		_, _ = db.Collection(draftsCollection).DeleteMany(context.TODO(), bson.M{"league_key": league.LeagueKey})
+		_ = playersTable.Drop(context.TODO())
+	}()
+	_, err = db.Collection(leaguesCollection).InsertOne(context.TODO(), bson.M{"league_key": league.LeagueKey, "current_pick": 1})
+	assert.Nil(t, err)
+	for idx := 0; idx < requests; idx++ {
+		_, err = playersTable.InsertOne(context.TODO(), bson.M{"league_key": league.LeagueKey, "player": bson.M{"_id": fmt.Sprintf("0.p.%d", idx)}})
+		assert.Nil(t, err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, requests)
+	for idx := 0; idx < requests; idx++ {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			player := entities.PlayerSeason{PlayerKey: fmt.Sprintf("0.p.%d", idx)}
+			_, err := mongoRepo.SaveDraftResultFromUser(context.TODO(), league, entities.User{}, team, player, 1, 1)
+			errs <- err
+		}(idx)
+	}
+	wg.Wait()
+	close(errs)
+
+	saved := 0
+	for err := range errs {
+		if err == nil {
+			saved++
+			continue
+		}
+		assert.Equal(t, entities.ErrPickConflict, err)
+	}
+	assert.Equal(t, 1, saved)
+
+	results, err := mongoRepo.GetDraftResults(context.TODO(), league.LeagueKey)
+	assert.Nil(t, err)
+	assert.Len(t, results, 1)
+	savedLeague, err := mongoRepo.GetLeague(context.TODO(), league.LeagueKey)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, savedLeague.CurrentPick)
+}
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+	"github.com/go-kit/kit/log/level"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"go.elastic.co/apm"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// draft events are only ever inserted, numbered per league by a counter kept in draftEventSequencesCollection
+const (
+	draftEventsCollection         string = "draft_events"
+	draftEventSequencesCollection string = "draft_event_sequences"
+)
+
+// AppendDraftEvent gives the event the league's next sequence and adds it to the log
+func (m MongoRepository) AppendDraftEvent(ctx context.Context, event entities.DraftEvent) error {
+	span, ctx := apm.StartSpan(ctx, "AppendDraftEvent", "repository.Mongo")
+	defer span.End()
+
+	var counter struct {
+		Sequence int `bson:"sequence"`
+	}
+	sequences := m.client.Database(database).Collection(draftEventSequencesCollection)
+	findOptions := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	err := sequences.FindOneAndUpdate(ctx, bson.M{"_id": event.LeagueKey}, bson.M{"$inc": bson.M{"sequence": 1}}, findOptions).Decode(&counter)
+	if err != nil {
+		level.Error(m.logger).Log("error", err, "message", "could not get next draft event sequence", "league_key", event.LeagueKey)
+		return err
+	}
+
+	event.Sequence = counter.Sequence
+	_, err = m.client.Database(database).Collection(draftEventsCollection).InsertOne(ctx, event)
+	if err != nil {
+		level.Error(m.logger).Log("error", err, "message", "could not append draft event", "league_key", event.LeagueKey, "sequence", event.Sequence)
+	}
+	return err
+}
+
+// GetDraftEvents returns a league's logged draft events in sequence order
+func (m MongoRepository) GetDraftEvents(ctx context.Context, leagueKey string) ([]entities.DraftEvent, error) {
+	span, ctx := apm.StartSpan(ctx, "GetDraftEvents", "repository.Mongo")
+	defer span.End()
+
+	collection := m.client.Database(database).Collection(draftEventsCollection)
+	findOptions := options.Find().SetSort(bson.D{{Key: "sequence", Value: 1}})
+	cursor, err := collection.Find(ctx, bson.M{"league_key": leagueKey}, findOptions)
+	if err != nil {
+		level.Error(m.logger).Log("error", err, "message", "could not get draft events", "league_key", leagueKey)
+		return nil, err
+	}
+
+	events := make([]entities.DraftEvent, 0)
+	err = cursor.All(ctx, &events)
+	return events, err
+}
+
+// EnsureDraftEventIndexes keeps a sequence from being logged twice for a league
+func (m MongoRepository) EnsureDraftEventIndexes(ctx context.Context) error {
+	span, ctx := apm.StartSpan(ctx, "EnsureDraftEventIndexes", "repository.Mongo")
+	defer span.End()
+
+	collection := m.client.Database(database).Collection(draftEventsCollection)
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "league_key", Value: 1}, {Key: "sequence", Value: 1}},
+		Options: options.Index().SetUnique(true).SetName("league_key_sequence"),
+	})
+	if err != nil {
+		level.Error(m.logger).Log("message", "could not create draft event indexes", "error", err)
+	}
+	return err
+}
@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/thethan/fdr-users/internal/test_helpers"
+	"github.com/thethan/fdr-users/pkg/broadcast"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"github.com/thethan/fdr-users/pkg/mongo"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestMongoRepository_GetDraftEvents_Broadcast(t *testing.T) {
+	logger := test_helpers.LogrusLogger(t)
+	client, err := mongo.NewMongoDBClient(context.TODO(), os.Getenv("MONGO_USERNAME"), os.Getenv("MONGO_PASSWORD"), os.Getenv("MONGO_HOST"), os.Getenv("MONGO_PORT"))
+	assert.Nil(t, err)
+	if t.Failed() {
+		t.FailNow()
+	}
+
+	mongoRepo := NewMongoRepository(logger, client, "fdr", "draft", "fdr_user", "roster")
+	broadcastRepo := broadcast.NewRepository(log.NewNopLogger(), broadcast.NewMemory())
+	broadcastRepo.WithEventLog(&mongoRepo)
+
+	// a league of its own, so the events of earlier runs are not read back
+	league := entities.League{LeagueKey: fmt.Sprintf("399.l.%d", time.Now().UnixNano()), DraftOrder: []string{"399.l.1.t.2", "399.l.1.t.1"}, CurrentPick: 2}
+	league.SetState(entities.DraftStateOpen)
+	result := entities.DraftResult{PlayerKey: "399.p.1", TeamKey: "399.l.1.t.2", Pick: 1, Round: 1}
+	assert.Nil(t, broadcastRepo.BroadCastLeagueInformation(context.TODO(), league, "league is opened", entities.BroadCastTypeDraftOpen))
+	assert.Nil(t, broadcastRepo.BroadCastDraftResult(context.TODO(), league, entities.User{Guid: "manager-2"}, entities.Team{TeamKey: result.TeamKey}, result, 1, 1, nil))
+
+	events, err := mongoRepo.GetDraftEvents(context.TODO(), league.LeagueKey)
+	assert.Nil(t, err)
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, 1, events[0].Sequence)
+		assert.Equal(t, entities.DraftStateOpen, events[0].State)
+		assert.Equal(t, 2, events[1].Sequence)
+		assert.Equal(t, entities.BroadCastTypePlayerDrafted, events[1].Type)
+		if assert.NotNil(t, events[1].DraftResult) {
+			assert.Equal(t, "399.p.1", events[1].DraftResult.PlayerKey)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"github.com/go-kit/kit/log/level"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"go.elastic.co/apm"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// mock drafts live in their own collection so rehearsals never reach draft_results or players_per_league_*
+const mockDraftsCollection string = "mock_drafts"
+
+// MockDraftRetention is how long a mock draft can be replayed after it expired. Mongo deletes it after that.
+const MockDraftRetention = 7 * 24 * time.Hour
+
+func (m MongoRepository) CreateMockDraft(ctx context.Context, mock entities.MockDraft) error {
+	span, ctx := apm.StartSpan(ctx, "CreateMockDraft", "repository.Mongo")
+	defer span.End()
+
+	collection := m.client.Database(database).Collection(mockDraftsCollection)
+	_, err := collection.InsertOne(ctx, mock)
+	if err != nil {
+		level.Error(m.logger).Log("error", err, "message", "could not create mock draft", "league_key", mock.LeagueKey, "mock_draft_id", mock.ID)
+	}
+	return err
+}
+
+func (m MongoRepository) GetMockDraft(ctx context.Context, mockID string) (entities.MockDraft, error) {
+	span, ctx := apm.StartSpan(ctx, "GetMockDraft", "repository.Mongo")
+	defer span.End()
+
+	collection := m.client.Database(database).Collection(mockDraftsCollection)
+	var mock entities.MockDraft
+	err := collection.FindOne(ctx, bson.M{"_id": mockID}).Decode(&mock)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return mock, entities.ErrMockDraftNotFound
+	}
+	return mock, err
+}
+
+// ListMockDrafts returns a user's mock drafts of a league, newest first
+func (m MongoRepository) ListMockDrafts(ctx context.Context, leagueKey, userGUID string) ([]entities.MockDraft, error) {
+	span, ctx := apm.StartSpan(ctx, "ListMockDrafts", "repository.Mongo")
+	defer span.End()
+
+	collection := m.client.Database(database).Collection(mockDraftsCollection)
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := collection.Find(ctx, bson.M{"league_key": leagueKey, "user_guid": userGUID}, findOptions)
+	if err != nil {
+		level.Error(m.logger).Log("error", err, "message", "could not list mock drafts", "league_key", leagueKey)
+		return nil, err
+	}
+
+	mocks := make([]entities.MockDraft, 0)
+	err = cursor.All(ctx, &mocks)
+	return mocks, err
+}
+
+// SaveMockDraftPicks saves the picks made since the mock draft was loaded at fromPick. It fails with
+// entities.ErrMockDraftChanged when another request moved the mock draft on first.
+func (m MongoRepository) SaveMockDraftPicks(ctx context.Context, mock entities.MockDraft, fromPick int) error {
+	span, ctx := apm.StartSpan(ctx, "SaveMockDraftPicks", "repository.Mongo")
+	defer span.End()
+
+	collection := m.client.Database(database).Collection(mockDraftsCollection)
+	update := bson.M{
+		"draft_results": mock.Results,
+		"current_pick":  mock.CurrentPick,
+		"completed_at":  mock.CompletedAt,
+	}
+	res, err := collection.UpdateOne(ctx, bson.M{"_id": mock.ID, "current_pick": fromPick}, bson.M{"$set": update})
+	if err != nil {
+		level.Error(m.logger).Log("error", err, "message", "could not save mock draft", "mock_draft_id", mock.ID)
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return entities.ErrMockDraftChanged
+	}
+	return nil
+}
+
+// EnsureMockDraftIndexes lets Mongo delete mock drafts MockDraftRetention after they expire
+func (m MongoRepository) EnsureMockDraftIndexes(ctx context.Context) error {
+	span, ctx := apm.StartSpan(ctx, "EnsureMockDraftIndexes", "repository.Mongo")
+	defer span.End()
+
+	collection := m.client.Database(database).Collection(mockDraftsCollection)
+	_, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(MockDraftRetention.Seconds())).SetName("expires_at_ttl"),
+		},
+		{
+			Keys:    bson.D{{Key: "league_key", Value: 1}, {Key: "user_guid", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("league_key_user_guid_created_at"),
+		},
+	})
+	if err != nil {
+		level.Error(m.logger).Log("message", "could not create mock draft indexes", "error", err)
+	}
+	return err
+}
+
+// GetPlayersByGameRank returns a game's players ordered by their yahoo rank, whatever has been drafted in
+// any league. Offset is a page number.
+func (m MongoRepository) GetPlayersByGameRank(ctx context.Context, gameID, limit, offset int) ([]entities.PlayerSeason, error) {
+	span, ctx := apm.StartSpan(ctx, "GetPlayersByGameRank", "repository.Mongo")
+	defer span.End()
+
+	collection := m.client.Database(database).Collection(playersBySeason)
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "ranks.yahoo", Value: 1}}).
+		SetSkip(int64(offset * limit)).
+		SetLimit(int64(limit))
+	cursor, err := collection.Find(ctx, bson.M{"game_id": gameID, "ranks.yahoo": bson.M{"$gt": 0}}, findOptions)
+	if err != nil {
+		level.Error(m.logger).Log("message", "could not get ranked players", "error", err, "game_id", gameID)
+		return nil, err
+	}
+
+	players := make([]entities.PlayerSeason, 0, limit)
+	err = cursor.All(ctx, &players)
+	return players, err
+}
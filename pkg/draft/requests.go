@@ -1,9 +1,134 @@
 package draft
 
+import "github.com/thethan/fdr-users/pkg/draft/entities"
+
 type OpenDraftRequest struct {
 	LeagueID string
 }
 
+// DraftStateRequest pauses, resumes or closes a league's draft
+type DraftStateRequest struct {
+	LeagueID string
+}
+
 type ShuffleDraftOrderRequest struct {
 	LeagueID string
 }
+
+type UpdateDraftSettingsRequest struct {
+	LeagueID string                 `json:"-"`
+	Settings entities.DraftSettings `json:"settings"`
+}
+
+type UndoLastPickRequest struct {
+	LeagueID string
+}
+
+type ReplacePickPlayerRequest struct {
+	LeagueID  string `json:"-"`
+	Pick      int    `json:"-"`
+	PlayerKey string `json:"player_key"`
+}
+
+type AssignPickRequest struct {
+	LeagueID  string `json:"-"`
+	Pick      int    `json:"-"`
+	TeamKey   string `json:"team_key"`
+	PlayerKey string `json:"player_key"`
+}
+
+type NominateRequest struct {
+	LeagueID  string `json:"-"`
+	TeamKey   string `json:"team_key"`
+	PlayerKey string `json:"player_key"`
+	Amount    int    `json:"amount"`
+}
+
+type BidRequest struct {
+	LeagueID string `json:"-"`
+	TeamKey  string `json:"team_key"`
+	Amount   int    `json:"amount"`
+}
+
+type DeclareKeepersRequest struct {
+	LeagueID   string   `json:"-"`
+	TeamKey    string   `json:"team_key"`
+	PlayerKeys []string `json:"player_keys"`
+}
+
+type ProposePickTradeRequest struct {
+	LeagueID string                `json:"-"`
+	Picks    []entities.TradedPick `json:"picks"`
+}
+
+// PickTradeDecisionRequest approves or rejects a pending pick trade
+type PickTradeDecisionRequest struct {
+	LeagueID string
+	TradeID  string
+}
+
+// MockDraftRequest reads a mock draft, as of pick Through when it is not zero
+type MockDraftRequest struct {
+	LeagueID string
+	MockID   string
+	Through  int
+}
+
+type MockDraftPickRequest struct {
+	LeagueID  string `json:"-"`
+	MockID    string `json:"-"`
+	PlayerKey string `json:"player_key"`
+}
+
+type DraftReplayRequest struct {
+	LeagueKey string
+	Pick      int
+}
+
+type DraftExportRequest struct {
+	LeagueKey string
+	Format    ExportFormat
+}
+
+type DraftExportResponse struct {
+	Format ExportFormat
+	Export *entities.DraftExport
+}
+
+type ChatMessageRequest struct {
+	LeagueKey string `json:"-"`
+	Text      string `json:"text"`
+}
+
+// ChatHistoryRequest reads a page of chat from before the message with id Before, the newest page when it is empty
+type ChatHistoryRequest struct {
+	LeagueKey string
+	Before    string
+	Limit     int
+}
+
+type ChatMessageIDRequest struct {
+	LeagueKey string
+	MessageID string
+}
+
+type ChatReactionRequest struct {
+	LeagueKey string `json:"-"`
+	MessageID string `json:"-"`
+	Emoji     string `json:"emoji"`
+}
+
+// ChatMuteRequest mutes a user for Minutes, or until they are unmuted when Minutes is 0
+type ChatMuteRequest struct {
+	LeagueKey string `json:"-"`
+	UserGUID  string `json:"user_guid"`
+	Minutes   int    `json:"minutes"`
+}
+
+// DraftLotteryRequest draws the draft order. RevealSeconds is how long each slot is shown before the next.
+// The seed is always drawn by the server.
+type DraftLotteryRequest struct {
+	LeagueKey     string                 `json:"-"`
+	Method        entities.LotteryMethod `json:"method"`
+	RevealSeconds int                    `json:"reveal_seconds"`
+}
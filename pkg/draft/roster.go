@@ -0,0 +1,220 @@
+package draft
+
+import (
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"math"
+	"sort"
+	"strings"
+)
+
+// benchSlot takes any player who does not start
+const benchSlot = "BN"
+
+// utilitySlot takes any player but pitchers and goalies
+const utilitySlot = "Util"
+
+// flexSlots are the Yahoo roster slots shared by several positions, across football, baseball, basketball and hockey.
+// A player also fits any slot named like one of its eligible positions, which covers the single position slots.
+var flexSlots = map[string][]string{
+	"W/R/T":   {"WR", "RB", "TE"},
+	"W/R":     {"WR", "RB"},
+	"W/T":     {"WR", "TE"},
+	"R/T":     {"RB", "TE"},
+	"Q/W/R/T": {"QB", "WR", "RB", "TE"},
+	"IDP":     {"DL", "DE", "DT", "LB", "DB", "CB", "S"},
+	"D":       {"DL", "DE", "DT", "LB", "DB", "CB", "S"},
+	"IF":      {"1B", "2B", "3B", "SS"},
+	"CI":      {"1B", "3B"},
+	"MI":      {"2B", "SS"},
+	"OF":      {"LF", "CF", "RF"},
+	"P":       {"SP", "RP"},
+	"G":       {"PG", "SG"},
+	"F":       {"SF", "PF"},
+	"W":       {"LW", "RW"},
+}
+
+// notUtility are the positions a utility slot does not take
+var notUtility = map[string]bool{"SP": true, "RP": true, "P": true, "G": true}
+
+// reserveSlots hold injured and minor league players. Yahoo only moves players there after the draft,
+// so a drafted player never lands in one and they add no room to a roster.
+var reserveSlots = map[string]bool{"IR": true, "IL": true, "IL+": true, "NA": true}
+
+// slotAccepts reports whether a player with positions can be rostered in slot
+func slotAccepts(slot string, positions []string) bool {
+	if slot == benchSlot {
+		return true
+	}
+	if reserveSlots[slot] {
+		return false
+	}
+	utility := strings.EqualFold(slot, utilitySlot)
+	for _, position := range positions {
+		if position == slot {
+			return true
+		}
+		if utility && !notUtility[position] {
+			return true
+		}
+		for _, flex := range flexSlots[slot] {
+			if position == flex {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// starterSeats lists one entry per starting place of the roster. Single position slots come first, then flex
+// slots from the narrowest to utility, so a player starts in its own position's slot when either would do.
+func starterSeats(roster entities.Roster) []string {
+	var slots []string
+	for slot := range roster.Roster {
+		if slot != benchSlot && !reserveSlots[slot] {
+			slots = append(slots, slot)
+		}
+	}
+	sort.Slice(slots, func(i, j int) bool {
+		if breadth(slots[i]) != breadth(slots[j]) {
+			return breadth(slots[i]) < breadth(slots[j])
+		}
+		return slots[i] < slots[j]
+	})
+
+	var seats []string
+	for _, slot := range slots {
+		for idx := 0; idx < roster.Roster[slot].Count; idx++ {
+			seats = append(seats, slot)
+		}
+	}
+	return seats
+}
+
+// breadth is how many positions a slot takes, for ordering slots from the narrowest
+func breadth(slot string) int {
+	if strings.EqualFold(slot, utilitySlot) {
+		return math.MaxInt32
+	}
+	if positions, ok := flexSlots[slot]; ok {
+		return len(positions)
+	}
+	return 1
+}
+
+// assignRosterSlots assigns players, in draft order, to the roster's slots. It starts as many players as the
+// starting slots allow, using every eligible position, and earlier picks keep their starting place over later ones.
+// Players who do not start go to the bench while it has room. ok is false for the players left over, who are still
+// reported on the bench.
+func assignRosterSlots(roster entities.Roster, players []*entities.PlayerSeason) (slots []string, ok []bool) {
+	seats := starterSeats(roster)
+
+	// who starts is decided in draft order, then the starters are seated again with the players who fit the
+	// fewest seats first, so flex slots go to the players who can use them
+	order := make([]int, len(players))
+	for idx := range order {
+		order[idx] = idx
+	}
+	starters := make([]int, 0, len(seats))
+	for _, player := range matchSeats(seats, players, order) {
+		if player != -1 {
+			starters = append(starters, player)
+		}
+	}
+	sort.Ints(starters)
+	fits := func(player int) int {
+		count := 0
+		for _, slot := range seats {
+			if slotAccepts(slot, players[player].EligiblePositions) {
+				count++
+			}
+		}
+		return count
+	}
+	sort.SliceStable(starters, func(i, j int) bool {
+		return fits(starters[i]) < fits(starters[j])
+	})
+
+	slots = make([]string, len(players))
+	ok = make([]bool, len(players))
+	for seat, player := range matchSeats(seats, players, starters) {
+		if player != -1 {
+			slots[player], ok[player] = seats[seat], true
+		}
+	}
+	bench := roster.Roster[benchSlot].Count
+	for player := range players {
+		if ok[player] {
+			continue
+		}
+		slots[player] = benchSlot
+		if bench > 0 {
+			ok[player] = true
+			bench--
+		}
+	}
+	return slots, ok
+}
+
+// matchSeats seats players in order and returns the player in every seat, or -1 for an empty one. A player takes
+// the first open seat they fit; otherwise a player already seated is moved to another seat they fit when that frees
+// one, so every player seated earlier stays seated.
+func matchSeats(seats []string, players []*entities.PlayerSeason, order []int) []int {
+	seatPlayer := make([]int, len(seats))
+	for idx := range seatPlayer {
+		seatPlayer[idx] = -1
+	}
+
+	var augment func(player int, visited []bool) bool
+	augment = func(player int, visited []bool) bool {
+		if players[player] == nil {
+			return false
+		}
+		for seat, slot := range seats {
+			if seatPlayer[seat] == -1 && slotAccepts(slot, players[player].EligiblePositions) {
+				seatPlayer[seat] = player
+				return true
+			}
+		}
+		for seat, slot := range seats {
+			if visited[seat] || !slotAccepts(slot, players[player].EligiblePositions) {
+				continue
+			}
+			visited[seat] = true
+			if augment(seatPlayer[seat], visited) {
+				seatPlayer[seat] = player
+				return true
+			}
+		}
+		return false
+	}
+	for _, player := range order {
+		augment(player, make([]bool, len(seats)))
+	}
+	return seatPlayer
+}
+
+// rosteredResults returns the draft results already on a roster in draft order
+func rosteredResults(roster entities.Roster) []entities.DraftResult {
+	slots := make([]string, 0, len(roster.Roster))
+	for slot := range roster.Roster {
+		slots = append(slots, slot)
+	}
+	sort.Strings(slots)
+
+	var results []entities.DraftResult
+	for _, slot := range slots {
+		results = append(results, roster.Roster[slot].DraftResults...)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Pick < results[j].Pick
+	})
+	return results
+}
+
+// resultPlayer is the player of a draft result, or nil when it was not loaded
+func resultPlayer(result entities.DraftResult) *entities.PlayerSeason {
+	if len(result.Player) == 0 {
+		return nil
+	}
+	return result.Player[0]
+}
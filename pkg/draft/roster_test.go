@@ -0,0 +1,165 @@
+package draft
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"testing"
+)
+
+func rosterOf(positions ...entities.RosterPosition) entities.Roster {
+	return makeRoster(entities.League{Settings: &entities.LeagueSettings{RosterPositions: positions}})
+}
+
+func Test_assignRosterSlots(t *testing.T) {
+	nfl := rosterOf(
+		entities.RosterPosition{Position: "QB", Count: 1}, entities.RosterPosition{Position: "RB", Count: 1},
+		entities.RosterPosition{Position: "WR", Count: 1}, entities.RosterPosition{Position: "TE", Count: 1},
+		entities.RosterPosition{Position: "W/R/T", Count: 1}, entities.RosterPosition{Position: "W/T", Count: 1},
+		entities.RosterPosition{Position: "Q/W/R/T", Count: 1}, entities.RosterPosition{Position: "LB", Count: 1},
+		entities.RosterPosition{Position: "IDP", Count: 1}, entities.RosterPosition{Position: "BN", Count: 1},
+		entities.RosterPosition{Position: "IR", Count: 1},
+	)
+	mlb := rosterOf(
+		entities.RosterPosition{Position: "C", Count: 1}, entities.RosterPosition{Position: "1B", Count: 1},
+		entities.RosterPosition{Position: "2B", Count: 1}, entities.RosterPosition{Position: "SS", Count: 1},
+		entities.RosterPosition{Position: "OF", Count: 1}, entities.RosterPosition{Position: "Util", Count: 1},
+		entities.RosterPosition{Position: "SP", Count: 1}, entities.RosterPosition{Position: "RP", Count: 1},
+		entities.RosterPosition{Position: "P", Count: 1}, entities.RosterPosition{Position: "BN", Count: 1},
+		entities.RosterPosition{Position: "IL", Count: 1}, entities.RosterPosition{Position: "NA", Count: 1},
+	)
+	nba := rosterOf(
+		entities.RosterPosition{Position: "PG", Count: 1}, entities.RosterPosition{Position: "SG", Count: 1},
+		entities.RosterPosition{Position: "G", Count: 1}, entities.RosterPosition{Position: "SF", Count: 1},
+		entities.RosterPosition{Position: "PF", Count: 1}, entities.RosterPosition{Position: "F", Count: 1},
+		entities.RosterPosition{Position: "C", Count: 1}, entities.RosterPosition{Position: "Util", Count: 2},
+		entities.RosterPosition{Position: "BN", Count: 1}, entities.RosterPosition{Position: "IL", Count: 1},
+	)
+	nhl := rosterOf(
+		entities.RosterPosition{Position: "C", Count: 1}, entities.RosterPosition{Position: "LW", Count: 1},
+		entities.RosterPosition{Position: "RW", Count: 1}, entities.RosterPosition{Position: "D", Count: 2},
+		entities.RosterPosition{Position: "Util", Count: 1}, entities.RosterPosition{Position: "G", Count: 1},
+		entities.RosterPosition{Position: "BN", Count: 1}, entities.RosterPosition{Position: "IR", Count: 1},
+	)
+
+	tests := []struct {
+		name      string
+		roster    entities.Roster
+		positions [][]string
+		want      []string
+		wantOK    []bool
+	}{
+		{
+			name:      "NFL starters fill flex slots",
+			roster:    nfl,
+			positions: [][]string{{"RB"}, {"RB"}, {"QB"}, {"QB"}, {"TE"}, {"TE"}, {"WR"}},
+			want:      []string{"RB", "W/R/T", "QB", "Q/W/R/T", "TE", "W/T", "WR"},
+		},
+		{
+			name:      "NFL flex slot goes to the multi-eligible player",
+			roster:    nfl,
+			positions: [][]string{{"RB", "WR"}, {"RB"}, {"WR"}},
+			want:      []string{"W/T", "RB", "WR"},
+		},
+		{
+			name: "NFL multi-eligible player moves to let a later pick start",
+			roster: rosterOf(
+				entities.RosterPosition{Position: "RB", Count: 1}, entities.RosterPosition{Position: "WR", Count: 1},
+				entities.RosterPosition{Position: "BN", Count: 1},
+			),
+			positions: [][]string{{"RB", "WR"}, {"RB"}, {"WR"}},
+			want:      []string{"WR", "RB", "BN"},
+		},
+		{
+			name:      "NFL defensive players fill IDP",
+			roster:    nfl,
+			positions: [][]string{{"LB"}, {"LB"}, {"K"}},
+			want:      []string{"LB", "IDP", "BN"},
+		},
+		{
+			name:      "NFL full bench leaves IR empty",
+			roster:    nfl,
+			positions: [][]string{{"QB"}, {"QB"}, {"QB"}, {"QB"}},
+			want:      []string{"QB", "Q/W/R/T", "BN", "BN"},
+			wantOK:    []bool{true, true, true, false},
+		},
+		{
+			name:   "MLB infielders and pitchers use every eligible position",
+			roster: mlb,
+			positions: [][]string{
+				{"2B", "SS"}, {"2B"}, {"SP"}, {"SP", "RP"}, {"RP"}, {"SP"}, {"CF"}, {"1B"}, {"1B"}, {"C"}, {"SP"},
+			},
+			want:   []string{"SS", "2B", "SP", "P", "RP", "BN", "OF", "1B", "Util", "C", "BN"},
+			wantOK: []bool{true, true, true, true, true, true, true, true, true, true, false},
+		},
+		{
+			name:      "MLB utility does not take pitchers",
+			roster:    mlb,
+			positions: [][]string{{"SP"}, {"SP"}, {"SP"}},
+			want:      []string{"SP", "P", "BN"},
+		},
+		{
+			name:      "NBA guards and forwards",
+			roster:    nba,
+			positions: [][]string{{"PG", "SG"}, {"PG"}, {"SG"}, {"C"}, {"C"}, {"SF", "PF"}, {"SF"}, {"PF"}, {"PG"}, {"C"}},
+			want:      []string{"Util", "PG", "SG", "C", "Util", "F", "SF", "PF", "G", "BN"},
+		},
+		{
+			name:      "NHL skaters and goalies",
+			roster:    nhl,
+			positions: [][]string{{"C", "LW"}, {"C"}, {"LW"}, {"G"}, {"G"}, {"D"}, {"D"}, {"RW"}, {"D"}},
+			want:      []string{"Util", "C", "LW", "G", "BN", "D", "D", "RW", "BN"},
+			wantOK:    []bool{true, true, true, true, true, true, true, true, false},
+		},
+		{
+			name:      "players without positions go to the bench",
+			roster:    nhl,
+			positions: [][]string{nil, {"C"}},
+			want:      []string{"BN", "C"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			players := make([]*entities.PlayerSeason, len(tt.positions))
+			for idx, positions := range tt.positions {
+				players[idx] = &entities.PlayerSeason{EligiblePositions: positions}
+			}
+			wantOK := tt.wantOK
+			if wantOK == nil {
+				wantOK = make([]bool, len(tt.want))
+				for idx := range wantOK {
+					wantOK[idx] = true
+				}
+			}
+
+			slots, ok := assignRosterSlots(tt.roster, players)
+			assert.Equal(t, tt.want, slots)
+			assert.Equal(t, wantOK, ok)
+		})
+	}
+}
+
+func Test_rosterSlotFor(t *testing.T) {
+	league := testLeague()
+	flex, rb, secondRB := testPlayer("flex", "RB", "WR"), testPlayer("rb", "RB"), testPlayer("rb-2", "RB")
+	roster := buildTeamRoster([]entities.DraftResult{
+		{Pick: 1, PlayerKey: "flex", Player: []*entities.PlayerSeason{&flex}},
+		{Pick: 2, PlayerKey: "rb", Player: []*entities.PlayerSeason{&rb}},
+		{Pick: 3, PlayerKey: "rb-2", Player: []*entities.PlayerSeason{&secondRB}},
+	}, makeRoster(league))
+	assert.Len(t, roster.Roster["RB"].DraftResults, 2)
+	if assert.Len(t, roster.Roster["WR"].DraftResults, 1) {
+		assert.Equal(t, "flex", roster.Roster["WR"].DraftResults[0].PlayerKey, "the multi-eligible player makes room for the second running back")
+	}
+
+	thirdRB := testPlayer("rb-3", "RB")
+	slot, ok := rosterSlotFor(roster, &thirdRB)
+	assert.True(t, ok)
+	assert.Equal(t, "W/R/T", slot)
+	assert.Len(t, roster.Roster["W/R/T"].DraftResults, 0, "asking does not change the roster")
+
+	roster = buildTeamRoster([]entities.DraftResult{{Pick: 4, PlayerKey: "rb-3", Player: []*entities.PlayerSeason{&thirdRB}}}, roster)
+	fourthRB := testPlayer("rb-4", "RB")
+	slot, ok = rosterSlotFor(roster, &fourthRB)
+	assert.True(t, ok)
+	assert.Equal(t, "BN", slot)
+}
@@ -3,20 +3,20 @@ package draft
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
 	"github.com/thethan/fdr-users/pkg/auth"
 	"github.com/thethan/fdr-users/pkg/draft/entities"
 	userEntities "github.com/thethan/fdr-users/pkg/users/entities"
+	"github.com/thethan/fdr-users/pkg/yahoo"
 	"go.elastic.co/apm"
-	"math"
-	"math/rand"
-	"reflect"
+	"sort"
 	"time"
 )
 
 func NewService(logger log.Logger, repository draftRepository, broadcastRepo broadCastRepo) Service {
-	return Service{logger: logger, draftRepo: repository, broadCastRepo: broadcastRepo}
+	return Service{logger: logger, draftRepo: repository, broadCastRepo: broadcastRepo, clock: newPickClock(), auctions: newAuctionHouse(), chat: newChatLimiter(), presence: newPresenceTracker(presenceTimeout)}
 }
 
 type draftRepository interface {
@@ -28,11 +28,42 @@ type draftRepository interface {
 	GetTeamDraftResultsByTeam(ctx context.Context, leagueKey string) (map[string][]entities.DraftResult, error)
 	SaveUserPlayerPreference(ctx context.Context, preference entities.UserPlayerPreference) error
 	GetUserPlayerPreference(ctx context.Context, userGUID, leagueKey string) (entities.UserPlayerPreference, error)
+	SaveDraftSettings(ctx context.Context, leagueKey string, settings entities.DraftSettings) error
+	SaveCurrentPick(ctx context.Context, leagueKey string, pick int) error
+	SaveDraftState(ctx context.Context, leagueKey string, state entities.DraftState) error
+	AdvanceCurrentPick(ctx context.Context, leagueKey string, pick int) error
+	DeleteDraftResult(ctx context.Context, leagueKey string, pick int) error
+	ReplaceDraftResultPlayer(ctx context.Context, leagueKey string, pick int, player entities.PlayerSeason) (*entities.DraftResult, error)
+	SaveAuctionResult(ctx context.Context, league entities.League, user entities.User, team entities.Team, player entities.PlayerSeason, pick, cost int) (*entities.DraftResult, error)
+	SaveKeeperResult(ctx context.Context, league entities.League, user entities.User, keeper entities.Keeper, player entities.PlayerSeason) (*entities.DraftResult, error)
+	SaveTeamKeepers(ctx context.Context, leagueKey, teamKey string, keepers []entities.Keeper) error
+	SavePickTrade(ctx context.Context, leagueKey string, trade entities.PickTrade) error
+	DecidePickTrade(ctx context.Context, leagueKey string, trade entities.PickTrade, owners []entities.PickOwner) error
+	GetPlayers(ctx context.Context, playerKeys []string) ([]entities.PlayerSeason, error)
+	GetAvailablePlayersByRank(ctx context.Context, leagueKey string, limit, offset int) ([]entities.PlayerSeason, error)
+	GetPlayersByGameRank(ctx context.Context, gameID, limit, offset int) ([]entities.PlayerSeason, error)
+	CreateMockDraft(ctx context.Context, mock entities.MockDraft) error
+	GetMockDraft(ctx context.Context, mockID string) (entities.MockDraft, error)
+	ListMockDrafts(ctx context.Context, leagueKey, userGUID string) ([]entities.MockDraft, error)
+	SaveMockDraftPicks(ctx context.Context, mock entities.MockDraft, fromPick int) error
+	GetDraftEvents(ctx context.Context, leagueKey string) ([]entities.DraftEvent, error)
+	SaveChatMessage(ctx context.Context, message entities.ChatMessage) error
+	GetChatMessage(ctx context.Context, leagueKey, messageID string) (entities.ChatMessage, error)
+	GetChatMessages(ctx context.Context, leagueKey, before string, limit int) ([]entities.ChatMessage, error)
+	DeleteChatMessage(ctx context.Context, leagueKey, messageID, deletedBy string, deletedAt time.Time) (entities.ChatMessage, error)
+	SaveChatReaction(ctx context.Context, leagueKey, messageID string, reaction entities.ChatReaction, add bool) (entities.ChatMessage, error)
+	SaveChatMutes(ctx context.Context, leagueKey string, mutes []entities.ChatMute) error
 }
 
 type broadCastRepo interface {
 	BroadCastDraftResult(ctx context.Context, league entities.League, user entities.User, team entities.Team, draftResult entities.DraftResult, pick, round int, rosters map[string]entities.Roster) error
 	BroadCastLeagueInformation(ctx context.Context, league entities.League, message string, broadcastType entities.BroadcastType) error
+	BroadCastDraftCorrection(ctx context.Context, league entities.League, user entities.User, correction entities.DraftCorrection, rosters map[string]entities.Roster) error
+	BroadCastAuction(ctx context.Context, league entities.League, broadcastType entities.BroadcastType, lot entities.AuctionLot, draftResult *entities.DraftResult, rosters map[string]entities.Roster) error
+	BroadCastDraftGrades(ctx context.Context, league entities.League, report entities.DraftReport) error
+	BroadCastChatMessage(ctx context.Context, league entities.League, message entities.ChatMessage) error
+	BroadCastPresence(ctx context.Context, league entities.League, broadcastType entities.BroadcastType, user entities.User, team entities.Team) error
+	BroadCastLotteryDraw(ctx context.Context, league entities.League, step entities.LotteryStep) error
 	ChangeTeamName(ctx context.Context, league entities.League, user entities.User, team entities.Team) error
 }
 
@@ -40,6 +71,11 @@ type Service struct {
 	logger    log.Logger
 	draftRepo draftRepository
 	broadCastRepo
+	clock    *pickClock
+	auctions *auctionHouse
+	chat     *chatLimiter
+	presence *presenceTracker
+	yahoo    *yahoo.Service
 }
 
 func (service *Service) ListDraftResults(ctx context.Context, leagueKey string) (*entities.League, []entities.DraftResult, error) {
@@ -59,6 +95,7 @@ func (service *Service) ListDraftResults(ctx context.Context, leagueKey string)
 
 		return nil, nil, err
 	}
+	service.setClockExpiresAt(&league)
 
 	return &league, results, err
 }
@@ -104,29 +141,44 @@ func makeRoster(league entities.League) entities.Roster {
 	return entities.Roster{Roster: teamRoster}
 }
 
+// buildTeamRoster adds a team's draft results to roster and assigns every player on it a slot again,
+// since a new pick can move an earlier one into a flex slot
 func buildTeamRoster(teamDraftResults []entities.DraftResult, roster entities.Roster) entities.Roster {
-	for _, teamDraftResult := range teamDraftResults {
-		rosterSlotKey := teamDraftResult.Player[0].EligiblePositions[0]
-		if roster.CanAddResult(rosterSlotKey) {
-			roster = addToRoster(rosterSlotKey, roster, teamDraftResult)
-			continue
-		}
-
-		if (rosterSlotKey == "RB" || rosterSlotKey == "WR" || rosterSlotKey == "TE") && roster.CanAddResult("W/R/T") {
-			rosterSlotKey = "W/R/T"
-			roster = addToRoster(rosterSlotKey, roster, teamDraftResult)
-			continue
-		}
+	results := append(rosteredResults(roster), teamDraftResults...)
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Pick < results[j].Pick
+	})
+
+	players := make([]*entities.PlayerSeason, len(results))
+	for idx, result := range results {
+		players[idx] = resultPlayer(result)
+	}
+	// a full roster still sends the player to the bench
+	rosterSlotKeys, _ := assignRosterSlots(roster, players)
 
-		if !roster.CanAddResult(rosterSlotKey) {
-			// add roster to bench
-			rosterSlotKey = "BN"
-			roster = addToRoster(rosterSlotKey, roster, teamDraftResult)
-		}
+	for slot, rosterSlot := range roster.Roster {
+		rosterSlot.DraftResults = []entities.DraftResult{}
+		roster.Roster[slot] = rosterSlot
+	}
+	for idx, result := range results {
+		roster = addToRoster(rosterSlotKeys[idx], roster, result)
 	}
 	return roster
 }
 
+// rosterSlotFor returns the roster slot a drafted player lands in and whether that slot still has room
+func rosterSlotFor(roster entities.Roster, player *entities.PlayerSeason) (string, bool) {
+	results := rosteredResults(roster)
+	players := make([]*entities.PlayerSeason, 0, len(results)+1)
+	for _, result := range results {
+		players = append(players, resultPlayer(result))
+	}
+	players = append(players, player)
+
+	slots, ok := assignRosterSlots(roster, players)
+	return slots[len(players)-1], ok[len(players)-1]
+}
+
 func addToRoster(rosterSlotKey string, roster entities.Roster, result entities.DraftResult) entities.Roster {
 	roster.AddResult(rosterSlotKey, result)
 	return roster
@@ -146,26 +198,41 @@ func (s *Service) OpenDraft(ctx context.Context, leagueKey string) (*entities.Le
 	if !isUserCommissioner(ctx, league) {
 		return nil, errors.New("user is not commissioner")
 	}
-	league.DraftStarted = true
+	if league.State() != entities.DraftStateScheduled {
+		return nil, &ErrorDraftState{state: league.State(), action: "open"}
+	}
+	err = s.fillKeeperPicks(ctx, &league)
+	if err != nil {
+		return nil, err
+	}
+	league.SetState(entities.DraftStateOpen)
+	if league.CurrentPick == 0 {
+		league.CurrentPick = 1
+	}
+	for league.IsKeeperPick(league.CurrentPick) {
+		league.CurrentPick++
+	}
 
 	league, err = s.draftRepo.SaveLeague(ctx, league)
 	if err != nil {
 		level.Error(s.logger).Log("message", "could not save draft", "error", err)
 		return nil, err
 	}
+	s.startPickClock(&league)
+
 	err = s.broadCastRepo.BroadCastLeagueInformation(ctx, league, "league is opened", 0)
 
 	if err != nil {
 		level.Error(s.logger).Log("message", "could not save draft", "error", err)
 		return nil, err
 	}
+	go s.autoDraftIfEnabled(league.LeagueKey, league.CurrentPick)
+
 	return &league, err
 }
 
 func isUserCommissioner(ctx context.Context, league entities.League) bool {
-	userInterface := ctx.Value(auth.User)
-	reflect.TypeOf(userInterface)
-	user, ok := userInterface.(*userEntities.User)
+	user, ok := userFromContext(ctx)
 	if !ok {
 		return false
 	}
@@ -179,6 +246,38 @@ func isUserCommissioner(ctx context.Context, league entities.League) bool {
 	return false
 }
 
+func userFromContext(ctx context.Context) (*userEntities.User, bool) {
+	user, ok := ctx.Value(auth.User).(*userEntities.User)
+	return user, ok && user != nil
+}
+
+// isUserTeamManager reports whether the logged in user manages the team
+func isUserTeamManager(ctx context.Context, league entities.League, teamKey string) bool {
+	user, ok := userFromContext(ctx)
+	if !ok {
+		return false
+	}
+	team, ok := leagueTeam(league, teamKey)
+	if !ok {
+		return false
+	}
+	for _, manager := range team.Manager {
+		if user.GUID == manager.Guid {
+			return true
+		}
+	}
+	return false
+}
+
+func leagueTeam(league entities.League, teamKey string) (entities.Team, bool) {
+	for _, team := range league.Teams {
+		if team.TeamKey == teamKey {
+			return team, true
+		}
+	}
+	return entities.Team{}, false
+}
+
 func (s *Service) ShuffleOrder(ctx context.Context, leagueKey string) (*entities.League, error) {
 	span, ctx := apm.StartSpan(ctx, "OpenDraft", "service")
 	defer func() {
@@ -195,21 +294,26 @@ func (s *Service) ShuffleOrder(ctx context.Context, leagueKey string) (*entities
 		return nil, errors.New("user is not commissioner")
 	}
 
-	if league.DraftStarted {
-		_ = level.Error(s.logger).Log("message", "draft is started and could not change order", "error", errors.New("could not change order"))
+	err = checkCanChangeOrder(league)
+	if err != nil {
+		return nil, err
 	}
 
-	draftOrder := league.DraftOrder
-	rand.Seed(time.Now().UnixNano())
-	rand.Shuffle(len(draftOrder), func(i, j int) { draftOrder[i], draftOrder[j] = draftOrder[j], draftOrder[i] })
-	league.DraftOrder = draftOrder
+	// a shuffle is a uniform lottery, so it is saved with its seed like any other
+	lottery, err := drawLottery(league, nil, entities.LotteryUniform, "")
+	if err != nil {
+		level.Error(s.logger).Log("message", "could not draw draft order", "error", err)
+		return nil, err
+	}
+	lottery.DrawnBy = commissionerUser(ctx).Guid
+	keepLottery(&league, &lottery)
 
 	league, err = s.draftRepo.SaveLeague(ctx, league)
 	if err != nil {
 		level.Error(s.logger).Log("message", "could not save draft", "error", err)
 		return nil, err
 	}
-	err = s.broadCastRepo.BroadCastLeagueInformation(ctx, league, "draft order change", 0)
+	err = s.broadCastRepo.BroadCastLeagueInformation(ctx, league, "draft order change", entities.BroadCastTypeDraftOrder)
 
 	if err != nil {
 		level.Error(s.logger).Log("message", "could not save draft", "error", err)
@@ -218,23 +322,6 @@ func (s *Service) ShuffleOrder(ctx context.Context, leagueKey string) (*entities
 	return &league, err
 }
 
-// let numRounds = 0
-//        league.settings.roster_positions.map((position, idx) => {
-//            numRounds += position.count
-//        })
-//        return numRounds
-func getRound(pick int, league entities.League) float64 {
-	count := 0
-	positions := league.Settings.RosterPositions
-	for _, pos := range positions {
-		count += pos.Count
-	}
-	something := float64(pick) / float64(len(league.DraftOrder))
-	roundMod := math.Ceil(something)
-
-	return roundMod
-}
-
 func (service *Service) SaveDraftRequest(ctx context.Context, user entities.User, reqKey entities.League, team entities.Team, player entities.PlayerSeason, pick int) (*entities.DraftResult, error) {
 	span, ctx := apm.StartSpan(ctx, "SaveDraftRequest", "service")
 	span.Context.SetLabel("user_id", user.Guid)
@@ -248,28 +335,134 @@ func (service *Service) SaveDraftRequest(ctx context.Context, user entities.User
 		level.Error(service.logger).Log("message", "could not get key", "err", err, "league_key", reqKey.LeagueKey)
 		return nil, err
 	}
+	results, err := service.draftRepo.GetDraftResults(ctx, league.LeagueKey)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not get draft results", "error", err, "league_key", league.LeagueKey)
+		return nil, err
+	}
+
+	err = validatePick(league, results, team.TeamKey, player.PlayerKey, pick)
+	if err != nil {
+		level.Debug(service.logger).Log("message", "pick rejected", "error", err, "league_key", league.LeagueKey, "team_key", team.TeamKey, "pick", pick)
+		return nil, err
+	}
+	if !isUserTeamManager(ctx, league, team.TeamKey) && !isUserCommissioner(ctx, league) {
+		return nil, &ErrorNotTeamManager{teamKey: team.TeamKey}
+	}
+	team, _ = leagueTeam(league, team.TeamKey)
+
+	// the client's copy of the player is only trusted for its key
+	player, err = service.getPlayer(ctx, player.PlayerKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.savePick(ctx, league, user, team, player, pick)
+}
+
+// getPlayer loads a player from the database rather than trusting a client's copy
+func (service *Service) getPlayer(ctx context.Context, playerKey string) (entities.PlayerSeason, error) {
+	players, err := service.draftRepo.GetPlayers(ctx, []string{playerKey})
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not get player", "error", err, "player_key", playerKey)
+		return entities.PlayerSeason{}, err
+	}
+	players = orderByPlayerKeys(players, []string{playerKey})
+	if len(players) == 0 {
+		return entities.PlayerSeason{}, &ErrorUnknownPlayer{playerKey: playerKey}
+	}
+	return players[0], nil
+}
+
+// savePick is the single path every pick takes, manual or automatic: persist it,
+// move the clock on, broadcast it and let an autodrafting team on the clock pick
+func (service *Service) savePick(ctx context.Context, league entities.League, user entities.User, team entities.Team, player entities.PlayerSeason, pick int) (*entities.DraftResult, error) {
+	span, ctx := apm.StartSpan(ctx, "savePick", "service")
+	defer span.End()
+
 	round := getRound(pick, league)
 
-	draftResult, err := service.draftRepo.SaveDraftResultFromUser(ctx, league, user, team, player, pick, int(round))
+	draftResult, err := service.draftRepo.SaveDraftResultFromUser(ctx, league, user, team, player, pick, round)
+	if errors.Is(err, entities.ErrPickConflict) {
+		level.Debug(service.logger).Log("message", "lost the race for a pick", "league_key", league.LeagueKey, "pick", pick, "player_key", player.PlayerKey)
+		return nil, &ErrorPickConflict{pick: pick}
+	}
 	if err != nil {
 		level.Error(service.logger).Log("message", "error in updating draft result", "error", err)
 
 		return nil, &ErrorUpdateDraft{}
 	}
+	service.advancePick(ctx, &league, pick)
 
 	rosters, err := service.buildRosters(ctx, league)
 	if err != nil {
 		return nil, err
 	}
 
-	err = service.broadCastRepo.BroadCastDraftResult(ctx, league, user, team, *draftResult, pick, int(round), rosters)
+	err = service.broadCastRepo.BroadCastDraftResult(ctx, league, user, team, *draftResult, pick, round, rosters)
 	if err != nil {
 		level.Error(service.logger).Log("message", "error in broadcasting", "error", err)
 		return nil, &ErrorUpdateDraft{}
 	}
+	service.completeIfFull(ctx, &league)
+	go service.autoDraftIfEnabled(league.LeagueKey, league.CurrentPick)
+
 	return draftResult, err
 }
 
+// UpdateDraftSettings saves the commissioner controlled draft settings and applies a
+// changed pick clock to a draft that is already running
+func (service *Service) UpdateDraftSettings(ctx context.Context, leagueKey string, settings entities.DraftSettings) (*entities.League, error) {
+	span, ctx := apm.StartSpan(ctx, "UpdateDraftSettings", "service")
+	defer span.End()
+
+	if settings.PickSeconds < 0 {
+		return nil, errors.New("pick seconds can not be negative")
+	}
+	if settings.Keepers.Count < 0 {
+		return nil, errors.New("keeper count can not be negative")
+	}
+	switch settings.ExpiryAction {
+	case "":
+		settings.ExpiryAction = entities.ClockExpirySkip
+	case entities.ClockExpirySkip, entities.ClockExpiryAutoPick:
+	default:
+		return nil, fmt.Errorf("unknown clock expiry action %q", settings.ExpiryAction)
+	}
+	switch settings.OrderType {
+	case "":
+		settings.OrderType = entities.DraftOrderSnake
+	case entities.DraftOrderSnake, entities.DraftOrderLinear, entities.DraftOrderThirdRoundReversal:
+	default:
+		return nil, fmt.Errorf("unknown draft order type %q", settings.OrderType)
+	}
+
+	league, err := service.draftRepo.GetLeague(ctx, leagueKey)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not get league", "error", err, "league_key", leagueKey)
+		return nil, err
+	}
+	if !isUserCommissioner(ctx, league) {
+		return nil, errors.New("user is not commissioner")
+	}
+
+	err = service.draftRepo.SaveDraftSettings(ctx, leagueKey, settings)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not save draft settings", "error", err, "league_key", leagueKey)
+		return nil, err
+	}
+	league.DraftSettings = settings
+
+	if league.State().CanPick() {
+		if settings.HasPickClock() {
+			service.startPickClock(&league)
+		} else {
+			service.clock.stop(leagueKey)
+		}
+	}
+	return &league, nil
+}
+
 func (service *Service) SaveUserPlayerPreference(ctx context.Context, preference entities.UserPlayerPreference) error {
 	span, ctx := apm.StartSpan(ctx, "SaveUserPlayerPreference", "service")
 	defer func(span *apm.Span) {
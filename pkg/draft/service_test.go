@@ -1,9 +1,15 @@
 package draft
 
 import (
+	"context"
+	"errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/thethan/fdr-users/pkg/auth"
 	"github.com/thethan/fdr-users/pkg/draft/entities"
+	userEntities "github.com/thethan/fdr-users/pkg/users/entities"
+	"sync"
 	"testing"
+	"time"
 )
 
 func Test_getRound(t *testing.T) {
@@ -87,4 +93,572 @@ func Test_getRound(t *testing.T) {
 	
 	round = getRound(11, league)
 	assert.Equal(t, 2, round)
-}
\ No newline at end of file
+}
+type fakeDraftRepository struct {
+	mu          *sync.Mutex
+	leagues     map[string]entities.League
+	results     map[string][]entities.DraftResult
+	preferences map[string]entities.UserPlayerPreference
+	players     []entities.PlayerSeason
+	mocks       map[string]entities.MockDraft
+	events      map[string][]entities.DraftEvent
+	chats       map[string][]entities.ChatMessage
+}
+
+func newFakeDraftRepository(leagues ...entities.League) *fakeDraftRepository {
+	repo := &fakeDraftRepository{
+		mu:          &sync.Mutex{},
+		leagues:     make(map[string]entities.League),
+		results:     make(map[string][]entities.DraftResult),
+		preferences: make(map[string]entities.UserPlayerPreference),
+		mocks:       make(map[string]entities.MockDraft),
+		events:      make(map[string][]entities.DraftEvent),
+		chats:       make(map[string][]entities.ChatMessage),
+	}
+	for _, league := range leagues {
+		repo.leagues[league.LeagueKey] = league
+	}
+	return repo
+}
+
+func (f *fakeDraftRepository) GetDraftResults(ctx context.Context, leagueKey string) ([]entities.DraftResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]entities.DraftResult{}, f.results[leagueKey]...), nil
+}
+
+func (f *fakeDraftRepository) GetLeague(ctx context.Context, leagueKey string) (entities.League, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	league, ok := f.leagues[leagueKey]
+	if !ok {
+		return entities.League{}, errors.New("league not found")
+	}
+	return league, nil
+}
+
+func (f *fakeDraftRepository) SaveLeague(ctx context.Context, league entities.League) (entities.League, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.leagues[league.LeagueKey] = league
+	return league, nil
+}
+
+func (f *fakeDraftRepository) ImportAllAvailablePlayers(ctx context.Context, gameID int, leagueKey string) error {
+	return nil
+}
+
+func (f *fakeDraftRepository) SaveDraftResultFromUser(ctx context.Context, league entities.League, user entities.User, team entities.Team, player entities.PlayerSeason, pick, round int) (*entities.DraftResult, error) {
+	return f.saveDraftResult(league, fakeDraftResult(league, user, team.TeamKey, player, pick, round))
+}
+
+func (f *fakeDraftRepository) SaveAuctionResult(ctx context.Context, league entities.League, user entities.User, team entities.Team, player entities.PlayerSeason, pick, cost int) (*entities.DraftResult, error) {
+	result := fakeDraftResult(league, user, team.TeamKey, player, pick, 0)
+	result.Cost = cost
+	return f.saveDraftResult(league, result)
+}
+
+func (f *fakeDraftRepository) SaveKeeperResult(ctx context.Context, league entities.League, user entities.User, keeper entities.Keeper, player entities.PlayerSeason) (*entities.DraftResult, error) {
+	result := fakeDraftResult(league, user, keeper.TeamKey, player, keeper.Pick, keeper.Round)
+	result.Keeper = true
+	league.CurrentPick = 0
+	return f.saveDraftResult(league, result)
+}
+
+func fakeDraftResult(league entities.League, user entities.User, teamKey string, player entities.PlayerSeason, pick, round int) entities.DraftResult {
+	return entities.DraftResult{
+		UserGUID:  user.Guid,
+		PlayerKey: player.PlayerKey,
+		PlayerID:  player.PlayerID,
+		LeagueKey: league.LeagueKey,
+		TeamKey:   teamKey,
+		Round:     round,
+		Pick:      pick,
+		Timestamp: time.Now(),
+		Player:    []*entities.PlayerSeason{&player},
+	}
+}
+
+func (f *fakeDraftRepository) saveDraftResult(league entities.League, result entities.DraftResult) (*entities.DraftResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	pick := result.Pick
+	// mirrors the mongo repository: claim the current pick, then the unique indexes on pick and player
+	for _, saved := range f.results[league.LeagueKey] {
+		if saved.Pick == pick || saved.PlayerKey == result.PlayerKey {
+			return nil, entities.ErrPickConflict
+		}
+	}
+	if league.CurrentPick == pick {
+		if err := f.advanceCurrentPick(league.LeagueKey, pick); err != nil {
+			return nil, err
+		}
+	}
+	f.results[league.LeagueKey] = append(f.results[league.LeagueKey], result)
+	return &result, nil
+}
+
+func (f *fakeDraftRepository) DeleteDraftResult(ctx context.Context, leagueKey string, pick int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	results := f.results[leagueKey]
+	for idx := range results {
+		if results[idx].Pick == pick {
+			f.results[leagueKey] = append(results[:idx:idx], results[idx+1:]...)
+			return nil
+		}
+	}
+	return errors.New("draft result not found")
+}
+
+func (f *fakeDraftRepository) ReplaceDraftResultPlayer(ctx context.Context, leagueKey string, pick int, player entities.PlayerSeason) (*entities.DraftResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	results := f.results[leagueKey]
+	for _, result := range results {
+		if result.PlayerKey == player.PlayerKey {
+			return nil, entities.ErrPickConflict
+		}
+	}
+	for idx := range results {
+		if results[idx].Pick == pick {
+			results[idx].PlayerKey = player.PlayerKey
+			results[idx].PlayerID = player.PlayerID
+			results[idx].Player = []*entities.PlayerSeason{&player}
+			result := results[idx]
+			return &result, nil
+		}
+	}
+	return nil, errors.New("draft result not found")
+}
+
+func (f *fakeDraftRepository) AdvanceCurrentPick(ctx context.Context, leagueKey string, pick int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.advanceCurrentPick(leagueKey, pick)
+}
+
+func (f *fakeDraftRepository) advanceCurrentPick(leagueKey string, pick int) error {
+	league := f.leagues[leagueKey]
+	if league.CurrentPick != pick {
+		return entities.ErrPickConflict
+	}
+	league.CurrentPick = pick + 1
+	f.leagues[leagueKey] = league
+	return nil
+}
+
+func (f *fakeDraftRepository) GetTeamDraftResultsByTeam(ctx context.Context, leagueKey string) (map[string][]entities.DraftResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	teams := make(map[string][]entities.DraftResult)
+	for _, result := range f.results[leagueKey] {
+		teams[result.TeamKey] = append(teams[result.TeamKey], result)
+	}
+	return teams, nil
+}
+
+func (f *fakeDraftRepository) SaveUserPlayerPreference(ctx context.Context, preference entities.UserPlayerPreference) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.preferences[preference.UserID+preference.LeagueKey] = preference
+	return nil
+}
+
+func (f *fakeDraftRepository) GetUserPlayerPreference(ctx context.Context, userGUID, leagueKey string) (entities.UserPlayerPreference, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.preferences[userGUID+leagueKey], nil
+}
+
+func (f *fakeDraftRepository) SaveDraftSettings(ctx context.Context, leagueKey string, settings entities.DraftSettings) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	league := f.leagues[leagueKey]
+	league.DraftSettings = settings
+	f.leagues[leagueKey] = league
+	return nil
+}
+
+func (f *fakeDraftRepository) SaveDraftState(ctx context.Context, leagueKey string, state entities.DraftState) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	league := f.leagues[leagueKey]
+	league.SetState(state)
+	f.leagues[leagueKey] = league
+	return nil
+}
+
+func (f *fakeDraftRepository) SaveTeamKeepers(ctx context.Context, leagueKey, teamKey string, keepers []entities.Keeper) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	league := f.leagues[leagueKey]
+	kept := make([]entities.Keeper, 0, len(league.Keepers)+len(keepers))
+	for _, keeper := range league.Keepers {
+		if keeper.TeamKey != teamKey {
+			kept = append(kept, keeper)
+		}
+	}
+	league.Keepers = append(kept, keepers...)
+	f.leagues[leagueKey] = league
+	return nil
+}
+
+func (f *fakeDraftRepository) SavePickTrade(ctx context.Context, leagueKey string, trade entities.PickTrade) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	league := f.leagues[leagueKey]
+	league.PickTrades = append(league.PickTrades, trade)
+	f.leagues[leagueKey] = league
+	return nil
+}
+
+func (f *fakeDraftRepository) DecidePickTrade(ctx context.Context, leagueKey string, trade entities.PickTrade, owners []entities.PickOwner) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	league := f.leagues[leagueKey]
+	for idx := range league.PickTrades {
+		if league.PickTrades[idx].ID != trade.ID {
+			continue
+		}
+		if league.PickTrades[idx].Status != entities.PickTradePending {
+			return entities.ErrTradeNotPending
+		}
+		league.PickTrades = append([]entities.PickTrade{}, league.PickTrades...)
+		league.PickTrades[idx] = trade
+		if owners != nil {
+			league.PickOwners = owners
+		}
+		f.leagues[leagueKey] = league
+		return nil
+	}
+	return entities.ErrTradeNotPending
+}
+
+func (f *fakeDraftRepository) SaveCurrentPick(ctx context.Context, leagueKey string, pick int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	league := f.leagues[leagueKey]
+	league.CurrentPick = pick
+	f.leagues[leagueKey] = league
+	return nil
+}
+
+func (f *fakeDraftRepository) GetPlayers(ctx context.Context, playerKeys []string) ([]entities.PlayerSeason, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	wanted := make(map[string]bool, len(playerKeys))
+	for _, playerKey := range playerKeys {
+		wanted[playerKey] = true
+	}
+	players := make([]entities.PlayerSeason, 0, len(playerKeys))
+	for _, player := range f.players {
+		if wanted[player.PlayerKey] {
+			players = append(players, player)
+		}
+	}
+	return players, nil
+}
+
+func (f *fakeDraftRepository) GetAvailablePlayersByRank(ctx context.Context, leagueKey string, limit, offset int) ([]entities.PlayerSeason, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	drafted := make(map[string]bool)
+	for _, result := range f.results[leagueKey] {
+		drafted[result.PlayerKey] = true
+	}
+	available := make([]entities.PlayerSeason, 0, len(f.players))
+	for _, player := range f.players {
+		if !drafted[player.PlayerKey] && player.Ranks["yahoo"] > 0 {
+			available = append(available, player)
+		}
+	}
+	sortByRank(available, "yahoo")
+	start := offset * limit
+	if start >= len(available) {
+		return []entities.PlayerSeason{}, nil
+	}
+	end := start + limit
+	if end > len(available) {
+		end = len(available)
+	}
+	return available[start:end], nil
+}
+
+func (f *fakeDraftRepository) GetPlayersByGameRank(ctx context.Context, gameID, limit, offset int) ([]entities.PlayerSeason, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ranked := make([]entities.PlayerSeason, 0, len(f.players))
+	for _, player := range f.players {
+		if player.Ranks["yahoo"] > 0 {
+			ranked = append(ranked, player)
+		}
+	}
+	sortByRank(ranked, "yahoo")
+	start := offset * limit
+	if start >= len(ranked) {
+		return []entities.PlayerSeason{}, nil
+	}
+	end := start + limit
+	if end > len(ranked) {
+		end = len(ranked)
+	}
+	return ranked[start:end], nil
+}
+
+func (f *fakeDraftRepository) CreateMockDraft(ctx context.Context, mock entities.MockDraft) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.mocks[mock.ID] = mock
+	return nil
+}
+
+func (f *fakeDraftRepository) GetMockDraft(ctx context.Context, mockID string) (entities.MockDraft, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	mock, ok := f.mocks[mockID]
+	if !ok {
+		return mock, entities.ErrMockDraftNotFound
+	}
+	return mock, nil
+}
+
+func (f *fakeDraftRepository) ListMockDrafts(ctx context.Context, leagueKey, userGUID string) ([]entities.MockDraft, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	mocks := make([]entities.MockDraft, 0)
+	for _, mock := range f.mocks {
+		if mock.LeagueKey == leagueKey && mock.UserGUID == userGUID {
+			mocks = append(mocks, mock)
+		}
+	}
+	return mocks, nil
+}
+
+func (f *fakeDraftRepository) SaveMockDraftPicks(ctx context.Context, mock entities.MockDraft, fromPick int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.mocks[mock.ID].CurrentPick != fromPick {
+		return entities.ErrMockDraftChanged
+	}
+	f.mocks[mock.ID] = mock
+	return nil
+}
+
+func (f *fakeDraftRepository) GetDraftEvents(ctx context.Context, leagueKey string) ([]entities.DraftEvent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]entities.DraftEvent{}, f.events[leagueKey]...), nil
+}
+
+func (f *fakeDraftRepository) SaveChatMessage(ctx context.Context, message entities.ChatMessage) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.chats[message.LeagueKey] = append(f.chats[message.LeagueKey], message)
+	return nil
+}
+
+func (f *fakeDraftRepository) GetChatMessage(ctx context.Context, leagueKey, messageID string) (entities.ChatMessage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, message := range f.chats[leagueKey] {
+		if message.ID == messageID {
+			return message, nil
+		}
+	}
+	return entities.ChatMessage{}, entities.ErrChatMessageNotFound
+}
+
+func (f *fakeDraftRepository) GetChatMessages(ctx context.Context, leagueKey, before string, limit int) ([]entities.ChatMessage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	messages := make([]entities.ChatMessage, 0, limit)
+	for idx := len(f.chats[leagueKey]) - 1; idx >= 0 && len(messages) < limit; idx-- {
+		if message := f.chats[leagueKey][idx]; before == "" || message.ID < before {
+			messages = append(messages, message)
+		}
+	}
+	return messages, nil
+}
+
+func (f *fakeDraftRepository) DeleteChatMessage(ctx context.Context, leagueKey, messageID, deletedBy string, deletedAt time.Time) (entities.ChatMessage, error) {
+	return f.updateChat(leagueKey, messageID, func(message *entities.ChatMessage) {
+		message.Text = ""
+		message.DeletedAt = &deletedAt
+		message.DeletedBy = deletedBy
+	})
+}
+
+func (f *fakeDraftRepository) SaveChatReaction(ctx context.Context, leagueKey, messageID string, reaction entities.ChatReaction, add bool) (entities.ChatMessage, error) {
+	return f.updateChat(leagueKey, messageID, func(message *entities.ChatMessage) {
+		reactions := []entities.ChatReaction{}
+		for _, existing := range message.Reactions {
+			if existing != reaction {
+				reactions = append(reactions, existing)
+			}
+		}
+		if add {
+			reactions = append(reactions, reaction)
+		}
+		message.Reactions = reactions
+	})
+}
+
+func (f *fakeDraftRepository) updateChat(leagueKey, messageID string, update func(message *entities.ChatMessage)) (entities.ChatMessage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for idx := range f.chats[leagueKey] {
+		if f.chats[leagueKey][idx].ID == messageID {
+			update(&f.chats[leagueKey][idx])
+			return f.chats[leagueKey][idx], nil
+		}
+	}
+	return entities.ChatMessage{}, entities.ErrChatMessageNotFound
+}
+
+func (f *fakeDraftRepository) SaveChatMutes(ctx context.Context, leagueKey string, mutes []entities.ChatMute) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	league := f.leagues[leagueKey]
+	league.ChatMutes = mutes
+	f.leagues[leagueKey] = league
+	return nil
+}
+
+type fakeBroadcast struct {
+	Type       entities.BroadcastType
+	Message    string
+	League     entities.League
+	Result     entities.DraftResult
+	Correction *entities.DraftCorrection
+	Rosters    map[string]entities.Roster
+	Lot        *entities.AuctionLot
+	Grades     *entities.DraftReport
+	Chat       *entities.ChatMessage
+	User       entities.User
+	Team       entities.Team
+	Lottery    *entities.LotteryStep
+}
+
+type fakeBroadcaster struct {
+	mu         *sync.Mutex
+	broadcasts []fakeBroadcast
+}
+
+func newFakeBroadcaster() *fakeBroadcaster {
+	return &fakeBroadcaster{mu: &sync.Mutex{}}
+}
+
+func (f *fakeBroadcaster) BroadCastDraftResult(ctx context.Context, league entities.League, user entities.User, team entities.Team, draftResult entities.DraftResult, pick, round int, rosters map[string]entities.Roster) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.broadcasts = append(f.broadcasts, fakeBroadcast{Type: entities.BroadCastTypePlayerDrafted, League: league, Result: draftResult})
+	return nil
+}
+
+func (f *fakeBroadcaster) BroadCastLeagueInformation(ctx context.Context, league entities.League, message string, broadcastType entities.BroadcastType) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.broadcasts = append(f.broadcasts, fakeBroadcast{Type: broadcastType, Message: message, League: league})
+	return nil
+}
+
+func (f *fakeBroadcaster) BroadCastDraftCorrection(ctx context.Context, league entities.League, user entities.User, correction entities.DraftCorrection, rosters map[string]entities.Roster) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.broadcasts = append(f.broadcasts, fakeBroadcast{Type: entities.BroadCastTypePickCorrected, League: league, Correction: &correction, Rosters: rosters})
+	return nil
+}
+
+func (f *fakeBroadcaster) BroadCastAuction(ctx context.Context, league entities.League, broadcastType entities.BroadcastType, lot entities.AuctionLot, draftResult *entities.DraftResult, rosters map[string]entities.Roster) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	broadcast := fakeBroadcast{Type: broadcastType, League: league, Rosters: rosters, Lot: &lot}
+	if draftResult != nil {
+		broadcast.Result = *draftResult
+	}
+	f.broadcasts = append(f.broadcasts, broadcast)
+	return nil
+}
+
+func (f *fakeBroadcaster) BroadCastDraftGrades(ctx context.Context, league entities.League, report entities.DraftReport) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.broadcasts = append(f.broadcasts, fakeBroadcast{Type: entities.BroadCastTypeDraftGraded, League: league, Grades: &report})
+	return nil
+}
+
+func (f *fakeBroadcaster) BroadCastChatMessage(ctx context.Context, league entities.League, message entities.ChatMessage) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.broadcasts = append(f.broadcasts, fakeBroadcast{Type: entities.BroadCastTypeChatMessage, League: league, Chat: &message})
+	return nil
+}
+
+func (f *fakeBroadcaster) BroadCastPresence(ctx context.Context, league entities.League, broadcastType entities.BroadcastType, user entities.User, team entities.Team) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.broadcasts = append(f.broadcasts, fakeBroadcast{Type: broadcastType, League: league, User: user, Team: team})
+	return nil
+}
+
+func (f *fakeBroadcaster) BroadCastLotteryDraw(ctx context.Context, league entities.League, step entities.LotteryStep) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.broadcasts = append(f.broadcasts, fakeBroadcast{Type: entities.BroadCastTypeLotteryDraw, League: league, Lottery: &step})
+	return nil
+}
+
+func (f *fakeBroadcaster) last() fakeBroadcast {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.broadcasts[len(f.broadcasts)-1]
+}
+
+func (f *fakeBroadcaster) ChangeTeamName(ctx context.Context, league entities.League, user entities.User, team entities.Team) error {
+	return nil
+}
+
+func (f *fakeBroadcaster) types() []entities.BroadcastType {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	types := make([]entities.BroadcastType, len(f.broadcasts))
+	for idx := range f.broadcasts {
+		types[idx] = f.broadcasts[idx].Type
+	}
+	return types
+}
+
+// testLeague is a four team league with a QB, two RB, WR, W/R/T and two BN roster
+func testLeague() entities.League {
+	return entities.League{
+		LeagueKey:  "399.l.1",
+		DraftOrder: []string{"399.l.1.t.1", "399.l.1.t.2", "399.l.1.t.3", "399.l.1.t.4"},
+		Teams: []entities.Team{
+			{TeamKey: "399.l.1.t.1", Manager: []entities.User{{Guid: "commish", IsCommissioner: true}}},
+			{TeamKey: "399.l.1.t.2", Manager: []entities.User{{Guid: "manager-2"}}},
+			{TeamKey: "399.l.1.t.3", Manager: []entities.User{{Guid: "manager-3"}}},
+			{TeamKey: "399.l.1.t.4", Manager: []entities.User{{Guid: "manager-4"}}},
+		},
+		Settings: &entities.LeagueSettings{
+			RosterPositions: []entities.RosterPosition{
+				{Position: "QB", Count: 1}, {Position: "RB", Count: 2}, {Position: "WR", Count: 1},
+				{Position: "W/R/T", Count: 1}, {Position: "BN", Count: 2},
+			},
+		},
+	}
+}
+
+func commissionerContext() context.Context {
+	return context.WithValue(context.Background(), auth.User, &userEntities.User{GUID: "commish"})
+}
+
+func testPlayer(key string, positions ...string) entities.PlayerSeason {
+	return entities.PlayerSeason{PlayerKey: key, EligiblePositions: positions}
+}
+
+func rankedPlayer(key string, rank int, positions ...string) entities.PlayerSeason {
+	player := testPlayer(key, positions...)
+	player.Ranks = entities.PlayerRanks{"yahoo": rank}
+	return player
+}
@@ -0,0 +1,236 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/gorilla/websocket"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultHeartbeat = 15 * time.Second
+	DefaultBuffer    = 64
+
+	writeTimeout = 10 * time.Second
+)
+
+// Event is one broadcast on a league's draft channel. Sequence orders the events of a league
+// and is what a client sends back to resume after a reconnect.
+type Event struct {
+	Sequence uint64
+	// Data is the broadcast exactly as it was published, a JSON encoded draft room message
+	Data []byte
+}
+
+// Source delivers a league's draft events in order, starting with the first event after sequence
+// after, or with new events when after is zero. The channel is closed once ctx is done or the
+// subscription fails.
+type Source interface {
+	Subscribe(ctx context.Context, leagueKey string, after uint64) (<-chan Event, error)
+}
+
+// Gateway streams a league's draft room broadcasts to browsers over Server-Sent Events or a WebSocket.
+// Every connection has its own subscription to the source starting at the client's last seen event,
+// so replay after a reconnect comes from the source rather than from memory on this server.
+type Gateway struct {
+	logger log.Logger
+	source Source
+	// Heartbeat is how often a connection is pinged, so proxies keep an idle draft room open
+	Heartbeat time.Duration
+	// Buffer is how many events can wait for a slow client. A client that falls further behind
+	// is disconnected and resumes from its last event when it reconnects.
+	Buffer   int
+	upgrader websocket.Upgrader
+}
+
+func NewGateway(logger log.Logger, source Source) *Gateway {
+	return &Gateway{
+		logger:    logger,
+		source:    source,
+		Heartbeat: DefaultHeartbeat,
+		Buffer:    DefaultBuffer,
+		upgrader: websocket.Upgrader{
+			// the bearer token authenticates the connection, not the origin
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+// subscription is one client's view of a league's events
+type subscription struct {
+	events <-chan Event
+	// overflowed is closed when the client fell more than Buffer events behind
+	overflowed chan struct{}
+}
+
+// subscribe starts a subscription that never blocks the source on a slow client
+func (g *Gateway) subscribe(ctx context.Context, cancel context.CancelFunc, leagueKey string, after uint64) (*subscription, error) {
+	upstream, err := g.source.Subscribe(ctx, leagueKey, after)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan Event, g.Buffer)
+	sub := &subscription{events: events, overflowed: make(chan struct{})}
+	go func() {
+		defer close(events)
+		for event := range upstream {
+			select {
+			case events <- event:
+			default:
+				level.Info(g.logger).Log("message", "draft stream client fell behind, disconnecting", "league_key", leagueKey, "sequence", event.Sequence)
+				close(sub.overflowed)
+				cancel()
+				return
+			}
+		}
+	}()
+	return sub, nil
+}
+
+// ServeSSE streams a league's events as Server-Sent Events. The event id is the sequence, so a browser's
+// EventSource resumes on its own by sending Last-Event-ID when it reconnects.
+func (g *Gateway) ServeSSE(w http.ResponseWriter, r *http.Request, leagueKey string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	after, err := lastEventID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	sub, err := g.subscribe(ctx, cancel, leagueKey, after)
+	if err != nil {
+		level.Error(g.logger).Log("message", "could not subscribe to draft events", "error", err, "league_key", leagueKey)
+		http.Error(w, "could not subscribe to draft events", http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// nginx buffers responses unless told otherwise
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(g.Heartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case event, ok := <-sub.events:
+			if !ok {
+				return
+			}
+			if _, err = fmt.Fprintf(w, "id: %d\ndata: %s\n\n", event.Sequence, event.Data); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err = fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// message is how an event is framed on a WebSocket
+type message struct {
+	ID   uint64          `json:"id"`
+	Data json.RawMessage `json:"data"`
+}
+
+// ServeWebSocket streams a league's events over a WebSocket. Clients resume by connecting with
+// the last_event_id query parameter set to the id of the last message they handled.
+func (g *Gateway) ServeWebSocket(w http.ResponseWriter, r *http.Request, leagueKey string) {
+	after, err := lastEventID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	conn, err := g.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		level.Debug(g.logger).Log("message", "could not upgrade draft stream", "error", err, "league_key", leagueKey)
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	sub, err := g.subscribe(ctx, cancel, leagueKey, after)
+	if err != nil {
+		level.Error(g.logger).Log("message", "could not subscribe to draft events", "error", err, "league_key", leagueKey)
+		g.closeWebSocket(conn, websocket.CloseInternalServerErr, "could not subscribe to draft events")
+		return
+	}
+
+	// the read loop handles pongs and notices the client going away; clients have nothing to send
+	_ = conn.SetReadDeadline(time.Now().Add(2 * g.Heartbeat))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * g.Heartbeat))
+	})
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(g.Heartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case event, ok := <-sub.events:
+			if !ok {
+				select {
+				case <-sub.overflowed:
+					g.closeWebSocket(conn, websocket.CloseTryAgainLater, "client fell behind, reconnect with last_event_id")
+				default:
+					g.closeWebSocket(conn, websocket.CloseGoingAway, "draft stream closed")
+				}
+				return
+			}
+			_ = conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err = conn.WriteJSON(message{ID: event.Sequence, Data: event.Data}); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if err = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (g *Gateway) closeWebSocket(conn *websocket.Conn, code int, text string) {
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, text), time.Now().Add(writeTimeout))
+}
+
+// lastEventID is the sequence a client last saw, from the Last-Event-ID header an EventSource sends
+// or the last_event_id query parameter. Zero means the client wants new events only.
+func lastEventID(r *http.Request) (uint64, error) {
+	id := r.Header.Get("Last-Event-ID")
+	if id == "" {
+		id = r.URL.Query().Get("last_event_id")
+	}
+	if id == "" {
+		return 0, nil
+	}
+	sequence, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("last event id %q is not a sequence", id)
+	}
+	return sequence, nil
+}
@@ -0,0 +1,204 @@
+package stream
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"github.com/go-kit/kit/log"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSource replays every event after the requested sequence, then passes on whatever is published
+type fakeSource struct {
+	mu          *sync.Mutex
+	history     []Event
+	subscribers []chan Event
+}
+
+func newFakeSource() *fakeSource {
+	return &fakeSource{mu: &sync.Mutex{}}
+}
+
+func (f *fakeSource) Subscribe(ctx context.Context, leagueKey string, after uint64) (<-chan Event, error) {
+	f.mu.Lock()
+	var replay []Event
+	for _, event := range f.history {
+		if event.Sequence > after {
+			replay = append(replay, event)
+		}
+	}
+	live := make(chan Event, 16)
+	f.subscribers = append(f.subscribers, live)
+	f.mu.Unlock()
+
+	events := make(chan Event)
+	go func() {
+		defer close(events)
+		for _, event := range replay {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+		for {
+			select {
+			case event := <-live:
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
+
+func (f *fakeSource) publish(data string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	event := Event{Sequence: uint64(len(f.history) + 1), Data: []byte(data)}
+	f.history = append(f.history, event)
+	for _, subscriber := range f.subscribers {
+		subscriber <- event
+	}
+}
+
+func (f *fakeSource) waitForSubscribers(t *testing.T, count int) {
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		f.mu.Lock()
+		subscribed := len(f.subscribers)
+		f.mu.Unlock()
+		if subscribed >= count {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("no subscriber after a second")
+}
+
+func TestGateway_ServeSSE(t *testing.T) {
+	source := newFakeSource()
+	for idx := 1; idx <= 3; idx++ {
+		source.publish(fmt.Sprintf(`{"message":"event %d"}`, idx))
+	}
+	gateway := NewGateway(log.NewNopLogger(), source)
+	gateway.Heartbeat = 20 * time.Millisecond
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gateway.ServeSSE(w, r, "399.l.1")
+	}))
+	defer server.Close()
+
+	request, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	request.Header.Set("Last-Event-ID", "1")
+	response, err := http.DefaultClient.Do(request)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer response.Body.Close()
+	assert.Equal(t, "text/event-stream", response.Header.Get("Content-Type"))
+
+	lines := bufio.NewScanner(response.Body)
+	readUntil := func(want string) {
+		for lines.Scan() {
+			if lines.Text() == want {
+				return
+			}
+		}
+		t.Fatalf("stream ended before %q", want)
+	}
+
+	readUntil("id: 2")
+	readUntil(`data: {"message":"event 2"}`)
+	readUntil("id: 3")
+
+	source.waitForSubscribers(t, 1)
+	source.publish(`{"message":"event 4"}`)
+	readUntil("id: 4")
+	readUntil(": heartbeat")
+}
+
+func TestGateway_ServeWebSocket(t *testing.T) {
+	source := newFakeSource()
+	source.publish(`{"message":"event 1"}`)
+	source.publish(`{"message":"event 2"}`)
+	gateway := NewGateway(log.NewNopLogger(), source)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gateway.ServeWebSocket(w, r, "399.l.1")
+	}))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"?last_event_id=1", nil)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer conn.Close()
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+
+	var received message
+	assert.Nil(t, conn.ReadJSON(&received))
+	assert.Equal(t, uint64(2), received.ID)
+	assert.JSONEq(t, `{"message":"event 2"}`, string(received.Data))
+
+	source.waitForSubscribers(t, 1)
+	source.publish(`{"message":"event 3"}`)
+	assert.Nil(t, conn.ReadJSON(&received))
+	assert.Equal(t, uint64(3), received.ID)
+}
+
+func TestGateway_subscribe_DisconnectsSlowClients(t *testing.T) {
+	source := newFakeSource()
+	for idx := 1; idx <= 10; idx++ {
+		source.publish(fmt.Sprintf(`{"message":"event %d"}`, idx))
+	}
+	gateway := NewGateway(log.NewNopLogger(), source)
+	gateway.Buffer = 2
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sub, err := gateway.subscribe(ctx, cancel, "399.l.1", 0)
+	assert.Nil(t, err)
+
+	select {
+	case <-sub.overflowed:
+	case <-time.After(time.Second):
+		t.Fatal("a client that never reads was not disconnected")
+	}
+	assert.NotNil(t, ctx.Err(), "the source subscription is cancelled")
+
+	// what was buffered is still delivered before the channel closes
+	var delivered []uint64
+	for event := range sub.events {
+		delivered = append(delivered, event.Sequence)
+	}
+	assert.Equal(t, []uint64{1, 2}, delivered)
+}
+
+func Test_lastEventID(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "/?last_event_id=7", nil)
+	sequence, err := lastEventID(request)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(7), sequence)
+
+	request.Header.Set("Last-Event-ID", "9")
+	sequence, _ = lastEventID(request)
+	assert.Equal(t, uint64(9), sequence, "the EventSource header wins")
+
+	_, err = lastEventID(httptest.NewRequest(http.MethodGet, "/?last_event_id=abc", nil))
+	assert.NotNil(t, err)
+
+	sequence, err = lastEventID(httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), sequence)
+}
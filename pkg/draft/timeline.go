@@ -0,0 +1,163 @@
+package draft
+
+import (
+	"context"
+	"github.com/go-kit/kit/log/level"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"go.elastic.co/apm"
+	"sort"
+	"time"
+)
+
+// DraftTimeline returns every logged event of a league's draft in order, with how long each pick took
+func (service *Service) DraftTimeline(ctx context.Context, leagueKey string) (*entities.DraftTimeline, error) {
+	span, ctx := apm.StartSpan(ctx, "DraftTimeline", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	defer span.End()
+
+	_, events, err := service.draftEvents(ctx, leagueKey)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.DraftTimeline{LeagueKey: leagueKey, Events: timeline(events)}, nil
+}
+
+// ReplayDraft rebuilds the draft board from the event log as it stood right after pick was first made, or before
+// the first pick when pick is 0. Keepers are on the board from the start.
+func (service *Service) ReplayDraft(ctx context.Context, leagueKey string, pick int) (*entities.DraftReplay, error) {
+	span, ctx := apm.StartSpan(ctx, "ReplayDraft", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	defer span.End()
+
+	if pick < 0 {
+		return nil, &ErrorReplayPick{pick: pick}
+	}
+	league, events, err := service.draftEvents(ctx, leagueKey)
+	if err != nil {
+		return nil, err
+	}
+	results, err := service.draftRepo.GetDraftResults(ctx, leagueKey)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not get draft results", "error", err, "league_key", leagueKey)
+		return nil, err
+	}
+
+	board := make(map[int]entities.DraftResult)
+	for _, result := range results {
+		if result.Keeper {
+			board[result.Pick] = result
+		}
+	}
+	replay := entities.DraftReplay{LeagueKey: leagueKey, Pick: pick, DraftOrder: league.DraftOrder}
+	reached := pick == 0
+	for _, event := range events {
+		made := pickMade(event)
+		if pick == 0 && made != nil {
+			break
+		}
+		replayEvent(board, event)
+		replay.Sequence = event.Sequence
+		replay.At = event.At
+		if event.DraftOrder != nil {
+			replay.DraftOrder = event.DraftOrder
+		}
+		if made != nil && made.Pick == pick {
+			reached = true
+			break
+		}
+	}
+	if !reached {
+		return nil, &ErrorReplayPick{pick: pick}
+	}
+
+	replay.Results = make([]entities.DraftResult, 0, len(board))
+	for _, result := range board {
+		replay.Results = append(replay.Results, result)
+	}
+	sort.Slice(replay.Results, func(i, j int) bool {
+		return replay.Results[i].Pick < replay.Results[j].Pick
+	})
+	replay.Rosters = replayRosters(league, replay.Results)
+	return &replay, nil
+}
+
+func (service *Service) draftEvents(ctx context.Context, leagueKey string) (entities.League, []entities.DraftEvent, error) {
+	league, err := service.draftRepo.GetLeague(ctx, leagueKey)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not get league", "error", err, "league_key", leagueKey)
+		return league, nil, err
+	}
+	events, err := service.draftRepo.GetDraftEvents(ctx, leagueKey)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not get draft events", "error", err, "league_key", leagueKey)
+		return league, nil, err
+	}
+	return league, events, nil
+}
+
+// timeline times every pick from the last event that put a team on the clock. Pausing or completing the draft
+// stops the clock, so paused time never counts against a pick.
+func timeline(events []entities.DraftEvent) []entities.TimelineEvent {
+	timed := make([]entities.TimelineEvent, len(events))
+	var clockStarted time.Time
+	for idx, event := range events {
+		timed[idx] = entities.TimelineEvent{DraftEvent: event}
+		if event.EndsPick() && !clockStarted.IsZero() {
+			timed[idx].ElapsedSeconds = event.At.Sub(clockStarted).Seconds()
+		}
+
+		switch {
+		case event.EndsPick():
+			clockStarted = event.At
+		case event.Type == entities.BroadCastTypeDraftOpen, event.Type == entities.BroadCastTypeDraftResumed,
+			event.Type == entities.BroadCastTypePickCorrected, event.Type == entities.BroadCastTypeNomination:
+			clockStarted = event.At
+		case event.Type == entities.BroadCastTypeDraftPaused, event.Type == entities.BroadCastTypeDraftCompleted:
+			clockStarted = time.Time{}
+		}
+	}
+	return timed
+}
+
+// pickMade is the result an event put on the board, if any
+func pickMade(event entities.DraftEvent) *entities.DraftResult {
+	if event.DraftResult != nil && (event.Type == entities.BroadCastTypePlayerDrafted || event.Type == entities.BroadCastTypePlayerSold) {
+		return event.DraftResult
+	}
+	if event.Correction != nil && event.Correction.Action == entities.CorrectionAssign {
+		return event.Correction.Result
+	}
+	return nil
+}
+
+// replayEvent applies the picks and corrections of an event to board, which holds the results by pick
+func replayEvent(board map[int]entities.DraftResult, event entities.DraftEvent) {
+	if made := pickMade(event); made != nil {
+		board[made.Pick] = *made
+		return
+	}
+	if event.Correction == nil {
+		return
+	}
+	switch event.Correction.Action {
+	case entities.CorrectionUndo:
+		delete(board, event.Correction.Pick)
+	case entities.CorrectionReplace:
+		if event.Correction.Result != nil {
+			board[event.Correction.Pick] = *event.Correction.Result
+		}
+	}
+}
+
+// replayRosters builds every team's roster from the replayed results, including teams yet to pick
+func replayRosters(league entities.League, results []entities.DraftResult) map[string]entities.Roster {
+	byTeam := make(map[string][]entities.DraftResult, len(league.Teams))
+	for _, result := range results {
+		byTeam[result.TeamKey] = append(byTeam[result.TeamKey], result)
+	}
+	rosters := make(map[string]entities.Roster, len(league.Teams))
+	for _, team := range league.Teams {
+		rosters[team.TeamKey] = buildTeamRoster(byTeam[team.TeamKey], makeRoster(league))
+	}
+	return rosters
+}
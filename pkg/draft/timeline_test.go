@@ -0,0 +1,128 @@
+package draft
+
+import (
+	"context"
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"testing"
+	"time"
+)
+
+var draftStart = time.Date(2020, time.August, 30, 19, 0, 0, 0, time.UTC)
+
+func timelineResult(teamKey, playerKey string, pick int, positions ...string) *entities.DraftResult {
+	player := testPlayer(playerKey, positions...)
+	return &entities.DraftResult{TeamKey: teamKey, PlayerKey: playerKey, Pick: pick, Round: 1, Player: []*entities.PlayerSeason{&player}}
+}
+
+// timelineEvents is a draft that had its order changed, paused after two picks, and had two picks corrected
+func timelineEvents() []entities.DraftEvent {
+	league := testLeague()
+	league.DraftOrder = []string{"399.l.1.t.2", "399.l.1.t.1", "399.l.1.t.3", "399.l.1.t.4"}
+	drafted := func(result *entities.DraftResult) entities.BroadcastDraftResult {
+		return entities.BroadcastDraftResult{Type: entities.BroadCastTypePlayerDrafted, League: league, DraftResult: *result}
+	}
+	corrected := func(correction entities.DraftCorrection) entities.BroadcastDraftResult {
+		return entities.BroadcastDraftResult{Type: entities.BroadCastTypePickCorrected, League: league, Correction: &correction}
+	}
+
+	messages := []struct {
+		after   time.Duration
+		message entities.BroadcastDraftResult
+	}{
+		{0, entities.BroadcastDraftResult{Type: entities.BroadCastTypeDraftOrder, League: league}},
+		{time.Minute, entities.BroadcastDraftResult{Type: entities.BroadCastTypeDraftOpen, League: league}},
+		{90 * time.Second, drafted(timelineResult("399.l.1.t.2", "399.p.1", 1, "QB"))},
+		{150 * time.Second, drafted(timelineResult("399.l.1.t.1", "399.p.2", 2, "RB"))},
+		{3 * time.Minute, entities.BroadcastDraftResult{Type: entities.BroadCastTypeDraftPaused, League: league}},
+		{10 * time.Minute, entities.BroadcastDraftResult{Type: entities.BroadCastTypeDraftResumed, League: league}},
+		{10*time.Minute + 20*time.Second, drafted(timelineResult("399.l.1.t.3", "399.p.3", 3, "WR"))},
+		{11 * time.Minute, corrected(entities.DraftCorrection{Action: entities.CorrectionUndo, Pick: 3, Previous: timelineResult("399.l.1.t.3", "399.p.3", 3, "WR")})},
+		{11*time.Minute + 45*time.Second, drafted(timelineResult("399.l.1.t.3", "399.p.4", 3, "RB"))},
+		{12 * time.Minute, corrected(entities.DraftCorrection{Action: entities.CorrectionReplace, Pick: 1, Result: timelineResult("399.l.1.t.2", "399.p.5", 1, "QB")})},
+	}
+	events := make([]entities.DraftEvent, len(messages))
+	for idx, message := range messages {
+		events[idx] = entities.NewDraftEvent(message.message, draftStart.Add(message.after))
+		events[idx].Sequence = idx + 1
+	}
+	return events
+}
+
+func timelineService() *Service {
+	league := testLeague()
+	repo := newFakeDraftRepository(league)
+	repo.events[league.LeagueKey] = timelineEvents()
+	repo.results[league.LeagueKey] = []entities.DraftResult{
+		{TeamKey: "399.l.1.t.4", PlayerKey: "399.p.8", Pick: 8, Keeper: true},
+		*timelineResult("399.l.1.t.2", "399.p.5", 1, "QB"),
+	}
+	service := NewService(log.NewNopLogger(), repo, newFakeBroadcaster())
+	return &service
+}
+
+func TestService_DraftTimeline(t *testing.T) {
+	got, err := timelineService().DraftTimeline(context.Background(), "399.l.1")
+	if !assert.Nil(t, err) || !assert.Len(t, got.Events, 10) {
+		return
+	}
+
+	elapsed := make([]float64, len(got.Events))
+	for idx, event := range got.Events {
+		assert.Equal(t, idx+1, event.Sequence, "events are in log order")
+		elapsed[idx] = event.ElapsedSeconds
+	}
+	assert.Equal(t, []float64{0, 0, 30, 60, 0, 0, 20, 0, 45, 0}, elapsed, "a pick is timed from the open, resume or correction before it")
+	assert.Equal(t, []string{"399.l.1.t.2", "399.l.1.t.1", "399.l.1.t.3", "399.l.1.t.4"}, got.Events[0].DraftOrder)
+}
+
+func TestService_ReplayDraft(t *testing.T) {
+	service := timelineService()
+
+	start, err := service.ReplayDraft(context.Background(), "399.l.1", 0)
+	if assert.Nil(t, err) {
+		assert.Equal(t, 2, start.Sequence, "the board before the first pick")
+		assert.Equal(t, "399.l.1.t.2", start.DraftOrder[0])
+		if assert.Len(t, start.Results, 1) {
+			assert.True(t, start.Results[0].Keeper, "keepers are on the board from the start")
+		}
+		assert.Len(t, start.Rosters, 4)
+	}
+
+	third, err := service.ReplayDraft(context.Background(), "399.l.1", 3)
+	if assert.Nil(t, err) {
+		assert.Equal(t, 7, third.Sequence)
+		assert.Equal(t, draftStart.Add(10*time.Minute+20*time.Second), third.At)
+		assert.Equal(t, []string{"399.p.1", "399.p.2", "399.p.3", "399.p.8"}, replayedPlayers(third))
+		assert.Equal(t, "399.p.2", third.Rosters["399.l.1.t.1"].Roster["RB"].DraftResults[0].PlayerKey)
+	}
+
+	second, err := service.ReplayDraft(context.Background(), "399.l.1", 2)
+	if assert.Nil(t, err) {
+		assert.Equal(t, []string{"399.p.1", "399.p.2", "399.p.8"}, replayedPlayers(second))
+	}
+
+	_, err = service.ReplayDraft(context.Background(), "399.l.1", 4)
+	assert.IsType(t, &ErrorReplayPick{}, err)
+	_, err = service.ReplayDraft(context.Background(), "399.l.1", -1)
+	assert.IsType(t, &ErrorReplayPick{}, err)
+}
+
+func Test_replayEvent_Corrections(t *testing.T) {
+	board := make(map[int]entities.DraftResult)
+	for _, event := range timelineEvents() {
+		replayEvent(board, event)
+	}
+	assert.Equal(t, "399.p.5", board[1].PlayerKey, "replaced")
+	assert.Equal(t, "399.p.4", board[3].PlayerKey, "undone and drafted again")
+	assert.Len(t, board, 3)
+}
+
+func replayedPlayers(replay *entities.DraftReplay) []string {
+	players := make([]string, len(replay.Results))
+	for idx, result := range replay.Results {
+		players[idx] = result.PlayerKey
+	}
+	return players
+}
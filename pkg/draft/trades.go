@@ -0,0 +1,271 @@
+package draft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-kit/kit/log/level"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"go.elastic.co/apm"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// ProposePickTrade records a trade of picks between teams for the commissioner to approve.
+// Any manager of a team giving or getting a pick can propose it.
+func (service *Service) ProposePickTrade(ctx context.Context, leagueKey string, picks []entities.TradedPick) (*entities.PickTrade, error) {
+	span, ctx := apm.StartSpan(ctx, "ProposePickTrade", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	defer span.End()
+
+	league, err := service.draftRepo.GetLeague(ctx, leagueKey)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not get league", "error", err, "league_key", leagueKey)
+		return nil, err
+	}
+	results, err := service.draftRepo.GetDraftResults(ctx, leagueKey)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not get draft results", "error", err, "league_key", leagueKey)
+		return nil, err
+	}
+	err = validatePickTrade(league, results, picks)
+	if err != nil {
+		return nil, err
+	}
+
+	user, _ := userFromContext(ctx)
+	if !isUserCommissioner(ctx, league) && !managesTradingTeam(ctx, league, picks) {
+		return nil, &ErrorNotTeamManager{teamKey: picks[0].FromTeam}
+	}
+
+	trade := entities.PickTrade{
+		ID:         primitive.NewObjectID().Hex(),
+		Picks:      picks,
+		Status:     entities.PickTradePending,
+		ProposedBy: user.GUID,
+		ProposedAt: time.Now(),
+	}
+	err = service.draftRepo.SavePickTrade(ctx, leagueKey, trade)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not save pick trade", "error", err, "league_key", leagueKey)
+		return nil, &ErrorUpdateDraft{}
+	}
+	league.PickTrades = append(league.PickTrades, trade)
+
+	service.broadcastTrade(ctx, league, trade, entities.BroadCastTypePickTradeProposed)
+	return &trade, nil
+}
+
+// ApprovePickTrade moves the traded picks to their new teams. The picks are checked again since the
+// draft may have moved past them, or another trade moved them, while the trade waited for approval.
+func (service *Service) ApprovePickTrade(ctx context.Context, leagueKey, tradeID string) (*entities.PickTrade, error) {
+	span, ctx := apm.StartSpan(ctx, "ApprovePickTrade", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	span.Context.SetLabel("trade_id", tradeID)
+	defer span.End()
+
+	league, results, err := service.commissionerDraft(ctx, leagueKey)
+	if err != nil {
+		return nil, err
+	}
+	trade, err := pendingTrade(league, tradeID)
+	if err != nil {
+		return nil, err
+	}
+	err = validatePickTrade(league, results, trade.Picks)
+	if err != nil {
+		return nil, err
+	}
+
+	owners := applyPickTrade(league, trade.Picks)
+	err = service.decidePickTrade(ctx, &league, &trade, entities.PickTradeApproved, owners)
+	if err != nil {
+		return nil, err
+	}
+
+	service.broadcastTrade(ctx, league, trade, entities.BroadCastTypePickTraded)
+	if league.State().CanPick() {
+		// the pick on the clock may now belong to a team that autodrafts
+		go service.autoDraftIfEnabled(league.LeagueKey, league.CurrentPick)
+	}
+	return &trade, nil
+}
+
+// RejectPickTrade closes a pending trade without moving any picks
+func (service *Service) RejectPickTrade(ctx context.Context, leagueKey, tradeID string) (*entities.PickTrade, error) {
+	span, ctx := apm.StartSpan(ctx, "RejectPickTrade", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	span.Context.SetLabel("trade_id", tradeID)
+	defer span.End()
+
+	league, err := service.commissionerLeague(ctx, leagueKey)
+	if err != nil {
+		return nil, err
+	}
+	trade, err := pendingTrade(league, tradeID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = service.decidePickTrade(ctx, &league, &trade, entities.PickTradeRejected, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	service.broadcastTrade(ctx, league, trade, entities.BroadCastTypePickTradeRejected)
+	return &trade, nil
+}
+
+// GetPickTrades lists every pick trade proposed in the league
+func (service *Service) GetPickTrades(ctx context.Context, leagueKey string) ([]entities.PickTrade, error) {
+	span, ctx := apm.StartSpan(ctx, "GetPickTrades", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	defer span.End()
+
+	league, err := service.draftRepo.GetLeague(ctx, leagueKey)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not get league", "error", err, "league_key", leagueKey)
+		return nil, err
+	}
+	if league.PickTrades == nil {
+		return []entities.PickTrade{}, nil
+	}
+	return league.PickTrades, nil
+}
+
+// decidePickTrade saves the decision on trade, and the new ownership ledger when owners is not nil
+func (service *Service) decidePickTrade(ctx context.Context, league *entities.League, trade *entities.PickTrade, status entities.PickTradeStatus, owners []entities.PickOwner) error {
+	decidedAt := time.Now()
+	trade.Status = status
+	trade.DecidedAt = &decidedAt
+
+	err := service.draftRepo.DecidePickTrade(ctx, league.LeagueKey, *trade, owners)
+	if errors.Is(err, entities.ErrTradeNotPending) {
+		return &ErrorTradeNotPending{tradeID: trade.ID}
+	}
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not save pick trade", "error", err, "league_key", league.LeagueKey, "trade_id", trade.ID, "status", status)
+		return &ErrorUpdateDraft{}
+	}
+
+	for idx := range league.PickTrades {
+		if league.PickTrades[idx].ID == trade.ID {
+			league.PickTrades[idx] = *trade
+		}
+	}
+	if owners != nil {
+		league.PickOwners = owners
+	}
+	return nil
+}
+
+func (service *Service) broadcastTrade(ctx context.Context, league entities.League, trade entities.PickTrade, broadcastType entities.BroadcastType) {
+	message := fmt.Sprintf("pick trade %s %s", trade.ID, trade.Status)
+	err := service.broadCastRepo.BroadCastLeagueInformation(ctx, league, message, broadcastType)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not broadcast pick trade", "error", err, "league_key", league.LeagueKey, "trade_id", trade.ID)
+	}
+}
+
+// validatePickTrade checks that every traded pick exists, has not been made yet and belongs to the team giving it up,
+// and that the team does not need it for one of its keepers
+func validatePickTrade(league entities.League, results []entities.DraftResult, picks []entities.TradedPick) error {
+	if league.IsAuction() {
+		return &ErrorAuctionDraft{}
+	}
+	if league.State() == entities.DraftStateCompleted {
+		return &ErrorDraftState{state: league.State(), action: "trade picks"}
+	}
+	if len(picks) == 0 {
+		return &ErrorInvalidTrade{reason: "a trade needs at least one pick"}
+	}
+
+	made := make(map[int]bool, len(results))
+	for _, result := range results {
+		made[result.Pick] = true
+	}
+	traded := make(map[entities.TradedPick]bool, len(picks))
+	for _, pick := range picks {
+		if pick.Round < 1 || pick.Round > numberOfRounds(league) || pick.Slot < 1 || pick.Slot > len(league.DraftOrder) {
+			return &ErrorInvalidTrade{reason: fmt.Sprintf("round %d slot %d is not part of the draft", pick.Round, pick.Slot)}
+		}
+		key := entities.TradedPick{Round: pick.Round, Slot: pick.Slot}
+		if traded[key] {
+			return &ErrorInvalidTrade{reason: fmt.Sprintf("round %d slot %d is traded twice", pick.Round, pick.Slot)}
+		}
+		traded[key] = true
+
+		if pick.FromTeam == pick.ToTeam {
+			return &ErrorInvalidTrade{reason: "a team can not trade a pick to itself"}
+		}
+		if _, ok := leagueTeam(league, pick.ToTeam); !ok {
+			return &ErrorUnknownTeam{teamKey: pick.ToTeam}
+		}
+		owner, _ := slotOwner(league, pick.Round, pick.Slot)
+		if owner != pick.FromTeam {
+			return &ErrorPickNotOwned{round: pick.Round, slot: pick.Slot, teamKey: pick.FromTeam, owner: owner}
+		}
+		overall := pickForSlot(league, pick.Round, pick.Slot)
+		if made[overall] {
+			return &ErrorPickAlreadyMade{pick: overall}
+		}
+		if keeper, ok := keeperOnPick(league, pick.FromTeam, overall); ok {
+			return &ErrorInvalidTrade{reason: fmt.Sprintf("round %d slot %d is the pick %s keeps %s with", pick.Round, pick.Slot, pick.FromTeam, keeper.PlayerKey)}
+		}
+	}
+	return nil
+}
+
+// applyPickTrade returns the league's ownership ledger with picks moved to their new teams.
+// A pick traded back to the team in its slot drops out of the ledger.
+func applyPickTrade(league entities.League, picks []entities.TradedPick) []entities.PickOwner {
+	moved := make(map[entities.PickOwner]string, len(picks))
+	for _, pick := range picks {
+		moved[entities.PickOwner{Round: pick.Round, Slot: pick.Slot}] = pick.ToTeam
+	}
+
+	owners := make([]entities.PickOwner, 0, len(league.PickOwners)+len(picks))
+	for _, owner := range league.PickOwners {
+		if _, ok := moved[entities.PickOwner{Round: owner.Round, Slot: owner.Slot}]; !ok {
+			owners = append(owners, owner)
+		}
+	}
+	for _, pick := range picks {
+		if league.DraftOrder[pick.Slot-1] != pick.ToTeam {
+			owners = append(owners, entities.PickOwner{Round: pick.Round, Slot: pick.Slot, TeamKey: pick.ToTeam})
+		}
+	}
+	return owners
+}
+
+// pickForSlot is the overall pick made from slot of DraftOrder in round
+func pickForSlot(league entities.League, round, slot int) int {
+	teams := len(league.DraftOrder)
+	if isRoundReversed(league.DraftSettings.OrderType, round) {
+		slot = teams - slot + 1
+	}
+	return (round-1)*teams + slot
+}
+
+func pendingTrade(league entities.League, tradeID string) (entities.PickTrade, error) {
+	for _, trade := range league.PickTrades {
+		if trade.ID != tradeID {
+			continue
+		}
+		if trade.Status != entities.PickTradePending {
+			return trade, &ErrorTradeNotPending{tradeID: tradeID, status: trade.Status}
+		}
+		return trade, nil
+	}
+	return entities.PickTrade{}, &ErrorTradeNotFound{tradeID: tradeID}
+}
+
+// managesTradingTeam reports whether the logged in user manages a team giving or getting one of the picks
+func managesTradingTeam(ctx context.Context, league entities.League, picks []entities.TradedPick) bool {
+	for _, pick := range picks {
+		if isUserTeamManager(ctx, league, pick.FromTeam) || isUserTeamManager(ctx, league, pick.ToTeam) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package draft
+
+import (
+	"context"
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/thethan/fdr-users/pkg/auth"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	userEntities "github.com/thethan/fdr-users/pkg/users/entities"
+	"testing"
+)
+
+func Test_teamKeyForPick_TradedPicks(t *testing.T) {
+	league := testLeague()
+	league.PickOwners = []entities.PickOwner{{Round: 2, Slot: 1, TeamKey: "399.l.1.t.4"}}
+
+	owner, _ := teamKeyForPick(league, 8)
+	assert.Equal(t, "399.l.1.t.4", owner, "round two slot one is the last pick of the snaking round")
+	owner, _ = teamKeyForPick(league, 1)
+	assert.Equal(t, "399.l.1.t.1", owner)
+
+	picks := draftPicks(league)
+	assert.Equal(t, entities.DraftPick{Pick: 8, Round: 2, Slot: 1, TeamKey: "399.l.1.t.4", OriginalTeamKey: "399.l.1.t.1"}, picks[7])
+	assert.Equal(t, "", picks[0].OriginalTeamKey)
+}
+
+func Test_pickForSlot(t *testing.T) {
+	league := testLeague()
+	for _, pick := range draftPicks(league) {
+		assert.Equal(t, pick.Pick, pickForSlot(league, pick.Round, pick.Slot))
+	}
+}
+
+func Test_applyPickTrade(t *testing.T) {
+	league := testLeague()
+	league.PickOwners = []entities.PickOwner{{Round: 2, Slot: 1, TeamKey: "399.l.1.t.4"}, {Round: 3, Slot: 2, TeamKey: "399.l.1.t.4"}}
+
+	owners := applyPickTrade(league, []entities.TradedPick{
+		{Round: 2, Slot: 1, FromTeam: "399.l.1.t.4", ToTeam: "399.l.1.t.1"},
+		{Round: 1, Slot: 3, FromTeam: "399.l.1.t.3", ToTeam: "399.l.1.t.4"},
+	})
+	assert.Equal(t, []entities.PickOwner{{Round: 3, Slot: 2, TeamKey: "399.l.1.t.4"}, {Round: 1, Slot: 3, TeamKey: "399.l.1.t.4"}}, owners,
+		"a pick traded back to its slot's team leaves the ledger")
+}
+
+func TestService_PickTrade(t *testing.T) {
+	league := testLeague()
+	repo := newFakeDraftRepository(league)
+	broadcaster := newFakeBroadcaster()
+	service := NewService(log.NewNopLogger(), repo, broadcaster)
+	managerContext := context.WithValue(context.Background(), auth.User, &userEntities.User{GUID: "manager-2"})
+	picks := []entities.TradedPick{
+		{Round: 1, Slot: 2, FromTeam: "399.l.1.t.2", ToTeam: "399.l.1.t.3"},
+		{Round: 2, Slot: 3, FromTeam: "399.l.1.t.3", ToTeam: "399.l.1.t.2"},
+	}
+
+	_, err := service.ProposePickTrade(managerContext, league.LeagueKey, []entities.TradedPick{{Round: 1, Slot: 3, FromTeam: "399.l.1.t.2", ToTeam: "399.l.1.t.3"}})
+	assert.IsType(t, &ErrorPickNotOwned{}, err)
+	_, err = service.ProposePickTrade(managerContext, league.LeagueKey, []entities.TradedPick{{Round: 8, Slot: 1, FromTeam: "399.l.1.t.1", ToTeam: "399.l.1.t.2"}})
+	assert.IsType(t, &ErrorInvalidTrade{}, err)
+	_, err = service.ProposePickTrade(managerContext, league.LeagueKey, []entities.TradedPick{{Round: 1, Slot: 3, FromTeam: "399.l.1.t.3", ToTeam: "399.l.1.t.4"}})
+	assert.IsType(t, &ErrorNotTeamManager{}, err)
+
+	trade, err := service.ProposePickTrade(managerContext, league.LeagueKey, picks)
+	assert.Nil(t, err)
+	assert.Equal(t, entities.PickTradePending, trade.Status)
+	assert.Equal(t, "manager-2", trade.ProposedBy)
+	assert.Equal(t, entities.BroadCastTypePickTradeProposed, broadcaster.last().Type)
+
+	_, err = service.ApprovePickTrade(managerContext, league.LeagueKey, trade.ID)
+	assert.IsType(t, &ErrorNotCommissioner{}, err)
+	_, err = service.ApprovePickTrade(commissionerContext(), league.LeagueKey, "missing")
+	assert.IsType(t, &ErrorTradeNotFound{}, err)
+
+	approved, err := service.ApprovePickTrade(commissionerContext(), league.LeagueKey, trade.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, entities.PickTradeApproved, approved.Status)
+	assert.NotNil(t, approved.DecidedAt)
+	assert.Equal(t, entities.BroadCastTypePickTraded, broadcaster.last().Type)
+
+	saved, _ := repo.GetLeague(context.Background(), league.LeagueKey)
+	owner, _ := teamKeyForPick(saved, 2)
+	assert.Equal(t, "399.l.1.t.3", owner)
+	owner, _ = teamKeyForPick(saved, 6)
+	assert.Equal(t, "399.l.1.t.2", owner)
+
+	_, err = service.ApprovePickTrade(commissionerContext(), league.LeagueKey, trade.ID)
+	assert.IsType(t, &ErrorTradeNotPending{}, err)
+	_, err = service.ShuffleOrder(commissionerContext(), league.LeagueKey)
+	assert.IsType(t, &ErrorPicksTraded{}, err)
+
+	trades, err := service.GetPickTrades(context.Background(), league.LeagueKey)
+	assert.Nil(t, err)
+	assert.Len(t, trades, 1)
+}
+
+func TestService_PickTrade_PickMadeBeforeApproval(t *testing.T) {
+	league := testLeague()
+	league.SetState(entities.DraftStateOpen)
+	league.CurrentPick = 1
+	repo := newFakeDraftRepository(league)
+	repo.players = []entities.PlayerSeason{testPlayer("399.p.1", "QB")}
+	broadcaster := newFakeBroadcaster()
+	service := NewService(log.NewNopLogger(), repo, broadcaster)
+
+	trade, err := service.ProposePickTrade(commissionerContext(), league.LeagueKey, []entities.TradedPick{{Round: 1, Slot: 1, FromTeam: "399.l.1.t.1", ToTeam: "399.l.1.t.2"}})
+	assert.Nil(t, err)
+	_, err = service.SaveDraftRequest(commissionerContext(), entities.User{Guid: "commish"}, league, league.Teams[0], testPlayer("399.p.1"), 1)
+	assert.Nil(t, err)
+
+	_, err = service.ApprovePickTrade(commissionerContext(), league.LeagueKey, trade.ID)
+	assert.IsType(t, &ErrorPickAlreadyMade{}, err)
+
+	rejected, err := service.RejectPickTrade(commissionerContext(), league.LeagueKey, trade.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, entities.PickTradeRejected, rejected.Status)
+	assert.Equal(t, entities.BroadCastTypePickTradeRejected, broadcaster.last().Type)
+	saved, _ := repo.GetLeague(context.Background(), league.LeagueKey)
+	assert.Empty(t, saved.PickOwners)
+}
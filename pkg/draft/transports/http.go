@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"github.com/go-kit/kit/log"
 	"github.com/thethan/fdr-users/pkg/draft"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
 	"github.com/thethan/fdr-users/pkg/players/transports"
 	"io"
 	"io/ioutil"
@@ -36,6 +37,11 @@ import (
 
 const contentType = "application/json; charset=utf-8"
 const leagueIdParam = "leagueId"
+const pickParam = "pick"
+const tradeIdParam = "tradeId"
+const mockIdParam = "mockId"
+const messageIdParam = "messageId"
+const userGuidParam = "userGuid"
 
 var (
 	_ = fmt.Sprint
@@ -97,6 +103,222 @@ func MakeHTTPHandler(logger log.Logger, endpoints draft.Endpoints, m *mux.Router
 		transports.EncodeHTTPLeague,
 		serverOptionsAuth...,
 	))
+	m.Methods(http.MethodPost).Path("/{" + leagueIdParam + "}/pause").Handler(httptransport.NewServer(
+		endpoints.PauseDraft,
+		DecodeHTTPDraftState,
+		transports.EncodeHTTPLeague,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodPost).Path("/{" + leagueIdParam + "}/resume").Handler(httptransport.NewServer(
+		endpoints.ResumeDraft,
+		DecodeHTTPDraftState,
+		transports.EncodeHTTPLeague,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodPost).Path("/{" + leagueIdParam + "}/close").Handler(httptransport.NewServer(
+		endpoints.CloseDraft,
+		DecodeHTTPDraftState,
+		transports.EncodeHTTPLeague,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodGet).Path("/{" + leagueIdParam + "}/draft/grades").Handler(httptransport.NewServer(
+		endpoints.GetDraftGrades,
+		DecodeHTTPGetLeaugueDraft,
+		EncodeHTTPDraftReport,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodGet).Path("/{" + leagueIdParam + "}/draft/timeline").Handler(httptransport.NewServer(
+		endpoints.GetDraftTimeline,
+		DecodeHTTPGetLeaugueDraft,
+		EncodeHTTPDraftTimeline,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodGet).Path("/{" + leagueIdParam + "}/draft/replay").Handler(httptransport.NewServer(
+		endpoints.ReplayDraft,
+		DecodeHTTPReplayDraft,
+		EncodeHTTPDraftReplay,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodPost).Path("/{" + leagueIdParam + "}/draft/yahoo").Handler(httptransport.NewServer(
+		endpoints.SyncDraftToYahoo,
+		DecodeHTTPGetLeaugueDraft,
+		EncodeHTTPYahooSyncReport,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodGet).Path("/{" + leagueIdParam + "}/draft/export").Handler(httptransport.NewServer(
+		endpoints.ExportDraft,
+		DecodeHTTPDraftExport,
+		EncodeHTTPDraftExport,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodPut).Path("/{" + leagueIdParam + "}/draft/settings").Handler(httptransport.NewServer(
+		endpoints.UpdateDraftSettings,
+		DecodeHTTPUpdateDraftSettings,
+		transports.EncodeHTTPLeague,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodGet).Path("/{" + leagueIdParam + "}/auction").Handler(httptransport.NewServer(
+		endpoints.GetAuction,
+		DecodeHTTPGetLeaugueDraft,
+		EncodeHTTPAuction,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodPost).Path("/{" + leagueIdParam + "}/auction/nominations").Handler(httptransport.NewServer(
+		endpoints.Nominate,
+		DecodeHTTPNominate,
+		EncodeHTTPAuctionLot,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodPost).Path("/{" + leagueIdParam + "}/auction/bids").Handler(httptransport.NewServer(
+		endpoints.Bid,
+		DecodeHTTPBid,
+		EncodeHTTPAuctionLot,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodGet).Path("/{" + leagueIdParam + "}/keepers").Handler(httptransport.NewServer(
+		endpoints.GetKeepers,
+		DecodeHTTPGetLeaugueDraft,
+		EncodeHTTPKeepers,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodPut).Path("/{" + leagueIdParam + "}/keepers").Handler(httptransport.NewServer(
+		endpoints.DeclareKeepers,
+		DecodeHTTPDeclareKeepers,
+		EncodeHTTPKeepers,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodGet).Path("/{" + leagueIdParam + "}/draft/trades").Handler(httptransport.NewServer(
+		endpoints.GetPickTrades,
+		DecodeHTTPGetLeaugueDraft,
+		EncodeHTTPPickTrades,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodPost).Path("/{" + leagueIdParam + "}/draft/trades").Handler(httptransport.NewServer(
+		endpoints.ProposePickTrade,
+		DecodeHTTPProposePickTrade,
+		EncodeHTTPPickTrade,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodPost).Path("/{" + leagueIdParam + "}/draft/trades/{" + tradeIdParam + "}/approve").Handler(httptransport.NewServer(
+		endpoints.ApprovePickTrade,
+		DecodeHTTPPickTradeDecision,
+		EncodeHTTPPickTrade,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodPost).Path("/{" + leagueIdParam + "}/draft/trades/{" + tradeIdParam + "}/reject").Handler(httptransport.NewServer(
+		endpoints.RejectPickTrade,
+		DecodeHTTPPickTradeDecision,
+		EncodeHTTPPickTrade,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodGet).Path("/{" + leagueIdParam + "}/mocks").Handler(httptransport.NewServer(
+		endpoints.ListMockDrafts,
+		DecodeHTTPGetLeaugueDraft,
+		EncodeHTTPMockDrafts,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodPost).Path("/{" + leagueIdParam + "}/mocks").Handler(httptransport.NewServer(
+		endpoints.StartMockDraft,
+		DecodeHTTPGetLeaugueDraft,
+		EncodeHTTPMockDraft,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodGet).Path("/{" + leagueIdParam + "}/mocks/{" + mockIdParam + "}").Handler(httptransport.NewServer(
+		endpoints.GetMockDraft,
+		DecodeHTTPGetMockDraft,
+		EncodeHTTPMockDraft,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodPost).Path("/{" + leagueIdParam + "}/mocks/{" + mockIdParam + "}/picks").Handler(httptransport.NewServer(
+		endpoints.MockDraftPick,
+		DecodeHTTPMockDraftPick,
+		EncodeHTTPMockDraft,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodGet).Path("/{" + leagueIdParam + "}/chat").Handler(httptransport.NewServer(
+		endpoints.GetChatHistory,
+		DecodeHTTPChatHistory,
+		EncodeHTTPChatPage,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodPost).Path("/{" + leagueIdParam + "}/chat").Handler(httptransport.NewServer(
+		endpoints.SendChatMessage,
+		DecodeHTTPChatMessage,
+		EncodeHTTPChatMessage,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodPost).Path("/{" + leagueIdParam + "}/chat/mutes").Handler(httptransport.NewServer(
+		endpoints.MuteChatUser,
+		DecodeHTTPChatMute,
+		EncodeHTTPChatMutes,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodDelete).Path("/{" + leagueIdParam + "}/chat/mutes/{" + userGuidParam + "}").Handler(httptransport.NewServer(
+		endpoints.UnmuteChatUser,
+		DecodeHTTPChatUnmute,
+		EncodeHTTPChatMutes,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodDelete).Path("/{" + leagueIdParam + "}/chat/{" + messageIdParam + "}").Handler(httptransport.NewServer(
+		endpoints.DeleteChatMessage,
+		DecodeHTTPChatMessageID,
+		EncodeHTTPChatMessage,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodPost).Path("/{" + leagueIdParam + "}/chat/{" + messageIdParam + "}/reactions").Handler(httptransport.NewServer(
+		endpoints.ReactToChatMessage,
+		DecodeHTTPChatReaction,
+		EncodeHTTPChatMessage,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodGet).Path("/{" + leagueIdParam + "}/presence").Handler(httptransport.NewServer(
+		endpoints.GetDraftPresence,
+		DecodeHTTPGetLeaugueDraft,
+		EncodeHTTPDraftPresence,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodPost).Path("/{" + leagueIdParam + "}/presence").Handler(httptransport.NewServer(
+		endpoints.Heartbeat,
+		DecodeHTTPGetLeaugueDraft,
+		EncodeHTTPDraftPresence,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodDelete).Path("/{" + leagueIdParam + "}/presence").Handler(httptransport.NewServer(
+		endpoints.LeaveDraftRoom,
+		DecodeHTTPGetLeaugueDraft,
+		EncodeHTTPDraftPresence,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodGet).Path("/{" + leagueIdParam + "}/lottery").Handler(httptransport.NewServer(
+		endpoints.GetDraftLottery,
+		DecodeHTTPGetLeaugueDraft,
+		EncodeHTTPDraftLottery,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodPost).Path("/{" + leagueIdParam + "}/lottery").Handler(httptransport.NewServer(
+		endpoints.RunDraftLottery,
+		DecodeHTTPDraftLottery,
+		EncodeHTTPDraftLottery,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodDelete).Path("/{" + leagueIdParam + "}/draft/picks/last").Handler(httptransport.NewServer(
+		endpoints.UndoLastPick,
+		DecodeHTTPUndoLastPick,
+		EncodeHTTPDraftCorrection,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodPut).Path("/{" + leagueIdParam + "}/draft/picks/{" + pickParam + ":[0-9]+}").Handler(httptransport.NewServer(
+		endpoints.ReplacePickPlayer,
+		DecodeHTTPReplacePickPlayer,
+		EncodeHTTPDraftCorrection,
+		serverOptionsAuth...,
+	))
+	m.Methods(http.MethodPost).Path("/{" + leagueIdParam + "}/draft/picks/{" + pickParam + ":[0-9]+}").Handler(httptransport.NewServer(
+		endpoints.AssignPick,
+		DecodeHTTPAssignPick,
+		EncodeHTTPDraftCorrection,
+		serverOptionsAuth...,
+	))
 	return m
 }
 
@@ -309,3 +531,802 @@ func EncodeHTTPDraftResult(_ context.Context, w http.ResponseWriter, response in
 	w.Write(bytesJson)
 	return nil
 }
+
+func DecodeHTTPUpdateDraftSettings(ctx context.Context, r *http.Request) (interface{}, error) {
+	defer r.Body.Close()
+	var req draft.UpdateDraftSettingsRequest
+	buf, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read body of http request")
+	}
+	if len(buf) > 0 {
+		if err = json.Unmarshal(buf, &req); err != nil {
+			const size = 8196
+			if len(buf) > size {
+				buf = buf[:size]
+			}
+			return nil, httpError{errors.Wrapf(err, "request body '%s': cannot parse non-json request body", buf),
+				http.StatusBadRequest,
+				nil,
+			}
+		}
+	}
+
+	pathParams := mux.Vars(r)
+	leagueKey, ok := pathParams[leagueIdParam]
+	if !ok {
+		return nil, errors.New("bad request")
+	}
+
+	req.LeagueID = leagueKey
+
+	return &req, err
+}
+
+func DecodeHTTPDraftState(ctx context.Context, r *http.Request) (interface{}, error) {
+	defer r.Body.Close()
+
+	pathParams := mux.Vars(r)
+	leagueKey, ok := pathParams[leagueIdParam]
+	if !ok {
+		return nil, errors.New("bad request")
+	}
+
+	return &draft.DraftStateRequest{LeagueID: leagueKey}, nil
+}
+
+func DecodeHTTPUndoLastPick(ctx context.Context, r *http.Request) (interface{}, error) {
+	defer r.Body.Close()
+
+	pathParams := mux.Vars(r)
+	leagueKey, ok := pathParams[leagueIdParam]
+	if !ok {
+		return nil, errors.New("bad request")
+	}
+
+	return &draft.UndoLastPickRequest{LeagueID: leagueKey}, nil
+}
+
+func DecodeHTTPReplacePickPlayer(ctx context.Context, r *http.Request) (interface{}, error) {
+	defer r.Body.Close()
+	var req draft.ReplacePickPlayerRequest
+	buf, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read body of http request")
+	}
+	if len(buf) > 0 {
+		if err = json.Unmarshal(buf, &req); err != nil {
+			const size = 8196
+			if len(buf) > size {
+				buf = buf[:size]
+			}
+			return nil, httpError{errors.Wrapf(err, "request body '%s': cannot parse non-json request body", buf),
+				http.StatusBadRequest,
+				nil,
+			}
+		}
+	}
+
+	req.LeagueID, req.Pick, err = leaguePickFromPath(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &req, err
+}
+
+func DecodeHTTPAssignPick(ctx context.Context, r *http.Request) (interface{}, error) {
+	defer r.Body.Close()
+	var req draft.AssignPickRequest
+	buf, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read body of http request")
+	}
+	if len(buf) > 0 {
+		if err = json.Unmarshal(buf, &req); err != nil {
+			const size = 8196
+			if len(buf) > size {
+				buf = buf[:size]
+			}
+			return nil, httpError{errors.Wrapf(err, "request body '%s': cannot parse non-json request body", buf),
+				http.StatusBadRequest,
+				nil,
+			}
+		}
+	}
+
+	req.LeagueID, req.Pick, err = leaguePickFromPath(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &req, err
+}
+
+func leaguePickFromPath(r *http.Request) (string, int, error) {
+	pathParams := mux.Vars(r)
+	leagueKey, ok := pathParams[leagueIdParam]
+	if !ok {
+		return "", 0, errors.New("bad request")
+	}
+	pick, err := strconv.Atoi(pathParams[pickParam])
+	if err != nil {
+		return "", 0, httpError{errors.Wrap(err, "pick must be a number"), http.StatusBadRequest, nil}
+	}
+	return leagueKey, pick, nil
+}
+
+// EncodeHTTPDraftCorrection is a transport/http.EncodeResponseFunc that encodes
+// a commissioner's draft correction as JSON to the response writer.
+func EncodeHTTPDraftCorrection(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res, ok := response.(*entities.DraftCorrection)
+	if !ok {
+		return errors.New("could not get draft correction response")
+	}
+	bytesJson, err := json.Marshal(&res)
+	if err != nil {
+		return err
+	}
+	w.Write(bytesJson)
+	return nil
+}
+
+func DecodeHTTPNominate(ctx context.Context, r *http.Request) (interface{}, error) {
+	defer r.Body.Close()
+	var req draft.NominateRequest
+	buf, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read body of http request")
+	}
+	if len(buf) > 0 {
+		if err = json.Unmarshal(buf, &req); err != nil {
+			const size = 8196
+			if len(buf) > size {
+				buf = buf[:size]
+			}
+			return nil, httpError{errors.Wrapf(err, "request body '%s': cannot parse non-json request body", buf),
+				http.StatusBadRequest,
+				nil,
+			}
+		}
+	}
+
+	pathParams := mux.Vars(r)
+	leagueKey, ok := pathParams[leagueIdParam]
+	if !ok {
+		return nil, errors.New("bad request")
+	}
+	req.LeagueID = leagueKey
+
+	return &req, err
+}
+
+func DecodeHTTPBid(ctx context.Context, r *http.Request) (interface{}, error) {
+	defer r.Body.Close()
+	var req draft.BidRequest
+	buf, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read body of http request")
+	}
+	if len(buf) > 0 {
+		if err = json.Unmarshal(buf, &req); err != nil {
+			const size = 8196
+			if len(buf) > size {
+				buf = buf[:size]
+			}
+			return nil, httpError{errors.Wrapf(err, "request body '%s': cannot parse non-json request body", buf),
+				http.StatusBadRequest,
+				nil,
+			}
+		}
+	}
+
+	pathParams := mux.Vars(r)
+	leagueKey, ok := pathParams[leagueIdParam]
+	if !ok {
+		return nil, errors.New("bad request")
+	}
+	req.LeagueID = leagueKey
+
+	return &req, err
+}
+
+// EncodeHTTPAuction is a transport/http.EncodeResponseFunc that encodes
+// the state of an auction draft room as JSON to the response writer.
+func EncodeHTTPAuction(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res, ok := response.(*entities.Auction)
+	if !ok {
+		return errors.New("could not get auction response")
+	}
+	bytesJson, err := json.Marshal(&res)
+	if err != nil {
+		return err
+	}
+	w.Write(bytesJson)
+	return nil
+}
+
+// EncodeHTTPAuctionLot is a transport/http.EncodeResponseFunc that encodes
+// the player on the block as JSON to the response writer.
+func EncodeHTTPAuctionLot(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res, ok := response.(*entities.AuctionLot)
+	if !ok {
+		return errors.New("could not get auction lot response")
+	}
+	bytesJson, err := json.Marshal(&res)
+	if err != nil {
+		return err
+	}
+	w.Write(bytesJson)
+	return nil
+}
+
+func DecodeHTTPDeclareKeepers(ctx context.Context, r *http.Request) (interface{}, error) {
+	defer r.Body.Close()
+	var req draft.DeclareKeepersRequest
+	buf, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read body of http request")
+	}
+	if len(buf) > 0 {
+		if err = json.Unmarshal(buf, &req); err != nil {
+			const size = 8196
+			if len(buf) > size {
+				buf = buf[:size]
+			}
+			return nil, httpError{errors.Wrapf(err, "request body '%s': cannot parse non-json request body", buf),
+				http.StatusBadRequest,
+				nil,
+			}
+		}
+	}
+
+	pathParams := mux.Vars(r)
+	leagueKey, ok := pathParams[leagueIdParam]
+	if !ok {
+		return nil, errors.New("bad request")
+	}
+	req.LeagueID = leagueKey
+
+	return &req, err
+}
+
+// EncodeHTTPKeepers is a transport/http.EncodeResponseFunc that encodes
+// a league's keepers as JSON to the response writer.
+func EncodeHTTPKeepers(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res, ok := response.([]entities.Keeper)
+	if !ok {
+		return errors.New("could not get keepers response")
+	}
+	bytesJson, err := json.Marshal(&res)
+	if err != nil {
+		return err
+	}
+	w.Write(bytesJson)
+	return nil
+}
+
+func DecodeHTTPProposePickTrade(ctx context.Context, r *http.Request) (interface{}, error) {
+	defer r.Body.Close()
+	var req draft.ProposePickTradeRequest
+	buf, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read body of http request")
+	}
+	if len(buf) > 0 {
+		if err = json.Unmarshal(buf, &req); err != nil {
+			const size = 8196
+			if len(buf) > size {
+				buf = buf[:size]
+			}
+			return nil, httpError{errors.Wrapf(err, "request body '%s': cannot parse non-json request body", buf),
+				http.StatusBadRequest,
+				nil,
+			}
+		}
+	}
+
+	pathParams := mux.Vars(r)
+	leagueKey, ok := pathParams[leagueIdParam]
+	if !ok {
+		return nil, errors.New("bad request")
+	}
+	req.LeagueID = leagueKey
+
+	return &req, err
+}
+
+func DecodeHTTPPickTradeDecision(ctx context.Context, r *http.Request) (interface{}, error) {
+	defer r.Body.Close()
+
+	pathParams := mux.Vars(r)
+	leagueKey, ok := pathParams[leagueIdParam]
+	if !ok {
+		return nil, errors.New("bad request")
+	}
+	tradeID, ok := pathParams[tradeIdParam]
+	if !ok {
+		return nil, errors.New("bad request")
+	}
+
+	return &draft.PickTradeDecisionRequest{LeagueID: leagueKey, TradeID: tradeID}, nil
+}
+
+// EncodeHTTPPickTrades is a transport/http.EncodeResponseFunc that encodes
+// a league's pick trades as JSON to the response writer.
+func EncodeHTTPPickTrades(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res, ok := response.([]entities.PickTrade)
+	if !ok {
+		return errors.New("could not get pick trades response")
+	}
+	bytesJson, err := json.Marshal(&res)
+	if err != nil {
+		return err
+	}
+	w.Write(bytesJson)
+	return nil
+}
+
+// EncodeHTTPPickTrade is a transport/http.EncodeResponseFunc that encodes
+// a pick trade as JSON to the response writer.
+func EncodeHTTPPickTrade(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res, ok := response.(*entities.PickTrade)
+	if !ok {
+		return errors.New("could not get pick trade response")
+	}
+	bytesJson, err := json.Marshal(&res)
+	if err != nil {
+		return err
+	}
+	w.Write(bytesJson)
+	return nil
+}
+
+// DecodeHTTPGetMockDraft reads a mock draft id from the path, and the last pick to replay from the through query parameter
+func DecodeHTTPGetMockDraft(ctx context.Context, r *http.Request) (interface{}, error) {
+	defer r.Body.Close()
+
+	pathParams := mux.Vars(r)
+	leagueKey, ok := pathParams[leagueIdParam]
+	if !ok {
+		return nil, errors.New("bad request")
+	}
+	mockID, ok := pathParams[mockIdParam]
+	if !ok {
+		return nil, errors.New("bad request")
+	}
+	req := draft.MockDraftRequest{LeagueID: leagueKey, MockID: mockID}
+
+	if through := r.URL.Query().Get("through"); through != "" {
+		pick, err := strconv.Atoi(through)
+		if err != nil {
+			return nil, httpError{errors.Wrap(err, "through must be a number"), http.StatusBadRequest, nil}
+		}
+		req.Through = pick
+	}
+	return &req, nil
+}
+
+func DecodeHTTPMockDraftPick(ctx context.Context, r *http.Request) (interface{}, error) {
+	defer r.Body.Close()
+	var req draft.MockDraftPickRequest
+	buf, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read body of http request")
+	}
+	if len(buf) > 0 {
+		if err = json.Unmarshal(buf, &req); err != nil {
+			const size = 8196
+			if len(buf) > size {
+				buf = buf[:size]
+			}
+			return nil, httpError{errors.Wrapf(err, "request body '%s': cannot parse non-json request body", buf),
+				http.StatusBadRequest,
+				nil,
+			}
+		}
+	}
+
+	pathParams := mux.Vars(r)
+	leagueKey, ok := pathParams[leagueIdParam]
+	if !ok {
+		return nil, errors.New("bad request")
+	}
+	mockID, ok := pathParams[mockIdParam]
+	if !ok {
+		return nil, errors.New("bad request")
+	}
+	req.LeagueID = leagueKey
+	req.MockID = mockID
+
+	return &req, err
+}
+
+// EncodeHTTPMockDrafts is a transport/http.EncodeResponseFunc that encodes
+// a user's mock drafts as JSON to the response writer.
+func EncodeHTTPMockDrafts(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res, ok := response.([]entities.MockDraft)
+	if !ok {
+		return errors.New("could not get mock drafts response")
+	}
+	bytesJson, err := json.Marshal(&res)
+	if err != nil {
+		return err
+	}
+	w.Write(bytesJson)
+	return nil
+}
+
+// EncodeHTTPMockDraft is a transport/http.EncodeResponseFunc that encodes
+// a mock draft as JSON to the response writer.
+func EncodeHTTPMockDraft(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res, ok := response.(*entities.MockDraft)
+	if !ok {
+		return errors.New("could not get mock draft response")
+	}
+	bytesJson, err := json.Marshal(&res)
+	if err != nil {
+		return err
+	}
+	w.Write(bytesJson)
+	return nil
+}
+
+// DecodeHTTPReplayDraft reads the pick to replay the draft to from the pick query parameter. Without it the
+// board is replayed to before the first pick.
+func DecodeHTTPReplayDraft(ctx context.Context, r *http.Request) (interface{}, error) {
+	defer r.Body.Close()
+
+	leagueKey, ok := mux.Vars(r)[leagueIdParam]
+	if !ok {
+		return nil, errors.New("bad request")
+	}
+	req := draft.DraftReplayRequest{LeagueKey: leagueKey}
+
+	if pick := r.URL.Query().Get("pick"); pick != "" {
+		number, err := strconv.Atoi(pick)
+		if err != nil {
+			return nil, httpError{errors.Wrap(err, "pick must be a number"), http.StatusBadRequest, nil}
+		}
+		req.Pick = number
+	}
+	return &req, nil
+}
+
+// EncodeHTTPDraftTimeline is a transport/http.EncodeResponseFunc that encodes
+// a draft's events in order as JSON to the response writer.
+func EncodeHTTPDraftTimeline(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res, ok := response.(*entities.DraftTimeline)
+	if !ok {
+		return errors.New("could not get draft timeline response")
+	}
+	bytesJson, err := json.Marshal(&res)
+	if err != nil {
+		return err
+	}
+	w.Write(bytesJson)
+	return nil
+}
+
+// EncodeHTTPDraftReplay is a transport/http.EncodeResponseFunc that encodes
+// a replayed draft board as JSON to the response writer.
+func EncodeHTTPDraftReplay(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res, ok := response.(*entities.DraftReplay)
+	if !ok {
+		return errors.New("could not get draft replay response")
+	}
+	bytesJson, err := json.Marshal(&res)
+	if err != nil {
+		return err
+	}
+	w.Write(bytesJson)
+	return nil
+}
+
+// EncodeHTTPYahooSyncReport is a transport/http.EncodeResponseFunc that encodes
+// the outcome of each pick sent to Yahoo as JSON to the response writer.
+func EncodeHTTPYahooSyncReport(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res, ok := response.(*entities.YahooSyncReport)
+	if !ok {
+		return errors.New("could not get yahoo sync report response")
+	}
+	bytesJson, err := json.Marshal(&res)
+	if err != nil {
+		return err
+	}
+	w.Write(bytesJson)
+	return nil
+}
+
+// EncodeHTTPDraftReport is a transport/http.EncodeResponseFunc that encodes
+// a draft's grades as JSON to the response writer.
+func EncodeHTTPDraftReport(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res, ok := response.(*entities.DraftReport)
+	if !ok {
+		return errors.New("could not get draft report response")
+	}
+	bytesJson, err := json.Marshal(&res)
+	if err != nil {
+		return err
+	}
+	w.Write(bytesJson)
+	return nil
+}
+
+// DecodeHTTPDraftExport reads the league from the path and the format from the format query parameter, csv by default
+func DecodeHTTPDraftExport(ctx context.Context, r *http.Request) (interface{}, error) {
+	defer r.Body.Close()
+
+	leagueKey, ok := mux.Vars(r)[leagueIdParam]
+	if !ok {
+		return nil, errors.New("bad request")
+	}
+	format := draft.ExportCSV
+	if query := r.URL.Query().Get("format"); query != "" {
+		parsed, err := draft.ParseExportFormat(query)
+		if err != nil {
+			return nil, err
+		}
+		format = parsed
+	}
+	return &draft.DraftExportRequest{LeagueKey: leagueKey, Format: format}, nil
+}
+
+// EncodeHTTPDraftExport is a transport/http.EncodeResponseFunc that writes
+// a draft export to the response writer as a file download.
+func EncodeHTTPDraftExport(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res, ok := response.(*draft.DraftExportResponse)
+	if !ok {
+		return errors.New("could not get draft export response")
+	}
+	w.Header().Set("Content-Type", res.Format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", res.Format.FileName(res.Export.League.LeagueKey)))
+	return draft.WriteDraftExport(w, res.Format, *res.Export)
+}
+
+// DecodeHTTPChatHistory reads the message to page back from with the before query parameter, and the page size with limit
+func DecodeHTTPChatHistory(ctx context.Context, r *http.Request) (interface{}, error) {
+	defer r.Body.Close()
+
+	leagueKey, ok := mux.Vars(r)[leagueIdParam]
+	if !ok {
+		return nil, errors.New("bad request")
+	}
+	req := draft.ChatHistoryRequest{LeagueKey: leagueKey, Before: r.URL.Query().Get("before")}
+
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		number, err := strconv.Atoi(limit)
+		if err != nil {
+			return nil, httpError{errors.Wrap(err, "limit must be a number"), http.StatusBadRequest, nil}
+		}
+		req.Limit = number
+	}
+	return &req, nil
+}
+
+func DecodeHTTPChatMessage(ctx context.Context, r *http.Request) (interface{}, error) {
+	defer r.Body.Close()
+	var req draft.ChatMessageRequest
+	buf, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read body of http request")
+	}
+	if len(buf) > 0 {
+		if err = json.Unmarshal(buf, &req); err != nil {
+			const size = 8196
+			if len(buf) > size {
+				buf = buf[:size]
+			}
+			return nil, httpError{errors.Wrapf(err, "request body '%s': cannot parse non-json request body", buf),
+				http.StatusBadRequest,
+				nil,
+			}
+		}
+	}
+
+	leagueKey, ok := mux.Vars(r)[leagueIdParam]
+	if !ok {
+		return nil, errors.New("bad request")
+	}
+	req.LeagueKey = leagueKey
+
+	return &req, err
+}
+
+func DecodeHTTPChatMessageID(ctx context.Context, r *http.Request) (interface{}, error) {
+	defer r.Body.Close()
+
+	pathParams := mux.Vars(r)
+	leagueKey, ok := pathParams[leagueIdParam]
+	if !ok {
+		return nil, errors.New("bad request")
+	}
+	messageID, ok := pathParams[messageIdParam]
+	if !ok {
+		return nil, errors.New("bad request")
+	}
+	return &draft.ChatMessageIDRequest{LeagueKey: leagueKey, MessageID: messageID}, nil
+}
+
+func DecodeHTTPChatReaction(ctx context.Context, r *http.Request) (interface{}, error) {
+	defer r.Body.Close()
+	var req draft.ChatReactionRequest
+	buf, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read body of http request")
+	}
+	if len(buf) > 0 {
+		if err = json.Unmarshal(buf, &req); err != nil {
+			const size = 8196
+			if len(buf) > size {
+				buf = buf[:size]
+			}
+			return nil, httpError{errors.Wrapf(err, "request body '%s': cannot parse non-json request body", buf),
+				http.StatusBadRequest,
+				nil,
+			}
+		}
+	}
+
+	pathParams := mux.Vars(r)
+	leagueKey, ok := pathParams[leagueIdParam]
+	if !ok {
+		return nil, errors.New("bad request")
+	}
+	messageID, ok := pathParams[messageIdParam]
+	if !ok {
+		return nil, errors.New("bad request")
+	}
+	req.LeagueKey = leagueKey
+	req.MessageID = messageID
+
+	return &req, err
+}
+
+func DecodeHTTPChatMute(ctx context.Context, r *http.Request) (interface{}, error) {
+	defer r.Body.Close()
+	var req draft.ChatMuteRequest
+	buf, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read body of http request")
+	}
+	if len(buf) > 0 {
+		if err = json.Unmarshal(buf, &req); err != nil {
+			const size = 8196
+			if len(buf) > size {
+				buf = buf[:size]
+			}
+			return nil, httpError{errors.Wrapf(err, "request body '%s': cannot parse non-json request body", buf),
+				http.StatusBadRequest,
+				nil,
+			}
+		}
+	}
+
+	leagueKey, ok := mux.Vars(r)[leagueIdParam]
+	if !ok {
+		return nil, errors.New("bad request")
+	}
+	req.LeagueKey = leagueKey
+
+	return &req, err
+}
+
+func DecodeHTTPChatUnmute(ctx context.Context, r *http.Request) (interface{}, error) {
+	defer r.Body.Close()
+
+	pathParams := mux.Vars(r)
+	leagueKey, ok := pathParams[leagueIdParam]
+	if !ok {
+		return nil, errors.New("bad request")
+	}
+	userGUID, ok := pathParams[userGuidParam]
+	if !ok {
+		return nil, errors.New("bad request")
+	}
+	return &draft.ChatMuteRequest{LeagueKey: leagueKey, UserGUID: userGUID}, nil
+}
+
+// EncodeHTTPChatMessage is a transport/http.EncodeResponseFunc that encodes
+// a chat message as JSON to the response writer.
+func EncodeHTTPChatMessage(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res, ok := response.(*entities.ChatMessage)
+	if !ok {
+		return errors.New("could not get chat message response")
+	}
+	bytesJson, err := json.Marshal(&res)
+	if err != nil {
+		return err
+	}
+	w.Write(bytesJson)
+	return nil
+}
+
+// EncodeHTTPChatPage is a transport/http.EncodeResponseFunc that encodes
+// a page of chat history as JSON to the response writer.
+func EncodeHTTPChatPage(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res, ok := response.(*entities.ChatPage)
+	if !ok {
+		return errors.New("could not get chat history response")
+	}
+	bytesJson, err := json.Marshal(&res)
+	if err != nil {
+		return err
+	}
+	w.Write(bytesJson)
+	return nil
+}
+
+// EncodeHTTPChatMutes is a transport/http.EncodeResponseFunc that encodes
+// a league's chat mutes as JSON to the response writer.
+func EncodeHTTPChatMutes(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res, ok := response.([]entities.ChatMute)
+	if !ok {
+		return errors.New("could not get chat mutes response")
+	}
+	bytesJson, err := json.Marshal(&res)
+	if err != nil {
+		return err
+	}
+	w.Write(bytesJson)
+	return nil
+}
+
+// EncodeHTTPDraftPresence is a transport/http.EncodeResponseFunc that encodes
+// which teams have a manager in the draft room as JSON to the response writer.
+func EncodeHTTPDraftPresence(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res, ok := response.(*entities.DraftPresence)
+	if !ok {
+		return errors.New("could not get draft presence response")
+	}
+	bytesJson, err := json.Marshal(&res)
+	if err != nil {
+		return err
+	}
+	w.Write(bytesJson)
+	return nil
+}
+
+func DecodeHTTPDraftLottery(ctx context.Context, r *http.Request) (interface{}, error) {
+	defer r.Body.Close()
+	var req draft.DraftLotteryRequest
+	buf, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read body of http request")
+	}
+	if len(buf) > 0 {
+		if err = json.Unmarshal(buf, &req); err != nil {
+			const size = 8196
+			if len(buf) > size {
+				buf = buf[:size]
+			}
+			return nil, httpError{errors.Wrapf(err, "request body '%s': cannot parse non-json request body", buf),
+				http.StatusBadRequest,
+				nil,
+			}
+		}
+	}
+
+	leagueKey, ok := mux.Vars(r)[leagueIdParam]
+	if !ok {
+		return nil, errors.New("bad request")
+	}
+	req.LeagueKey = leagueKey
+
+	return &req, err
+}
+
+// EncodeHTTPDraftLottery is a transport/http.EncodeResponseFunc that encodes
+// a draft lottery and every step of its draw as JSON to the response writer.
+func EncodeHTTPDraftLottery(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res, ok := response.(*entities.DraftLottery)
+	if !ok {
+		return errors.New("could not get draft lottery response")
+	}
+	bytesJson, err := json.Marshal(&res)
+	if err != nil {
+		return err
+	}
+	w.Write(bytesJson)
+	return nil
+}
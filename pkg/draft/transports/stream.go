@@ -0,0 +1,53 @@
+package transports
+
+import (
+	"context"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	"github.com/thethan/fdr-users/pkg/auth"
+	"github.com/thethan/fdr-users/pkg/draft/stream"
+	"net/http"
+)
+
+// MakeStreamHandler adds the draft room streams to the router:
+// Server-Sent Events on /leagues/{leagueId}/draft/events and a WebSocket on /leagues/{leagueId}/draft/ws.
+// Both take the same Firebase bearer token as the rest of the API.
+func MakeStreamHandler(logger log.Logger, gateway *stream.Gateway, m *mux.Router, authServerBefore httptransport.RequestFunc, authMiddleware endpoint.Middleware) *mux.Router {
+	authenticate := authenticateStream(authServerBefore, authMiddleware)
+
+	leagues := m.PathPrefix("/leagues").Subrouter()
+	leagues.Methods(http.MethodGet).Path("/{" + leagueIdParam + "}/draft/events").Handler(authenticate(func(w http.ResponseWriter, r *http.Request) {
+		gateway.ServeSSE(w, r, mux.Vars(r)[leagueIdParam])
+	}))
+	leagues.Methods(http.MethodGet).Path("/{" + leagueIdParam + "}/draft/ws").Handler(authenticate(func(w http.ResponseWriter, r *http.Request) {
+		gateway.ServeWebSocket(w, r, mux.Vars(r)[leagueIdParam])
+	}))
+	return m
+}
+
+// authenticateStream runs the auth middleware before a streaming handler takes over the connection.
+// Browsers can not set headers on an EventSource or a WebSocket, so the token may also come in the
+// access_token query parameter.
+func authenticateStream(authServerBefore httptransport.RequestFunc, authMiddleware endpoint.Middleware) func(http.HandlerFunc) http.Handler {
+	authenticated := authMiddleware(func(ctx context.Context, _ interface{}) (interface{}, error) {
+		return ctx, nil
+	})
+
+	return func(next http.HandlerFunc) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := authServerBefore(r.Context(), r)
+			if token, _ := ctx.Value(auth.BearerToken).(string); token == "" {
+				ctx = context.WithValue(ctx, auth.BearerToken, r.URL.Query().Get("access_token"))
+			}
+
+			response, err := authenticated(ctx, nil)
+			if err != nil {
+				errorEncoder(ctx, httpError{err, http.StatusUnauthorized, nil}, w)
+				return
+			}
+			next(w, r.WithContext(response.(context.Context)))
+		})
+	}
+}
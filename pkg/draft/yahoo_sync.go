@@ -0,0 +1,144 @@
+package draft
+
+import (
+	"context"
+	"errors"
+	"github.com/go-kit/kit/log/level"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"github.com/thethan/fdr-users/pkg/yahoo"
+	"go.elastic.co/apm"
+	"sort"
+	"strings"
+	"time"
+)
+
+// offlineDraftType is the draft type of Yahoo leagues whose commissioner enters the draft results
+const offlineDraftType = "offline"
+
+// WithYahoo lets the service send completed drafts of offline draft leagues to Yahoo. Requests are made for the
+// league's commissioner, with the session being their guid, so yahoo should use their stored token through
+// yahoo.Service.WithTokenStore, or yahoo.NewStoredTokenInformation where it is not refreshed.
+func (service *Service) WithYahoo(yahooService *yahoo.Service) {
+	service.yahoo = yahooService
+}
+
+// SyncDraftToYahoo sends the results of a completed draft to the league on Yahoo. Picks Yahoo already has are
+// left alone, so it can be run again after a failure or a correction.
+func (service *Service) SyncDraftToYahoo(ctx context.Context, leagueKey string) (*entities.YahooSyncReport, error) {
+	span, ctx := apm.StartSpan(ctx, "SyncDraftToYahoo", "service")
+	span.Context.SetLabel("league_key", leagueKey)
+	defer span.End()
+
+	league, err := service.commissionerLeague(ctx, leagueKey)
+	if err != nil {
+		return nil, err
+	}
+	if league.State() != entities.DraftStateCompleted {
+		return nil, &ErrorDraftState{state: league.State(), action: "be sent to yahoo"}
+	}
+	return service.syncDraft(ctx, league)
+}
+
+// syncToYahoo sends a draft that just completed to Yahoo when the league drafts offline there. It runs on its own
+// goroutine so a slow Yahoo never holds up the draft room.
+func (service *Service) syncToYahoo(league entities.League) {
+	if service.yahoo == nil || !isOfflineDraft(league) {
+		return
+	}
+	go func() {
+		tx := apm.DefaultTracer.StartTransaction("SyncDraftToYahoo", "yahoo")
+		defer tx.End()
+		ctx := apm.ContextWithTransaction(context.Background(), tx)
+
+		report, err := service.syncDraft(ctx, league)
+		if err != nil {
+			return
+		}
+		level.Info(service.logger).Log("message", "sent draft to yahoo", "league_key", league.LeagueKey, "submitted", report.Submitted, "unchanged", report.Unchanged, "failed", report.Failed)
+	}()
+}
+
+// syncDraft enters every pick Yahoo does not already have, one request per pick so that one refused pick does not
+// stop the rest
+func (service *Service) syncDraft(ctx context.Context, league entities.League) (*entities.YahooSyncReport, error) {
+	if service.yahoo == nil {
+		return nil, &ErrorYahooSync{}
+	}
+	if !isOfflineDraft(league) {
+		return nil, &ErrorNotOfflineDraft{leagueKey: league.LeagueKey}
+	}
+	commissioner, ok := leagueCommissioner(league)
+	if !ok {
+		return nil, &ErrorYahooSync{err: errors.New("the league has no commissioner to enter the results")}
+	}
+
+	results, err := service.draftRepo.GetDraftResults(ctx, league.LeagueKey)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not get draft results", "error", err, "league_key", league.LeagueKey)
+		return nil, err
+	}
+
+	client := service.yahoo.ForSession(commissioner.Guid)
+	existing, err := client.GetLeagueResourcesDraftResults(ctx, league.LeagueKey)
+	if err != nil {
+		level.Error(service.logger).Log("message", "could not get draft results from yahoo", "error", err, "league_key", league.LeagueKey)
+		return nil, &ErrorYahooSync{err: err}
+	}
+	onYahoo := make(map[int]string, len(existing.League.DraftResults.DraftResult))
+	for _, result := range existing.League.DraftResults.DraftResult {
+		onYahoo[result.Pick] = result.PlayerKey
+	}
+
+	sorted := make([]entities.DraftResult, len(results))
+	copy(sorted, results)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Pick < sorted[j].Pick
+	})
+
+	report := entities.YahooSyncReport{LeagueKey: league.LeagueKey, Picks: make([]entities.YahooSyncPick, 0, len(sorted))}
+	for _, result := range sorted {
+		pick := entities.YahooSyncPick{Pick: result.Pick, Round: result.Round, TeamKey: result.TeamKey, PlayerKey: result.PlayerKey}
+		if onYahoo[result.Pick] == result.PlayerKey {
+			pick.Status = entities.YahooSyncUnchanged
+			report.Unchanged++
+			report.Picks = append(report.Picks, pick)
+			continue
+		}
+
+		err = client.PutLeagueResourcesDraftResult(ctx, league.LeagueKey, yahoo.DraftResult{
+			Pick:      result.Pick,
+			Round:     result.Round,
+			TeamKey:   result.TeamKey,
+			PlayerKey: result.PlayerKey,
+			Cost:      result.Cost,
+		})
+		if err != nil {
+			level.Error(service.logger).Log("message", "yahoo did not take draft result", "error", err, "league_key", league.LeagueKey, "pick", result.Pick)
+			pick.Status = entities.YahooSyncFailed
+			pick.Error = err.Error()
+			report.Failed++
+		} else {
+			pick.Status = entities.YahooSyncSubmitted
+			report.Submitted++
+		}
+		report.Picks = append(report.Picks, pick)
+	}
+	report.SyncedAt = time.Now()
+	return &report, nil
+}
+
+func isOfflineDraft(league entities.League) bool {
+	return league.Settings != nil && strings.EqualFold(league.Settings.DraftType, offlineDraftType)
+}
+
+// leagueCommissioner is the manager who runs the league on Yahoo
+func leagueCommissioner(league entities.League) (entities.User, bool) {
+	for _, team := range league.Teams {
+		for _, manager := range team.Manager {
+			if manager.IsCommissioner {
+				return manager, true
+			}
+		}
+	}
+	return entities.User{}, false
+}
@@ -0,0 +1,189 @@
+package draft
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"github.com/thethan/fdr-users/pkg/yahoo"
+	"golang.org/x/oauth2"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeYahoo is an offline draft league on Yahoo. Only the commissioner's token may read and enter its results.
+type fakeYahoo struct {
+	mu      sync.Mutex
+	picks   map[int]yahoo.DraftResult
+	refuse  map[int]string
+	puts    int
+	unauths int
+}
+
+func newFakeYahoo() *fakeYahoo {
+	return &fakeYahoo{picks: map[int]yahoo.DraftResult{}, refuse: map[int]string{}}
+}
+
+func (f *fakeYahoo) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer commish-token" {
+		f.unauths++
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if r.URL.Path != "/league/399.l.1/draftresults" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if r.Method == http.MethodPut {
+		f.puts++
+		body, _ := ioutil.ReadAll(r.Body)
+		var request yahoo.DraftResultRequest
+		if err := xml.Unmarshal(body, &request); err != nil || len(request.League.DraftResults.DraftResult) != 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		result := request.League.DraftResults.DraftResult[0]
+		if reason, ok := f.refuse[result.Pick]; ok {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `<?xml version="1.0"?><error xml:lang="en-us"><description>%s</description></error>`, reason)
+			return
+		}
+		f.picks[result.Pick] = result
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+
+	fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?><fantasy_content><league><league_key>399.l.1</league_key><draft_results>`)
+	for _, result := range f.picks {
+		fmt.Fprintf(w, `<draft_result><pick>%d</pick><round>%d</round><team_key>%s</team_key><player_key>%s</player_key></draft_result>`, result.Pick, result.Round, result.TeamKey, result.PlayerKey)
+	}
+	fmt.Fprint(w, `</draft_results></league></fantasy_content>`)
+}
+
+func (f *fakeYahoo) putCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.puts
+}
+
+type fakeOAuthTokens map[string]string
+
+func (f fakeOAuthTokens) GetUserOAuthToken(_ context.Context, guid string) (oauth2.Token, error) {
+	return oauth2.Token{AccessToken: f[guid]}, nil
+}
+
+func offlineLeague() entities.League {
+	league := testLeague()
+	league.Settings.DraftType = "offline"
+	league.SetState(entities.DraftStateCompleted)
+	return league
+}
+
+func yahooSyncService(t *testing.T, league entities.League) (*Service, *fakeDraftRepository, *fakeYahoo) {
+	fake := newFakeYahoo()
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	repo := newFakeDraftRepository(league)
+	repo.results[league.LeagueKey] = []entities.DraftResult{
+		{TeamKey: "399.l.1.t.2", PlayerKey: "399.p.2", Round: 1, Pick: 2},
+		{TeamKey: "399.l.1.t.1", PlayerKey: "399.p.1", Round: 1, Pick: 1},
+		{TeamKey: "399.l.1.t.3", PlayerKey: "399.p.3", Round: 1, Pick: 3},
+	}
+	tokens := fakeOAuthTokens{"commish": "commish-token", "manager-2": "manager-token"}
+	service := NewService(log.NewNopLogger(), repo, newFakeBroadcaster())
+	service.WithYahoo(yahoo.NewService(log.NewNopLogger(), yahoo.NewStoredTokenInformation(tokens)).WithBaseURL(server.URL))
+	return &service, repo, fake
+}
+
+func TestService_SyncDraftToYahoo(t *testing.T) {
+	service, _, fake := yahooSyncService(t, offlineLeague())
+	fake.refuse[3] = "player is not available"
+
+	report, err := service.SyncDraftToYahoo(commissionerContext(), "399.l.1")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, 2, report.Submitted)
+	assert.Equal(t, 1, report.Failed)
+	if assert.Len(t, report.Picks, 3) {
+		assert.Equal(t, entities.YahooSyncPick{Pick: 1, Round: 1, TeamKey: "399.l.1.t.1", PlayerKey: "399.p.1", Status: entities.YahooSyncSubmitted}, report.Picks[0])
+		assert.Equal(t, entities.YahooSyncFailed, report.Picks[2].Status)
+		assert.Equal(t, "yahoo responded with 400: player is not available", report.Picks[2].Error)
+	}
+	assert.Zero(t, fake.unauths, "requests are made with the commissioner's token")
+
+	delete(fake.refuse, 3)
+	report, err = service.SyncDraftToYahoo(commissionerContext(), "399.l.1")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, report.Unchanged, "picks yahoo already has are not sent again")
+	assert.Equal(t, 1, report.Submitted)
+	assert.Zero(t, report.Failed)
+	assert.Equal(t, 4, fake.putCount())
+}
+
+func TestService_SyncDraftToYahoo_SendsCorrectedPicks(t *testing.T) {
+	service, repo, fake := yahooSyncService(t, offlineLeague())
+	_, err := service.SyncDraftToYahoo(commissionerContext(), "399.l.1")
+	assert.Nil(t, err)
+
+	repo.results["399.l.1"][0].PlayerKey = "399.p.20"
+	report, err := service.SyncDraftToYahoo(commissionerContext(), "399.l.1")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, report.Submitted)
+	assert.Equal(t, "399.p.20", fake.picks[2].PlayerKey)
+}
+
+func TestService_SyncDraftToYahoo_Refused(t *testing.T) {
+	league := offlineLeague()
+	service, _, fake := yahooSyncService(t, league)
+
+	_, err := service.SyncDraftToYahoo(context.Background(), "399.l.1")
+	assert.IsType(t, &ErrorNotCommissioner{}, err)
+
+	live := testLeague()
+	live.Settings.DraftType = "live"
+	live.SetState(entities.DraftStateCompleted)
+	service, _, _ = yahooSyncService(t, live)
+	_, err = service.SyncDraftToYahoo(commissionerContext(), "399.l.1")
+	if assert.IsType(t, &ErrorNotOfflineDraft{}, err) {
+		assert.Equal(t, http.StatusConflict, err.(*ErrorNotOfflineDraft).StatusCode())
+	}
+
+	open := testLeague()
+	open.Settings.DraftType = "offline"
+	open.SetState(entities.DraftStateOpen)
+	service, _, _ = yahooSyncService(t, open)
+	_, err = service.SyncDraftToYahoo(commissionerContext(), "399.l.1")
+	assert.IsType(t, &ErrorDraftState{}, err)
+
+	unconfigured := NewService(log.NewNopLogger(), newFakeDraftRepository(league), newFakeBroadcaster())
+	_, err = unconfigured.SyncDraftToYahoo(commissionerContext(), "399.l.1")
+	if assert.IsType(t, &ErrorYahooSync{}, err) {
+		assert.Equal(t, http.StatusServiceUnavailable, err.(*ErrorYahooSync).StatusCode())
+	}
+	assert.Zero(t, fake.putCount())
+}
+
+func TestService_CloseDraft_SyncsToYahoo(t *testing.T) {
+	league := offlineLeague()
+	league.SetState(entities.DraftStateOpen)
+	service, _, fake := yahooSyncService(t, league)
+
+	_, err := service.CloseDraft(commissionerContext(), "399.l.1")
+	assert.Nil(t, err)
+	assert.Eventually(t, func() bool {
+		return fake.putCount() == 3
+	}, time.Second, 10*time.Millisecond, "a completed offline draft is sent to yahoo")
+	assert.Equal(t, "399.p.1", fake.picks[1].PlayerKey)
+}
@@ -3,7 +3,6 @@ package kubemq
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
@@ -14,8 +13,6 @@ import (
 	"strconv"
 )
 
-const draftPrefix = "draft"
-
 type Repository struct {
 	client *kubemq.Client
 	logger log.Logger
@@ -28,108 +25,49 @@ func NewDraftRepository(logger log.Logger, client *kubemq.Client) Repository {
 	}
 }
 
-func buildDraftChannel(leagueKey string) string {
-	return fmt.Sprintf("%s-%s", draftPrefix, leagueKey)
-}
-
-type BroadcastDraftResult struct {
-	Type        entities.BroadcastType
-	Message     string                     `json:"message"`
-	DraftOpen   bool                       `json:"draft_open"`
-	Team        entities.Team              `json:"team"`
-	League      entities.League            `json:"league"`
-	User        entities.User              `json:"user"`
-	DraftResult entities.DraftResult       `json:"draft_result"`
-	Rosters     map[string]entities.Roster `json:"rosters"`
-}
-
-func (r *Repository) BroadCastDraftResult(ctx context.Context, league entities.League, user entities.User, team entities.Team, draftResult entities.DraftResult, pick, round int, rosters map[string]entities.Roster) error {
-	span, ctx := apm.StartSpan(ctx, "BroadCastDraftResult", "kubemq")
+// Publish sends a draft room message to the league's channel in the KubeMQ event store
+func (r *Repository) Publish(ctx context.Context, leagueKey string, message entities.BroadcastDraftResult) error {
+	span, ctx := apm.StartSpan(ctx, "Publish", "kubemq")
 	defer span.End()
 
-	broadcast := BroadcastDraftResult{
-		Type:        entities.BroadCastTypePlayerDrafted,
-		Message:     "Player has been drafted",
-		Team:        team,
-		League:      league,
-		User:        user,
-		DraftResult: draftResult,
-		Rosters:     rosters,
-	}
-
-	event := r.client.ES()
-	event.AddTag("player_key", draftResult.PlayerKey)
-	event.AddTag("pick", strconv.Itoa(draftResult.Pick))
-	event.AddTag("round", strconv.Itoa(draftResult.Round))
-	event.AddTag("team_key", draftResult.TeamKey)
-	event.AddTag("player_id", strconv.Itoa(draftResult.PlayerID))
-
-	channelName := buildDraftChannel(league.LeagueKey)
-	channelName = "draft-" + league.LeagueKey
-
-	data, err := json.Marshal(&broadcast)
+	channelName := entities.DraftChannel(leagueKey)
+	data, err := json.Marshal(&message)
 	if err != nil {
 		level.Error(r.logger).Log("message", "could not set broadcast data", "error", err, "channel_name", channelName)
 		return err
 	}
 
-	level.Debug(r.logger).Log("message", "sending draft result to kubeqm", "pick", pick, "round", round, "league_key", league.LeagueKey, "channel_name", channelName)
-
-	result, err :=
-		event.SetId(fmt.Sprintf("%s-%d", channelName, draftResult.Pick)).
-			SetChannel(channelName).
-			SetMetadata(fmt.Sprintf("%s-%d-%d", league.LeagueKey, draftResult.Round, draftResult.Pick)).
-			SetBody(data).
-			Send(ctx)
-
-	if err != nil {
-		level.Debug(r.logger).Log("message", "error sending draft result to kubeqm", "pick", pick, "round", round, "league_key", league.LeagueKey, "channel_name", channelName)
-	}
-	level.Debug(r.logger).Log("kubemq_result", result)
-	return err
-}
-
-func buildDraftInformationChannel(leagueKey string) string {
-	return fmt.Sprintf("%s-info-%s", draftPrefix, leagueKey)
-}
-
-func (r *Repository) BroadCastLeagueInformation(ctx context.Context, league entities.League, message string, broadcastType entities.BroadcastType) error {
-	span, ctx := apm.StartSpan(ctx, "BroadCastLeagueInformation", "kubemq")
-	defer span.End()
-
 	event := r.client.ES()
-	event.AddTag("league_key", league.LeagueKey)
-
-	channelName := buildDraftInformationChannel(league.LeagueKey)
-	channelName = "draft-" + league.LeagueKey
-
-	broadcast := BroadcastDraftResult{
-		Type:    broadcastType,
-		League:  league,
-		Message: "Draft Is Open",
+	event.AddTag("league_key", leagueKey)
+	event.AddTag("type", strconv.Itoa(int(message.Type)))
+	id := fmt.Sprintf("%s-%s", channelName, uuid.New().String())
+	metadata := leagueKey
+	if message.Type == entities.BroadCastTypePlayerDrafted {
+		event.AddTag("player_key", message.DraftResult.PlayerKey)
+		event.AddTag("pick", strconv.Itoa(message.DraftResult.Pick))
+		event.AddTag("round", strconv.Itoa(message.DraftResult.Round))
+		event.AddTag("team_key", message.DraftResult.TeamKey)
+		event.AddTag("player_id", strconv.Itoa(message.DraftResult.PlayerID))
+		id = fmt.Sprintf("%s-%d", channelName, message.DraftResult.Pick)
+		metadata = fmt.Sprintf("%s-%d-%d", leagueKey, message.DraftResult.Round, message.DraftResult.Pick)
 	}
-	data, err := json.Marshal(&broadcast)
-	if err != nil {
-		level.Error(r.logger).Log("message", "could not set broadcast data", "error", err, "channel_name", channelName)
-		return err
+	if message.Auction != nil {
+		event.AddTag("player_key", message.Auction.PlayerKey)
+		event.AddTag("team_key", message.Auction.HighBid.TeamKey)
+		event.AddTag("amount", strconv.Itoa(message.Auction.HighBid.Amount))
 	}
 
-	level.Debug(r.logger).Log("message", "broadcast open draft failed", "league_key", league.LeagueKey, "channel_name", channelName)
-
+	level.Debug(r.logger).Log("message", "sending broadcast to kubemq", "type", message.Type, "league_key", leagueKey, "channel_name", channelName)
 	result, err :=
-		event.SetId(fmt.Sprintf("%s-%s", channelName, uuid.New().String())).
+		event.SetId(id).
 			SetChannel(channelName).
-			SetMetadata(fmt.Sprintf("%s", league.LeagueKey)).
+			SetMetadata(metadata).
 			SetBody(data).
 			Send(ctx)
 
 	if err != nil {
-		level.Debug(r.logger).Log("message", "error sending draft result to kubeqm", "league_key", league.LeagueKey, "channel_name", channelName)
+		level.Debug(r.logger).Log("message", "error sending broadcast to kubeqm", "type", message.Type, "league_key", leagueKey, "channel_name", channelName)
 	}
 	level.Debug(r.logger).Log("kubemq_result", result)
 	return err
 }
-
-func (r *Repository) ChangeTeamName(ctx context.Context, league entities.League, user entities.User, team entities.Team) error {
-	return errors.New("not yet implemented")
-}
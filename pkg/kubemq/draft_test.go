@@ -4,71 +4,32 @@ import (
 	"context"
 	"github.com/go-kit/kit/log"
 	"github.com/google/uuid"
-	"github.com/kubemq-io/kubemq-go"
-	"github.com/thethan/fdr-users/pkg/draft/entities"
-	"go.mongodb.org/mongo-driver/bson/primitive"
+	"github.com/thethan/fdr-users/pkg/broadcast"
+	"github.com/thethan/fdr-users/pkg/broadcast/broadcasttest"
+	"os"
+	"strconv"
 	"testing"
-	"time"
 )
 
-func TestRepository_BroadCastDraftResult(t *testing.T) {
-	type fields struct {
-		client *kubemq.Client
-		logger log.Logger
+// TestRepository runs the broadcast conformance suite against the KubeMQ cluster at KUBEMQ_SERVICE
+func TestRepository(t *testing.T) {
+	address := os.Getenv("KUBEMQ_SERVICE")
+	if address == "" {
+		t.Skip("KUBEMQ_SERVICE is not set")
 	}
-	type args struct {
-		ctx         context.Context
-		league      entities.League
-		user        entities.User
-		team        entities.Team
-		draftResult entities.DraftResult
-		pick        int
-		round       int
+	port, err := strconv.Atoi(os.Getenv("KUBEMQ_PORT"))
+	if err != nil {
+		port = 50000
 	}
-	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		wantErr bool
-	}{
-		// TODO: Add test cases.
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			client, _ := NewKubeMQClient(context.TODO(), "localhost", 50000, uuid.New().String())
-			r := &Repository{
-				client: client,
-				logger: log.NewNopLogger(),
-			}
-			league := entities.League{
-				LeagueKey:      "399.l.19481",
-				Name:           "",
-				LeagueID:       0,
-				LeagueGroup:    primitive.ObjectID{},
-				PreviousLeague: nil,
-				Settings:       nil,
-				Teams:          nil,
-				Game:           entities.Game{},
-				DraftOrder:     nil,
-				TeamDraftOrder: nil,
-				DraftStarted:   false,
-				DraftedCheck:   nil,
-			}
-			draftResult := entities.DraftResult{
-				UserGUID:  "",
-				PlayerKey: "",
-				PlayerID:  0,
-				LeagueKey: "",
-				TeamKey:   "",
-				Round:     0,
-				Pick:      time.Now().Nanosecond(),
-				Timestamp: time.Time{},
-				GameID:    0,
-				Player:    nil,
-			}
-			if err := r.BroadCastDraftResult(tt.args.ctx, league, tt.args.user, tt.args.team, draftResult, tt.args.pick, tt.args.round); (err != nil) != tt.wantErr {
-				t.Errorf("BroadCastDraftResult() error = %v, wantErr %v", err, tt.wantErr)
-			}
-		})
-	}
-}
\ No newline at end of file
+
+	broadcasttest.Run(t, func(t *testing.T) broadcast.Backend {
+		client, err := NewKubeMQClient(context.Background(), address, port, uuid.New().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = client.Close() })
+
+		repository := NewDraftRepository(log.NewNopLogger(), client)
+		return &repository
+	})
+}
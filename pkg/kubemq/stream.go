@@ -0,0 +1,59 @@
+package kubemq
+
+import (
+	"context"
+	"github.com/go-kit/kit/log/level"
+	"github.com/google/uuid"
+	"github.com/kubemq-io/kubemq-go"
+	"github.com/thethan/fdr-users/pkg/broadcast"
+	"github.com/thethan/fdr-users/pkg/draft/entities"
+	"github.com/thethan/fdr-users/pkg/draft/stream"
+)
+
+var _ broadcast.Backend = &Repository{}
+
+// Subscribe reads a league's draft channel from the event store for the streaming gateway. The event
+// store keeps every broadcast, so a subscription can start right after any sequence a client has seen.
+func (r *Repository) Subscribe(ctx context.Context, leagueKey string, after uint64) (<-chan stream.Event, error) {
+	channelName := entities.DraftChannel(leagueKey)
+
+	start := kubemq.StartFromNewEvents()
+	if after > 0 {
+		start = kubemq.StartFromSequence(int(after + 1))
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	errCh := make(chan error, 1)
+	received, err := r.client.SubscribeToEventsStore(ctx, channelName, "", errCh, start)
+	if err != nil {
+		cancel()
+		level.Error(r.logger).Log("message", "could not subscribe to draft channel", "error", err, "channel_name", channelName)
+		return nil, err
+	}
+
+	subscriberID := uuid.New().String()
+	events := make(chan stream.Event)
+	go func() {
+		defer cancel()
+		defer close(events)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-errCh:
+				level.Error(r.logger).Log("message", "draft channel subscription failed", "error", err, "channel_name", channelName, "subscriber", subscriberID)
+				return
+			case event, ok := <-received:
+				if !ok {
+					return
+				}
+				select {
+				case events <- stream.Event{Sequence: event.Sequence, Data: event.Body}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return events, nil
+}
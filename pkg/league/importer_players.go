@@ -18,7 +18,7 @@ func (i Importer) ImportGamePlayers(ctx context.Context, gameID int, ) error {
 	var fin bool
 	for !fin {
 		startingCounter := count * counter
-		res, err := i.yahooService.GetGameResourcesPlayers(ctx, gameID, startingCounter, count)
+		res, err := i.yahooService.GetGameResourcesPlayers(ctx, gameID, yahoo.NewOptions().Start(startingCounter).Count(count))
 		if err != nil {
 			// complain
 			level.Error(i.logger).Log("error", err)
@@ -2,64 +2,119 @@ package league
 
 import (
 	"context"
+	"errors"
 	"github.com/go-kit/kit/log"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
 	"github.com/thethan/fdr-users/internal/test_helpers"
+	"github.com/thethan/fdr-users/pkg/auth"
 	"github.com/thethan/fdr-users/pkg/draft/entities"
-	"github.com/thethan/fdr-users/pkg/draft/repositories"
-	"github.com/thethan/fdr-users/pkg/mongo"
-	entities2 "github.com/thethan/fdr-users/pkg/users/entities"
 	"github.com/thethan/fdr-users/pkg/yahoo"
-	"go.elastic.co/apm"
-	"os"
-	"reflect"
+	"github.com/thethan/fdr-users/pkg/yahoo/yahootest"
+	"strconv"
+	"sync"
 	"testing"
 )
 
-type mockGetUserInformation struct {
-	mock.Mock
+// fakeLeagueRepository keeps what the importer saves in memory
+type fakeLeagueRepository struct {
+	mu           *sync.Mutex
+	leagues      map[string][]entities.League // by manager guid
+	teams        map[string][]entities.Team   // by league key
+	leagueGroups []*entities.LeagueGroup
+	players      []entities.PlayerSeason
+	results      []entities.DraftResult
+	orders       map[string][]string
 }
 
-func getUser() entities2.User {
-	return entities2.User{
-		AccessToken: "3oxRd8Sd7gCQ1_5hI6It6aon70cfgK_yg5PjVt4mnCIhFSeMZ6u9SUNlweofBLten53DznzPyIe._wPbKSKZapFlXGvgmLwJewZJqP_WwG3TcUzZfIPEY5wOcEMQBwsmExsyoj_Eb5uzY69Ptp6tgVfORfyhFY1Kl4HsHljUbzPhMW_2m97uMcubv5heQqjtOwv5tHSqXadUMF4A8ENS.txbjPmZIu2KWwQkR7rwYvE8WxRucaXX6K0KG.Hu8gDgkUFJfM67CzK25PaH0DI7dG1X.2DtDrcSDF85xVy8vObWKHKejXEUdHllnxEpUQbJ3wN3Cb2oYX8b77f50y00jki.J5.F58Df3wNLFZQvVmWF_2_eCueBbPgXigLsnDGw1ltlDgS5vtmxvqe_c8HGPCjOAvkaeaBk2qx10Zwcpt9yiBdv1t1.3XWl3F3nL9qsoIk1u1bk.ldCkZxnh1YItKIxwdy1y_aE5SBxidr7NAVuhyeRv0ZRI0vv7Fi9tMoflQfKIe_fWwvJOQ.s.2qjp3nSUSGPwrHgYbAt4QrcYqXvclJ5IJt5EsCQmpS6X5r3L5O33ujzRRs9gR07m2_VuYajr6wFB4OeMpSGXL0edxJoncOJP5vmS46nOVvHCW3NsHFN5z5gZ_QYBo5ifihWy2qTJnzL6Cd2IfhwWRxiSZGFWAnDRT1yz95QDazsMSM.fF1WHAaxYoq7xwldSiTQtLeTswejOj8AsscB7vnNozblYg0.jq0kUKUVxHSueXl21zjmy6Euew005YSukQTaNaiFLcdyGUd01HP56Joed4KVO7CvA54QtEuTH2dedWkNwkTzVyl1yYFRX2HWFcSZoD.4YVMfnivAxl92EHg.qiJ3w4_5xcLmCGm8lbR5IJ0o1JQKi2BhmN7_d5M4d58tTbXEFlRs3IpMwdbWZzSetwza7geVeLrIT_F6CD2nd.dg189s76TYh6Fyokx.d1PyGGGrLQkIXH3VAxdq1PQEiJnV7dlxCN3CYHBol5gviOseYQtym_YdJszvWVtix2BY74L8DeMpLLmeIlB8fvGTLIdfBeVAlFDMBG1hhDYV5GJr4yU.bLZQ8yHAgHDLzpJ4Y_h6Lrl1tC4YjCBVjuieug--",
+func newFakeLeagueRepository() *fakeLeagueRepository {
+	return &fakeLeagueRepository{
+		mu:      &sync.Mutex{},
+		leagues: map[string][]entities.League{},
+		teams:   map[string][]entities.Team{},
+		orders:  map[string][]string{},
 	}
 }
 
-func (m *mockGetUserInformation) GetCredentialInformation(ctx context.Context, session string) (entities2.User, error) {
-	args := m.Called(ctx, session)
-	return args.Get(0).(entities2.User), args.Error(1)
+func (f *fakeLeagueRepository) GetLeague(ctx context.Context, leagueKey string) (entities.League, error) {
+	return entities.League{}, errors.New("not in the fake")
 }
 
-type mockSaveLeagues struct {
-	mock.Mock
+func (f *fakeLeagueRepository) GetPlayers(ctx context.Context, playerKeys []string) ([]entities.PlayerSeason, error) {
+	return nil, errors.New("not in the fake")
 }
 
-func (m *mockSaveLeagues) SaveLeague(ctx context.Context, group *entities.LeagueGroup) (*entities.LeagueGroup, error) {
-	args := m.Called(ctx, group)
-	return args.Get(0).(*entities.LeagueGroup), args.Error(1)
+func (f *fakeLeagueRepository) GetTeamsForLeague(ctx context.Context, leagueKey string) ([]entities.Team, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.teams[leagueKey], nil
 }
 
-func TestImporter_ImportLeagueFromUser(t *testing.T) {
-	logger := log.NewNopLogger()
-	ctx := context.Background()
+func (f *fakeLeagueRepository) GetTeamsForManagers(ctx context.Context, guid string) ([]entities.League, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.leagues[guid], nil
+}
 
-	mockUserInfo := mockGetUserInformation{}
+func (f *fakeLeagueRepository) SaveDraftOrder(ctx context.Context, leagueKey string, teamOrder []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.orders[leagueKey] = teamOrder
+	return nil
+}
 
-	mockUserInfo.On("GetCredentialInformation", mock.AnythingOfType(reflect.TypeOf(ctx).String()), mock.AnythingOfType("string")).Return(getUser(), nil)
+func (f *fakeLeagueRepository) SaveDraftResult(ctx context.Context, draftResult entities.DraftResult) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.results = append(f.results, draftResult)
+	return nil
+}
 
-	yahooService := yahoo.NewService(logger, &mockUserInfo)
+func (f *fakeLeagueRepository) SaveDraftResults(ctx context.Context, draftResults []entities.DraftResult) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.results = append(f.results, draftResults...)
+	return nil
+}
 
-	mongoClient, err := mongo.NewMongoDBClient(context.TODO(),os.Getenv("MONGO_USERNAME"), os.Getenv("MONGO_PASSWORD"), os.Getenv("MONGO_HOST"), os.Getenv("MONGO_PORT"))
-	if err != nil {
-		logger.Log("message", "error in initializing mongo client", "error", err)
-		t.FailNow()
+func (f *fakeLeagueRepository) SaveLeagueLeagueGroup(ctx context.Context, group *entities.LeagueGroup) (*entities.LeagueGroup, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.leagueGroups = append(f.leagueGroups, group)
+	return group, nil
+}
+
+func (f *fakeLeagueRepository) SavePlayers(ctx context.Context, players []entities.PlayerSeason) ([]entities.PlayerSeason, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.players = append(f.players, players...)
+	return players, nil
+}
+
+// fixtureLeagueTeams are the teams of the 2020 league in the fixtures, as the league import saves them
+func fixtureLeagueTeams() []entities.Team {
+	teams := make([]entities.Team, 4)
+	for idx := range teams {
+		id := idx + 1
+		teams[idx] = entities.Team{
+			TeamKey: "399.l.200.t." + strconv.Itoa(id),
+			TeamID:  id,
+			Manager: []entities.User{{Guid: "guid-manager-" + strconv.Itoa(id)}},
+		}
 	}
+	return teams
+}
 
-	mongoRepo := repositories.NewMongoRepository(logger, mongoClient, "fdr", "draft", "fdr_user", "roster")
+// fixtureImporter imports from the fake Yahoo into a fake repository
+func fixtureImporter(t *testing.T) (Importer, *fakeLeagueRepository, *yahootest.Server) {
+	server := yahootest.NewServer(t, yahootest.Fixtures())
+	yahooService := yahoo.NewService(log.NewNopLogger(), yahootest.Credentials(yahootest.Token)).WithBaseURL(server.URL)
+	repo := newFakeLeagueRepository()
+	return NewImportService(log.NewNopLogger(), yahooService, repo, test_helpers.TestingTracer()), repo, server
+}
 
-	svc := NewImportService(logger, yahooService, &mongoRepo, nil)
+func TestImporter_ImportLeagueFromUser(t *testing.T) {
+	svc, repo, _ := fixtureImporter(t)
+	ctx := context.Background()
 
 	leagueGroups, err := svc.ImportLeagueFromUser(ctx)
 	assert.Nil(t, err)
@@ -69,155 +124,70 @@ func TestImporter_ImportLeagueFromUser(t *testing.T) {
 	}
 	for idx := range leagueGroups {
 		assert.True(t, len(leagueGroups[idx].Leagues) > 0, "league groups do not have league")
-		// add league group to the first one
-		//nLeague, err := mongoRepo.SaveLeagueLeagueGroup(ctx, leagueGroup)
-		assert.Nil(t, err)
+	}
+	assert.Len(t, repo.leagueGroups, len(leagueGroups))
+
+	// the 2020 league renews the 2019 one, so both are in one group, oldest first
+	if assert.Len(t, leagueGroups, 1) && assert.Len(t, leagueGroups[0].Leagues, 2) {
+		renewed := leagueGroups[0].Leagues[1]
+		assert.Equal(t, "390.l.100", leagueGroups[0].Leagues[0].LeagueKey)
+		assert.Equal(t, "399.l.200", renewed.LeagueKey)
+		assert.Equal(t, "390.l.100", *renewed.PreviousLeague)
+		if assert.Len(t, renewed.Teams, 4) {
+			assert.Equal(t, "guid-manager-1", renewed.Teams[0].Manager[0].Guid)
+		}
 	}
 }
 
 func TestImporter_ImportGamePlayers(t *testing.T) {
-	logger := test_helpers.LogrusLogger(t)
+	svc, repo, server := fixtureImporter(t)
 	ctx := context.Background()
-	tracer, err := apm.NewTracer(t.Name(), "0")
-	if tracer == nil || err != nil {
-		t.Fail()
-		t.FailNow()
-	}
-	tx := tracer.StartTransaction(t.Name(), "0")
-	span, ctx := apm.StartSpanOptions(ctx, "TestImporter_ImportGamePlayers", "test", apm.SpanOptions{
-		Parent: tx.TraceContext(),
-	})
-	defer span.End()
-	defer tx.End()
-
-	mockUserInfo := mockGetUserInformation{}
-
-	mockUserInfo.On("GetCredentialInformation", mock.AnythingOfType(reflect.TypeOf(ctx).String()), mock.AnythingOfType("string")).Return(getUser(), nil)
-
-	yahooService := yahoo.NewService(logger, &mockUserInfo)
-
-	mongoClient, err := mongo.NewMongoDBClient(context.TODO(),os.Getenv("MONGO_USERNAME"), os.Getenv("MONGO_PASSWORD"), os.Getenv("MONGO_HOST"),os.Getenv("MONGO_PORT"))
-	if err != nil {
-		logger.Log("message", "error in initializing mongo client", "error", err)
-		t.FailNow()
-	}
-
-	mongoRepo := repositories.NewMongoRepository(logger, mongoClient, "fdr", "draft", "fdr_user", "roster")
 
-	svc := NewImportService(logger, yahooService, &mongoRepo, nil)
-
-	err = svc.ImportGamePlayers(ctx, 399)
+	err := svc.ImportGamePlayers(ctx, 399)
 	assert.Nil(t, err)
+	assert.Equal(t, []string{"/game/399/players;start=0;count=25/stats"}, server.Requests(), "a short page is the last")
+	if assert.Len(t, repo.players, 3) {
+		assert.Equal(t, "399.p.30123", repo.players[0].PlayerKey)
+		assert.Equal(t, 1, repo.players[0].Ranks["yahoo"])
+		assert.Equal(t, 3, repo.players[2].Ranks["yahoo"])
+		assert.Len(t, repo.players[0].SeasonStats, 4)
+	}
 }
 
-
-
 func TestImporter_ImportDraftResult(t *testing.T) {
-	logger := test_helpers.LogrusLogger(t)
+	svc, repo, _ := fixtureImporter(t)
 	ctx := context.Background()
-	tracer, err := apm.NewTracer(t.Name(), "0")
-	if tracer == nil || err != nil {
-		t.Fail()
-		t.FailNow()
-	}
-	tx := tracer.StartTransaction(t.Name(), "0")
-	span, ctx := apm.StartSpanOptions(ctx, "TestImporter_ImportDraftResults", "test", apm.SpanOptions{
-		Parent: tx.TraceContext(),
-	})
-	defer span.End()
-	defer tx.End()
-
-	mockUserInfo := mockGetUserInformation{}
-
-	mockUserInfo.On("GetCredentialInformation", mock.AnythingOfType(reflect.TypeOf(ctx).String()), mock.AnythingOfType("string")).Return(getUser(), nil)
+	repo.teams["399.l.200"] = fixtureLeagueTeams()
 
-	yahooService := yahoo.NewService(logger, &mockUserInfo)
-
-	mongoClient, err := mongo.NewMongoDBClient(context.TODO(),os.Getenv("MONGO_USERNAME"), os.Getenv("MONGO_PASSWORD"), os.Getenv("MONGO_HOST"), os.Getenv("MONGO_PORT"))
-	if err != nil {
-		logger.Log("message", "error in initializing mongo client", "error", err)
-		t.FailNow()
-	}
-
-	mongoRepo := repositories.NewMongoRepository(logger, mongoClient, "fdr", "draft", "fdr_user", "roster")
-
-	svc := NewImportService(logger, yahooService, &mongoRepo, nil)
-
-	_, err = svc.ImportDraftResults(ctx, "380.l.53275")
+	_, err := svc.ImportDraftResults(ctx, "399.l.200")
 	assert.Nil(t, err)
+	if assert.Len(t, repo.results, 8) {
+		assert.Equal(t, "guid-manager-2", repo.results[0].UserGUID)
+		assert.Equal(t, 30123, repo.results[0].PlayerID)
+	}
+	assert.Equal(t, []string{"399.l.200.t.2", "399.l.200.t.4", "399.l.200.t.1", "399.l.200.t.3"}, repo.orders["399.l.200"])
 }
 
-
-
 func TestImporter_ImportDraftResultForUser(t *testing.T) {
-	logger := test_helpers.LogrusLogger(t)
+	svc, repo, _ := fixtureImporter(t)
 	ctx := context.Background()
-	tracer, err := apm.NewTracer(t.Name(), "0")
-	if tracer == nil || err != nil {
-		t.Fail()
-		t.FailNow()
-	}
-	tx := tracer.StartTransaction(t.Name(), "0")
-	span, ctx := apm.StartSpanOptions(ctx, "TestImporter_ImportDraftResults", "test", apm.SpanOptions{
-		Parent: tx.TraceContext(),
-	})
-	defer span.End()
-	defer tx.End()
-
-	mockUserInfo := mockGetUserInformation{}
-
-	mockUserInfo.On("GetCredentialInformation", mock.AnythingOfType(reflect.TypeOf(ctx).String()), mock.AnythingOfType("string")).Return(getUser(), nil)
-
-	yahooService := yahoo.NewService(logger, &mockUserInfo)
-
-	mongoClient, err := mongo.NewMongoDBClient(context.TODO(),os.Getenv("MONGO_USERNAME"), os.Getenv("MONGO_PASSWORD"), os.Getenv("MONGO_HOST"), os.Getenv("MONGO_PORT"))
-	if err != nil {
-		logger.Log("message", "error in initializing mongo client", "error", err)
-		t.FailNow()
-	}
-
-	mongoRepo := repositories.NewMongoRepository(logger, mongoClient, "fdr", "draft", "fdr_user", "roster")
+	repo.teams["399.l.200"] = fixtureLeagueTeams()
+	repo.leagues["guid-manager-1"] = []entities.League{{LeagueKey: "399.l.0"}, {LeagueKey: "399.l.200"}}
 
-	svc := NewImportService(logger, yahooService, &mongoRepo, nil)
-
-	 err = svc.ImportDraftResultsForUser(ctx, "MFG5HMFDHC634Q7W2FPKJBVTKY")
-	assert.Nil(t, err)
+	err := svc.ImportDraftResultsForUser(ctx, "guid-manager-1")
+	assert.Nil(t, err, "a league yahoo does not know does not stop the others")
+	assert.Len(t, repo.results, 8)
+	assert.Len(t, repo.orders, 1)
 }
 
-
-
 func TestImporter_ImportPlayersFromGamesForUser(t *testing.T) {
-	logger := test_helpers.LogrusLogger(t)
+	svc, _, _ := fixtureImporter(t)
 	ctx := context.Background()
-	tracer, err := apm.NewTracer(t.Name(), "0")
-	if tracer == nil || err != nil {
-		t.Fail()
-		t.FailNow()
-	}
-	tx := tracer.StartTransaction(t.Name(), "0")
-	span, ctx := apm.StartSpanOptions(ctx, "TestImporter_ImportPlayersFromGamesForUser", "test", apm.SpanOptions{
-		Parent: tx.TraceContext(),
-	})
-	defer span.End()
-	defer tx.End()
 
-	mockUserInfo := mockGetUserInformation{}
+	err := svc.ImportGamePlayersUserHasAccessTo(ctx, "guid-manager-1")
+	assert.Error(t, err, "the user is taken from the context")
 
-	mockUserInfo.On("GetCredentialInformation", mock.AnythingOfType(reflect.TypeOf(ctx).String()), mock.AnythingOfType("string")).Return(getUser(), nil)
-
-	yahooService := yahoo.NewService(logger, &mockUserInfo)
-
-	mongoClient, err := mongo.NewMongoDBClient(context.TODO(),os.Getenv("MONGO_USERNAME"), os.Getenv("MONGO_PASSWORD"), os.Getenv("MONGO_HOST"), os.Getenv("MONGO_PORT"))
-	if err != nil {
-		logger.Log("message", "error in initializing mongo client", "error", err)
-		t.FailNow()
-	}
-
-	mongoRepo := repositories.NewMongoRepository(logger, mongoClient, "fdr", "draft", "fdr_user", "roster")
-
-	svc := NewImportService(logger, yahooService, &mongoRepo, nil)
-
-	err = svc.ImportGamePlayersUserHasAccessTo(ctx, "MFG5HMFDHC634Q7W2FPKJBVTKY")
+	ctx = context.WithValue(ctx, auth.User, entities.User{Guid: "guid-manager-1"})
+	err = svc.ImportGamePlayersUserHasAccessTo(ctx, "guid-manager-1")
 	assert.Nil(t, err)
 }
-
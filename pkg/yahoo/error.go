@@ -0,0 +1,123 @@
+package yahoo
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrorResponse is the body Yahoo answers a failed request with. A failure that is none of the errors below
+// is returned as it is.
+type ErrorResponse struct {
+	XMLName     xml.Name `xml:"error"`
+	Description string   `xml:"description"`
+	Status      int      `xml:"-"`
+}
+
+func (e *ErrorResponse) Error() string {
+	if e.Description == "" {
+		return fmt.Sprintf("yahoo responded with %d", e.Status)
+	}
+	return fmt.Sprintf("yahoo responded with %d: %s", e.Status, e.Description)
+}
+
+func (e *ErrorResponse) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// ErrorTokenExpired is returned when the user's access token has expired and has to be refreshed
+type ErrorTokenExpired struct {
+	ErrorResponse
+}
+
+func (e *ErrorTokenExpired) StatusCode() int {
+	return http.StatusUnauthorized
+}
+
+// ErrorForbidden is returned when the user may not see or change the resource, or Yahoo does not accept their token
+type ErrorForbidden struct {
+	ErrorResponse
+}
+
+func (e *ErrorForbidden) StatusCode() int {
+	return http.StatusForbidden
+}
+
+// ErrorNotFound is returned for a game, league, team or player key Yahoo does not know
+type ErrorNotFound struct {
+	ErrorResponse
+}
+
+func (e *ErrorNotFound) StatusCode() int {
+	return http.StatusNotFound
+}
+
+// ErrorRateLimited is returned when Yahoo still throttles the request after the Transport gave up retrying it
+type ErrorRateLimited struct {
+	ErrorResponse
+	RetryAfter time.Duration
+}
+
+func (e *ErrorRateLimited) StatusCode() int {
+	return http.StatusTooManyRequests
+}
+
+func (e *ErrorRateLimited) Headers() http.Header {
+	headers := http.Header{}
+	if e.RetryAfter > 0 {
+		headers.Set("Retry-After", fmt.Sprintf("%.0f", e.RetryAfter.Seconds()))
+	}
+	return headers
+}
+
+// ErrorServer is returned when Yahoo fails to answer
+type ErrorServer struct {
+	ErrorResponse
+}
+
+func (e *ErrorServer) StatusCode() int {
+	return http.StatusBadGateway
+}
+
+// CheckResponse returns nil for a successful response. Otherwise it reads and closes the body, and returns the
+// error that matches the status and Yahoo's description of what went wrong.
+func CheckResponse(res *http.Response) error {
+	if res.StatusCode < http.StatusMultipleChoices {
+		return nil
+	}
+	defer res.Body.Close()
+
+	failure := ErrorResponse{Status: res.StatusCode}
+	body, err := ioutil.ReadAll(res.Body)
+	if err == nil {
+		_ = xml.Unmarshal(body, &failure)
+	}
+	failure.Description = strings.TrimSpace(failure.Description)
+	description := strings.ToLower(failure.Description)
+
+	switch {
+	case res.StatusCode == http.StatusUnauthorized && (strings.Contains(description, "token_expired") || strings.Contains(res.Header.Get("WWW-Authenticate"), "token_expired")):
+		return &ErrorTokenExpired{ErrorResponse: failure}
+	case res.StatusCode == http.StatusUnauthorized || res.StatusCode == http.StatusForbidden:
+		return &ErrorForbidden{ErrorResponse: failure}
+	case res.StatusCode == http.StatusNotFound:
+		return &ErrorNotFound{ErrorResponse: failure}
+	case res.StatusCode == http.StatusBadRequest && isUnknownKey(description):
+		return &ErrorNotFound{ErrorResponse: failure}
+	case res.StatusCode == http.StatusTooManyRequests || res.StatusCode == StatusRequestDenied:
+		return &ErrorRateLimited{ErrorResponse: failure, RetryAfter: retryAfter(res.Header.Get("Retry-After"), time.Now())}
+	case res.StatusCode >= http.StatusInternalServerError:
+		return &ErrorServer{ErrorResponse: failure}
+	}
+	return &failure
+}
+
+// isUnknownKey is true for the 400s Yahoo answers a key it does not know with,
+// like "Invalid league key 399.l.0" or "League key 399.l.0 does not exist."
+func isUnknownKey(description string) bool {
+	return strings.Contains(description, "does not exist") ||
+		(strings.HasPrefix(description, "invalid") && strings.Contains(description, "key"))
+}
@@ -0,0 +1,108 @@
+package yahoo
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func yahooError(status int, description string) *http.Response {
+	body := `<?xml version="1.0" encoding="UTF-8"?>
+<error xml:lang="en-us" yahoo:uri="http://fantasysports.yahooapis.com/fantasy/v2/league/399.l.0" xmlns:yahoo="http://www.yahooapis.com/v1/base.rng" xmlns="http://www.yahooapis.com/v1/base.rng">
+ <description>` + description + `</description>
+ <detail/>
+</error>`
+	return &http.Response{StatusCode: status, Header: http.Header{}, Body: ioutil.NopCloser(strings.NewReader(body))}
+}
+
+func TestCheckResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		res         *http.Response
+		want        error
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:        "token expired",
+			res:         yahooError(http.StatusUnauthorized, `Please provide valid credentials. OAuth oauth_problem="token_expired", realm="yahooapis.com"`),
+			want:        &ErrorTokenExpired{},
+			wantStatus:  http.StatusUnauthorized,
+			wantMessage: `yahoo responded with 401: Please provide valid credentials. OAuth oauth_problem="token_expired", realm="yahooapis.com"`,
+		},
+		{
+			name:       "token rejected",
+			res:        yahooError(http.StatusUnauthorized, `Please provide valid credentials. OAuth oauth_problem="token_rejected", realm="yahooapis.com"`),
+			want:       &ErrorForbidden{},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "not the commissioner",
+			res:        yahooError(http.StatusForbidden, "You are not allowed to view this page."),
+			want:       &ErrorForbidden{},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:        "invalid league key",
+			res:         yahooError(http.StatusBadRequest, "Invalid league key 399.l.0"),
+			want:        &ErrorNotFound{},
+			wantStatus:  http.StatusNotFound,
+			wantMessage: "yahoo responded with 400: Invalid league key 399.l.0",
+		},
+		{
+			name:       "league does not exist",
+			res:        yahooError(http.StatusBadRequest, "League key 399.l.0 does not exist."),
+			want:       &ErrorNotFound{},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "request denied",
+			res:        &http.Response{StatusCode: StatusRequestDenied, Header: http.Header{}, Body: ioutil.NopCloser(strings.NewReader("Request denied"))},
+			want:       &ErrorRateLimited{},
+			wantStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:       "server error",
+			res:        yahooError(http.StatusServiceUnavailable, ""),
+			want:       &ErrorServer{},
+			wantStatus: http.StatusBadGateway,
+		},
+		{
+			name:        "refused",
+			res:         yahooError(http.StatusBadRequest, "player is not available"),
+			want:        &ErrorResponse{},
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "yahoo responded with 400: player is not available",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckResponse(tt.res)
+			assert.IsType(t, tt.want, err)
+			if coder, ok := err.(interface{ StatusCode() int }); assert.True(t, ok) {
+				assert.Equal(t, tt.wantStatus, coder.StatusCode())
+			}
+			if tt.wantMessage != "" {
+				assert.EqualError(t, err, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestCheckResponse_Success(t *testing.T) {
+	assert.Nil(t, CheckResponse(&http.Response{StatusCode: http.StatusOK}))
+	assert.Nil(t, CheckResponse(&http.Response{StatusCode: http.StatusCreated}))
+}
+
+func TestErrorRateLimited_Headers(t *testing.T) {
+	res := yahooError(http.StatusTooManyRequests, "")
+	res.Header.Set("Retry-After", "30")
+	err := CheckResponse(res)
+	if assert.IsType(t, &ErrorRateLimited{}, err) {
+		assert.Equal(t, 30*time.Second, err.(*ErrorRateLimited).RetryAfter)
+		assert.Equal(t, "30", err.(*ErrorRateLimited).Headers().Get("Retry-After"))
+	}
+}
@@ -0,0 +1,641 @@
+package yahoo
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"github.com/go-kit/kit/log/level"
+	"io/ioutil"
+	"strconv"
+)
+
+type GameResourceMetaResponse struct {
+	XMLName     xml.Name  `xml:"fantasy_content"`
+	Text        string    `xml:",chardata"`
+	Lang        string    `xml:"lang,attr"`
+	URI         string    `xml:"uri,attr"`
+	Time        string    `xml:"time,attr"`
+	Copyright   string    `xml:"copyright,attr"`
+	RefreshRate string    `xml:"refresh_rate,attr"`
+	Yahoo       string    `xml:"yahoo,attr"`
+	Xmlns       string    `xml:"xmlns,attr"`
+	Game        YahooGame `xml:"game"`
+}
+
+// GetGameResourcesMeta
+func (s *Service) GetGameResourcesMeta(ctx context.Context, gameKey string) (*Game, error) {
+	url := s.resourceURL("game", gameKey, "metadata")
+	res, err := s.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	v := GameResourceMetaResponse{}
+	bytes, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	// transform response to games
+	err = xml.Unmarshal(bytes, &v)
+	if err != nil {
+		level.Error(s.logger).Log("message", "could not unmarshal yahoo response", "error", err, "url", url)
+		return nil, err
+	}
+
+	game := transformYahooResponseGameToGame(v.Game)
+
+	return &game, nil
+
+}
+
+type GameResourceLeagues struct {
+	GameKey string   `json:"game_key"`
+	GameID  string   `json:"game_id"`
+	Name    string   `json:"name"`
+	Code    string   `json:"code"`
+	Type    string   `json:"type"`
+	URL     string   `json:"url"`
+	Season  string   `json:"season"`
+	Leagues []League `json:"league"`
+}
+type League struct {
+	LeagueKey             string `xml:"league_key"`
+	LeagueID              int    `xml:"league_id"`
+	Name                  string `xml:"name"`
+	URL                   string `xml:"url"`
+	LogoURL               string `xml:"logo_url"`
+	DraftStatus           string `xml:"draft_status"`
+	NumTeams              string `xml:"num_teams"`
+	EditKey               string `xml:"edit_key"`
+	WeeklyDeadline        string `xml:"weekly_deadline"`
+	LeagueUpdateTimestamp string `xml:"league_update_timestamp"`
+	ScoringType           string `xml:"scoring_type"`
+	LeagueType            string `xml:"league_type"`
+	Renew                 string `xml:"renew"`
+	Renewed               string `xml:"renewed"`
+	IrisGroupChatID       string `xml:"iris_group_chat_id"`
+	AllowAddToDlExtraPos  string `xml:"allow_add_to_dl_extra_pos"`
+	IsProLeague           string `xml:"is_pro_league"`
+	IsCashLeague          string `xml:"is_cash_league"`
+	CurrentWeek           string `xml:"current_week"`
+	StartWeek             string `xml:"start_week"`
+	StartDate             string `xml:"start_date"`
+	EndWeek               string `xml:"end_week"`
+	EndDate               string `xml:"end_date"`
+	IsFinished            string `xml:"is_finished"`
+	GameCode              string `xml:"game_code"`
+	Season                string `xml:"season"`
+	Password              string `xml:"password"`
+	ShortInvitationURL    string `xml:"short_invitation_url"`
+}
+type GameResourceLeaguesResponse struct {
+	XMLName     xml.Name `xml:"fantasy_content"`
+	Text        string   `xml:",chardata"`
+	Lang        string   `xml:"lang,attr"`
+	URI         string   `xml:"uri,attr"`
+	Time        string   `xml:"time,attr"`
+	Copyright   string   `xml:"copyright,attr"`
+	RefreshRate string   `xml:"refresh_rate,attr"`
+	Yahoo       string   `xml:"yahoo,attr"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	Game        struct {
+		Text               string `xml:",chardata"`
+		GameKey            string `xml:"game_key"`
+		GameID             string `xml:"game_id"`
+		Name               string `xml:"name"`
+		Code               string `xml:"code"`
+		Type               string `xml:"type"`
+		URL                string `xml:"url"`
+		Season             string `xml:"season"`
+		IsRegistrationOver string `xml:"is_registration_over"`
+		IsGameOver         string `xml:"is_game_over"`
+		IsOffseason        string `xml:"is_offseason"`
+		Leagues            struct {
+			Text   string        `xml:",chardata"`
+			Count  string        `xml:"count,attr"`
+			League []YahooLeague `xml:"league"`
+		} `xml:"league"`
+	} `xml:"game"`
+}
+
+type YahooLeague struct {
+	Text                  string `xml:",chardata"`
+	LeagueKey             string `xml:"league_key"`
+	LeagueID              int    `xml:"league_id"`
+	Name                  string `xml:"name"`
+	URL                   string `xml:"url"`
+	LogoURL               string `xml:"logo_url"`
+	DraftStatus           string `xml:"draft_status"`
+	NumTeams              int    `xml:"num_teams"`
+	EditKey               string `xml:"edit_key"`
+	WeeklyDeadline        string `xml:"weekly_deadline"`
+	LeagueUpdateTimestamp string `xml:"league_update_timestamp"`
+	ScoringType           string `xml:"scoring_type"`
+	LeagueType            string `xml:"league_type"`
+	Renew                 string `xml:"renew"`
+	Renewed               string `xml:"renewed"`
+	IrisGroupChatID       string `xml:"iris_group_chat_id"`
+	AllowAddToDlExtraPos  string `xml:"allow_add_to_dl_extra_pos"`
+	IsProLeague           string `xml:"is_pro_league"`
+	IsCashLeague          string `xml:"is_cash_league"`
+	CurrentWeek           string `xml:"current_week"`
+	StartWeek             string `xml:"start_week"`
+	StartDate             string `xml:"start_date"`
+	EndWeek               int    `xml:"end_week"`
+	EndDate               string `xml:"end_date"`
+	IsFinished            string `xml:"is_finished"`
+	GameCode              string `xml:"game_code"`
+	Season                string `xml:"season"`
+	Password              string `xml:"password"`
+	ShortInvitationURL    string `xml:"short_invitation_url"`
+}
+
+// GetGameResourcesLeagues
+func (s *Service) GetGameResourcesLeagues(ctx context.Context, gameKey string, leagueKeys []string) (*GameResourceLeagues, error) {
+	url := s.resourceURL("game", gameKey, "leagues"+NewOptions().Keys("league_keys", leagueKeys).String())
+	res, err := s.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	v := GameResourceLeaguesResponse{}
+	bytes, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	// transform response to games
+	err = xml.Unmarshal(bytes, &v)
+	if err != nil {
+		level.Error(s.logger).Log("message", "could not unmarshal yahoo response", "error", err, "url", url)
+		return nil, err
+	}
+
+	gLeagues := GameResourceLeagues{
+		GameKey: v.Game.GameKey,
+		GameID:  v.Game.GameID,
+		Name:    v.Game.Name,
+		Code:    v.Game.Code,
+		Type:    v.Game.Type,
+		URL:     v.Game.URL,
+		Season:  v.Game.Season,
+	}
+
+	leagues := make([]League, len(v.Game.Leagues.League))
+
+	for idx, yahooLeague := range v.Game.Leagues.League {
+		leagues[idx] = League{
+			LeagueKey:   yahooLeague.LeagueKey,
+			LeagueID:    yahooLeague.LeagueID,
+			Name:        yahooLeague.Name,
+			URL:         yahooLeague.URL,
+			DraftStatus: yahooLeague.DraftStatus,
+			//NumTeams:              yahooLeague.NumTeams,
+			EditKey:               yahooLeague.EditKey,
+			WeeklyDeadline:        yahooLeague.WeeklyDeadline,
+			LeagueUpdateTimestamp: yahooLeague.LeagueUpdateTimestamp,
+			ScoringType:           yahooLeague.ScoringType,
+			LeagueType:            yahooLeague.LeagueType,
+			Renew:                 yahooLeague.Renew,
+			Renewed:               yahooLeague.Renewed,
+			ShortInvitationURL:    yahooLeague.ShortInvitationURL,
+			IsProLeague:           yahooLeague.IsProLeague,
+			CurrentWeek:           yahooLeague.CurrentWeek,
+			StartWeek:             yahooLeague.StartWeek,
+			StartDate:             yahooLeague.StartDate,
+			//EndWeek:               yahooLeague.EndWeek,
+			//EndDate:               yahooLeague.EndDate,
+			IsFinished: yahooLeague.IsFinished,
+		}
+	}
+	gLeagues.Leagues = leagues
+
+	return &gLeagues, nil
+}
+
+type GameResourcePlayer struct {
+	GameKey string `json:"game_key"`
+	GameID  string `json:"game_id"`
+	Name    string `json:"name"`
+	Code    string `json:"code"`
+	Type    string `json:"type"`
+	URL     string `json:"url"`
+	Season  string `json:"season"`
+	Players []struct {
+		PlayerKey string `json:"player_key"`
+		PlayerID  string `json:"player_id"`
+		Name      struct {
+			Full       string `json:"full"`
+			First      string `json:"first"`
+			Last       string `json:"last"`
+			ASCIIFirst string `json:"ascii_first"`
+			ASCIILast  string `json:"ascii_last"`
+		} `json:"name"`
+		EditorialPlayerKey    string   `json:"editorial_player_key"`
+		EditorialTeamKey      string   `json:"editorial_team_key"`
+		EditorialTeamFullName string   `json:"editorial_team_full_name"`
+		EditorialTeamAbbr     string   `json:"editorial_team_abbr"`
+		UniformNumber         string   `json:"uniform_number"`
+		DisplayPosition       string   `json:"display_position"`
+		Headshot              string   `json:"headshot"`
+		IsUndroppable         string   `json:"is_undroppable"`
+		PositionType          string   `json:"position_type"`
+		EligiblePositions     []string `json:"eligible_positions"`
+	} `json:"fdr-players-import"`
+}
+type GameResourcePlayerResponse struct {
+	XMLName     xml.Name `xml:"fantasy_content"`
+	Text        string   `xml:",chardata"`
+	Lang        string   `xml:"lang,attr"`
+	URI         string   `xml:"uri,attr"`
+	Time        string   `xml:"time,attr"`
+	Copyright   string   `xml:"copyright,attr"`
+	RefreshRate string   `xml:"refresh_rate,attr"`
+	Yahoo       string   `xml:"yahoo,attr"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	Game        struct {
+		Text               string `xml:",chardata"`
+		GameKey            string `xml:"game_key"`
+		GameID             int    `xml:"game_id"`
+		Name               string `xml:"name"`
+		Code               string `xml:"code"`
+		Type               string `xml:"type"`
+		URL                string `xml:"url"`
+		Season             int    `xml:"season"`
+		IsRegistrationOver int    `xml:"is_registration_over"`
+		IsGameOver         int    `xml:"is_game_over"`
+		IsOffseason        int    `xml:"is_offseason"`
+		Players            struct {
+			Text   string                    `xml:",chardata"`
+			Count  string                    `xml:"count,attr"`
+			Player []GameResourcePlayerStats `xml:"player"`
+		} `xml:"players"`
+	} `xml:"game"`
+}
+
+type GameResourcePlayerStats struct {
+	PlayerKey string `xml:"player_key"`
+	PlayerID  int    `xml:"player_id"`
+	Name      struct {
+		Text       string `xml:",chardata"`
+		Full       string `xml:"full"`
+		First      string `xml:"first"`
+		Last       string `xml:"last"`
+		AsciiFirst string `xml:"ascii_first"`
+		AsciiLast  string `xml:"ascii_last"`
+	} `xml:"name"`
+	EditorialPlayerKey    string `xml:"editorial_player_key"`
+	EditorialTeamKey      string `xml:"editorial_team_key"`
+	EditorialTeamFullName string `xml:"editorial_team_full_name"`
+	EditorialTeamAbbr     string `xml:"editorial_team_abbr"`
+	ByeWeeks              struct {
+		Text string `xml:",chardata"`
+		Week int    `xml:"week"`
+	} `xml:"bye_weeks"`
+	UniformNumber   string `xml:"uniform_number"`
+	DisplayPosition string `xml:"display_position"`
+	Headshot        struct {
+		Text string `xml:",chardata"`
+		URL  string `xml:"url"`
+		Size string `xml:"size"`
+	} `xml:"headshot"`
+	ImageURL          string `xml:"image_url"`
+	IsUndroppable     int    `xml:"is_undroppable"`
+	PositionType      string `xml:"position_type"`
+	EligiblePositions struct {
+		Text     string `xml:",chardata"`
+		Position string `xml:"position"`
+	} `xml:"eligible_positions"`
+	PlayerStats              PlayerStats `xml:"player_stats"`
+	HasPlayerNotes           string      `xml:"has_player_notes"`
+	PlayerNotesLastTimestamp string      `xml:"player_notes_last_timestamp"`
+	Status                   string      `xml:"status"`
+	StatusFull               string      `xml:"status_full"`
+}
+
+type PlayerStats struct {
+	CoverageType string       `xml:"coverage_type"`
+	Season       int          `xml:"season"`
+	Stats        []PlayerStat `xml:"stats>stat"`
+}
+type PlayerStat struct {
+	StatID int     `xml:"stat_id"`
+	Value  float32 `xml:"value"`
+}
+
+// GetGameResourcesPlayers is a page of the game's players with their season stats, filtered and paged by options
+func (s *Service) GetGameResourcesPlayers(ctx context.Context, gameKey int, options Options) (GameResourcePlayerResponse, error) {
+	url := s.resourceURL("game", strconv.Itoa(gameKey), "players"+options.String(), "stats")
+	res, err := s.get(ctx, url)
+
+	v := GameResourcePlayerResponse{}
+	if err != nil {
+		return v, err
+	}
+
+	defer res.Body.Close()
+
+	bytes, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return v, err
+	}
+	// transform response to games
+	err = xml.Unmarshal(bytes, &v)
+	if err != nil {
+		level.Error(s.logger).Log("message", "could not unmarshal yahoo response", "error", err, "url", url)
+		return v, err
+	}
+	return v, nil
+}
+
+type GameResourcesGameWeeks struct {
+	GameKey string `json:"game_key"`
+	GameID  string `json:"game_id"`
+	Name    string `json:"name"`
+	Code    string `json:"code"`
+	Type    string `json:"type"`
+	URL     string `json:"url"`
+	Season  string `json:"season"`
+	Weeks   []struct {
+		Week  string `json:"week"`
+		Start string `json:"start"`
+		End   string `json:"end"`
+	} `json:"weeks"`
+}
+type GameResourcesGameWeeksResponse struct {
+	XMLName     xml.Name `xml:"fantasy_content"`
+	Text        string   `xml:",chardata"`
+	Lang        string   `xml:"lang,attr"`
+	URI         string   `xml:"uri,attr"`
+	Time        string   `xml:"time,attr"`
+	Copyright   string   `xml:"copyright,attr"`
+	RefreshRate string   `xml:"refresh_rate,attr"`
+	Yahoo       string   `xml:"yahoo,attr"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	Game        struct {
+		Text               string `xml:",chardata"`
+		GameKey            string `xml:"game_key"`
+		GameID             string `xml:"game_id"`
+		Name               string `xml:"name"`
+		Code               string `xml:"code"`
+		Type               string `xml:"type"`
+		URL                string `xml:"url"`
+		Season             string `xml:"season"`
+		IsRegistrationOver string `xml:"is_registration_over"`
+		IsGameOver         string `xml:"is_game_over"`
+		IsOffseason        string `xml:"is_offseason"`
+		GameWeeks          struct {
+			Text     string `xml:",chardata"`
+			Count    string `xml:"count,attr"`
+			GameWeek []struct {
+				Text        string `xml:",chardata"`
+				Week        string `xml:"week"`
+				DisplayName string `xml:"display_name"`
+				Start       string `xml:"start"`
+				End         string `xml:"end"`
+			} `xml:"game_week"`
+		} `xml:"game_weeks"`
+	} `xml:"game"`
+}
+
+// GetGameResourcesGameWeeks
+func (s *Service) GetGameResourcesGameWeeks(ctx context.Context, gameKey string) (*GameResourcesGameWeeks, error) {
+	url := s.resourceURL("game", gameKey, "game_weeks")
+	res, err := s.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	return nil, errors.New("not implemented")
+
+}
+
+type GameResourcesStatCategories struct {
+	GameKey        string `json:"game_key"`
+	GameID         string `json:"game_id"`
+	Name           string `json:"name"`
+	Code           string `json:"code"`
+	Type           string `json:"type"`
+	URL            string `json:"url"`
+	Season         string `json:"season"`
+	StatCategories []struct {
+		StatID          int      `json:"stat_id"`
+		Name            string   `json:"name"`
+		DisplayName     string   `json:"display_name"`
+		SortOrder       string   `json:"sort_order"`
+		PositionTypes   []string `json:"position_types,omitempty"`
+		IsCompositeStat int      `json:"is_composite_stat,omitempty"`
+		BaseStats       []string `json:"base_stats,omitempty"`
+	} `json:"stat_categories"`
+}
+type GameResourcesStatCategoriesResponse struct {
+	XMLName     xml.Name `xml:"fantasy_content"`
+	Text        string   `xml:",chardata"`
+	Lang        string   `xml:"lang,attr"`
+	URI         string   `xml:"uri,attr"`
+	Time        string   `xml:"time,attr"`
+	Copyright   string   `xml:"copyright,attr"`
+	RefreshRate string   `xml:"refresh_rate,attr"`
+	Yahoo       string   `xml:"yahoo,attr"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	Game        struct {
+		Text               string `xml:",chardata"`
+		GameKey            string `xml:"game_key"`
+		GameID             string `xml:"game_id"`
+		Name               string `xml:"name"`
+		Code               string `xml:"code"`
+		Type               string `xml:"type"`
+		URL                string `xml:"url"`
+		Season             string `xml:"season"`
+		IsRegistrationOver string `xml:"is_registration_over"`
+		IsGameOver         string `xml:"is_game_over"`
+		IsOffseason        string `xml:"is_offseason"`
+		StatCategories     struct {
+			Text  string `xml:",chardata"`
+			Stats struct {
+				Text string `xml:",chardata"`
+				Stat []struct {
+					Text          string `xml:",chardata"`
+					StatID        string `xml:"stat_id"`
+					Name          string `xml:"name"`
+					DisplayName   string `xml:"display_name"`
+					SortOrder     string `xml:"sort_order"`
+					PositionTypes struct {
+						Text         string   `xml:",chardata"`
+						PositionType []string `xml:"position_type"`
+					} `xml:"position_types"`
+				} `xml:"stat"`
+			} `xml:"stats"`
+		} `xml:"stat_categories"`
+	} `xml:"game"`
+}
+
+// GetGameResourcesStatCategories
+func (s *Service) GetGameResourcesStatCategories(ctx context.Context, gameKey string) (*GameResourcesStatCategories, error) {
+	url := s.resourceURL("game", gameKey, "stat_categories")
+	res, err := s.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	return nil, errors.New("not implemented")
+
+}
+
+type GameResourcesPositionTypes struct {
+	GameKey       string `json:"game_key"`
+	GameID        string `json:"game_id"`
+	Name          string `json:"name"`
+	Code          string `json:"code"`
+	Type          string `json:"type"`
+	URL           string `json:"url"`
+	Season        string `json:"season"`
+	PositionTypes []struct {
+		Type        string `json:"type"`
+		DisplayName string `json:"display_name"`
+	} `json:"position_types"`
+}
+
+// GetGameResourcesPositionTypes
+func (s *Service) GetGameResourcesPositionTypes(ctx context.Context, gameKey string) (*GameResourcesPositionTypes, error) {
+	url := s.resourceURL("game", gameKey, "position_types")
+	res, err := s.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	bytes, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	v := UserResourcesResponse{}
+	// transform response to games
+	err = xml.Unmarshal(bytes, &v)
+	if err != nil {
+		level.Error(s.logger).Log("message", "could not unmarshal yahoo response", "error", err, "url", url)
+		return nil, err
+	}
+
+	return nil, errors.New("not implemented")
+
+}
+
+type GameResourcesRosterPositions struct {
+	GameKey         string `json:"game_key"`
+	GameID          string `json:"game_id"`
+	Name            string `json:"name"`
+	Code            string `json:"code"`
+	Type            string `json:"type"`
+	URL             string `json:"url"`
+	Season          string `json:"season"`
+	RosterPositions []struct {
+		Position       string `json:"position"`
+		Abbreviation   string `json:"abbreviation"`
+		DisplayName    string `json:"display_name"`
+		PositionType   string `json:"position_type,omitempty"`
+		IsBench        int    `json:"is_bench,omitempty"`
+		IsDisabledList int    `json:"is_disabled_list,omitempty"`
+	} `json:"roster_positions"`
+}
+type GetGameResourcesRosterPositionsResponse struct {
+	XMLName     xml.Name `xml:"fantasy_content"`
+	Text        string   `xml:",chardata"`
+	Lang        string   `xml:"lang,attr"`
+	URI         string   `xml:"uri,attr"`
+	Time        string   `xml:"time,attr"`
+	Copyright   string   `xml:"copyright,attr"`
+	RefreshRate string   `xml:"refresh_rate,attr"`
+	Yahoo       string   `xml:"yahoo,attr"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	Game        struct {
+		Text               string `xml:",chardata"`
+		GameKey            string `xml:"game_key"`
+		GameID             string `xml:"game_id"`
+		Name               string `xml:"name"`
+		Code               string `xml:"code"`
+		Type               string `xml:"type"`
+		URL                string `xml:"url"`
+		Season             string `xml:"season"`
+		IsRegistrationOver string `xml:"is_registration_over"`
+		IsGameOver         string `xml:"is_game_over"`
+		IsOffseason        string `xml:"is_offseason"`
+
+		PositionTypes []struct {
+			Type        string `xml:"type"`
+			DisplayName string `xml:"display_name"`
+		} `xml:"position_types>position_type"`
+	} `xml:"game"`
+}
+
+func (s *Service) GetGameResourcesRosterPositions(ctx context.Context, gameKey string) (*GameResourcesRosterPositions, error) {
+	url := s.resourceURL("game", gameKey, "roster_positions")
+	res, err := s.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	return nil, errors.New("not implemented")
+
+}
+
+func transformYahooResponseGameToGame(yahooGame YahooGame) Game {
+	return Game{
+		GameKey: yahooGame.GameKey,
+		GameID:  yahooGame.GameID,
+		Name:    yahooGame.Name,
+		Code:    yahooGame.Code,
+		Type:    yahooGame.Type,
+		URL:     yahooGame.URL,
+		Season:  yahooGame.Season,
+	}
+}
+
+type Game struct {
+	GameKey string      `json:"game_key" bson:"_id"`
+	GameID  int         `json:"game_id"`
+	Name    string      `json:"name"`
+	Code    string      `json:"code"`
+	Type    string      `json:"type"`
+	URL     interface{} `json:"url"`
+	Season  int         `json:"season"`
+}
+
+type YahooGame struct {
+	Text               string `xml:",chardata"`
+	GameKey            string `xml:"game_key"`
+	GameID             int    `xml:"game_id"`
+	Name               string `xml:"name"`
+	Code               string `xml:"code"`
+	Type               string `xml:"type"`
+	URL                string `xml:"url"`
+	Season             int    `xml:"season"`
+	IsRegistrationOver int    `xml:"is_registration_over"`
+	IsGameOver         int    `xml:"is_game_over"`
+	IsOffseason        int    `xml:"is_offseason"`
+	EditorialSeason    string `xml:"editorial_season"`
+	PicksStatus        string `xml:"picks_status"`
+	ContestGroupID     string `xml:"contest_group_id"`
+	ScenarioGenerator  string `xml:"scenario_generator"`
+	CurrentWeek        string `xml:"current_week"`
+	IsContestRegActive bool   `xml:"is_contest_reg_active"`
+	IsContestOver      bool   `xml:"is_contest_over"`
+	//Teams              []YahooTeam   `xml:"teams>team"`
+	Leagues []YahooLeague `xml:"leagues>league"`
+}
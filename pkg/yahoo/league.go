@@ -0,0 +1,1283 @@
+package yahoo
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"github.com/go-kit/kit/log/level"
+	"go.elastic.co/apm"
+	"io/ioutil"
+)
+
+type LeagueResourcesMeta struct {
+	LeagueKey             string `json:"league_key"`
+	LeagueID              string `json:"league_id"`
+	Name                  string `json:"name"`
+	URL                   string `json:"url"`
+	DraftStatus           string `json:"draft_status"`
+	NumTeams              int    `json:"num_teams"`
+	EditKey               string `json:"edit_key"`
+	WeeklyDeadline        string `json:"weekly_deadline"`
+	LeagueUpdateTimestamp string `json:"league_update_timestamp"`
+	ScoringType           string `json:"scoring_type"`
+	LeagueType            string `json:"league_type"`
+	Renew                 string `json:"renew"`
+	Renewed               string `json:"renewed"`
+	ShortInvitationURL    string `json:"short_invitation_url"`
+	IsProLeague           string `json:"is_pro_league"`
+	CurrentWeek           string `json:"current_week"`
+	StartWeek             string `json:"start_week"`
+	StartDate             string `json:"start_date"`
+	EndWeek               string `json:"end_week"`
+	EndDate               string `json:"end_date"`
+	IsFinished            int    `json:"is_finished"`
+}
+type LeagueResourcesMetaResponse struct {
+	XMLName     xml.Name `xml:"fantasy_content"`
+	Text        string   `xml:",chardata"`
+	Lang        string   `xml:"lang,attr"`
+	URI         string   `xml:"uri,attr"`
+	Time        string   `xml:"time,attr"`
+	Copyright   string   `xml:"copyright,attr"`
+	RefreshRate string   `xml:"refresh_rate,attr"`
+	Yahoo       string   `xml:"yahoo,attr"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	League      struct {
+		Text                  string `xml:",chardata"`
+		LeagueKey             string `xml:"league_key"`
+		LeagueID              string `xml:"league_id"`
+		Name                  string `xml:"name"`
+		URL                   string `xml:"url"`
+		LogoURL               string `xml:"logo_url"`
+		Password              string `xml:"password"`
+		DraftStatus           string `xml:"draft_status"`
+		NumTeams              string `xml:"num_teams"`
+		EditKey               string `xml:"edit_key"`
+		WeeklyDeadline        string `xml:"weekly_deadline"`
+		LeagueUpdateTimestamp string `xml:"league_update_timestamp"`
+		ScoringType           string `xml:"scoring_type"`
+		LeagueType            string `xml:"league_type"`
+		Renew                 string `xml:"renew"`
+		Renewed               string `xml:"renewed"`
+		IrisGroupChatID       string `xml:"iris_group_chat_id"`
+		ShortInvitationURL    string `xml:"short_invitation_url"`
+		AllowAddToDlExtraPos  string `xml:"allow_add_to_dl_extra_pos"`
+		IsProLeague           string `xml:"is_pro_league"`
+		IsCashLeague          string `xml:"is_cash_league"`
+		CurrentWeek           string `xml:"current_week"`
+		StartWeek             string `xml:"start_week"`
+		StartDate             string `xml:"start_date"`
+		EndWeek               string `xml:"end_week"`
+		EndDate               string `xml:"end_date"`
+		GameCode              string `xml:"game_code"`
+		Season                string `xml:"season"`
+	} `xml:"league"`
+}
+
+func (s *Service) GetLeagueResourcesMeta(ctx context.Context, leagueKey string) (*LeagueResourcesMeta, error) {
+	url := s.resourceURL("league", leagueKey, "metadata")
+	res, err := s.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	return nil, errors.New("not implemented")
+}
+
+type ResponseRosterPosition struct {
+	Position     string `xml:"position"`
+	PositionType string `xml:"position_type"`
+	Count        int    `xml:"count"`
+}
+
+type ResponsePositionType struct {
+	PositionType      string `xml:"position_type"`
+	IsOnlyDisplayStat int    `xml:"is_only_display_stat"`
+}
+type ResponseStatCategory struct {
+	StatID            int                    `xml:"stat_id"`
+	Enabled           int                    `xml:"enabled"`
+	Name              string                 `xml:"name"`
+	DisplayName       string                 `xml:"display_name"`
+	SortOrder         int                    `xml:"sort_order"`
+	PositionType      string                 `xml:"position_type"`
+	StatPositionTypes []ResponsePositionType `xml:"stat_position_types"`
+	IsOnlyDisplayStat int                    `xml:"is_only_display_stat,omitempty"`
+}
+
+type StatModifier struct {
+	Text   string  `xml:",chardata"`
+	StatID int     `xml:"stat_id"`
+	Value  float32 `xml:"value"`
+	Bonus  *Bonus  `xml:"bonuses>bonus"`
+}
+
+type Bonus struct {
+	Target float32 `xml:"target"`
+	Points float32 `xml:"points"`
+}
+type LeagueResourcesSettingsResponse struct {
+	XMLName     xml.Name `xml:"fantasy_content"`
+	Text        string   `xml:",chardata"`
+	Lang        string   `xml:"lang,attr"`
+	URI         string   `xml:"uri,attr"`
+	Time        string   `xml:"time,attr"`
+	Copyright   string   `xml:"copyright,attr"`
+	RefreshRate string   `xml:"refresh_rate,attr"`
+	Yahoo       string   `xml:"yahoo,attr"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	League      struct {
+		Text                  string `xml:",chardata"`
+		LeagueKey             string `xml:"league_key"`
+		LeagueID              int    `xml:"league_id"`
+		Name                  string `xml:"name"`
+		URL                   string `xml:"url"`
+		LogoURL               string `xml:"logo_url"`
+		Password              string `xml:"password"`
+		DraftStatus           string `xml:"draft_status"`
+		NumTeams              int    `xml:"num_teams"`
+		EditKey               string `xml:"edit_key"`
+		WeeklyDeadline        string `xml:"weekly_deadline"`
+		LeagueUpdateTimestamp string `xml:"league_update_timestamp"`
+		ScoringType           string `xml:"scoring_type"`
+		LeagueType            string `xml:"league_type"`
+		Renew                 string `xml:"renew"`
+		Renewed               string `xml:"renewed"`
+		IrisGroupChatID       string `xml:"iris_group_chat_id"`
+		ShortInvitationURL    string `xml:"short_invitation_url"`
+		AllowAddToDlExtraPos  string `xml:"allow_add_to_dl_extra_pos"`
+		IsProLeague           string `xml:"is_pro_league"`
+		IsCashLeague          string `xml:"is_cash_league"`
+		CurrentWeek           string `xml:"current_week"`
+		StartWeek             string `xml:"start_week"`
+		StartDate             string `xml:"start_date"`
+		EndWeek               string `xml:"end_week"`
+		EndDate               string `xml:"end_date"`
+		GameCode              string `xml:"game_code"`
+		Season                string `xml:"season"`
+		Settings              struct {
+			Text                       string                   `xml:",chardata"`
+			DraftType                  string                   `xml:"draft_type"`
+			IsAuctionDraft             int                      `xml:"is_auction_draft"`
+			ScoringType                string                   `xml:"scoring_type"`
+			PersistentURL              string                   `xml:"persistent_url"`
+			UsesPlayoff                string                   `xml:"uses_playoff"`
+			HasPlayoffConsolationGames int                      `xml:"has_playoff_consolation_games"`
+			PlayoffStartWeek           string                   `xml:"playoff_start_week"`
+			UsesPlayoffReseeding       int                      `xml:"uses_playoff_reseeding"`
+			UsesLockEliminatedTeams    int                      `xml:"uses_lock_eliminated_teams"`
+			NumPlayoffTeams            int                      `xml:"num_playoff_teams"`
+			NumPlayoffConsolationTeams int                      `xml:"num_playoff_consolation_teams"`
+			HasMultiweekChampionship   string                   `xml:"has_multiweek_championship"`
+			UsesRosterImport           int                      `xml:"uses_roster_import"`
+			RosterImportDeadline       string                   `xml:"roster_import_deadline"`
+			WaiverType                 string                   `xml:"waiver_type"`
+			WaiverRule                 string                   `xml:"waiver_rule"`
+			UsesFaab                   int                      `xml:"uses_faab"`
+			DraftPickTime              string                   `xml:"draft_pick_time"`
+			PostDraftPlayers           string                   `xml:"post_draft_players"`
+			MaxTeams                   string                   `xml:"max_teams"`
+			WaiverTime                 string                   `xml:"waiver_time"`
+			TradeEndDate               string                   `xml:"trade_end_date"`
+			TradeRatifyType            string                   `xml:"trade_ratify_type"`
+			TradeRejectTime            string                   `xml:"trade_reject_time"`
+			PlayerPool                 string                   `xml:"player_pool"`
+			CantCutList                string                   `xml:"cant_cut_list"`
+			IsPubliclyViewable         int                      `xml:"is_publicly_viewable"`
+			CanTradeDraftPicks         string                   `xml:"can_trade_draft_picks"`
+			SendbirdChannelURL         string                   `xml:"sendbird_channel_url"`
+			RosterPositions            []ResponseRosterPosition `xml:"roster_positions>roster_position"`
+			StatCategories             struct {
+				Text  string `xml:",chardata"`
+				Stats struct {
+					Text string                 `xml:",chardata"`
+					Stat []ResponseStatCategory `xml:"stat"`
+				} `xml:"stats"`
+			} `xml:"stat_categories"`
+			StatModifiers struct {
+				Text  string `xml:",chardata"`
+				Stats struct {
+					Text string         `xml:",chardata"`
+					Stat []StatModifier `xml:"stat"`
+				} `xml:"stats"`
+			} `xml:"stat_modifiers"`
+		} `xml:"settings"`
+		MaxTrades            int    `xml:"max_trades"`
+		PickemEnabled        string `xml:"pickem_enabled"`
+		UsesFractionalPoints int    `xml:"uses_fractional_points"`
+		UsesNegativePoints   int    `xml:"uses_negative_points"`
+	} `xml:"league"`
+}
+
+func (s *Service) GetLeagueResourcesSettings(ctx context.Context, leagueKey string) (*LeagueResourcesSettingsResponse, error) {
+	span, ctx := apm.StartSpan(ctx, "GetLeagueResourcesSettings", "repo")
+	defer span.End()
+	url := s.resourceURL("league", leagueKey, "settings")
+	res, err := s.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	v := LeagueResourcesSettingsResponse{}
+	bytes, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	err = xml.Unmarshal(bytes, &v)
+	if err != nil {
+		level.Error(s.logger).Log("message", "could not unmarshal yahoo response", "error", err, "url", url)
+		return nil, err
+	}
+
+	return &v, nil
+	//var UsesNegativePoints bool
+	//if v.League.Settings.UsesNegativePoints == "1" {
+	//	UsesNegativePoints = true
+	//}
+	//
+	//var UsesFractionalPoints bool
+	//if v.League.Settings.UsesFractionalPoints == "1" {
+	//	UsesFractionalPoints = true
+	//}
+	//r := YahooLeagueSettings{
+	//	ID:                         v.League.LeagueID,
+	//	DraftType:                  v.League.Settings.DraftType,
+	//	IsAuctionDraft:             v.League.Settings.IsAuctionDraft,
+	//	ScoringType:                v.League.Settings.ScoringType,
+	//	PersistentURL:              v.League.Settings.PersistentURL,
+	//	UsesPlayoff:                v.League.Settings.UsesPlayoff,
+	//	HasPlayoffConsolationGames: v.League.Settings.HasPlayoffConsolationGames,
+	//	PlayoffStartWeek:           v.League.Settings.PlayoffStartWeek,
+	//	UsesPlayoffReseeding:       v.League.Settings.UsesPlayoffReseeding,
+	//	UsesLockEliminatedTeams:    v.League.Settings.UsesLockEliminatedTeams,
+	//	NumPlayoffTeams:            v.League.Settings.NumPlayoffTeams,
+	//	NumPlayoffConsolationTeams: v.League.Settings.NumPlayoffConsolationTeams,
+	//	UsesRosterImport:           v.League.Settings.UsesRosterImport,
+	//	RosterImportDeadline:       v.League.Settings.RosterImportDeadline,
+	//	WaiverType:                 v.League.Settings.WaiverType,
+	//	WaiverRule:                 v.League.Settings.WaiverRule,
+	//	UsesFaab:                   v.League.Settings.UsesFaab,
+	//	DraftTime:                  v.League.Settings.DraftPickTime,
+	//	PostDraftPlayers:           v.League.Settings.PostDraftPlayers,
+	//	MaxTeams:                   v.League.Settings.MaxTeams,
+	//	WaiverTime:                 v.League.Settings.WaiverTime,
+	//	TradeEndDate:               v.League.Settings.TradeEndDate,
+	//	TradeRatifyType:            v.League.Settings.TradeRatifyType,
+	//	TradeRejectTime:            v.League.Settings.TradeRejectTime,
+	//	PlayerPool:                 v.League.Settings.PlayerPool,
+	//	CantCutList:                v.League.Settings.CantCutList,
+	//	IsPubliclyViewable:         v.League.Settings.IsPubliclyViewable,
+	//	UsesNegativePoints:         UsesNegativePoints,
+	//	UsesFractalPoints:          UsesFractionalPoints,
+	//}
+	//
+	//yahooStatCategories := v.League.Settings.StatCategories.Stats.Stat
+	//statCategoties := make([]StatCategory, len(v.League.Settings.StatCategories.Stats.Stat))
+	//for idx, val := range yahooStatCategories {
+	//	statPositions := make([]PositionType, 1)
+	//
+	//	posType := PositionType{
+	//		PositionType:      val.StatPositionTypes.StatPositionType.PositionType,
+	//		IsOnlyDisplayStat: val.StatPositionTypes.StatPositionType.IsOnlyDisplayStat,
+	//	}
+	//	statPositions[0] = posType
+	//
+	//	statcategory := StatCategory{
+	//		StatID:            val.StatID,
+	//		Enabled:           val.Enabled,
+	//		Name:              val.Name,
+	//		DisplayName:       val.DisplayName,
+	//		SortOrder:         val.SortOrder,
+	//		PositionType:      val.PositionType,
+	//		StatPositionTypes: statPositions,
+	//		IsOnlyDisplayStat: val.IsOnlyDisplayStat,
+	//	}
+	//	statCategoties[idx] = statcategory
+	//}
+	//r.StatCategories = statCategoties
+	//
+	//yahooStatModifiers := v.League.Settings.StatModifiers.Stats.Stat
+	//statModifiers := make([]StatModifier, len(v.League.Settings.StatCategories.Stats.Stat))
+	//for idx, val := range yahooStatModifiers {
+	//
+	//	var bonus *Bonus
+	//	if val.Bonus.Target != 0 {
+	//		bonus = &Bonus{}
+	//		bonus.Target = val.Bonus.Target
+	//		bonus.Points = val.Bonus.Points
+	//	}
+	//
+	//	statModifier := StatModifier{
+	//		StatID:  val.StatID,
+	//		Value:   val.Value,
+	//		Bonuses: bonus,
+	//	}
+	//	statModifiers[idx] = statModifier
+	//}
+	//
+	//r.StatModifiers = statModifiers
+	//// roster positions
+	//yahooPositions := v.League.Settings.RosterPositions
+	//positions := make([]RosterPosition, len(yahooPositions))
+	//for idx, val := range yahooPositions {
+	//	pos := RosterPosition{
+	//		Pick:     val.Pick,
+	//		PositionType: val.PositionType,
+	//		Count:        val.Count,
+	//	}
+	//	positions[idx] = pos
+	//}
+	//r.RosterPositions = positions
+	//return &r, nil
+}
+
+type LeagueResourcesStandingsResponse struct {
+	XMLName     xml.Name `xml:"fantasy_content"`
+	Text        string   `xml:",chardata"`
+	Lang        string   `xml:"lang,attr"`
+	URI         string   `xml:"uri,attr"`
+	Time        string   `xml:"time,attr"`
+	Copyright   string   `xml:"copyright,attr"`
+	RefreshRate string   `xml:"refresh_rate,attr"`
+	Yahoo       string   `xml:"yahoo,attr"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	League      struct {
+		Text                  string `xml:",chardata"`
+		LeagueKey             string `xml:"league_key"`
+		LeagueID              string `xml:"league_id"`
+		Name                  string `xml:"name"`
+		URL                   string `xml:"url"`
+		LogoURL               string `xml:"logo_url"`
+		Password              string `xml:"password"`
+		DraftStatus           string `xml:"draft_status"`
+		NumTeams              int    `xml:"num_teams"`
+		EditKey               string `xml:"edit_key"`
+		WeeklyDeadline        string `xml:"weekly_deadline"`
+		LeagueUpdateTimestamp string `xml:"league_update_timestamp"`
+		ScoringType           string `xml:"scoring_type"`
+		LeagueType            string `xml:"league_type"`
+		Renew                 string `xml:"renew"`
+		Renewed               string `xml:"renewed"`
+		IrisGroupChatID       string `xml:"iris_group_chat_id"`
+		ShortInvitationURL    string `xml:"short_invitation_url"`
+		AllowAddToDlExtraPos  string `xml:"allow_add_to_dl_extra_pos"`
+		IsProLeague           string `xml:"is_pro_league"`
+		IsCashLeague          string `xml:"is_cash_league"`
+		CurrentWeek           string `xml:"current_week"`
+		StartWeek             string `xml:"start_week"`
+		StartDate             string `xml:"start_date"`
+		EndWeek               string `xml:"end_week"`
+		EndDate               string `xml:"end_date"`
+		GameCode              string `xml:"game_code"`
+		Season                string `xml:"season"`
+		Standings             struct {
+			Text  string `xml:",chardata"`
+			Teams struct {
+				Text  string `xml:",chardata"`
+				Count string `xml:"count,attr"`
+				Team  []struct {
+					Text                  string `xml:",chardata"`
+					TeamKey               string `xml:"team_key"`
+					TeamID                int    `xml:"team_id"`
+					Name                  string `xml:"name"`
+					IsOwnedByCurrentLogin int    `xml:"is_owned_by_current_login"`
+					URL                   string `xml:"url"`
+					TeamLogos             struct {
+						Text     string `xml:",chardata"`
+						TeamLogo struct {
+							Text string `xml:",chardata"`
+							Size string `xml:"size"`
+							URL  string `xml:"url"`
+						} `xml:"team_logo"`
+					} `xml:"team_logos"`
+					WaiverPriority    int        `xml:"waiver_priority"`
+					NumberOfMoves     int        `xml:"number_of_moves"`
+					NumberOfTrades    int        `xml:"number_of_trades"`
+					RosterAdds        RosterAdds `xml:"roster_adds"`
+					LeagueScoringType string     `xml:"league_scoring_type"`
+					HasDraftGrade     int        `xml:"has_draft_grade"`
+					DraftGrade        string     `xml:"draft_grade"`
+					Managers          struct {
+						Text    string  `xml:",chardata"`
+						Manager Manager `xml:"manager"`
+					} `xml:"managers"`
+					TeamPoints struct {
+						Text         string `xml:",chardata"`
+						CoverageType string `xml:"coverage_type"`
+						Season       string `xml:"season"`
+						Total        string `xml:"total"`
+					} `xml:"team_points"`
+					TeamStandings struct {
+						Text          string        `xml:",chardata"`
+						PlayoffSeed   int           `xml:"playoff_seed"`
+						Rank          int           `xml:"rank"`
+						OutcomeTotals OutcomeTotals `xml:"outcome_totals"`
+						PointsFor     float32       `xml:"points_for"`
+						PointsAgainst float32       `xml:"points_against"`
+					} `xml:"team_standings"`
+				} `xml:"team"`
+			} `xml:"teams"`
+		} `xml:"standings"`
+	} `xml:"league"`
+}
+
+type f struct {
+	Text          string        `xml:",chardata"`
+	Rank          int           `xml:"rank"`
+	OutcomeTotals OutcomeTotals `xml:"outcome_totals"`
+	PointsFor     int           `xml:"points_for"`
+	PointsAgainst int           `xml:"points_against"`
+}
+
+type OutcomeTotals struct {
+	//Text       int `xml:",chardata"`
+	Wins       int    `xml:"wins"`
+	Losses     int    `xml:"losses"`
+	Ties       int    `xml:"ties"`
+	Percentage string `xml:"percentage"`
+}
+
+type RosterAdds struct {
+	CoverageType  string `xml:"coverage_type"`
+	CoverageValue int    `xml:"coverage_value"`
+	Value         int    `xml:"value"`
+}
+
+func (s *Service) GetLeagueResourcesStandings(ctx context.Context, leagueKey string) (*LeagueResourcesStandingsResponse, error) {
+	url := s.resourceURL("league", leagueKey, "standings")
+	res, err := s.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	v := LeagueResourcesStandingsResponse{}
+	bytes, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	// transform response to games
+	err = xml.Unmarshal(bytes, &v)
+	if err != nil {
+		level.Error(s.logger).Log("message", "could not unmarshal yahoo response", "error", err, "url", url)
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+type LeagueResourcesScoreboard struct {
+	ID                    string `json:"id"  bson:"_id,omitempty"`
+	LeagueKey             string `json:"league_key"`
+	LeagueID              string `json:"league_id"`
+	Name                  string `json:"name"`
+	URL                   string `json:"url"`
+	DraftStatus           string `json:"draft_status"`
+	NumTeams              int    `json:"num_teams"`
+	EditKey               string `json:"edit_key"`
+	WeeklyDeadline        string `json:"weekly_deadline"`
+	LeagueUpdateTimestamp string `json:"league_update_timestamp"`
+	ScoringType           string `json:"scoring_type"`
+	LeagueType            string `json:"league_type"`
+	Renew                 string `json:"renew"`
+	Renewed               string `json:"renewed"`
+	ShortInvitationURL    string `json:"short_invitation_url"`
+	IsProLeague           string `json:"is_pro_league"`
+	CurrentWeek           string `json:"current_week"`
+	StartWeek             string `json:"start_week"`
+	StartDate             string `json:"start_date"`
+	EndWeek               string `json:"end_week"`
+	EndDate               string `json:"end_date"`
+	IsFinished            int    `json:"is_finished"`
+	Scoreboard            struct {
+		Matchups []struct {
+			Week          string `json:"week"`
+			WeekStart     string `json:"week_start"`
+			WeekEnd       string `json:"week_end"`
+			Status        string `json:"status"`
+			IsPlayoffs    string `json:"is_playoffs"`
+			IsConsolation string `json:"is_consolation"`
+			IsTied        int    `json:"is_tied"`
+			WinnerTeamKey string `json:"winner_team_key"`
+			Teams         []struct {
+				TeamKey          string `json:"team_key"`
+				TeamID           string `json:"team_id"`
+				Name             string `json:"name"`
+				URL              string `json:"url"`
+				TeamLogo         string `json:"team_logo"`
+				WaiverPriority   int    `json:"waiver_priority"`
+				NumberOfMoves    string `json:"number_of_moves"`
+				NumberOfTrades   int    `json:"number_of_trades"`
+				ClinchedPlayoffs int    `json:"clinched_playoffs"`
+				Managers         []struct {
+					ManagerID      string `json:"manager_id"`
+					Nickname       string `json:"nickname"`
+					GUID           string `json:"guid"`
+					IsCommissioner int    `json:"is_commissioner"`
+					ImageURl       string `json:"image_url"`
+				} `json:"managers"`
+				Points struct {
+					CoverageType string `json:"coverage_type"`
+					Week         string `json:"week"`
+					Total        string `json:"total"`
+				} `json:"points"`
+				Stats []struct {
+					StatID string `json:"stat_id"`
+					Value  string `json:"value"`
+				} `json:"stats"`
+			} `json:"teams"`
+		} `json:"matchups"`
+		Week string `json:"week"`
+	} `json:"scoreboard"`
+}
+type LeagueResourcesScoreboardResponse struct {
+	XMLName     xml.Name `xml:"fantasy_content"`
+	Text        string   `xml:",chardata"`
+	Lang        string   `xml:"lang,attr"`
+	URI         string   `xml:"uri,attr"`
+	Time        string   `xml:"time,attr"`
+	Copyright   string   `xml:"copyright,attr"`
+	RefreshRate string   `xml:"refresh_rate,attr"`
+	Yahoo       string   `xml:"yahoo,attr"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	League      struct {
+		Text                  string `xml:",chardata"`
+		LeagueKey             string `xml:"league_key"`
+		LeagueID              string `xml:"league_id"`
+		Name                  string `xml:"name"`
+		URL                   string `xml:"url"`
+		LogoURL               string `xml:"logo_url"`
+		Password              string `xml:"password"`
+		DraftStatus           string `xml:"draft_status"`
+		NumTeams              string `xml:"num_teams"`
+		EditKey               string `xml:"edit_key"`
+		WeeklyDeadline        string `xml:"weekly_deadline"`
+		LeagueUpdateTimestamp string `xml:"league_update_timestamp"`
+		ScoringType           string `xml:"scoring_type"`
+		LeagueType            string `xml:"league_type"`
+		Renew                 string `xml:"renew"`
+		Renewed               string `xml:"renewed"`
+		IrisGroupChatID       string `xml:"iris_group_chat_id"`
+		ShortInvitationURL    string `xml:"short_invitation_url"`
+		AllowAddToDlExtraPos  string `xml:"allow_add_to_dl_extra_pos"`
+		IsProLeague           string `xml:"is_pro_league"`
+		IsCashLeague          string `xml:"is_cash_league"`
+		CurrentWeek           string `xml:"current_week"`
+		StartWeek             string `xml:"start_week"`
+		StartDate             string `xml:"start_date"`
+		EndWeek               string `xml:"end_week"`
+		EndDate               string `xml:"end_date"`
+		GameCode              string `xml:"game_code"`
+		Season                string `xml:"season"`
+		Scoreboard            struct {
+			Text     string `xml:",chardata"`
+			Week     string `xml:"week"`
+			Matchups struct {
+				Text    string `xml:",chardata"`
+				Count   string `xml:"count,attr"`
+				Matchup []struct {
+					Text                    string `xml:",chardata"`
+					Week                    string `xml:"week"`
+					WeekStart               string `xml:"week_start"`
+					WeekEnd                 string `xml:"week_end"`
+					Status                  string `xml:"status"`
+					IsPlayoffs              string `xml:"is_playoffs"`
+					IsConsolation           string `xml:"is_consolation"`
+					IsMatchupRecapAvailable string `xml:"is_matchup_recap_available"`
+					Teams                   struct {
+						Text  string `xml:",chardata"`
+						Count string `xml:"count,attr"`
+						Team  []struct {
+							Text                  string `xml:",chardata"`
+							TeamKey               string `xml:"team_key"`
+							TeamID                string `xml:"team_id"`
+							Name                  string `xml:"name"`
+							IsOwnedByCurrentLogin string `xml:"is_owned_by_current_login"`
+							URL                   string `xml:"url"`
+							TeamLogos             struct {
+								Text     string `xml:",chardata"`
+								TeamLogo struct {
+									Text string `xml:",chardata"`
+									Size string `xml:"size"`
+									URL  string `xml:"url"`
+								} `xml:"team_logo"`
+							} `xml:"team_logos"`
+							WaiverPriority string `xml:"waiver_priority"`
+							NumberOfMoves  string `xml:"number_of_moves"`
+							NumberOfTrades string `xml:"number_of_trades"`
+							RosterAdds     struct {
+								Text          string `xml:",chardata"`
+								CoverageType  string `xml:"coverage_type"`
+								CoverageValue string `xml:"coverage_value"`
+								Value         string `xml:"value"`
+							} `xml:"roster_adds"`
+							LeagueScoringType string `xml:"league_scoring_type"`
+							HasDraftGrade     string `xml:"has_draft_grade"`
+							Managers          struct {
+								Text    string `xml:",chardata"`
+								Manager struct {
+									Text           string `xml:",chardata"`
+									ManagerID      string `xml:"manager_id"`
+									Nickname       string `xml:"nickname"`
+									Guid           string `xml:"guid"`
+									IsCommissioner string `xml:"is_commissioner"`
+									IsCurrentLogin string `xml:"is_current_login"`
+									Email          string `xml:"email"`
+									ImageURL       string `xml:"image_url"`
+								} `xml:"manager"`
+							} `xml:"managers"`
+							WinProbability string `xml:"win_probability"`
+							TeamPoints     struct {
+								Text         string `xml:",chardata"`
+								CoverageType string `xml:"coverage_type"`
+								Week         string `xml:"week"`
+								Total        string `xml:"total"`
+							} `xml:"team_points"`
+							TeamProjectedPoints struct {
+								Text         string `xml:",chardata"`
+								CoverageType string `xml:"coverage_type"`
+								Week         string `xml:"week"`
+								Total        string `xml:"total"`
+							} `xml:"team_projected_points"`
+						} `xml:"team"`
+					} `xml:"teams"`
+				} `xml:"matchup"`
+			} `xml:"matchups"`
+		} `xml:"scoreboard"`
+	} `xml:"league"`
+}
+
+func (s *Service) GetLeagueResourcesScoreboard(ctx context.Context, leagueKey string, options Options) (*LeagueResourcesScoreboard, error) {
+	url := s.resourceURL("league", leagueKey, "scoreboard"+options.String())
+	res, err := s.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	return nil, errors.New("not implemented")
+}
+
+type LeagueResourcesTeams struct {
+	LeagueKey             string `json:"league_key"`
+	LeagueID              string `json:"league_id"`
+	Name                  string `json:"name"`
+	URL                   string `json:"url"`
+	DraftStatus           string `json:"draft_status"`
+	NumTeams              int    `json:"num_teams"`
+	EditKey               string `json:"edit_key"`
+	WeeklyDeadline        string `json:"weekly_deadline"`
+	LeagueUpdateTimestamp string `json:"league_update_timestamp"`
+	ScoringType           string `json:"scoring_type"`
+	LeagueType            string `json:"league_type"`
+	Renew                 string `json:"renew"`
+	Renewed               string `json:"renewed"`
+	ShortInvitationURL    string `json:"short_invitation_url"`
+	IsProLeague           string `json:"is_pro_league"`
+	CurrentWeek           string `json:"current_week"`
+	StartWeek             string `json:"start_week"`
+	StartDate             string `json:"start_date"`
+	EndWeek               string `json:"end_week"`
+	EndDate               string `json:"end_date"`
+	IsFinished            int    `json:"is_finished"`
+	Teams                 []Team `json:"teams"`
+}
+
+type Team struct {
+	TeamKey          string    `json:"team_key"`
+	TeamID           string    `json:"team_id" bson:"_id"`
+	Name             string    `json:"name"`
+	URL              string    `json:"url"`
+	TeamLogo         string    `json:"team_logo"`
+	WaiverPriority   int       `json:"waiver_priority"`
+	NumberOfMoves    int       `json:"number_of_moves"`
+	NumberOfTrades   int       `json:"number_of_trades"`
+	ClinchedPlayoffs int       `json:"clinched_playoffs,omitempty"`
+	Managers         []Manager `json:"managers"`
+}
+type LeagueResourcesTeamsResponse struct {
+	XMLName     xml.Name `xml:"fantasy_content"`
+	Text        string   `xml:",chardata"`
+	Lang        string   `xml:"lang,attr"`
+	URI         string   `xml:"uri,attr"`
+	Time        string   `xml:"time,attr"`
+	Copyright   string   `xml:"copyright,attr"`
+	RefreshRate string   `xml:"refresh_rate,attr"`
+	Yahoo       string   `xml:"yahoo,attr"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	League      struct {
+		Text                  string `xml:",chardata"`
+		LeagueKey             string `xml:"league_key"`
+		LeagueID              string `xml:"league_id"`
+		Name                  string `xml:"name"`
+		URL                   string `xml:"url"`
+		LogoURL               string `xml:"logo_url"`
+		Password              string `xml:"password"`
+		DraftStatus           string `xml:"draft_status"`
+		NumTeams              string `xml:"num_teams"`
+		EditKey               string `xml:"edit_key"`
+		WeeklyDeadline        string `xml:"weekly_deadline"`
+		LeagueUpdateTimestamp string `xml:"league_update_timestamp"`
+		ScoringType           string `xml:"scoring_type"`
+		LeagueType            string `xml:"league_type"`
+		Renew                 string `xml:"renew"`
+		Renewed               string `xml:"renewed"`
+		IrisGroupChatID       string `xml:"iris_group_chat_id"`
+		ShortInvitationURL    string `xml:"short_invitation_url"`
+		AllowAddToDlExtraPos  string `xml:"allow_add_to_dl_extra_pos"`
+		IsProLeague           string `xml:"is_pro_league"`
+		IsCashLeague          string `xml:"is_cash_league"`
+		CurrentWeek           string `xml:"current_week"`
+		StartWeek             string `xml:"start_week"`
+		StartDate             string `xml:"start_date"`
+		EndWeek               string `xml:"end_week"`
+		EndDate               string `xml:"end_date"`
+		GameCode              string `xml:"game_code"`
+		Season                string `xml:"season"`
+		Teams                 struct {
+			Text  string `xml:",chardata"`
+			Count string `xml:"count,attr"`
+			Team  []struct {
+				Text                  string `xml:",chardata"`
+				TeamKey               string `xml:"team_key"`
+				TeamID                string `xml:"team_id"`
+				Name                  string `xml:"name"`
+				IsOwnedByCurrentLogin string `xml:"is_owned_by_current_login"`
+				URL                   string `xml:"url"`
+				TeamLogos             struct {
+					Text     string `xml:",chardata"`
+					TeamLogo struct {
+						Text string `xml:",chardata"`
+						Size string `xml:"size"`
+						URL  string `xml:"url"`
+					} `xml:"team_logo"`
+				} `xml:"team_logos"`
+				WaiverPriority int `xml:"waiver_priority"`
+				NumberOfMoves  int `xml:"number_of_moves"`
+				NumberOfTrades int `xml:"number_of_trades"`
+				RosterAdds     struct {
+					Text          string `xml:",chardata"`
+					CoverageType  string `xml:"coverage_type"`
+					CoverageValue string `xml:"coverage_value"`
+					Value         string `xml:"value"`
+				} `xml:"roster_adds"`
+				LeagueScoringType string  `xml:"league_scoring_type"`
+				HasDraftGrade     string  `xml:"has_draft_grade"`
+				Managers          Manager `xml:"managers>Manager"`
+			} `xml:"team"`
+		} `xml:"teams"`
+	} `xml:"league"`
+}
+
+func (s *Service) GetLeagueResourcesTeams(ctx context.Context, leagueKey string) (*LeagueResourcesTeams, error) {
+	url := s.resourceURL("league", leagueKey, "teams")
+	res, err := s.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	v := LeagueResourcesTeamsResponse{}
+	bytes, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	err = xml.Unmarshal(bytes, &v)
+	if err != nil {
+		level.Error(s.logger).Log("message", "could not unmarshal yahoo response", "error", err, "url", url)
+		return nil, err
+	}
+
+	return nil, errors.New("not implemented")
+}
+
+type LeagueResourcesDraftResults struct {
+	LeagueKey             string `json:"league_key"`
+	LeagueID              string `json:"league_id"`
+	Name                  string `json:"name"`
+	URL                   string `json:"url"`
+	DraftStatus           string `json:"draft_status"`
+	NumTeams              int    `json:"num_teams"`
+	EditKey               string `json:"edit_key"`
+	WeeklyDeadline        string `json:"weekly_deadline"`
+	LeagueUpdateTimestamp string `json:"league_update_timestamp"`
+	ScoringType           string `json:"scoring_type"`
+	LeagueType            string `json:"league_type"`
+	Renew                 string `json:"renew"`
+	Renewed               string `json:"renewed"`
+	ShortInvitationURL    string `json:"short_invitation_url"`
+	IsProLeague           string `json:"is_pro_league"`
+	CurrentWeek           string `json:"current_week"`
+	StartWeek             string `json:"start_week"`
+	StartDate             string `json:"start_date"`
+	EndWeek               string `json:"end_week"`
+	EndDate               string `json:"end_date"`
+	IsFinished            int    `json:"is_finished"`
+	DraftResults          []struct {
+		Pick      int    `json:"pick"`
+		Round     int    `json:"round"`
+		Cost      string `json:"cost"`
+		TeamKey   string `json:"team_key"`
+		PlayerKey string `json:"player_key"`
+	} `json:"draft_results"`
+}
+type LeagueResourcesDraftResultsResponse struct {
+	XMLName     xml.Name `xml:"fantasy_content"`
+	Text        string   `xml:",chardata"`
+	Lang        string   `xml:"lang,attr"`
+	URI         string   `xml:"uri,attr"`
+	Time        string   `xml:"time,attr"`
+	Copyright   string   `xml:"copyright,attr"`
+	RefreshRate string   `xml:"refresh_rate,attr"`
+	Yahoo       string   `xml:"yahoo,attr"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	League      struct {
+		Text                  string `xml:",chardata"`
+		LeagueKey             string `xml:"league_key"`
+		LeagueID              string `xml:"league_id"`
+		Name                  string `xml:"name"`
+		URL                   string `xml:"url"`
+		LogoURL               string `xml:"logo_url"`
+		Password              string `xml:"password"`
+		DraftStatus           string `xml:"draft_status"`
+		NumTeams              int    `xml:"num_teams"`
+		EditKey               string `xml:"edit_key"`
+		WeeklyDeadline        string `xml:"weekly_deadline"`
+		LeagueUpdateTimestamp string `xml:"league_update_timestamp"`
+		ScoringType           string `xml:"scoring_type"`
+		LeagueType            string `xml:"league_type"`
+		Renew                 string `xml:"renew"`
+		Renewed               string `xml:"renewed"`
+		IrisGroupChatID       string `xml:"iris_group_chat_id"`
+		ShortInvitationURL    string `xml:"short_invitation_url"`
+		AllowAddToDlExtraPos  string `xml:"allow_add_to_dl_extra_pos"`
+		IsProLeague           string `xml:"is_pro_league"`
+		IsCashLeague          string `xml:"is_cash_league"`
+		CurrentWeek           string `xml:"current_week"`
+		StartWeek             string `xml:"start_week"`
+		StartDate             string `xml:"start_date"`
+		EndWeek               string `xml:"end_week"`
+		EndDate               string `xml:"end_date"`
+		IsFinished            string `xml:"is_finished"`
+		GameCode              string `xml:"game_code"`
+		Season                string `xml:"season"`
+		DraftResults          struct {
+			Text        string `xml:",chardata"`
+			Count       string `xml:"count,attr"`
+			DraftResult []struct {
+				Text      string `xml:",chardata"`
+				Pick      int    `xml:"pick"`
+				Round     int    `xml:"round"`
+				TeamKey   string `xml:"team_key"`
+				PlayerKey string `xml:"player_key"`
+			} `xml:"draft_result"`
+		} `xml:"draft_results"`
+	} `xml:"league"`
+}
+
+func (s *Service) GetLeagueResourcesDraftResults(ctx context.Context, leagueKey string) (*LeagueResourcesDraftResultsResponse, error) {
+	url := s.resourceURL("league", leagueKey, "draftresults")
+	res, err := s.get(ctx, url)
+
+	v := LeagueResourcesDraftResultsResponse{}
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	bytes, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	// transform response to games
+	err = xml.Unmarshal(bytes, &v)
+	if err != nil {
+		level.Error(s.logger).Log("message", "could not unmarshal yahoo response", "error", err, "url", url)
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// DraftResultRequest is the body of a draft result written to a league with an offline draft
+type DraftResultRequest struct {
+	XMLName xml.Name `xml:"fantasy_content"`
+	League  struct {
+		LeagueKey    string `xml:"league_key"`
+		DraftResults struct {
+			DraftResult []DraftResult `xml:"draft_result"`
+		} `xml:"draft_results"`
+	} `xml:"league"`
+}
+
+type DraftResult struct {
+	Pick      int    `xml:"pick"`
+	Round     int    `xml:"round,omitempty"`
+	TeamKey   string `xml:"team_key"`
+	PlayerKey string `xml:"player_key"`
+	Cost      int    `xml:"cost,omitempty"`
+}
+
+// PutLeagueResourcesDraftResult enters one pick of a league's offline draft. Yahoo replaces whatever was at the
+// pick, so sending the same result again changes nothing. Only the league's commissioner may enter results.
+func (s *Service) PutLeagueResourcesDraftResult(ctx context.Context, leagueKey string, result DraftResult) error {
+	body := DraftResultRequest{}
+	body.League.LeagueKey = leagueKey
+	body.League.DraftResults.DraftResult = []DraftResult{result}
+	payload, err := xml.Marshal(&body)
+	if err != nil {
+		return err
+	}
+
+	url := s.resourceURL("league", leagueKey, "draftresults")
+	res, err := s.put(ctx, url, append([]byte(xml.Header), payload...))
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+type LeagueResourcesTransaction struct {
+	LeagueKey             string `json:"league_key"`
+	LeagueID              string `json:"league_id"`
+	Name                  string `json:"name"`
+	URL                   string `json:"url"`
+	DraftStatus           string `json:"draft_status"`
+	NumTeams              int    `json:"num_teams"`
+	EditKey               string `json:"edit_key"`
+	WeeklyDeadline        string `json:"weekly_deadline"`
+	LeagueUpdateTimestamp string `json:"league_update_timestamp"`
+	ScoringType           string `json:"scoring_type"`
+	LeagueType            string `json:"league_type"`
+	Renew                 string `json:"renew"`
+	Renewed               string `json:"renewed"`
+	ShortInvitationURL    string `json:"short_invitation_url"`
+	IsProLeague           string `json:"is_pro_league"`
+	CurrentWeek           string `json:"current_week"`
+	StartWeek             string `json:"start_week"`
+	StartDate             string `json:"start_date"`
+	EndWeek               string `json:"end_week"`
+	EndDate               string `json:"end_date"`
+	IsFinished            int    `json:"is_finished"`
+	Transactions          []struct {
+		TransactionKey string        `json:"transaction_key"`
+		TransactionID  string        `json:"transaction_id"`
+		Type           string        `json:"type"`
+		Status         string        `json:"status"`
+		Timestamp      string        `json:"timestamp"`
+		Players        []interface{} `json:"fdr-players-import"`
+	} `json:"transactions"`
+}
+type LeagueResourcesTransactionResponse struct {
+	XMLName     xml.Name `xml:"fantasy_content"`
+	Text        string   `xml:",chardata"`
+	Lang        string   `xml:"lang,attr"`
+	URI         string   `xml:"uri,attr"`
+	Time        string   `xml:"time,attr"`
+	Copyright   string   `xml:"copyright,attr"`
+	RefreshRate string   `xml:"refresh_rate,attr"`
+	Yahoo       string   `xml:"yahoo,attr"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	League      struct {
+		Text                  string `xml:",chardata"`
+		LeagueKey             string `xml:"league_key"`
+		LeagueID              string `xml:"league_id"`
+		Name                  string `xml:"name"`
+		URL                   string `xml:"url"`
+		LogoURL               string `xml:"logo_url"`
+		Password              string `xml:"password"`
+		DraftStatus           string `xml:"draft_status"`
+		NumTeams              string `xml:"num_teams"`
+		EditKey               string `xml:"edit_key"`
+		WeeklyDeadline        string `xml:"weekly_deadline"`
+		LeagueUpdateTimestamp string `xml:"league_update_timestamp"`
+		ScoringType           string `xml:"scoring_type"`
+		LeagueType            string `xml:"league_type"`
+		Renew                 string `xml:"renew"`
+		Renewed               string `xml:"renewed"`
+		IrisGroupChatID       string `xml:"iris_group_chat_id"`
+		ShortInvitationURL    string `xml:"short_invitation_url"`
+		AllowAddToDlExtraPos  string `xml:"allow_add_to_dl_extra_pos"`
+		IsProLeague           string `xml:"is_pro_league"`
+		IsCashLeague          string `xml:"is_cash_league"`
+		CurrentWeek           string `xml:"current_week"`
+		StartWeek             string `xml:"start_week"`
+		StartDate             string `xml:"start_date"`
+		EndWeek               string `xml:"end_week"`
+		EndDate               string `xml:"end_date"`
+		IsFinished            string `xml:"is_finished"`
+		GameCode              string `xml:"game_code"`
+		Season                string `xml:"season"`
+		Transactions          struct {
+			Text        string `xml:",chardata"`
+			Count       string `xml:"count,attr"`
+			Transaction []struct {
+				Text           string `xml:",chardata"`
+				TransactionKey string `xml:"transaction_key"`
+				TransactionID  string `xml:"transaction_id"`
+				Type           string `xml:"type"`
+				Status         string `xml:"status"`
+				Timestamp      string `xml:"timestamp"`
+				Players        struct {
+					Text   string `xml:",chardata"`
+					Count  string `xml:"count,attr"`
+					Player []struct {
+						Text      string `xml:",chardata"`
+						PlayerKey string `xml:"player_key"`
+						PlayerID  string `xml:"player_id"`
+						Name      struct {
+							Text       string `xml:",chardata"`
+							Full       string `xml:"full"`
+							First      string `xml:"first"`
+							Last       string `xml:"last"`
+							AsciiFirst string `xml:"ascii_first"`
+							AsciiLast  string `xml:"ascii_last"`
+						} `xml:"name"`
+						EditorialTeamAbbr string `xml:"editorial_team_abbr"`
+						DisplayPosition   string `xml:"display_position"`
+						PositionType      string `xml:"position_type"`
+						TransactionData   struct {
+							Text                string `xml:",chardata"`
+							Type                string `xml:"type"`
+							SourceType          string `xml:"source_type"`
+							DestinationType     string `xml:"destination_type"`
+							DestinationTeamKey  string `xml:"destination_team_key"`
+							DestinationTeamName string `xml:"destination_team_name"`
+							SourceTeamKey       string `xml:"source_team_key"`
+							SourceTeamName      string `xml:"source_team_name"`
+						} `xml:"transaction_data"`
+					} `xml:"player"`
+				} `xml:"players"`
+			} `xml:"transaction"`
+		} `xml:"transactions"`
+	} `xml:"league"`
+}
+
+func (s *Service) GetLeagueResourcesTransaction(ctx context.Context, leagueKey string, options Options) (*LeagueResourcesTransaction, error) {
+	url := s.resourceURL("league", leagueKey, "transactions"+options.String())
+	res, err := s.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	return nil, errors.New("not implemented")
+}
+
+type GetLeagueResourcesPlayersResponse struct {
+	XMLName     xml.Name `xml:"fantasy_content"`
+	Text        string   `xml:",chardata"`
+	Lang        string   `xml:"lang,attr"`
+	URI         string   `xml:"uri,attr"`
+	Time        string   `xml:"time,attr"`
+	Copyright   string   `xml:"copyright,attr"`
+	RefreshRate string   `xml:"refresh_rate,attr"`
+	Yahoo       string   `xml:"yahoo,attr"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	League      struct {
+		Text                  string `xml:",chardata"`
+		LeagueKey             string `xml:"league_key"`
+		LeagueID              string `xml:"league_id"`
+		Name                  string `xml:"name"`
+		URL                   string `xml:"url"`
+		LogoURL               string `xml:"logo_url"`
+		Password              string `xml:"password"`
+		DraftStatus           string `xml:"draft_status"`
+		NumTeams              string `xml:"num_teams"`
+		EditKey               string `xml:"edit_key"`
+		WeeklyDeadline        string `xml:"weekly_deadline"`
+		LeagueUpdateTimestamp string `xml:"league_update_timestamp"`
+		ScoringType           string `xml:"scoring_type"`
+		LeagueType            string `xml:"league_type"`
+		Renew                 string `xml:"renew"`
+		Renewed               string `xml:"renewed"`
+		IrisGroupChatID       string `xml:"iris_group_chat_id"`
+		ShortInvitationURL    string `xml:"short_invitation_url"`
+		AllowAddToDlExtraPos  string `xml:"allow_add_to_dl_extra_pos"`
+		IsProLeague           string `xml:"is_pro_league"`
+		IsCashLeague          string `xml:"is_cash_league"`
+		CurrentWeek           string `xml:"current_week"`
+		StartWeek             string `xml:"start_week"`
+		StartDate             string `xml:"start_date"`
+		EndWeek               string `xml:"end_week"`
+		EndDate               string `xml:"end_date"`
+		IsFinished            string `xml:"is_finished"`
+		GameCode              string `xml:"game_code"`
+		Season                string `xml:"season"`
+		Players               struct {
+			Text   string `xml:",chardata"`
+			Count  string `xml:"count,attr"`
+			Player []struct {
+				Text      string `xml:",chardata"`
+				PlayerKey string `xml:"player_key"`
+				PlayerID  string `xml:"player_id"`
+				Name      struct {
+					Text       string `xml:",chardata"`
+					Full       string `xml:"full"`
+					First      string `xml:"first"`
+					Last       string `xml:"last"`
+					AsciiFirst string `xml:"ascii_first"`
+					AsciiLast  string `xml:"ascii_last"`
+				} `xml:"name"`
+				Status                string `xml:"status"`
+				StatusFull            string `xml:"status_full"`
+				EditorialPlayerKey    string `xml:"editorial_player_key"`
+				EditorialTeamKey      string `xml:"editorial_team_key"`
+				EditorialTeamFullName string `xml:"editorial_team_full_name"`
+				EditorialTeamAbbr     string `xml:"editorial_team_abbr"`
+				ByeWeeks              struct {
+					Text string `xml:",chardata"`
+					Week int    `xml:"week"`
+				} `xml:"bye_weeks"`
+				UniformNumber   int    `xml:"uniform_number"`
+				DisplayPosition string `xml:"display_position"`
+				Headshot        struct {
+					Text string `xml:",chardata"`
+					URL  string `xml:"url"`
+					Size string `xml:"size"`
+				} `xml:"headshot"`
+				ImageURL          string `xml:"image_url"`
+				IsUndroppable     string `xml:"is_undroppable"`
+				PositionType      string `xml:"position_type"`
+				PrimaryPosition   string `xml:"primary_position"`
+				EligiblePositions struct {
+					Text     string   `xml:",chardata"`
+					Position []string `xml:"position"`
+				} `xml:"eligible_positions"`
+				HasPlayerNotes           string `xml:"has_player_notes"`
+				PlayerNotesLastTimestamp string `xml:"player_notes_last_timestamp"`
+			} `xml:"player"`
+		} `xml:"players"`
+	} `xml:"league"`
+}
+type GetLeagueResourcesPlayersStatsResponse struct {
+	XMLName     xml.Name `xml:"fantasy_content"`
+	Text        string   `xml:",chardata"`
+	Lang        string   `xml:"lang,attr"`
+	URI         string   `xml:"uri,attr"`
+	Time        string   `xml:"time,attr"`
+	Copyright   string   `xml:"copyright,attr"`
+	RefreshRate string   `xml:"refresh_rate,attr"`
+	Yahoo       string   `xml:"yahoo,attr"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	League      struct {
+		Text                  string `xml:",chardata"`
+		LeagueKey             string `xml:"league_key"`
+		LeagueID              string `xml:"league_id"`
+		Name                  string `xml:"name"`
+		URL                   string `xml:"url"`
+		LogoURL               string `xml:"logo_url"`
+		Password              string `xml:"password"`
+		DraftStatus           string `xml:"draft_status"`
+		NumTeams              string `xml:"num_teams"`
+		EditKey               string `xml:"edit_key"`
+		WeeklyDeadline        string `xml:"weekly_deadline"`
+		LeagueUpdateTimestamp string `xml:"league_update_timestamp"`
+		ScoringType           string `xml:"scoring_type"`
+		LeagueType            string `xml:"league_type"`
+		Renew                 string `xml:"renew"`
+		Renewed               string `xml:"renewed"`
+		IrisGroupChatID       string `xml:"iris_group_chat_id"`
+		ShortInvitationURL    string `xml:"short_invitation_url"`
+		AllowAddToDlExtraPos  string `xml:"allow_add_to_dl_extra_pos"`
+		IsProLeague           string `xml:"is_pro_league"`
+		IsCashLeague          string `xml:"is_cash_league"`
+		CurrentWeek           string `xml:"current_week"`
+		StartWeek             string `xml:"start_week"`
+		StartDate             string `xml:"start_date"`
+		EndWeek               string `xml:"end_week"`
+		EndDate               string `xml:"end_date"`
+		IsFinished            string `xml:"is_finished"`
+		GameCode              string `xml:"game_code"`
+		Season                string `xml:"season"`
+		Players               struct {
+			Text   string `xml:",chardata"`
+			Count  string `xml:"count,attr"`
+			Player struct {
+				Text      string `xml:",chardata"`
+				PlayerKey string `xml:"player_key"`
+				PlayerID  string `xml:"player_id"`
+				Name      struct {
+					Text       string `xml:",chardata"`
+					Full       string `xml:"full"`
+					First      string `xml:"first"`
+					Last       string `xml:"last"`
+					AsciiFirst string `xml:"ascii_first"`
+					AsciiLast  string `xml:"ascii_last"`
+				} `xml:"name"`
+				Status                int    `xml:"status"`
+				StatusFull            string `xml:"status_full"`
+				EditorialPlayerKey    string `xml:"editorial_player_key"`
+				EditorialTeamKey      string `xml:"editorial_team_key"`
+				EditorialTeamFullName string `xml:"editorial_team_full_name"`
+				EditorialTeamAbbr     string `xml:"editorial_team_abbr"`
+				ByeWeeks              struct {
+					Text string `xml:",chardata"`
+					Week int    `xml:"week"`
+				} `xml:"bye_weeks"`
+				UniformNumber   string `xml:"uniform_number"`
+				DisplayPosition string `xml:"display_position"`
+				Headshot        struct {
+					Text string `xml:",chardata"`
+					URL  string `xml:"url"`
+					Size string `xml:"size"`
+				} `xml:"headshot"`
+				ImageURL          string `xml:"image_url"`
+				IsUndroppable     string `xml:"is_undroppable"`
+				PositionType      string `xml:"position_type"`
+				PrimaryPosition   string `xml:"primary_position"`
+				EligiblePositions struct {
+					Text     string `xml:",chardata"`
+					Position string `xml:"position"`
+				} `xml:"eligible_positions"`
+				PlayerStats struct {
+					Text         string `xml:",chardata"`
+					CoverageType string `xml:"coverage_type"`
+					Week         string `xml:"week"`
+					Stats        struct {
+						Text string `xml:",chardata"`
+						Stat []struct {
+							Text   string `xml:",chardata"`
+							StatID string `xml:"stat_id"`
+							Value  string `xml:"value"`
+						} `xml:"stat"`
+					} `xml:"stats"`
+				} `xml:"player_stats"`
+				PlayerPoints struct {
+					Text         string `xml:",chardata"`
+					CoverageType string `xml:"coverage_type"`
+					Week         string `xml:"week"`
+					Total        string `xml:"total"`
+				} `xml:"player_points"`
+			} `xml:"player"`
+		} `xml:"players"`
+	} `xml:"league"`
+}
+
+func (s *Service) GetLeagueResourcesPlayers(ctx context.Context, leagueKey string, playerKeys []string, options Options) (*GetLeagueResourcesPlayersResponse, error) {
+	url := s.resourceURL("league", leagueKey, "players"+NewOptions().Keys("player_keys", playerKeys).String(), "stats"+options.String())
+	res, err := s.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	return nil, errors.New("not implemented")
+}
@@ -0,0 +1,105 @@
+package yahoo
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Values of Options.Type for stats and rosters
+const (
+	TypeSeason    = "season"
+	TypeWeek      = "week"
+	TypeDate      = "date"
+	TypeLastWeek  = "lastweek"
+	TypeLastMonth = "lastmonth"
+)
+
+// Values of Options.Status for a collection of players
+const (
+	StatusAvailable  = "A"
+	StatusFreeAgents = "FA"
+	StatusWaivers    = "W"
+	StatusTaken      = "T"
+	StatusKeepers    = "K"
+)
+
+// Values of Options.Sort for a collection of players. A stat id sorts by that stat.
+const (
+	SortActualRank   = "AR"
+	SortOverallRank  = "OR"
+	SortName         = "NAME"
+	SortPoints       = "PTS"
+	SortPercentOwned = "PO"
+)
+
+// Options are the filters Yahoo takes on a collection or a sub-resource, written after it as ;name=value, like
+// players;position=QB;status=A;start=25;count=25 or stats;type=week;week=3. The zero value filters nothing.
+// Every method returns a copy, so options can be shared and built on.
+type Options struct {
+	params []string
+}
+
+// NewOptions is the start of a chain like NewOptions().Type(TypeWeek).Week(3)
+func NewOptions() Options {
+	return Options{}
+}
+
+func (o Options) with(name, value string) Options {
+	params := make([]string, len(o.params), len(o.params)+1)
+	copy(params, o.params)
+	o.params = append(params, name+"="+value)
+	return o
+}
+
+// Week is the week of stats, rosters and scoreboards
+func (o Options) Week(week int) Options {
+	return o.with("week", strconv.Itoa(week))
+}
+
+// Date is the day of stats and rosters, as YYYY-MM-DD
+func (o Options) Date(date string) Options {
+	return o.with("date", date)
+}
+
+// Type is the period of stats, one of the Type constants
+func (o Options) Type(statType string) Options {
+	return o.with("type", statType)
+}
+
+// Position only keeps players eligible at position, like QB or RB
+func (o Options) Position(position string) Options {
+	return o.with("position", position)
+}
+
+// Status only keeps players with status, one of the Status constants
+func (o Options) Status(status string) Options {
+	return o.with("status", status)
+}
+
+// Sort orders players, by one of the Sort constants or a stat id
+func (o Options) Sort(sort string) Options {
+	return o.with("sort", sort)
+}
+
+// Start skips the first start entries of the collection
+func (o Options) Start(start int) Options {
+	return o.with("start", strconv.Itoa(start))
+}
+
+// Count is how many entries of the collection Yahoo returns, it returns 25 at most
+func (o Options) Count(count int) Options {
+	return o.with("count", strconv.Itoa(count))
+}
+
+// Keys only keeps the entries with these keys, name being player_keys, league_keys, team_keys and the like
+func (o Options) Keys(name string, keys []string) Options {
+	return o.with(name, strings.Join(keys, ","))
+}
+
+// String is the options as they are written after a resource, or nothing for no options
+func (o Options) String() string {
+	if len(o.params) == 0 {
+		return ""
+	}
+	return ";" + strings.Join(o.params, ";")
+}
@@ -0,0 +1,73 @@
+package yahoo
+
+import (
+	"context"
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestOptions_String(t *testing.T) {
+	assert.Equal(t, "", NewOptions().String())
+	assert.Equal(t, ";type=week;week=3", NewOptions().Type(TypeWeek).Week(3).String())
+	assert.Equal(t, ";position=QB;status=A;sort=AR;start=25;count=25", NewOptions().Position("QB").Status(StatusAvailable).Sort(SortActualRank).Start(25).Count(25).String())
+	assert.Equal(t, ";player_keys=399.p.1,399.p.2", NewOptions().Keys("player_keys", []string{"399.p.1", "399.p.2"}).String())
+	assert.Equal(t, ";type=date;date=2020-09-13", NewOptions().Type(TypeDate).Date("2020-09-13").String())
+}
+
+func TestOptions_BuildOnShared(t *testing.T) {
+	available := NewOptions().Status(StatusAvailable)
+	quarterbacks := available.Position("QB")
+	runningBacks := available.Position("RB")
+
+	assert.Equal(t, ";status=A", available.String())
+	assert.Equal(t, ";status=A;position=QB", quarterbacks.String())
+	assert.Equal(t, ";status=A;position=RB", runningBacks.String())
+}
+
+func TestService_ResourceURLs(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><fantasy_content/>`))
+	}))
+	defer server.Close()
+
+	store := newFakeTokenStore("manager", oauth2.Token{AccessToken: "token", Expiry: time.Now().Add(time.Hour)})
+	service := NewService(log.NewNopLogger(), nil).WithBaseURL(server.URL).WithTokenStore(&oauth2.Config{}, store).WithSession("manager")
+	ctx := context.Background()
+
+	_, _ = service.GetGameResourcesPlayers(ctx, 399, NewOptions().Position("QB").Start(25).Count(25))
+	_, _ = service.GetLeagueResourcesPlayers(ctx, "399.l.1", []string{"399.p.1", "399.p.2"}, NewOptions().Type(TypeWeek).Week(3))
+	_, _ = service.GetPlayerResourcesOwnership(ctx, "399.l.1", "399.p.1")
+	_, _ = service.GetRosterResourcesPlayers(ctx, "399.l.1.t.1", NewOptions().Week(3))
+	_, _ = service.GetUserResourcesGames(ctx)
+
+	assert.Equal(t, []string{
+		"/game/399/players;position=QB;start=25;count=25/stats",
+		"/league/399.l.1/players;player_keys=399.p.1,399.p.2/stats;type=week;week=3",
+		"/league/399.l.1/players;player_keys=399.p.1/ownership",
+		"/team/399.l.1.t.1/roster;week=3/players",
+		"/users;use_login=1/games",
+	}, paths)
+}
+
+func TestService_ResourceCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	store := newFakeTokenStore("manager", oauth2.Token{AccessToken: "token", Expiry: time.Now().Add(time.Hour)})
+	service := NewService(log.NewNopLogger(), nil).WithBaseURL(server.URL).WithTokenStore(&oauth2.Config{}, store).WithSession("manager")
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := service.GetLeagueResourcesScoreboard(ctx, "399.l.1", NewOptions().Week(1))
+	assert.Error(t, err)
+	assert.Equal(t, context.DeadlineExceeded, ctx.Err())
+}
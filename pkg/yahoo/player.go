@@ -0,0 +1,523 @@
+package yahoo
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"github.com/go-kit/kit/log/level"
+	"io/ioutil"
+)
+
+type PlayerResourcesMeta struct {
+	PlayerKey string `json:"player_key"`
+	PlayerID  string `json:"player_id"`
+	Name      struct {
+		Full       string `json:"full"`
+		First      string `json:"first"`
+		Last       string `json:"last"`
+		ASCIIFirst string `json:"ascii_first"`
+		ASCIILast  string `json:"ascii_last"`
+	} `json:"name"`
+	EditorialPlayerKey    string   `json:"editorial_player_key"`
+	EditorialTeamKey      string   `json:"editorial_team_key"`
+	EditorialTeamFullName string   `json:"editorial_team_full_name"`
+	EditorialTeamAbbr     string   `json:"editorial_team_abbr"`
+	UniformNumber         string   `json:"uniform_number"`
+	DisplayPosition       string   `json:"display_position"`
+	Headshot              string   `json:"headshot"`
+	IsUndroppable         string   `json:"is_undroppable"`
+	PositionType          string   `json:"position_type"`
+	EligiblePositions     []string `json:"eligible_positions"`
+}
+type PlayerResourcesMetaResponse struct {
+	XMLName     xml.Name `xml:"fantasy_content"`
+	Text        string   `xml:",chardata"`
+	Lang        string   `xml:"lang,attr"`
+	URI         string   `xml:"uri,attr"`
+	Time        string   `xml:"time,attr"`
+	Copyright   string   `xml:"copyright,attr"`
+	RefreshRate string   `xml:"refresh_rate,attr"`
+	Yahoo       string   `xml:"yahoo,attr"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	Player      struct {
+		Text      string `xml:",chardata"`
+		PlayerKey string `xml:"player_key"`
+		PlayerID  string `xml:"player_id"`
+		Name      struct {
+			Text       string `xml:",chardata"`
+			Full       string `xml:"full"`
+			First      string `xml:"first"`
+			Last       string `xml:"last"`
+			AsciiFirst string `xml:"ascii_first"`
+			AsciiLast  string `xml:"ascii_last"`
+		} `xml:"name"`
+		Status                string `xml:"status"`
+		StatusFull            string `xml:"status_full"`
+		EditorialPlayerKey    string `xml:"editorial_player_key"`
+		EditorialTeamKey      string `xml:"editorial_team_key"`
+		EditorialTeamFullName string `xml:"editorial_team_full_name"`
+		EditorialTeamAbbr     string `xml:"editorial_team_abbr"`
+		ByeWeeks              struct {
+			Text string `xml:",chardata"`
+			Week string `xml:"week"`
+		} `xml:"bye_weeks"`
+		UniformNumber   string `xml:"uniform_number"`
+		DisplayPosition string `xml:"display_position"`
+		Headshot        struct {
+			Text string `xml:",chardata"`
+			URL  string `xml:"url"`
+			Size string `xml:"size"`
+		} `xml:"headshot"`
+		ImageURL          string `xml:"image_url"`
+		IsUndroppable     string `xml:"is_undroppable"`
+		PositionType      string `xml:"position_type"`
+		EligiblePositions struct {
+			Text     string `xml:",chardata"`
+			Position string `xml:"position"`
+		} `xml:"eligible_positions"`
+	} `xml:"player"`
+}
+
+func (s *Service) GetPlayerResourcesMeta(ctx context.Context, playerKey string) (*PlayerResourcesMeta, error) {
+	url := s.resourceURL("player", playerKey, "metadata")
+	res, err := s.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	return nil, errors.New("not implemented")
+}
+
+type PlayerResourcesStats struct {
+	PlayerKey string `json:"player_key"`
+	PlayerID  string `json:"player_id"`
+	Name      struct {
+		Full       string `json:"full"`
+		First      string `json:"first"`
+		Last       string `json:"last"`
+		ASCIIFirst string `json:"ascii_first"`
+		ASCIILast  string `json:"ascii_last"`
+	} `json:"name"`
+	EditorialPlayerKey    string   `json:"editorial_player_key"`
+	EditorialTeamKey      string   `json:"editorial_team_key"`
+	EditorialTeamFullName string   `json:"editorial_team_full_name"`
+	EditorialTeamAbbr     string   `json:"editorial_team_abbr"`
+	UniformNumber         string   `json:"uniform_number"`
+	DisplayPosition       string   `json:"display_position"`
+	Headshot              string   `json:"headshot"`
+	IsUndroppable         string   `json:"is_undroppable"`
+	PositionType          string   `json:"position_type"`
+	EligiblePositions     []string `json:"eligible_positions"`
+	Stats                 struct {
+		CoverageType  string `json:"coverage_type"`
+		CoverageValue string `json:"coverage_value"`
+		Stats         []struct {
+			StatID string `json:"stat_id"`
+			Value  string `json:"value"`
+		} `json:"stats"`
+	} `json:"stats,omitempty"`
+	Ownership struct {
+		Value int `json:"value,omitempty"`
+	} `json:"ownership,omitempty"`
+}
+type PlayerResourcesStatsResponse struct {
+	XMLName     xml.Name `xml:"fantasy_content"`
+	Text        string   `xml:",chardata"`
+	Lang        string   `xml:"lang,attr"`
+	URI         string   `xml:"uri,attr"`
+	Time        string   `xml:"time,attr"`
+	Copyright   string   `xml:"copyright,attr"`
+	RefreshRate string   `xml:"refresh_rate,attr"`
+	Yahoo       string   `xml:"yahoo,attr"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	Player      struct {
+		Text      string `xml:",chardata"`
+		PlayerKey string `xml:"player_key"`
+		PlayerID  string `xml:"player_id"`
+		Name      struct {
+			Text       string `xml:",chardata"`
+			Full       string `xml:"full"`
+			First      string `xml:"first"`
+			Last       string `xml:"last"`
+			AsciiFirst string `xml:"ascii_first"`
+			AsciiLast  string `xml:"ascii_last"`
+		} `xml:"name"`
+		Status                string `xml:"status"`
+		StatusFull            string `xml:"status_full"`
+		EditorialPlayerKey    string `xml:"editorial_player_key"`
+		EditorialTeamKey      string `xml:"editorial_team_key"`
+		EditorialTeamFullName string `xml:"editorial_team_full_name"`
+		EditorialTeamAbbr     string `xml:"editorial_team_abbr"`
+		ByeWeeks              struct {
+			Text string `xml:",chardata"`
+			Week string `xml:"week"`
+		} `xml:"bye_weeks"`
+		UniformNumber   string `xml:"uniform_number"`
+		DisplayPosition string `xml:"display_position"`
+		Headshot        struct {
+			Text string `xml:",chardata"`
+			URL  string `xml:"url"`
+			Size string `xml:"size"`
+		} `xml:"headshot"`
+		ImageURL          string `xml:"image_url"`
+		IsUndroppable     string `xml:"is_undroppable"`
+		PositionType      string `xml:"position_type"`
+		EligiblePositions struct {
+			Text     string `xml:",chardata"`
+			Position string `xml:"position"`
+		} `xml:"eligible_positions"`
+		PlayerStats struct {
+			Text         string `xml:",chardata"`
+			CoverageType string `xml:"coverage_type"`
+			Season       string `xml:"season"`
+			Stats        struct {
+				Text string `xml:",chardata"`
+				Stat []struct {
+					Text   string `xml:",chardata"`
+					StatID string `xml:"stat_id"`
+					Value  string `xml:"value"`
+				} `xml:"stat"`
+			} `xml:"stats"`
+		} `xml:"player_stats"`
+	} `xml:"player"`
+}
+
+func (s *Service) GetPlayerResourcesStats(ctx context.Context, playerKey string, options Options) (*PlayerResourcesStats, error) {
+	url := s.resourceURL("player", playerKey, "stats"+options.String())
+	res, err := s.get(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	v := PlayerResourcesStats{}
+	bytes, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	err = xml.Unmarshal(bytes, &v)
+	if err != nil {
+		level.Error(s.logger).Log("message", "could not unmarshal yahoo response", "error", err, "url", url)
+		return nil, err
+	}
+	return &v, nil
+}
+
+type PlayerResourcesPercentOwned struct {
+	PlayerKey string `json:"player_key"`
+	PlayerID  string `json:"player_id"`
+	Name      struct {
+		Full       string `json:"full"`
+		First      string `json:"first"`
+		Last       string `json:"last"`
+		ASCIIFirst string `json:"ascii_first"`
+		ASCIILast  string `json:"ascii_last"`
+	} `json:"name"`
+	EditorialPlayerKey    string   `json:"editorial_player_key"`
+	EditorialTeamKey      string   `json:"editorial_team_key"`
+	EditorialTeamFullName string   `json:"editorial_team_full_name"`
+	EditorialTeamAbbr     string   `json:"editorial_team_abbr"`
+	UniformNumber         string   `json:"uniform_number"`
+	DisplayPosition       string   `json:"display_position"`
+	Headshot              string   `json:"headshot"`
+	IsUndroppable         string   `json:"is_undroppable"`
+	PositionType          string   `json:"position_type"`
+	EligiblePositions     []string `json:"eligible_positions"`
+	Ownership             struct {
+		Value int `json:"value"`
+	} `json:"ownership"`
+}
+type PlayerResourcesPercentOwnedResponse struct {
+	XMLName     xml.Name `xml:"fantasy_content"`
+	Text        string   `xml:",chardata"`
+	Lang        string   `xml:"lang,attr"`
+	URI         string   `xml:"uri,attr"`
+	Time        string   `xml:"time,attr"`
+	Copyright   string   `xml:"copyright,attr"`
+	RefreshRate string   `xml:"refresh_rate,attr"`
+	Yahoo       string   `xml:"yahoo,attr"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	Player      struct {
+		Text      string `xml:",chardata"`
+		PlayerKey string `xml:"player_key"`
+		PlayerID  string `xml:"player_id"`
+		Name      struct {
+			Text       string `xml:",chardata"`
+			Full       string `xml:"full"`
+			First      string `xml:"first"`
+			Last       string `xml:"last"`
+			AsciiFirst string `xml:"ascii_first"`
+			AsciiLast  string `xml:"ascii_last"`
+		} `xml:"name"`
+		Status                string `xml:"status"`
+		StatusFull            string `xml:"status_full"`
+		EditorialPlayerKey    string `xml:"editorial_player_key"`
+		EditorialTeamKey      string `xml:"editorial_team_key"`
+		EditorialTeamFullName string `xml:"editorial_team_full_name"`
+		EditorialTeamAbbr     string `xml:"editorial_team_abbr"`
+		ByeWeeks              struct {
+			Text string `xml:",chardata"`
+			Week string `xml:"week"`
+		} `xml:"bye_weeks"`
+		UniformNumber   string `xml:"uniform_number"`
+		DisplayPosition string `xml:"display_position"`
+		Headshot        struct {
+			Text string `xml:",chardata"`
+			URL  string `xml:"url"`
+			Size string `xml:"size"`
+		} `xml:"headshot"`
+		ImageURL          string `xml:"image_url"`
+		IsUndroppable     string `xml:"is_undroppable"`
+		PositionType      string `xml:"position_type"`
+		EligiblePositions struct {
+			Text     string `xml:",chardata"`
+			Position string `xml:"position"`
+		} `xml:"eligible_positions"`
+		PercentOwned struct {
+			Text         string `xml:",chardata"`
+			CoverageType string `xml:"coverage_type"`
+			Week         string `xml:"week"`
+			Value        string `xml:"value"`
+			Delta        string `xml:"delta"`
+		} `xml:"percent_owned"`
+	} `xml:"player"`
+}
+
+func (s *Service) GetPlayerResourcesPercentOwned(ctx context.Context, playerKey string) (*PlayerResourcesPercentOwned, error) {
+	url := s.resourceURL("player", playerKey, "percent_owned")
+	res, err := s.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	return nil, errors.New("not implemented")
+}
+
+type PlayerResourcesOwnership struct {
+	PlayerKey string `json:"player_key"`
+	PlayerID  string `json:"player_id"`
+	Name      struct {
+		Full       string `json:"full"`
+		First      string `json:"first"`
+		Last       string `json:"last"`
+		ASCIIFirst string `json:"ascii_first"`
+		ASCIILast  string `json:"ascii_last"`
+	} `json:"name"`
+	EditorialPlayerKey    string   `json:"editorial_player_key"`
+	EditorialTeamKey      string   `json:"editorial_team_key"`
+	EditorialTeamFullName string   `json:"editorial_team_full_name"`
+	EditorialTeamAbbr     string   `json:"editorial_team_abbr"`
+	UniformNumber         string   `json:"uniform_number"`
+	DisplayPosition       string   `json:"display_position"`
+	Headshot              string   `json:"headshot"`
+	IsUndroppable         string   `json:"is_undroppable"`
+	PositionType          string   `json:"position_type"`
+	EligiblePositions     []string `json:"eligible_positions"`
+	Ownership             struct {
+		Value int `json:"value"`
+	} `json:"ownership"`
+}
+type PlayerResourcesOwnershipResponse struct {
+	XMLName     xml.Name `xml:"fantasy_content"`
+	Text        string   `xml:",chardata"`
+	Lang        string   `xml:"lang,attr"`
+	URI         string   `xml:"uri,attr"`
+	Time        string   `xml:"time,attr"`
+	Copyright   string   `xml:"copyright,attr"`
+	RefreshRate string   `xml:"refresh_rate,attr"`
+	Yahoo       string   `xml:"yahoo,attr"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	League      struct {
+		Text                  string `xml:",chardata"`
+		LeagueKey             string `xml:"league_key"`
+		LeagueID              string `xml:"league_id"`
+		Name                  string `xml:"name"`
+		URL                   string `xml:"url"`
+		LogoURL               string `xml:"logo_url"`
+		Password              string `xml:"password"`
+		DraftStatus           string `xml:"draft_status"`
+		NumTeams              string `xml:"num_teams"`
+		EditKey               string `xml:"edit_key"`
+		WeeklyDeadline        string `xml:"weekly_deadline"`
+		LeagueUpdateTimestamp string `xml:"league_update_timestamp"`
+		ScoringType           string `xml:"scoring_type"`
+		LeagueType            string `xml:"league_type"`
+		Renew                 string `xml:"renew"`
+		Renewed               string `xml:"renewed"`
+		IrisGroupChatID       string `xml:"iris_group_chat_id"`
+		ShortInvitationURL    string `xml:"short_invitation_url"`
+		AllowAddToDlExtraPos  string `xml:"allow_add_to_dl_extra_pos"`
+		IsProLeague           string `xml:"is_pro_league"`
+		IsCashLeague          string `xml:"is_cash_league"`
+		CurrentWeek           string `xml:"current_week"`
+		StartWeek             string `xml:"start_week"`
+		StartDate             string `xml:"start_date"`
+		EndWeek               string `xml:"end_week"`
+		EndDate               string `xml:"end_date"`
+		IsFinished            string `xml:"is_finished"`
+		GameCode              string `xml:"game_code"`
+		Season                string `xml:"season"`
+		Players               struct {
+			Text   string `xml:",chardata"`
+			Count  string `xml:"count,attr"`
+			Player struct {
+				Text      string `xml:",chardata"`
+				PlayerKey string `xml:"player_key"`
+				PlayerID  string `xml:"player_id"`
+				Name      struct {
+					Text       string `xml:",chardata"`
+					Full       string `xml:"full"`
+					First      string `xml:"first"`
+					Last       string `xml:"last"`
+					AsciiFirst string `xml:"ascii_first"`
+					AsciiLast  string `xml:"ascii_last"`
+				} `xml:"name"`
+				Status                string `xml:"status"`
+				StatusFull            string `xml:"status_full"`
+				EditorialPlayerKey    string `xml:"editorial_player_key"`
+				EditorialTeamKey      string `xml:"editorial_team_key"`
+				EditorialTeamFullName string `xml:"editorial_team_full_name"`
+				EditorialTeamAbbr     string `xml:"editorial_team_abbr"`
+				ByeWeeks              struct {
+					Text string `xml:",chardata"`
+					Week string `xml:"week"`
+				} `xml:"bye_weeks"`
+				UniformNumber   string `xml:"uniform_number"`
+				DisplayPosition string `xml:"display_position"`
+				Headshot        struct {
+					Text string `xml:",chardata"`
+					URL  string `xml:"url"`
+					Size string `xml:"size"`
+				} `xml:"headshot"`
+				ImageURL          string `xml:"image_url"`
+				IsUndroppable     string `xml:"is_undroppable"`
+				PositionType      string `xml:"position_type"`
+				PrimaryPosition   string `xml:"primary_position"`
+				EligiblePositions struct {
+					Text     string `xml:",chardata"`
+					Position string `xml:"position"`
+				} `xml:"eligible_positions"`
+				Ownership struct {
+					Text          string `xml:",chardata"`
+					OwnershipType string `xml:"ownership_type"`
+					WaiverDate    string `xml:"waiver_date"`
+				} `xml:"ownership"`
+			} `xml:"player"`
+		} `xml:"players"`
+	} `xml:"league"`
+}
+
+func (s *Service) GetPlayerResourcesOwnership(ctx context.Context, leagueKey, playerKey string) (*PlayerResourcesOwnership, error) {
+	url := s.resourceURL("league", leagueKey, "players"+NewOptions().Keys("player_keys", []string{playerKey}).String(), "ownership")
+	res, err := s.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	return nil, errors.New("not implemented")
+}
+
+type PlayerResourcesDraftAnalysis struct {
+	PlayerKey string `json:"player_key"`
+	PlayerID  string `json:"player_id"`
+	Name      struct {
+		Full       string `json:"full"`
+		First      string `json:"first"`
+		Last       string `json:"last"`
+		ASCIIFirst string `json:"ascii_first"`
+		ASCIILast  string `json:"ascii_last"`
+	} `json:"name"`
+	EditorialPlayerKey    string   `json:"editorial_player_key"`
+	EditorialTeamKey      string   `json:"editorial_team_key"`
+	EditorialTeamFullName string   `json:"editorial_team_full_name"`
+	EditorialTeamAbbr     string   `json:"editorial_team_abbr"`
+	UniformNumber         string   `json:"uniform_number"`
+	DisplayPosition       string   `json:"display_position"`
+	Headshot              string   `json:"headshot"`
+	IsUndroppable         string   `json:"is_undroppable"`
+	PositionType          string   `json:"position_type"`
+	EligiblePositions     []string `json:"eligible_positions"`
+	DraftAnalysis         struct {
+		AveragePick    string `json:"average_pick"`
+		AverageRound   string `json:"average_round"`
+		AverageCost    string `json:"average_cost"`
+		PercentDrafted string `json:"percent_drafted"`
+	} `json:"draft_analysis"`
+}
+type PlayerResourcesDraftAnalysisResponse struct {
+	XMLName     xml.Name `xml:"fantasy_content"`
+	Text        string   `xml:",chardata"`
+	Lang        string   `xml:"lang,attr"`
+	URI         string   `xml:"uri,attr"`
+	Time        string   `xml:"time,attr"`
+	Copyright   string   `xml:"copyright,attr"`
+	RefreshRate string   `xml:"refresh_rate,attr"`
+	Yahoo       string   `xml:"yahoo,attr"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	Player      struct {
+		Text      string `xml:",chardata"`
+		PlayerKey string `xml:"player_key"`
+		PlayerID  string `xml:"player_id"`
+		Name      struct {
+			Text       string `xml:",chardata"`
+			Full       string `xml:"full"`
+			First      string `xml:"first"`
+			Last       string `xml:"last"`
+			AsciiFirst string `xml:"ascii_first"`
+			AsciiLast  string `xml:"ascii_last"`
+		} `xml:"name"`
+		Status                string `xml:"status"`
+		StatusFull            string `xml:"status_full"`
+		EditorialPlayerKey    string `xml:"editorial_player_key"`
+		EditorialTeamKey      string `xml:"editorial_team_key"`
+		EditorialTeamFullName string `xml:"editorial_team_full_name"`
+		EditorialTeamAbbr     string `xml:"editorial_team_abbr"`
+		ByeWeeks              struct {
+			Text string `xml:",chardata"`
+			Week string `xml:"week"`
+		} `xml:"bye_weeks"`
+		UniformNumber   string `xml:"uniform_number"`
+		DisplayPosition string `xml:"display_position"`
+		Headshot        struct {
+			Text string `xml:",chardata"`
+			URL  string `xml:"url"`
+			Size string `xml:"size"`
+		} `xml:"headshot"`
+		ImageURL          string `xml:"image_url"`
+		IsUndroppable     string `xml:"is_undroppable"`
+		PositionType      string `xml:"position_type"`
+		EligiblePositions struct {
+			Text     string `xml:",chardata"`
+			Position string `xml:"position"`
+		} `xml:"eligible_positions"`
+		DraftAnalysis struct {
+			Text           string `xml:",chardata"`
+			AveragePick    string `xml:"average_pick"`
+			AverageRound   string `xml:"average_round"`
+			AverageCost    string `xml:"average_cost"`
+			PercentDrafted string `xml:"percent_drafted"`
+		} `xml:"draft_analysis"`
+	} `xml:"player"`
+}
+
+func (s *Service) GetPlayerResourcesDraftAnalysis(ctx context.Context, playerKey string) (*PlayerResourcesDraftAnalysis, error) {
+	url := s.resourceURL("player", playerKey, "draft_analysis")
+	res, err := s.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	return nil, errors.New("not implemented")
+}
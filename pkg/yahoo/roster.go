@@ -0,0 +1,173 @@
+package yahoo
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+)
+
+type RosterResourcesPlayers struct {
+	TeamKey          string        `json:"team_key"`
+	TeamID           string        `json:"team_id"`
+	Name             string        `json:"name"`
+	URL              string        `json:"url"`
+	TeamLogo         string        `json:"team_logo"`
+	WaiverPriority   int           `json:"waiver_priority"`
+	NumberOfMoves    string        `json:"number_of_moves"`
+	NumberOfTrades   int           `json:"number_of_trades"`
+	ClinchedPlayoffs int           `json:"clinched_playoffs"`
+	Managers         []interface{} `json:"managers"`
+	Roster           []struct {
+		PlayerKey string `json:"player_key"`
+		PlayerID  string `json:"player_id"`
+		Name      struct {
+			Full       string `json:"full"`
+			First      string `json:"first"`
+			Last       string `json:"last"`
+			ASCIIFirst string `json:"ascii_first"`
+			ASCIILast  string `json:"ascii_last"`
+		} `json:"name"`
+		EditorialPlayerKey    string `json:"editorial_player_key"`
+		EditorialTeamKey      string `json:"editorial_team_key"`
+		EditorialTeamFullName string `json:"editorial_team_full_name"`
+		EditorialTeamAbbr     string `json:"editorial_team_abbr"`
+		UniformNumber         string `json:"uniform_number"`
+		DisplayPosition       string `json:"display_position"`
+		Headshot              struct {
+			URL  string `json:"url"`
+			Size string `json:"size"`
+		} `json:"headshot"`
+		IsUndroppable        string   `json:"is_undroppable"`
+		PositionType         string   `json:"position_type"`
+		EligiblePositions    []string `json:"eligible_positions"`
+		HasPlayerNotes       int      `json:"has_player_notes,omitempty"`
+		Status               string   `json:"status,omitempty"`
+		OnDisabledList       string   `json:"on_disabled_list,omitempty"`
+		HasRecentPlayerNotes int      `json:"has_recent_player_notes,omitempty"`
+	} `json:"roster"`
+}
+type RosterResourcesPlayersResponse struct {
+	XMLName     xml.Name `xml:"fantasy_content"`
+	Text        string   `xml:",chardata"`
+	Lang        string   `xml:"lang,attr"`
+	URI         string   `xml:"uri,attr"`
+	Time        string   `xml:"time,attr"`
+	Copyright   string   `xml:"copyright,attr"`
+	RefreshRate string   `xml:"refresh_rate,attr"`
+	Yahoo       string   `xml:"yahoo,attr"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	Team        struct {
+		Text                  string `xml:",chardata"`
+		TeamKey               string `xml:"team_key"`
+		TeamID                string `xml:"team_id"`
+		Name                  string `xml:"name"`
+		IsOwnedByCurrentLogin string `xml:"is_owned_by_current_login"`
+		URL                   string `xml:"url"`
+		TeamLogos             struct {
+			Text     string `xml:",chardata"`
+			TeamLogo struct {
+				Text string `xml:",chardata"`
+				Size string `xml:"size"`
+				URL  string `xml:"url"`
+			} `xml:"team_logo"`
+		} `xml:"team_logos"`
+		WaiverPriority string `xml:"waiver_priority"`
+		NumberOfMoves  string `xml:"number_of_moves"`
+		NumberOfTrades string `xml:"number_of_trades"`
+		RosterAdds     struct {
+			Text          string `xml:",chardata"`
+			CoverageType  string `xml:"coverage_type"`
+			CoverageValue string `xml:"coverage_value"`
+			Value         string `xml:"value"`
+		} `xml:"roster_adds"`
+		ClinchedPlayoffs  string `xml:"clinched_playoffs"`
+		LeagueScoringType string `xml:"league_scoring_type"`
+		HasDraftGrade     string `xml:"has_draft_grade"`
+		DraftGrade        string `xml:"draft_grade"`
+		DraftRecapURL     string `xml:"draft_recap_url"`
+		Managers          struct {
+			Text    string `xml:",chardata"`
+			Manager struct {
+				Text           string `xml:",chardata"`
+				ManagerID      string `xml:"manager_id"`
+				Nickname       string `xml:"nickname"`
+				Guid           string `xml:"guid"`
+				IsCommissioner string `xml:"is_commissioner"`
+				IsCurrentLogin string `xml:"is_current_login"`
+				Email          string `xml:"email"`
+				ImageURL       string `xml:"image_url"`
+			} `xml:"manager"`
+		} `xml:"managers"`
+		Roster struct {
+			Text         string `xml:",chardata"`
+			CoverageType string `xml:"coverage_type"`
+			Week         string `xml:"week"`
+			IsEditable   string `xml:"is_editable"`
+			Players      struct {
+				Text   string `xml:",chardata"`
+				Count  string `xml:"count,attr"`
+				Player []struct {
+					Text      string `xml:",chardata"`
+					PlayerKey string `xml:"player_key"`
+					PlayerID  string `xml:"player_id"`
+					Name      struct {
+						Text       string `xml:",chardata"`
+						Full       string `xml:"full"`
+						First      string `xml:"first"`
+						Last       string `xml:"last"`
+						AsciiFirst string `xml:"ascii_first"`
+						AsciiLast  string `xml:"ascii_last"`
+					} `xml:"name"`
+					EditorialPlayerKey    string `xml:"editorial_player_key"`
+					EditorialTeamKey      string `xml:"editorial_team_key"`
+					EditorialTeamFullName string `xml:"editorial_team_full_name"`
+					EditorialTeamAbbr     string `xml:"editorial_team_abbr"`
+					ByeWeeks              struct {
+						Text string `xml:",chardata"`
+						Week string `xml:"week"`
+					} `xml:"bye_weeks"`
+					UniformNumber   string `xml:"uniform_number"`
+					DisplayPosition string `xml:"display_position"`
+					Headshot        struct {
+						Text string `xml:",chardata"`
+						URL  string `xml:"url"`
+						Size string `xml:"size"`
+					} `xml:"headshot"`
+					ImageURL          string `xml:"image_url"`
+					IsUndroppable     string `xml:"is_undroppable"`
+					PositionType      string `xml:"position_type"`
+					PrimaryPosition   string `xml:"primary_position"`
+					EligiblePositions struct {
+						Text     string   `xml:",chardata"`
+						Position []string `xml:"position"`
+					} `xml:"eligible_positions"`
+					SelectedPosition struct {
+						Text         string `xml:",chardata"`
+						CoverageType string `xml:"coverage_type"`
+						Week         string `xml:"week"`
+						Position     string `xml:"position"`
+						IsFlex       string `xml:"is_flex"`
+					} `xml:"selected_position"`
+					IsEditable               string `xml:"is_editable"`
+					HasPlayerNotes           string `xml:"has_player_notes"`
+					PlayerNotesLastTimestamp string `xml:"player_notes_last_timestamp"`
+					Status                   string `xml:"status"`
+					StatusFull               string `xml:"status_full"`
+				} `xml:"player"`
+			} `xml:"players"`
+		} `xml:"roster"`
+	} `xml:"team"`
+}
+
+func (s *Service) GetRosterResourcesPlayers(ctx context.Context, teamKey string, options Options) (*RosterResourcesPlayers, error) {
+	url := s.resourceURL("team", teamKey, "roster"+options.String(), "players")
+	res, err := s.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	return nil, errors.New("not implemented")
+}
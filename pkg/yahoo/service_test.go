@@ -2,76 +2,106 @@ package yahoo
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"github.com/go-kit/kit/log"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
-	"github.com/thethan/fdr-users/pkg/users/entities"
+	"github.com/thethan/fdr-users/pkg/yahoo/yahootest"
 	"testing"
 )
 
-type mockGetUserInformation struct {
-	mock.Mock
-}
-
-func getUser() entities.User {
-	return entities.User{
-		AccessToken: "yobokzCY4x_Btuckuyb67JaTPDi7M.zEvtt_ZmIoKSUPvcc.ayGoBdlLdczPjo3AlD1RbWBf8yfUQ5lXlxXXjOSgRBn9CKIm0O2hKqggqIW9.B4LpczY774ui05fCcQ77SQ57fR2IgRp1XJFqd1CHqagJ1jDUj_yJm.TKiXzdLUYDtaedAR86IOXRbdsKZwTWp08GXo8ijSJgavoVVBURr5lVCy42QoVuc8Kk9rzsRbSJYZ93WtthvA.Bmj1lj.TQ_AYbhlGv.qzlRrJ0ZhlK8Xhd_NFmdDvW5mPE2qTTP0ZytIX0VGLKrnK5AYnhx6el1g3xVtOaH92uKv2CTE1AlDyBz4zln4NFaLczGjJD2.yge_SPv6l5ULukwYZ1W8RK9ijpZv0VE2PUeKXPYQ2fNZRRTd5_exOGlPvy9E.ggg5sC2scCwTKDtZJrUPcPMcoBNex39LrQmJ9M.mdx9b.x8stZtKfa5h8HsNKvkAhNqgOgUSXPGeCQVkWLRt9.K3rmwPxy90xTjNVLaSmwgJWX30w1kgw4X0TA1Ba3y3NG8knxCsF4hGGVTfR.NKXLxb9KrwP7HIrklwqGWSPPhmRPd5TM_flwgioK.BwPn2AHuXwqJrAtqzAQiz3oBdpJSCIlSbFAmvVyH05YAj8S7FpbvoTynRM7AHyuAUGvptkaxT3hc3aKv417C3gSD9EmPa9FfTYt6RMucw_03tazB0G3G_A_G7hjf0zHrPZEPTRq6CQXTyfy1csX0d8oU_WJN.NlgLrEk4KYe59U8TEWhIs4PUpgCg2EPZ2vhp4ycy63ZlWKBscSizCcvZGYfJcpbwz_T4iKZjn3909e4sooxGCqg7RhvFqFMcrKIygdvcTI_rXfw8Tye7bRLNTa.1xJhPdJlxTZKuhOmY2CiXS_LlAtt_HILnTyahrpEJw574YUXM0Lw6dyFEwLd44WnWm9e21TBjKjR6Ddo8.AYWxHXcvSXFaZJyFsMJjFHvT0kSbs4S71isRuP4jsJZEvfHQ.Xmvl1q1HkWd_G13OhnrYKuZ.kR4zeMfpry3H7qFFK7vhTgtp8Cb07UkgrPAL5CiGqQxCYzj9Ts_eTk0egQW9IxOr0MfBy4iLkDFb_bdpF.M.D86y4zFPav.pc14jiS_OoiG.uLq8X443ojPAJSNddCQ1L_FYY7ZHnMjWhgV_ovIA--",
-	}
-}
-
-
-func (m mockGetUserInformation) GetCredentialInformation(ctx context.Context, session string) (entities.User, error) {
-	args := m.Called(ctx, session)
-	return args.Get(0).(entities.User), args.Error(1)
+// fixtureService is a service reading the fixtures of the fake Yahoo
+func fixtureService(t *testing.T) (*Service, *yahootest.Server) {
+	server := yahootest.NewServer(t, yahootest.Fixtures())
+	svc := NewService(log.NewNopLogger(), yahootest.Credentials(yahootest.Token)).WithBaseURL(server.URL)
+	return svc, server
 }
 
 func TestService_GetUserResourcesGames(t *testing.T) {
-	mockUserInfo :=  mockGetUserInformation{}
-	logger := log.NewNopLogger()
+	svc, _ := fixtureService(t)
 	ctx := context.Background()
 
-	mockUserInfo.On("GetCredentialInformation", ctx, mock.AnythingOfType("string")).Return(getUser(), nil)
-
-
-	svc := NewService(logger, mockUserInfo)
 	games, err := svc.GetUserResourcesGames(ctx)
 	assert.Nil(t, err)
-	mockUserInfo.AssertExpectations(t)
 	assert.True(t, len(games.Games) > 0, "Length of games is not greater than 0")
+	assert.Equal(t, "guid-manager-1", games.GUID)
 }
 
-func TestService_GetLeaguesUserInfo(t *testing.T) {
-	mockUserInfo :=  mockGetUserInformation{}
-	logger := log.NewNopLogger()
+func TestService_GetUserResourcesGameLeaguesResponse(t *testing.T) {
+	svc, _ := fixtureService(t)
 	ctx := context.Background()
 
-	mockUserInfo.On("GetCredentialInformation", ctx, mock.AnythingOfType("string")).Return(getUser(), nil)
+	res, err := svc.GetUserResourcesGameLeaguesResponse(ctx)
+	assert.Nil(t, err)
+	if assert.Len(t, res.Users.User.Games.Game, 2) {
+		assert.Equal(t, "390.l.100", res.Users.User.Games.Game[0].Leagues[0].LeagueKey)
+		assert.Equal(t, "390_100", res.Users.User.Games.Game[1].Leagues[0].Renew)
+	}
+}
 
+func TestService_GetLeaguesUserInfo(t *testing.T) {
+	svc, _ := fixtureService(t)
+	ctx := context.Background()
 
-	svc := NewService(logger, mockUserInfo)
-	res, err := svc.GetLeagueResourcesStandings(ctx, "273.l.652120")
+	res, err := svc.GetLeagueResourcesStandings(ctx, "390.l.100")
 	assert.Nil(t, err)
-	mockUserInfo.AssertExpectations(t)
-	fmt.Printf("%v", res)
-	//assert.True(t, len(res.League) > 0, "Length of games is not greater than 0")
+	teams := res.League.Standings.Teams.Team
+	if assert.Len(t, teams, res.League.NumTeams) {
+		assert.Equal(t, "390.l.100.t.1", teams[0].TeamKey)
+		assert.Equal(t, "guid-manager-1", teams[0].Managers.Manager.GUID)
+		assert.Equal(t, 10, teams[0].TeamStandings.OutcomeTotals.Wins)
+	}
 }
 
 func TestService_GetLeaguesSettings(t *testing.T) {
-	mockUserInfo :=  mockGetUserInformation{}
-	logger := log.NewNopLogger()
+	svc, _ := fixtureService(t)
+	ctx := context.Background()
+
+	res, err := svc.GetLeagueResourcesSettings(ctx, "399.l.200")
+	assert.Nil(t, err)
+	assert.Equal(t, 200, res.League.LeagueID)
+	assert.Equal(t, "live", res.League.Settings.DraftType)
+	assert.Len(t, res.League.Settings.RosterPositions, 5)
+	if assert.Len(t, res.League.Settings.StatModifiers.Stats.Stat, 4) && assert.NotNil(t, res.League.Settings.StatModifiers.Stats.Stat[0].Bonus) {
+		assert.Equal(t, float32(300), res.League.Settings.StatModifiers.Stats.Stat[0].Bonus.Target)
+	}
+}
+
+func TestService_GetLeagueResourcesDraftResults(t *testing.T) {
+	svc, _ := fixtureService(t)
 	ctx := context.Background()
 
-	mockUserInfo.On("GetCredentialInformation", ctx, mock.AnythingOfType("string")).Return(getUser(), nil)
+	res, err := svc.GetLeagueResourcesDraftResults(ctx, "399.l.200")
+	assert.Nil(t, err)
+	results := res.League.DraftResults.DraftResult
+	if assert.Len(t, results, 8) {
+		assert.Equal(t, "399.l.200.t.2", results[0].TeamKey)
+		assert.Equal(t, "399.p.30123", results[0].PlayerKey)
+		assert.Equal(t, 2, results[7].Round)
+	}
+}
 
+func TestService_GetGameResourcesPlayers(t *testing.T) {
+	svc, server := fixtureService(t)
+	ctx := context.Background()
 
-	svc := NewService(logger, mockUserInfo)
-	res, err := svc.GetLeagueResourcesSettings(ctx, "273.l.652120")
+	res, err := svc.GetGameResourcesPlayers(ctx, 399, NewOptions().Start(0).Count(25))
 	assert.Nil(t, err)
-	mockUserInfo.AssertExpectations(t)
-	fmt.Printf("%v", res)
-	//assert.True(t, len(res.League) > 0, "Length of games is not greater than 0")
+	if assert.Len(t, res.Game.Players.Player, 3) {
+		assert.Equal(t, "Patrick Mahomes", res.Game.Players.Player[0].Name.Full)
+		assert.Len(t, res.Game.Players.Player[0].PlayerStats.Stats, 4)
+	}
+	assert.Equal(t, []string{"/game/399/players;start=0;count=25/stats"}, server.Requests())
+}
+
+func TestService_UnknownLeague(t *testing.T) {
+	svc, _ := fixtureService(t)
+	ctx := context.Background()
+
+	_, err := svc.GetLeagueResourcesSettings(ctx, "399.l.0")
+	var notFound *ErrorNotFound
+	assert.True(t, errors.As(err, &notFound))
 }
+
 //
 //func TestService_GetUserResourcesGameLeagues(t *testing.T) {
 //	mockUserInfo :=  mockGetUserInformation{}
@@ -143,7 +173,6 @@ func TestService_ImportForAUser(t *testing.T) {
 	// Get User Games
 }
 
-
 func TestService_GetGameResourcesPositionTypes(t *testing.T) {
 	//mockUserInfo :=  mockGetUserInformation{}
 	//logger := log.NewNopLogger()
@@ -151,15 +180,13 @@ func TestService_GetGameResourcesPositionTypes(t *testing.T) {
 	//
 	//mockUserInfo.On("GetCredentialInformation", ctx, mock.AnythingOfType("string")).Return(getUser(), nil)
 
-
 	//svc := NewService(logger, mockUserInfo)
 	//league, err := svc.GetGameResourcesPositionTypes(ctx, "390")
 	//assert.Nil(t, err)
 	//mockUserInfo.AssertExpectations(t)
 
-
-
 }
+
 //func TestService_GetUserResourcesGameLeaguesSettings(t *testing.T) {
 //	mockUserInfo := mockGetUserInformation{}
 //	logger := log.NewNopLogger()
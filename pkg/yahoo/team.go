@@ -0,0 +1,771 @@
+package yahoo
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+)
+
+type TeamResourcesMeta struct {
+	TeamKey          string `json:"team_key"`
+	TeamID           string `json:"team_id"`
+	Name             string `json:"name"`
+	URL              string `json:"url"`
+	TeamLogo         string `json:"team_logo"`
+	WaiverPriority   int    `json:"waiver_priority"`
+	NumberOfMoves    string `json:"number_of_moves"`
+	NumberOfTrades   int    `json:"number_of_trades"`
+	ClinchedPlayoffs int    `json:"clinched_playoffs"`
+	Managers         []struct {
+		ManagerID      string `json:"manager_id"`
+		Nickname       string `json:"nickname"`
+		GUID           string `json:"guid"`
+		IsCommissioner string `json:"is_commissioner"`
+	} `json:"managers"`
+}
+type TeamResourcesMetaResponse struct {
+	XMLName     xml.Name `xml:"fantasy_content"`
+	Text        string   `xml:",chardata"`
+	Lang        string   `xml:"lang,attr"`
+	URI         string   `xml:"uri,attr"`
+	Time        string   `xml:"time,attr"`
+	Copyright   string   `xml:"copyright,attr"`
+	RefreshRate string   `xml:"refresh_rate,attr"`
+	Yahoo       string   `xml:"yahoo,attr"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	Team        struct {
+		Text                  string `xml:",chardata"`
+		TeamKey               string `xml:"team_key"`
+		TeamID                string `xml:"team_id"`
+		Name                  string `xml:"name"`
+		IsOwnedByCurrentLogin string `xml:"is_owned_by_current_login"`
+		URL                   string `xml:"url"`
+		TeamLogos             struct {
+			Text     string `xml:",chardata"`
+			TeamLogo struct {
+				Text string `xml:",chardata"`
+				Size string `xml:"size"`
+				URL  string `xml:"url"`
+			} `xml:"team_logo"`
+		} `xml:"team_logos"`
+		WaiverPriority string `xml:"waiver_priority"`
+		NumberOfMoves  string `xml:"number_of_moves"`
+		NumberOfTrades string `xml:"number_of_trades"`
+		RosterAdds     struct {
+			Text          string `xml:",chardata"`
+			CoverageType  string `xml:"coverage_type"`
+			CoverageValue string `xml:"coverage_value"`
+			Value         string `xml:"value"`
+		} `xml:"roster_adds"`
+		ClinchedPlayoffs  string `xml:"clinched_playoffs"`
+		LeagueScoringType string `xml:"league_scoring_type"`
+		HasDraftGrade     string `xml:"has_draft_grade"`
+		DraftGrade        string `xml:"draft_grade"`
+		DraftRecapURL     string `xml:"draft_recap_url"`
+		Managers          struct {
+			Text    string `xml:",chardata"`
+			Manager struct {
+				Text           string `xml:",chardata"`
+				ManagerID      string `xml:"manager_id"`
+				Nickname       string `xml:"nickname"`
+				Guid           string `xml:"guid"`
+				IsCommissioner string `xml:"is_commissioner"`
+				IsCurrentLogin string `xml:"is_current_login"`
+				Email          string `xml:"email"`
+				ImageURL       string `xml:"image_url"`
+			} `xml:"manager"`
+		} `xml:"managers"`
+	} `xml:"team"`
+}
+
+func (s *Service) GetTeamResourcesMeta(ctx context.Context, teamKey string) (*TeamResourcesMeta, error) {
+	url := s.resourceURL("team", teamKey, "metadata")
+	res, err := s.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	return nil, errors.New("not implemented")
+}
+
+type TeamResourcesStats struct {
+	TeamKey          string `json:"team_key"`
+	TeamID           string `json:"team_id"`
+	Name             string `json:"name"`
+	URL              string `json:"url"`
+	TeamLogo         string `json:"team_logo"`
+	WaiverPriority   int    `json:"waiver_priority"`
+	NumberOfMoves    string `json:"number_of_moves"`
+	NumberOfTrades   int    `json:"number_of_trades"`
+	ClinchedPlayoffs int    `json:"clinched_playoffs"`
+	Managers         []struct {
+		ManagerID      string `json:"manager_id"`
+		Nickname       string `json:"nickname"`
+		GUID           string `json:"guid"`
+		IsCommissioner string `json:"is_commissioner"`
+	} `json:"managers"`
+}
+type TeamResourcesStatsResponse struct {
+	XMLName     xml.Name `xml:"fantasy_content"`
+	Text        string   `xml:",chardata"`
+	Lang        string   `xml:"lang,attr"`
+	URI         string   `xml:"uri,attr"`
+	Time        string   `xml:"time,attr"`
+	Copyright   string   `xml:"copyright,attr"`
+	RefreshRate string   `xml:"refresh_rate,attr"`
+	Yahoo       string   `xml:"yahoo,attr"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	Team        struct {
+		Text                  string `xml:",chardata"`
+		TeamKey               string `xml:"team_key"`
+		TeamID                string `xml:"team_id"`
+		Name                  string `xml:"name"`
+		IsOwnedByCurrentLogin string `xml:"is_owned_by_current_login"`
+		URL                   string `xml:"url"`
+		TeamLogos             struct {
+			Text     string `xml:",chardata"`
+			TeamLogo struct {
+				Text string `xml:",chardata"`
+				Size string `xml:"size"`
+				URL  string `xml:"url"`
+			} `xml:"team_logo"`
+		} `xml:"team_logos"`
+		WaiverPriority string `xml:"waiver_priority"`
+		NumberOfMoves  string `xml:"number_of_moves"`
+		NumberOfTrades string `xml:"number_of_trades"`
+		RosterAdds     struct {
+			Text          string `xml:",chardata"`
+			CoverageType  string `xml:"coverage_type"`
+			CoverageValue string `xml:"coverage_value"`
+			Value         string `xml:"value"`
+		} `xml:"roster_adds"`
+		ClinchedPlayoffs  string `xml:"clinched_playoffs"`
+		LeagueScoringType string `xml:"league_scoring_type"`
+		HasDraftGrade     string `xml:"has_draft_grade"`
+		DraftGrade        string `xml:"draft_grade"`
+		DraftRecapURL     string `xml:"draft_recap_url"`
+		Managers          struct {
+			Text    string `xml:",chardata"`
+			Manager struct {
+				Text           string `xml:",chardata"`
+				ManagerID      string `xml:"manager_id"`
+				Nickname       string `xml:"nickname"`
+				Guid           string `xml:"guid"`
+				IsCommissioner string `xml:"is_commissioner"`
+				IsCurrentLogin string `xml:"is_current_login"`
+				Email          string `xml:"email"`
+				ImageURL       string `xml:"image_url"`
+			} `xml:"manager"`
+		} `xml:"managers"`
+		TeamPoints struct {
+			Text         string `xml:",chardata"`
+			CoverageType string `xml:"coverage_type"`
+			Season       string `xml:"season"`
+			Total        string `xml:"total"`
+		} `xml:"team_points"`
+	} `xml:"team"`
+}
+
+func (s *Service) GetTeamResourcesStats(ctx context.Context, teamKey string, options Options) (*TeamResourcesStats, error) {
+	url := s.resourceURL("team", teamKey, "stats"+options.String())
+	res, err := s.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	return nil, errors.New("not implemented")
+}
+
+type TeamResourcesStandings struct {
+	TeamKey          string `json:"team_key"`
+	TeamID           string `json:"team_id"`
+	Name             string `json:"name"`
+	URL              string `json:"url"`
+	TeamLogo         string `json:"team_logo"`
+	WaiverPriority   int    `json:"waiver_priority"`
+	NumberOfMoves    string `json:"number_of_moves"`
+	NumberOfTrades   int    `json:"number_of_trades"`
+	ClinchedPlayoffs int    `json:"clinched_playoffs"`
+	Managers         []struct {
+		ManagerID      string `json:"manager_id"`
+		Nickname       string `json:"nickname"`
+		GUID           string `json:"guid"`
+		IsCommissioner string `json:"is_commissioner"`
+	} `json:"managers"`
+	Standings struct {
+		Rank          int `json:"rank"`
+		OutcomeTotals struct {
+			Wins       string `json:"wins"`
+			Losses     string `json:"losses"`
+			Ties       string `json:"ties"`
+			Percentage string `json:"percentage"`
+		} `json:"outcome_totals"`
+		GamesBack string `json:"games_back"`
+	} `json:"standings"`
+}
+type TeamResourcesStandingsResponse struct {
+	XMLName     xml.Name `xml:"fantasy_content"`
+	Text        string   `xml:",chardata"`
+	Lang        string   `xml:"lang,attr"`
+	URI         string   `xml:"uri,attr"`
+	Time        string   `xml:"time,attr"`
+	Copyright   string   `xml:"copyright,attr"`
+	RefreshRate string   `xml:"refresh_rate,attr"`
+	Yahoo       string   `xml:"yahoo,attr"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	Team        struct {
+		Text                  string `xml:",chardata"`
+		TeamKey               string `xml:"team_key"`
+		TeamID                string `xml:"team_id"`
+		Name                  string `xml:"name"`
+		IsOwnedByCurrentLogin string `xml:"is_owned_by_current_login"`
+		URL                   string `xml:"url"`
+		TeamLogos             struct {
+			Text     string `xml:",chardata"`
+			TeamLogo struct {
+				Text string `xml:",chardata"`
+				Size string `xml:"size"`
+				URL  string `xml:"url"`
+			} `xml:"team_logo"`
+		} `xml:"team_logos"`
+		WaiverPriority string `xml:"waiver_priority"`
+		NumberOfMoves  string `xml:"number_of_moves"`
+		NumberOfTrades string `xml:"number_of_trades"`
+		RosterAdds     struct {
+			Text          string `xml:",chardata"`
+			CoverageType  string `xml:"coverage_type"`
+			CoverageValue string `xml:"coverage_value"`
+			Value         string `xml:"value"`
+		} `xml:"roster_adds"`
+		ClinchedPlayoffs  string `xml:"clinched_playoffs"`
+		LeagueScoringType string `xml:"league_scoring_type"`
+		HasDraftGrade     string `xml:"has_draft_grade"`
+		DraftGrade        string `xml:"draft_grade"`
+		DraftRecapURL     string `xml:"draft_recap_url"`
+		Managers          struct {
+			Text    string `xml:",chardata"`
+			Manager struct {
+				Text           string `xml:",chardata"`
+				ManagerID      string `xml:"manager_id"`
+				Nickname       string `xml:"nickname"`
+				Guid           string `xml:"guid"`
+				IsCommissioner string `xml:"is_commissioner"`
+				IsCurrentLogin string `xml:"is_current_login"`
+				Email          string `xml:"email"`
+				ImageURL       string `xml:"image_url"`
+			} `xml:"manager"`
+		} `xml:"managers"`
+		TeamStandings struct {
+			Text          string `xml:",chardata"`
+			Rank          string `xml:"rank"`
+			PlayoffSeed   string `xml:"playoff_seed"`
+			OutcomeTotals struct {
+				Text       string `xml:",chardata"`
+				Wins       string `xml:"wins"`
+				Losses     string `xml:"losses"`
+				Ties       string `xml:"ties"`
+				Percentage string `xml:"percentage"`
+			} `xml:"outcome_totals"`
+			Streak struct {
+				Text  string `xml:",chardata"`
+				Type  string `xml:"type"`
+				Value string `xml:"value"`
+			} `xml:"streak"`
+			PointsFor     string `xml:"points_for"`
+			PointsAgainst string `xml:"points_against"`
+		} `xml:"team_standings"`
+	} `xml:"team"`
+}
+
+func (s *Service) GetTeamResourcesStandings(ctx context.Context, teamKey string) (*TeamResourcesStandings, error) {
+	url := s.resourceURL("team", teamKey, "standings")
+	res, err := s.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	return nil, errors.New("not implemented")
+}
+
+type TeamResourcesRoster struct {
+	TeamKey          string `json:"team_key"`
+	TeamID           string `json:"team_id"`
+	Name             string `json:"name"`
+	URL              string `json:"url"`
+	TeamLogo         string `json:"team_logo"`
+	WaiverPriority   int    `json:"waiver_priority"`
+	NumberOfMoves    string `json:"number_of_moves"`
+	NumberOfTrades   int    `json:"number_of_trades"`
+	ClinchedPlayoffs int    `json:"clinched_playoffs"`
+	Managers         []struct {
+		ManagerID      string `json:"manager_id"`
+		Nickname       string `json:"nickname"`
+		GUID           string `json:"guid"`
+		IsCommissioner string `json:"is_commissioner"`
+	} `json:"managers"`
+	Roster []struct {
+		PlayerKey string `json:"player_key"`
+		PlayerID  string `json:"player_id"`
+		Name      struct {
+			Full       string `json:"full"`
+			First      string `json:"first"`
+			Last       string `json:"last"`
+			ASCIIFirst string `json:"ascii_first"`
+			ASCIILast  string `json:"ascii_last"`
+		} `json:"name"`
+		EditorialPlayerKey    string   `json:"editorial_player_key,omitempty"`
+		EditorialTeamKey      string   `json:"editorial_team_key,omitempty"`
+		EditorialTeamFullName string   `json:"editorial_team_full_name,omitempty"`
+		EditorialTeamAbbr     string   `json:"editorial_team_abbr,omitempty"`
+		UniformNumber         string   `json:"uniform_number,omitempty"`
+		DisplayPosition       string   `json:"display_position,omitempty"`
+		Headshot              string   `json:"headshot"`
+		IsUndroppable         string   `json:"is_undroppable"`
+		PositionType          string   `json:"position_type"`
+		EligiblePositions     []string `json:"eligible_positions"`
+	} `json:"roster"`
+}
+type TeamResourcesRosterResponse struct {
+	XMLName     xml.Name `xml:"fantasy_content"`
+	Text        string   `xml:",chardata"`
+	Lang        string   `xml:"lang,attr"`
+	URI         string   `xml:"uri,attr"`
+	Time        string   `xml:"time,attr"`
+	Copyright   string   `xml:"copyright,attr"`
+	RefreshRate string   `xml:"refresh_rate,attr"`
+	Yahoo       string   `xml:"yahoo,attr"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	Team        struct {
+		Text                  string `xml:",chardata"`
+		TeamKey               string `xml:"team_key"`
+		TeamID                string `xml:"team_id"`
+		Name                  string `xml:"name"`
+		IsOwnedByCurrentLogin string `xml:"is_owned_by_current_login"`
+		URL                   string `xml:"url"`
+		TeamLogos             struct {
+			Text     string `xml:",chardata"`
+			TeamLogo struct {
+				Text string `xml:",chardata"`
+				Size string `xml:"size"`
+				URL  string `xml:"url"`
+			} `xml:"team_logo"`
+		} `xml:"team_logos"`
+		WaiverPriority string `xml:"waiver_priority"`
+		NumberOfMoves  string `xml:"number_of_moves"`
+		NumberOfTrades string `xml:"number_of_trades"`
+		RosterAdds     struct {
+			Text          string `xml:",chardata"`
+			CoverageType  string `xml:"coverage_type"`
+			CoverageValue string `xml:"coverage_value"`
+			Value         string `xml:"value"`
+		} `xml:"roster_adds"`
+		ClinchedPlayoffs  string `xml:"clinched_playoffs"`
+		LeagueScoringType string `xml:"league_scoring_type"`
+		HasDraftGrade     string `xml:"has_draft_grade"`
+		DraftGrade        string `xml:"draft_grade"`
+		DraftRecapURL     string `xml:"draft_recap_url"`
+		Managers          struct {
+			Text    string `xml:",chardata"`
+			Manager struct {
+				Text           string `xml:",chardata"`
+				ManagerID      string `xml:"manager_id"`
+				Nickname       string `xml:"nickname"`
+				Guid           string `xml:"guid"`
+				IsCommissioner string `xml:"is_commissioner"`
+				IsCurrentLogin string `xml:"is_current_login"`
+				Email          string `xml:"email"`
+				ImageURL       string `xml:"image_url"`
+			} `xml:"manager"`
+		} `xml:"managers"`
+		Roster struct {
+			Text         string `xml:",chardata"`
+			CoverageType string `xml:"coverage_type"`
+			Week         string `xml:"week"`
+			IsEditable   string `xml:"is_editable"`
+			Players      struct {
+				Text   string `xml:",chardata"`
+				Count  string `xml:"count,attr"`
+				Player []struct {
+					Text      string `xml:",chardata"`
+					PlayerKey string `xml:"player_key"`
+					PlayerID  string `xml:"player_id"`
+					Name      struct {
+						Text       string `xml:",chardata"`
+						Full       string `xml:"full"`
+						First      string `xml:"first"`
+						Last       string `xml:"last"`
+						AsciiFirst string `xml:"ascii_first"`
+						AsciiLast  string `xml:"ascii_last"`
+					} `xml:"name"`
+					EditorialPlayerKey    string `xml:"editorial_player_key"`
+					EditorialTeamKey      string `xml:"editorial_team_key"`
+					EditorialTeamFullName string `xml:"editorial_team_full_name"`
+					EditorialTeamAbbr     string `xml:"editorial_team_abbr"`
+					ByeWeeks              struct {
+						Text string `xml:",chardata"`
+						Week string `xml:"week"`
+					} `xml:"bye_weeks"`
+					UniformNumber   string `xml:"uniform_number"`
+					DisplayPosition string `xml:"display_position"`
+					Headshot        struct {
+						Text string `xml:",chardata"`
+						URL  string `xml:"url"`
+						Size string `xml:"size"`
+					} `xml:"headshot"`
+					ImageURL          string `xml:"image_url"`
+					IsUndroppable     string `xml:"is_undroppable"`
+					PositionType      string `xml:"position_type"`
+					PrimaryPosition   string `xml:"primary_position"`
+					EligiblePositions struct {
+						Text     string   `xml:",chardata"`
+						Position []string `xml:"position"`
+					} `xml:"eligible_positions"`
+					SelectedPosition struct {
+						Text         string `xml:",chardata"`
+						CoverageType string `xml:"coverage_type"`
+						Week         string `xml:"week"`
+						Position     string `xml:"position"`
+						IsFlex       string `xml:"is_flex"`
+					} `xml:"selected_position"`
+					IsEditable               string `xml:"is_editable"`
+					HasPlayerNotes           string `xml:"has_player_notes"`
+					PlayerNotesLastTimestamp string `xml:"player_notes_last_timestamp"`
+					Status                   string `xml:"status"`
+					StatusFull               string `xml:"status_full"`
+				} `xml:"player"`
+			} `xml:"players"`
+		} `xml:"roster"`
+	} `xml:"team"`
+}
+
+func (s *Service) GetTeamResourcesRoster(ctx context.Context, teamKey string, options Options) (*TeamResourcesRoster, error) {
+	url := s.resourceURL("team", teamKey, "roster"+options.String())
+	res, err := s.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	return nil, errors.New("not implemented")
+}
+
+type TeamResourcesDraftResults struct {
+	TeamKey          string `json:"team_key"`
+	TeamID           string `json:"team_id"`
+	Name             string `json:"name"`
+	URL              string `json:"url"`
+	TeamLogo         string `json:"team_logo"`
+	WaiverPriority   int    `json:"waiver_priority"`
+	NumberOfMoves    string `json:"number_of_moves"`
+	NumberOfTrades   int    `json:"number_of_trades"`
+	ClinchedPlayoffs int    `json:"clinched_playoffs"`
+	Managers         []struct {
+		ManagerID      string `json:"manager_id"`
+		Nickname       string `json:"nickname"`
+		GUID           string `json:"guid"`
+		IsCommissioner string `json:"is_commissioner"`
+	} `json:"managers"`
+	DraftResults []struct {
+		Pick      int    `json:"pick"`
+		Round     int    `json:"round"`
+		Cost      string `json:"cost"`
+		TeamKey   string `json:"team_key"`
+		PlayerKey string `json:"player_key"`
+	} `json:"draft_results"`
+}
+type TeamResourcesDraftResultsResponse struct {
+	XMLName     xml.Name `xml:"fantasy_content"`
+	Text        string   `xml:",chardata"`
+	Lang        string   `xml:"lang,attr"`
+	URI         string   `xml:"uri,attr"`
+	Time        string   `xml:"time,attr"`
+	Copyright   string   `xml:"copyright,attr"`
+	RefreshRate string   `xml:"refresh_rate,attr"`
+	Yahoo       string   `xml:"yahoo,attr"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	Team        struct {
+		Text                  string `xml:",chardata"`
+		TeamKey               string `xml:"team_key"`
+		TeamID                string `xml:"team_id"`
+		Name                  string `xml:"name"`
+		IsOwnedByCurrentLogin string `xml:"is_owned_by_current_login"`
+		URL                   string `xml:"url"`
+		TeamLogos             struct {
+			Text     string `xml:",chardata"`
+			TeamLogo struct {
+				Text string `xml:",chardata"`
+				Size string `xml:"size"`
+				URL  string `xml:"url"`
+			} `xml:"team_logo"`
+		} `xml:"team_logos"`
+		WaiverPriority string `xml:"waiver_priority"`
+		NumberOfMoves  string `xml:"number_of_moves"`
+		NumberOfTrades string `xml:"number_of_trades"`
+		RosterAdds     struct {
+			Text          string `xml:",chardata"`
+			CoverageType  string `xml:"coverage_type"`
+			CoverageValue string `xml:"coverage_value"`
+			Value         string `xml:"value"`
+		} `xml:"roster_adds"`
+		ClinchedPlayoffs  string `xml:"clinched_playoffs"`
+		LeagueScoringType string `xml:"league_scoring_type"`
+		HasDraftGrade     string `xml:"has_draft_grade"`
+		DraftGrade        string `xml:"draft_grade"`
+		DraftRecapURL     string `xml:"draft_recap_url"`
+		Managers          struct {
+			Text    string `xml:",chardata"`
+			Manager struct {
+				Text           string `xml:",chardata"`
+				ManagerID      string `xml:"manager_id"`
+				Nickname       string `xml:"nickname"`
+				Guid           string `xml:"guid"`
+				IsCommissioner string `xml:"is_commissioner"`
+				IsCurrentLogin string `xml:"is_current_login"`
+				Email          string `xml:"email"`
+				ImageURL       string `xml:"image_url"`
+			} `xml:"manager"`
+		} `xml:"managers"`
+		DraftResults struct {
+			Text        string `xml:",chardata"`
+			Count       string `xml:"count,attr"`
+			DraftResult []struct {
+				Text      string `xml:",chardata"`
+				Pick      string `xml:"pick"`
+				Round     string `xml:"round"`
+				TeamKey   string `xml:"team_key"`
+				PlayerKey string `xml:"player_key"`
+			} `xml:"draft_result"`
+		} `xml:"draft_results"`
+	} `xml:"team"`
+}
+
+func (s *Service) GetTeamResourcesDraftResults(ctx context.Context, teamKey string) (*TeamResourcesDraftResults, error) {
+	url := s.resourceURL("team", teamKey, "draftresults")
+	res, err := s.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	return nil, errors.New("not implemented")
+}
+
+type TeamResourcesMatchups struct {
+	TeamKey          string `json:"team_key"`
+	TeamID           string `json:"team_id"`
+	Name             string `json:"name"`
+	URL              string `json:"url"`
+	TeamLogo         string `json:"team_logo"`
+	WaiverPriority   int    `json:"waiver_priority"`
+	NumberOfMoves    string `json:"number_of_moves"`
+	NumberOfTrades   int    `json:"number_of_trades"`
+	ClinchedPlayoffs int    `json:"clinched_playoffs"`
+	Managers         []struct {
+		ManagerID      string `json:"manager_id"`
+		Nickname       string `json:"nickname"`
+		GUID           string `json:"guid"`
+		IsCommissioner string `json:"is_commissioner"`
+	} `json:"managers"`
+	Matchups []struct {
+		Week          string `json:"week"`
+		WeekStart     string `json:"week_start"`
+		WeekEnd       string `json:"week_end"`
+		Status        string `json:"status"`
+		IsPlayoffs    string `json:"is_playoffs"`
+		IsConsolation string `json:"is_consolation"`
+		IsTied        int    `json:"is_tied"`
+		WinnerTeamKey string `json:"winner_team_key,omitempty"`
+		Teams         []struct {
+			TeamKey          string `json:"team_key"`
+			TeamID           string `json:"team_id"`
+			Name             string `json:"name"`
+			URL              string `json:"url"`
+			TeamLogo         string `json:"team_logo"`
+			WaiverPriority   int    `json:"waiver_priority"`
+			NumberOfMoves    string `json:"number_of_moves"`
+			NumberOfTrades   int    `json:"number_of_trades"`
+			ClinchedPlayoffs int    `json:"clinched_playoffs"`
+			Managers         []struct {
+				ManagerID      string `json:"manager_id"`
+				Nickname       string `json:"nickname"`
+				GUID           string `json:"guid"`
+				IsCommissioner string `json:"is_commissioner"`
+			} `json:"managers"`
+			Points struct {
+				CoverageType string `json:"coverage_type"`
+				Week         string `json:"week"`
+				Total        string `json:"total"`
+			} `json:"points"`
+			Stats []struct {
+				StatID string `json:"stat_id"`
+				Value  string `json:"value"`
+			} `json:"stats"`
+		} `json:"teams"`
+	} `json:"matchups"`
+}
+type TeamResourcesMatchupsResponse struct {
+	XMLName     xml.Name `xml:"fantasy_content"`
+	Text        string   `xml:",chardata"`
+	Lang        string   `xml:"lang,attr"`
+	URI         string   `xml:"uri,attr"`
+	Time        string   `xml:"time,attr"`
+	Copyright   string   `xml:"copyright,attr"`
+	RefreshRate string   `xml:"refresh_rate,attr"`
+	Yahoo       string   `xml:"yahoo,attr"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	Team        struct {
+		Text                  string `xml:",chardata"`
+		TeamKey               string `xml:"team_key"`
+		TeamID                string `xml:"team_id"`
+		Name                  string `xml:"name"`
+		IsOwnedByCurrentLogin string `xml:"is_owned_by_current_login"`
+		URL                   string `xml:"url"`
+		TeamLogos             struct {
+			Text     string `xml:",chardata"`
+			TeamLogo struct {
+				Text string `xml:",chardata"`
+				Size string `xml:"size"`
+				URL  string `xml:"url"`
+			} `xml:"team_logo"`
+		} `xml:"team_logos"`
+		WaiverPriority string `xml:"waiver_priority"`
+		NumberOfMoves  string `xml:"number_of_moves"`
+		NumberOfTrades string `xml:"number_of_trades"`
+		RosterAdds     struct {
+			Text          string `xml:",chardata"`
+			CoverageType  string `xml:"coverage_type"`
+			CoverageValue string `xml:"coverage_value"`
+			Value         string `xml:"value"`
+		} `xml:"roster_adds"`
+		ClinchedPlayoffs  string `xml:"clinched_playoffs"`
+		LeagueScoringType string `xml:"league_scoring_type"`
+		HasDraftGrade     string `xml:"has_draft_grade"`
+		DraftGrade        string `xml:"draft_grade"`
+		DraftRecapURL     string `xml:"draft_recap_url"`
+		Managers          struct {
+			Text    string `xml:",chardata"`
+			Manager struct {
+				Text           string `xml:",chardata"`
+				ManagerID      string `xml:"manager_id"`
+				Nickname       string `xml:"nickname"`
+				Guid           string `xml:"guid"`
+				IsCommissioner string `xml:"is_commissioner"`
+				IsCurrentLogin string `xml:"is_current_login"`
+				Email          string `xml:"email"`
+				ImageURL       string `xml:"image_url"`
+			} `xml:"manager"`
+		} `xml:"managers"`
+		Matchups struct {
+			Text    string `xml:",chardata"`
+			Count   string `xml:"count,attr"`
+			Matchup []struct {
+				Text                    string `xml:",chardata"`
+				Week                    string `xml:"week"`
+				WeekStart               string `xml:"week_start"`
+				WeekEnd                 string `xml:"week_end"`
+				Status                  string `xml:"status"`
+				IsPlayoffs              string `xml:"is_playoffs"`
+				IsConsolation           string `xml:"is_consolation"`
+				IsMatchupRecapAvailable string `xml:"is_matchup_recap_available"`
+				MatchupRecapURL         string `xml:"matchup_recap_url"`
+				MatchupRecapTitle       string `xml:"matchup_recap_title"`
+				MatchupGrades           struct {
+					Text         string `xml:",chardata"`
+					MatchupGrade []struct {
+						Text    string `xml:",chardata"`
+						TeamKey string `xml:"team_key"`
+						Grade   string `xml:"grade"`
+					} `xml:"matchup_grade"`
+				} `xml:"matchup_grades"`
+				IsTied        string `xml:"is_tied"`
+				WinnerTeamKey string `xml:"winner_team_key"`
+				Teams         struct {
+					Text  string `xml:",chardata"`
+					Count string `xml:"count,attr"`
+					Team  []struct {
+						Text                  string `xml:",chardata"`
+						TeamKey               string `xml:"team_key"`
+						TeamID                string `xml:"team_id"`
+						Name                  string `xml:"name"`
+						IsOwnedByCurrentLogin string `xml:"is_owned_by_current_login"`
+						URL                   string `xml:"url"`
+						TeamLogos             struct {
+							Text     string `xml:",chardata"`
+							TeamLogo struct {
+								Text string `xml:",chardata"`
+								Size string `xml:"size"`
+								URL  string `xml:"url"`
+							} `xml:"team_logo"`
+						} `xml:"team_logos"`
+						WaiverPriority string `xml:"waiver_priority"`
+						NumberOfMoves  string `xml:"number_of_moves"`
+						NumberOfTrades string `xml:"number_of_trades"`
+						RosterAdds     struct {
+							Text          string `xml:",chardata"`
+							CoverageType  string `xml:"coverage_type"`
+							CoverageValue string `xml:"coverage_value"`
+							Value         string `xml:"value"`
+						} `xml:"roster_adds"`
+						ClinchedPlayoffs  string `xml:"clinched_playoffs"`
+						LeagueScoringType string `xml:"league_scoring_type"`
+						HasDraftGrade     string `xml:"has_draft_grade"`
+						DraftGrade        string `xml:"draft_grade"`
+						DraftRecapURL     string `xml:"draft_recap_url"`
+						Managers          struct {
+							Text    string `xml:",chardata"`
+							Manager struct {
+								Text           string `xml:",chardata"`
+								ManagerID      string `xml:"manager_id"`
+								Nickname       string `xml:"nickname"`
+								Guid           string `xml:"guid"`
+								IsCommissioner string `xml:"is_commissioner"`
+								IsCurrentLogin string `xml:"is_current_login"`
+								Email          string `xml:"email"`
+								ImageURL       string `xml:"image_url"`
+							} `xml:"manager"`
+						} `xml:"managers"`
+						WinProbability string `xml:"win_probability"`
+						TeamPoints     struct {
+							Text         string `xml:",chardata"`
+							CoverageType string `xml:"coverage_type"`
+							Week         string `xml:"week"`
+							Total        string `xml:"total"`
+						} `xml:"team_points"`
+						TeamProjectedPoints struct {
+							Text         string `xml:",chardata"`
+							CoverageType string `xml:"coverage_type"`
+							Week         string `xml:"week"`
+							Total        string `xml:"total"`
+						} `xml:"team_projected_points"`
+					} `xml:"team"`
+				} `xml:"teams"`
+			} `xml:"matchup"`
+		} `xml:"matchups"`
+	} `xml:"team"`
+}
+
+func (s *Service) GetTeamResourcesMatchups(ctx context.Context, teamKey string) (*TeamResourcesMatchups, error) {
+	url := s.resourceURL("team", teamKey, "matchups")
+	res, err := s.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	return nil, errors.New("not implemented")
+}
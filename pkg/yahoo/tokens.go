@@ -0,0 +1,125 @@
+package yahoo
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/thethan/fdr-users/pkg/users/entities"
+	"golang.org/x/oauth2"
+	"golang.org/x/sync/singleflight"
+	"net/http"
+	"time"
+)
+
+// OAuthTokens looks up the OAuth token saved when a user signed in with Yahoo, like the repository in
+// internal/oauth/repositories
+type OAuthTokens interface {
+	GetUserOAuthToken(ctx context.Context, guid string) (oauth2.Token, error)
+}
+
+type storedTokens struct {
+	tokens OAuthTokens
+}
+
+// NewStoredTokenInformation authenticates requests with users' stored OAuth tokens instead of their sessions.
+// The session a service is given is the guid of the user the requests are made for.
+func NewStoredTokenInformation(tokens OAuthTokens) UserInformation {
+	return storedTokens{tokens: tokens}
+}
+
+func (s storedTokens) GetCredentialInformation(ctx context.Context, guid string) (entities.User, error) {
+	token, err := s.tokens.GetUserOAuthToken(ctx, guid)
+	if err != nil {
+		return entities.User{}, err
+	}
+	return entities.User{
+		GUID:         guid,
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		ExpiresAt:    token.Expiry,
+	}, nil
+}
+
+// TokenStore keeps users' OAuth tokens, like the repository in internal/oauth/repositories
+type TokenStore interface {
+	OAuthTokens
+	SaveOauthToken(ctx context.Context, uuid string, token oauth2.Token) error
+}
+
+// NewTokenSource is the oauth2.TokenSource of guid's stored token for Yahoo clients that are not a Service, like the
+// player importer's. The token is reused until it expires, then refreshed through config with client and saved back
+// to store.
+func NewTokenSource(ctx context.Context, logger log.Logger, config *oauth2.Config, store TokenStore, guid string, client *http.Client) oauth2.TokenSource {
+	return oauth2.ReuseTokenSource(nil, &storedTokenSource{
+		ctx:     ctx,
+		logger:  logger,
+		guid:    guid,
+		store:   store,
+		config:  config,
+		client:  client,
+		refresh: &singleflight.Group{},
+	})
+}
+
+// storedTokenSource is the oauth2.TokenSource of one user's stored token. An expired token is refreshed once for
+// every request of the user waiting on it, and the refreshed token is saved for the next request.
+type storedTokenSource struct {
+	ctx     context.Context
+	logger  log.Logger
+	guid    string
+	store   TokenStore
+	config  *oauth2.Config
+	client  *http.Client
+	refresh *singleflight.Group
+	// last is the access token last handed out, refused the one Yahoo answered as expired
+	last    string
+	refused string
+}
+
+func (s *storedTokenSource) Token() (*oauth2.Token, error) {
+	token, err := s.store.GetUserOAuthToken(s.ctx, s.guid)
+	if err != nil {
+		return nil, err
+	}
+	if s.expired(token) {
+		refreshed, err, _ := s.refresh.Do(s.guid, s.refreshToken)
+		if err != nil {
+			return nil, err
+		}
+		token = refreshed.(oauth2.Token)
+	}
+	s.last = token.AccessToken
+	return &token, nil
+}
+
+func (s *storedTokenSource) expired(token oauth2.Token) bool {
+	return !token.Valid() || token.AccessToken == s.refused
+}
+
+// refreshToken reads the token again first, another request may have refreshed it while this one waited
+func (s *storedTokenSource) refreshToken() (interface{}, error) {
+	token, err := s.store.GetUserOAuthToken(s.ctx, s.guid)
+	if err != nil {
+		return nil, err
+	}
+	if !s.expired(token) {
+		return token, nil
+	}
+
+	// an expiry in the past makes the config's source refresh a token Yahoo refused before it was due
+	token.Expiry = time.Now()
+	ctx := context.WithValue(s.ctx, oauth2.HTTPClient, s.client)
+	refreshed, err := s.config.TokenSource(ctx, &token).Token()
+	if err != nil {
+		level.Error(s.logger).Log("message", "could not refresh yahoo token", "error", err, "guid", s.guid)
+		return nil, &ErrorTokenExpired{ErrorResponse{Status: http.StatusUnauthorized, Description: fmt.Sprintf("the token could not be refreshed: %s", err)}}
+	}
+
+	err = s.store.SaveOauthToken(s.ctx, s.guid, *refreshed)
+	if err != nil {
+		// the request can still use it, the next one refreshes again
+		level.Error(s.logger).Log("message", "could not save refreshed yahoo token", "error", err, "guid", s.guid)
+	}
+	return *refreshed, nil
+}
@@ -0,0 +1,153 @@
+package yahoo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeTokenStore struct {
+	mu     *sync.Mutex
+	tokens map[string]oauth2.Token
+	saves  int
+}
+
+func newFakeTokenStore(guid string, token oauth2.Token) *fakeTokenStore {
+	return &fakeTokenStore{mu: &sync.Mutex{}, tokens: map[string]oauth2.Token{guid: token}}
+}
+
+func (f *fakeTokenStore) GetUserOAuthToken(ctx context.Context, guid string) (oauth2.Token, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	token, ok := f.tokens[guid]
+	if !ok {
+		return oauth2.Token{}, errors.New("no token")
+	}
+	return token, nil
+}
+
+func (f *fakeTokenStore) SaveOauthToken(ctx context.Context, guid string, token oauth2.Token) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.saves++
+	f.tokens[guid] = token
+	return nil
+}
+
+// tokenYahoo hands out fresh-token for refresh-token and only takes fresh-token. Any other token is refused as
+// expired, even one whose expiry has not passed.
+type tokenYahoo struct {
+	mu        *sync.Mutex
+	refreshes int
+	refused   int
+}
+
+func (f *tokenYahoo) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/token" {
+		f.mu.Lock()
+		f.refreshes++
+		f.mu.Unlock()
+		// slow enough for the other requests to wait on this refresh
+		time.Sleep(50 * time.Millisecond)
+		if r.FormValue("refresh_token") != "refresh-token" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"invalid_grant"}`)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"fresh-token","token_type":"bearer","refresh_token":"refresh-token","expires_in":3600}`)
+		return
+	}
+
+	if r.Header.Get("Authorization") != "Bearer fresh-token" {
+		f.mu.Lock()
+		f.refused++
+		f.mu.Unlock()
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `<?xml version="1.0"?><error><description>Please provide valid credentials. OAuth oauth_problem="token_expired", realm="yahooapis.com"</description></error>`)
+		return
+	}
+	fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?><fantasy_content><league><league_key>399.l.1</league_key><draft_results/></league></fantasy_content>`)
+}
+
+func tokenService(t *testing.T, token oauth2.Token) (*Service, *fakeTokenStore, *tokenYahoo) {
+	fake := &tokenYahoo{mu: &sync.Mutex{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	store := newFakeTokenStore("commish", token)
+	config := &oauth2.Config{ClientID: "client", Endpoint: oauth2.Endpoint{TokenURL: server.URL + "/token", AuthStyle: oauth2.AuthStyleInParams}}
+	service := NewService(log.NewNopLogger(), nil).WithBaseURL(server.URL).WithTokenStore(config, store).WithSession("commish")
+	return service, store, fake
+}
+
+func TestService_WithTokenStore_RefreshesOnce(t *testing.T) {
+	service, store, fake := tokenService(t, oauth2.Token{AccessToken: "old-token", RefreshToken: "refresh-token", Expiry: time.Now().Add(-time.Hour)})
+
+	wg := sync.WaitGroup{}
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := service.GetLeagueResourcesDraftResults(context.Background(), "399.l.1")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.Nil(t, err)
+	}
+
+	assert.Equal(t, 1, fake.refreshes, "requests waiting on the same refresh share it")
+	assert.Equal(t, 0, fake.refused)
+	assert.Equal(t, 1, store.saves)
+	assert.Equal(t, "fresh-token", store.tokens["commish"].AccessToken)
+}
+
+func TestNewTokenSource(t *testing.T) {
+	fake := &tokenYahoo{mu: &sync.Mutex{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	store := newFakeTokenStore("manager", oauth2.Token{AccessToken: "old-token", RefreshToken: "refresh-token", Expiry: time.Now().Add(-time.Hour)})
+	config := &oauth2.Config{ClientID: "client", Endpoint: oauth2.Endpoint{TokenURL: server.URL + "/token", AuthStyle: oauth2.AuthStyleInParams}}
+
+	source := NewTokenSource(context.Background(), log.NewNopLogger(), config, store, "manager", server.Client())
+	for i := 0; i < 2; i++ {
+		token, err := source.Token()
+		if assert.Nil(t, err) {
+			assert.Equal(t, "fresh-token", token.AccessToken)
+		}
+	}
+	assert.Equal(t, 1, fake.refreshes, "the refreshed token is reused until it expires")
+	assert.Equal(t, 1, store.saves)
+	assert.Equal(t, "fresh-token", store.tokens["manager"].AccessToken)
+}
+
+func TestService_WithTokenStore_RefusedAsExpired(t *testing.T) {
+	service, store, fake := tokenService(t, oauth2.Token{AccessToken: "expired-early", RefreshToken: "refresh-token", Expiry: time.Now().Add(time.Hour)})
+
+	_, err := service.GetLeagueResourcesDraftResults(context.Background(), "399.l.1")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, fake.refused, "the request is sent again with the refreshed token")
+	assert.Equal(t, 1, fake.refreshes)
+	assert.Equal(t, "fresh-token", store.tokens["commish"].AccessToken)
+}
+
+func TestService_WithTokenStore_RefreshFails(t *testing.T) {
+	service, store, _ := tokenService(t, oauth2.Token{AccessToken: "old-token", RefreshToken: "revoked", Expiry: time.Now().Add(-time.Hour)})
+
+	_, err := service.GetLeagueResourcesDraftResults(context.Background(), "399.l.1")
+	var expired *ErrorTokenExpired
+	assert.True(t, errors.As(err, &expired), "the user has to sign in again")
+	assert.Equal(t, 0, store.saves)
+}
@@ -0,0 +1,144 @@
+package yahoo
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+)
+
+type TransactionsResourcesMeta struct {
+	TransactionKey string `json:"transaction_key"`
+	TransactionID  string `json:"transaction_id"`
+	Type           string `json:"type"`
+	Status         string `json:"status"`
+	Timestamp      string `json:"timestamp"`
+	Players        []struct {
+		PlayerKey string `json:"player_key"`
+		PlayerID  string `json:"player_id"`
+		Name      struct {
+			Full       string `json:"full"`
+			First      string `json:"first"`
+			Last       string `json:"last"`
+			ASCIIFirst string `json:"ascii_first"`
+			ASCIILast  string `json:"ascii_last"`
+		} `json:"name"`
+		EditorialTeamAbbr string `json:"editorial_team_abbr"`
+		DisplayPosition   string `json:"display_position"`
+		PositionType      string `json:"position_type"`
+		TransactionData   struct {
+			Type                string `json:"type"`
+			SourceType          string `json:"source_type"`
+			DestinationType     string `json:"destination_type"`
+			DestinationTeamKey  string `json:"destination_team_key"`
+			DestinationTeamName string `json:"destination_team_name"`
+			SourceTeamKey       string `json:"source_team_key"`
+			SourceTeamName      string `json:"source_team_name"`
+		} `json:"transaction_data,omitempty"`
+	} `json:"fdr-players-import"`
+}
+
+func (s *Service) GetTransactionsResourcesMeta(ctx context.Context, transactionKey string) (*TransactionsResourcesMeta, error) {
+	url := s.resourceURL("transaction", transactionKey)
+	res, err := s.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	return nil, errors.New("not implemented")
+}
+
+type TransactionsResourcesPlayers struct {
+	TransactionKey string `json:"transaction_key"`
+	TransactionID  string `json:"transaction_id"`
+	Type           string `json:"type"`
+	Status         string `json:"status"`
+	Timestamp      string `json:"timestamp"`
+	Players        []struct {
+		PlayerKey string `json:"player_key"`
+		PlayerID  string `json:"player_id"`
+		Name      struct {
+			Full       string `json:"full"`
+			First      string `json:"first"`
+			Last       string `json:"last"`
+			ASCIIFirst string `json:"ascii_first"`
+			ASCIILast  string `json:"ascii_last"`
+		} `json:"name"`
+		EditorialTeamAbbr string `json:"editorial_team_abbr"`
+		DisplayPosition   string `json:"display_position"`
+		PositionType      string `json:"position_type"`
+		TransactionData   struct {
+			Type                string `json:"type"`
+			SourceType          string `json:"source_type"`
+			DestinationType     string `json:"destination_type"`
+			DestinationTeamKey  string `json:"destination_team_key"`
+			DestinationTeamName string `json:"destination_team_name"`
+			SourceTeamKey       string `json:"source_team_key"`
+			SourceTeamName      string `json:"source_team_name"`
+		} `json:"transaction_data,omitempty"`
+	} `json:"fdr-players-import"`
+}
+type TransactionsResourcesPlayersResponse struct {
+	XMLName     xml.Name `xml:"fantasy_content"`
+	Text        string   `xml:",chardata"`
+	Lang        string   `xml:"lang,attr"`
+	URI         string   `xml:"uri,attr"`
+	Time        string   `xml:"time,attr"`
+	Copyright   string   `xml:"copyright,attr"`
+	RefreshRate string   `xml:"refresh_rate,attr"`
+	Yahoo       string   `xml:"yahoo,attr"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	Transaction struct {
+		Text           string `xml:",chardata"`
+		TransactionKey string `xml:"transaction_key"`
+		TransactionID  string `xml:"transaction_id"`
+		Type           string `xml:"type"`
+		Status         string `xml:"status"`
+		Timestamp      string `xml:"timestamp"`
+		Players        struct {
+			Text   string `xml:",chardata"`
+			Count  string `xml:"count,attr"`
+			Player []struct {
+				Text      string `xml:",chardata"`
+				PlayerKey string `xml:"player_key"`
+				PlayerID  string `xml:"player_id"`
+				Name      struct {
+					Text       string `xml:",chardata"`
+					Full       string `xml:"full"`
+					First      string `xml:"first"`
+					Last       string `xml:"last"`
+					AsciiFirst string `xml:"ascii_first"`
+					AsciiLast  string `xml:"ascii_last"`
+				} `xml:"name"`
+				EditorialTeamAbbr string `xml:"editorial_team_abbr"`
+				DisplayPosition   string `xml:"display_position"`
+				PositionType      string `xml:"position_type"`
+				TransactionData   struct {
+					Text                string `xml:",chardata"`
+					Type                string `xml:"type"`
+					SourceType          string `xml:"source_type"`
+					DestinationType     string `xml:"destination_type"`
+					DestinationTeamKey  string `xml:"destination_team_key"`
+					DestinationTeamName string `xml:"destination_team_name"`
+					SourceTeamKey       string `xml:"source_team_key"`
+					SourceTeamName      string `xml:"source_team_name"`
+				} `xml:"transaction_data"`
+			} `xml:"player"`
+		} `xml:"players"`
+	} `xml:"transaction"`
+}
+
+func (s *Service) GetTransactionsResourcesPlayers(ctx context.Context, transactionKey string) (*TransactionsResourcesPlayers, error) {
+	url := s.resourceURL("transaction", transactionKey, "players")
+	res, err := s.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	return nil, errors.New("not implemented")
+}
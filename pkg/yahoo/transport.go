@@ -0,0 +1,354 @@
+package yahoo
+
+import (
+	"context"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/api/metric"
+	"go.opentelemetry.io/otel/label"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// StatusRequestDenied is what Yahoo answers with instead of 429 when it throttles a client
+const StatusRequestDenied = 999
+
+// Yahoo does not publish its limits. These keep a bulk import under what it tolerates.
+const (
+	defaultGlobalRate  = 20
+	defaultGlobalBurst = 20
+	defaultTokenRate   = 5
+	defaultTokenBurst  = 10
+	defaultMaxRetries  = 5
+	defaultMinBackoff  = 500 * time.Millisecond
+	defaultMaxBackoff  = time.Minute
+	// defaultAttemptTimeout is how long one attempt has, retries get their own
+	defaultAttemptTimeout = 10 * time.Second
+	// idleBucket is how long a token's bucket is kept after its last request
+	idleBucket = 10 * time.Minute
+)
+
+// Transport is the http.RoundTripper under every call to the Yahoo Fantasy API. It waits for a token from a
+// bucket shared by every request and one for the access token the request is made with, and retries throttled
+// and failed requests with exponential backoff and jitter, or after the Retry-After Yahoo asks for.
+// Build one per process and share it so the limits hold across clients.
+type Transport struct {
+	base           http.RoundTripper
+	logger         log.Logger
+	global         *tokenBucket
+	tokenRate      float64
+	tokenBurst     int
+	mu             *sync.Mutex
+	tokens         map[string]*tokenBucket
+	maxRetries     int
+	minBackoff     time.Duration
+	maxBackoff     time.Duration
+	attemptTimeout time.Duration
+	meter          otel.Meter
+	throttles      metric.Int64Counter
+	retries        metric.Int64Counter
+	sleep          func(ctx context.Context, d time.Duration) error
+}
+
+type TransportOption func(t *Transport)
+
+// WithBaseTransport sends the requests through base instead of http.DefaultTransport
+func WithBaseTransport(base http.RoundTripper) TransportOption {
+	return func(t *Transport) {
+		t.base = base
+	}
+}
+
+// WithGlobalRate limits every request through the transport to perSecond, allowing bursts of burst.
+// A rate of zero turns the limit off.
+func WithGlobalRate(perSecond float64, burst int) TransportOption {
+	return func(t *Transport) {
+		t.global = newTokenBucket(perSecond, burst)
+	}
+}
+
+// WithTokenRate limits the requests made with each access token to perSecond, allowing bursts of burst.
+// A rate of zero turns the limit off.
+func WithTokenRate(perSecond float64, burst int) TransportOption {
+	return func(t *Transport) {
+		t.tokenRate = perSecond
+		t.tokenBurst = burst
+	}
+}
+
+// WithRetries retries a request up to maxRetries times, backing off from minBackoff up to maxBackoff
+func WithRetries(maxRetries int, minBackoff, maxBackoff time.Duration) TransportOption {
+	return func(t *Transport) {
+		t.maxRetries = maxRetries
+		t.minBackoff = minBackoff
+		t.maxBackoff = maxBackoff
+	}
+}
+
+// WithAttemptTimeout gives up on one attempt after timeout
+func WithAttemptTimeout(timeout time.Duration) TransportOption {
+	return func(t *Transport) {
+		t.attemptTimeout = timeout
+	}
+}
+
+// WithMeter counts throttled and retried requests as yahoo_throttles and yahoo_retries
+func WithMeter(meter otel.Meter) TransportOption {
+	return func(t *Transport) {
+		t.meter = meter
+	}
+}
+
+func NewTransport(logger log.Logger, options ...TransportOption) *Transport {
+	t := &Transport{
+		base:           http.DefaultTransport,
+		logger:         logger,
+		global:         newTokenBucket(defaultGlobalRate, defaultGlobalBurst),
+		tokenRate:      defaultTokenRate,
+		tokenBurst:     defaultTokenBurst,
+		mu:             &sync.Mutex{},
+		tokens:         make(map[string]*tokenBucket),
+		maxRetries:     defaultMaxRetries,
+		minBackoff:     defaultMinBackoff,
+		maxBackoff:     defaultMaxBackoff,
+		attemptTimeout: defaultAttemptTimeout,
+		sleep:          sleepContext,
+	}
+	for _, option := range options {
+		option(t)
+	}
+
+	var err error
+	t.throttles, err = t.meter.NewInt64Counter("yahoo_throttles", metric.WithDescription("requests Yahoo throttled with 429 or 999"))
+	if err != nil {
+		level.Error(logger).Log("message", "could not create yahoo throttle counter", "error", err)
+	}
+	t.retries, err = t.meter.NewInt64Counter("yahoo_retries", metric.WithDescription("requests to Yahoo that were retried"))
+	if err != nil {
+		level.Error(logger).Log("message", "could not create yahoo retry counter", "error", err)
+	}
+	return t
+}
+
+// RoundTrip sends the request, waiting for the rate limits first and retrying it while Yahoo throttles it or fails.
+// A request with a body is only retried when its body can be read again through GetBody.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		err := t.wait(ctx, req.Header.Get("Authorization"))
+		if err != nil {
+			return nil, err
+		}
+		try, cancel, err := t.attemptRequest(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := t.base.RoundTrip(try)
+		if ctx.Err() != nil {
+			if res != nil {
+				res.Body.Close()
+			}
+			cancel()
+			return nil, ctx.Err()
+		}
+		if err == nil && !retryable(res.StatusCode) {
+			res.Body = cancelBody{ReadCloser: res.Body, cancel: cancel}
+			return res, nil
+		}
+
+		labels := []label.KeyValue{label.String("host", req.URL.Host)}
+		var delay time.Duration
+		if err == nil {
+			labels = append(labels, label.Int("status", res.StatusCode))
+			if res.StatusCode == http.StatusTooManyRequests || res.StatusCode == StatusRequestDenied {
+				t.throttles.Add(ctx, 1, labels...)
+			}
+			delay = retryAfter(res.Header.Get("Retry-After"), time.Now())
+		}
+		if attempt >= t.maxRetries || (req.Body != nil && req.GetBody == nil) {
+			if res == nil {
+				cancel()
+				return nil, err
+			}
+			res.Body = cancelBody{ReadCloser: res.Body, cancel: cancel}
+			return res, err
+		}
+		if delay == 0 {
+			delay = t.backoff(attempt)
+		}
+		if delay > t.maxBackoff {
+			delay = t.maxBackoff
+		}
+		if res != nil {
+			_, _ = io.Copy(ioutil.Discard, res.Body)
+			res.Body.Close()
+		}
+		cancel()
+
+		t.retries.Add(ctx, 1, labels...)
+		level.Debug(t.logger).Log("message", "retrying yahoo request", "url", req.URL.Path, "attempt", attempt+1, "delay", delay, "error", err)
+		err = t.sleep(ctx, delay)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// attemptRequest is the request for one attempt, with a fresh body and the attempt timeout.
+// The caller cancels the timeout once it is done with the response.
+func (t *Transport) attemptRequest(req *http.Request, attempt int) (*http.Request, context.CancelFunc, error) {
+	try := req
+	if attempt > 0 && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, nil, err
+		}
+		try = req.Clone(req.Context())
+		try.Body = body
+	}
+	if t.attemptTimeout <= 0 {
+		return try, func() {}, nil
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), t.attemptTimeout)
+	return try.WithContext(ctx), cancel, nil
+}
+
+// wait takes a token from the global bucket and the access token's bucket, sleeping until both allow the request
+func (t *Transport) wait(ctx context.Context, authorization string) error {
+	now := time.Now()
+	delay := t.global.reserve(now)
+	if bucket := t.tokenBucket(authorization, now); bucket != nil {
+		if tokenDelay := bucket.reserve(now); tokenDelay > delay {
+			delay = tokenDelay
+		}
+	}
+	if delay <= 0 {
+		return nil
+	}
+	return t.sleep(ctx, delay)
+}
+
+func (t *Transport) tokenBucket(authorization string, now time.Time) *tokenBucket {
+	if t.tokenRate <= 0 {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	bucket, ok := t.tokens[authorization]
+	if !ok {
+		for key, idle := range t.tokens {
+			if idle.idleSince(now) > idleBucket {
+				delete(t.tokens, key)
+			}
+		}
+		bucket = newTokenBucket(t.tokenRate, t.tokenBurst)
+		t.tokens[authorization] = bucket
+	}
+	return bucket
+}
+
+// backoff doubles from minBackoff with every attempt, and picks a delay between half of that and all of it
+// so clients throttled together do not retry together
+func (t *Transport) backoff(attempt int) time.Duration {
+	ceiling := float64(t.minBackoff) * math.Pow(2, float64(attempt))
+	if ceiling > float64(t.maxBackoff) {
+		ceiling = float64(t.maxBackoff)
+	}
+	return time.Duration(ceiling/2 + rand.Float64()*ceiling/2)
+}
+
+// retryable is true for Yahoo throttling and server errors
+func retryable(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode == StatusRequestDenied || statusCode >= http.StatusInternalServerError
+}
+
+// retryAfter reads a Retry-After header given in seconds or as an HTTP date. It is zero when there is none.
+func retryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(header); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// cancelBody cancels an attempt's timeout when the caller is done with the response
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b cancelBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// tokenBucket lets rate requests through a second on average, up to burst at once. Requests reserve a token
+// and wait until it is theirs, so a busy bucket queues them in the order they came.
+type tokenBucket struct {
+	mu     *sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket returns nil, which never waits, when rate is zero
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if rate <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{mu: &sync.Mutex{}, rate: rate, burst: float64(burst), tokens: float64(burst)}
+}
+
+// reserve takes a token and returns how long to wait before it can be used
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	if b == nil {
+		return 0
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.last.IsZero() {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	}
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+func (b *tokenBucket) idleSince(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return now.Sub(b.last)
+}
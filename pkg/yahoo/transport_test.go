@@ -0,0 +1,135 @@
+package yahoo
+
+import (
+	"bytes"
+	"context"
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testTransport records the delays it would have slept for instead of sleeping
+func testTransport(options ...TransportOption) (*Transport, *[]time.Duration) {
+	transport := NewTransport(log.NewNopLogger(), options...)
+	slept := &[]time.Duration{}
+	mu := &sync.Mutex{}
+	transport.sleep = func(ctx context.Context, d time.Duration) error {
+		mu.Lock()
+		defer mu.Unlock()
+		*slept = append(*slept, d)
+		return ctx.Err()
+	}
+	return transport, slept
+}
+
+// flakyYahoo answers with statuses in turn, then 200 with the request body
+func flakyYahoo(statuses ...int) (*httptest.Server, *int) {
+	calls := 0
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls <= len(statuses) {
+			if statuses[calls-1] == http.StatusTooManyRequests {
+				w.Header().Set("Retry-After", "7")
+			}
+			w.WriteHeader(statuses[calls-1])
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	})), &calls
+}
+
+func TestTransport_RetriesThrottlingAndServerErrors(t *testing.T) {
+	server, calls := flakyYahoo(StatusRequestDenied, http.StatusTooManyRequests, http.StatusBadGateway)
+	defer server.Close()
+	transport, slept := testTransport(WithGlobalRate(0, 0), WithTokenRate(0, 0))
+
+	req, _ := http.NewRequest(http.MethodPut, server.URL, bytes.NewReader([]byte("<draft_result/>")))
+	res, err := (&http.Client{Transport: transport}).Do(req)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer res.Body.Close()
+	body, _ := ioutil.ReadAll(res.Body)
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "<draft_result/>", string(body), "the body is sent again with every attempt")
+	assert.Equal(t, 4, *calls)
+	if assert.Len(t, *slept, 3) {
+		assert.Equal(t, 7*time.Second, (*slept)[1], "Retry-After is honored")
+		assert.True(t, (*slept)[0] >= defaultMinBackoff/2 && (*slept)[0] <= defaultMinBackoff)
+		assert.True(t, (*slept)[2] >= defaultMinBackoff*2 && (*slept)[2] <= defaultMinBackoff*4, "backoff doubles with each attempt")
+	}
+}
+
+func TestTransport_GivesUpAfterMaxRetries(t *testing.T) {
+	server, calls := flakyYahoo(500, 500, 500, 500)
+	defer server.Close()
+	transport, _ := testTransport(WithRetries(2, time.Millisecond, time.Second))
+
+	res, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if assert.Nil(t, err) {
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode, "the last response is returned for the caller to handle")
+		res.Body.Close()
+	}
+	assert.Equal(t, 3, *calls)
+}
+
+func TestTransport_DoesNotRetryClientErrors(t *testing.T) {
+	server, calls := flakyYahoo(http.StatusUnauthorized)
+	defer server.Close()
+	transport, slept := testTransport()
+
+	res, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if assert.Nil(t, err) {
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+		res.Body.Close()
+	}
+	assert.Equal(t, 1, *calls)
+	assert.Empty(t, *slept)
+}
+
+func TestTransport_RateLimitsEachToken(t *testing.T) {
+	server, _ := flakyYahoo()
+	defer server.Close()
+	transport, slept := testTransport(WithGlobalRate(0, 0), WithTokenRate(1, 2))
+	client := &http.Client{Transport: transport}
+
+	for _, token := range []string{"a", "a", "b", "a"} {
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		res, err := client.Do(req)
+		if assert.Nil(t, err) {
+			res.Body.Close()
+		}
+	}
+	if assert.Len(t, *slept, 1, "only the third request with token a waits") {
+		assert.True(t, (*slept)[0] > 900*time.Millisecond)
+	}
+}
+
+func Test_tokenBucket_reserve(t *testing.T) {
+	now := time.Now()
+	bucket := newTokenBucket(2, 2)
+	assert.Equal(t, time.Duration(0), bucket.reserve(now))
+	assert.Equal(t, time.Duration(0), bucket.reserve(now))
+	assert.Equal(t, 500*time.Millisecond, bucket.reserve(now))
+	assert.Equal(t, time.Second, bucket.reserve(now), "waiting requests queue up")
+	assert.Equal(t, time.Duration(0), bucket.reserve(now.Add(2*time.Second)))
+
+	var unlimited *tokenBucket
+	assert.Equal(t, time.Duration(0), unlimited.reserve(now))
+}
+
+func Test_retryAfter(t *testing.T) {
+	now := time.Date(2020, time.August, 30, 19, 0, 0, 0, time.UTC)
+	assert.Equal(t, 30*time.Second, retryAfter("30", now))
+	assert.Equal(t, 90*time.Second, retryAfter(now.Add(90*time.Second).Format(http.TimeFormat), now))
+	assert.Equal(t, time.Duration(0), retryAfter("", now))
+	assert.Equal(t, time.Duration(0), retryAfter("soon", now))
+}
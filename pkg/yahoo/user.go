@@ -0,0 +1,287 @@
+package yahoo
+
+import (
+	"context"
+	"encoding/xml"
+	"github.com/go-kit/kit/log/level"
+	"io/ioutil"
+)
+
+type UserResourcesGames struct {
+	GUID  string `json:"guid"`
+	Games []Game
+}
+type UserResourcesResponse struct {
+	XMLName     xml.Name  `xml:"fantasy_content"`
+	Text        string    `xml:",chardata"`
+	Lang        string    `xml:"lang,attr"`
+	URI         string    `xml:"uri,attr"`
+	Time        string    `xml:"time,attr"`
+	Copyright   string    `xml:"copyright,attr"`
+	RefreshRate string    `xml:"refresh_rate,attr"`
+	Yahoo       string    `xml:"yahoo,attr"`
+	Xmlns       string    `xml:"xmlns,attr"`
+	User        YahooUser `xml:"users>user"`
+}
+
+type YahooUser struct {
+	Text  string      `xml:",chardata"`
+	Guid  string      `xml:"guid"`
+	Games []YahooGame `xml:"games>game"`
+}
+
+// GetUserResourcesGames
+func (s *Service) GetUserResourcesGames(ctx context.Context) (*UserResourcesGames, error) {
+	url := s.resourceURL("users;use_login=1", "games")
+	res, err := s.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	v := UserResourcesResponse{}
+	bytes, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	// transform response to games
+	err = xml.Unmarshal(bytes, &v)
+	if err != nil {
+		level.Error(s.logger).Log("message", "could not unmarshal yahoo response", "error", err, "url", url)
+		return nil, err
+	}
+	games := make([]Game, len(v.User.Games))
+
+	for idx, yahooGame := range v.User.Games {
+		game := transformYahooResponseGameToGame(yahooGame)
+		games[idx] = game
+	}
+
+	return &UserResourcesGames{
+		GUID:  v.User.Guid,
+		Games: games,
+	}, nil
+
+}
+
+type GameLeagues struct {
+	ID      string   `json:"id" bson:"_id"`
+	GameKey string   `json:"game_key"`
+	GameID  string   `json:"game_id"`
+	Name    string   `json:"name"`
+	Code    string   `json:"code"`
+	Type    string   `json:"type"`
+	URL     string   `json:"url"`
+	Season  string   `json:"season"`
+	Leagues []League `json:"league"`
+}
+
+type UserResourcesGameLeagues struct {
+	GUID       string      `json:"guid"`
+	GameLeague GameLeagues `json:"league"`
+}
+
+type UserResourcesGameLeaguesResponse struct {
+	XMLName     xml.Name `xml:"fantasy_content"`
+	Text        string   `xml:",chardata"`
+	Lang        string   `xml:"lang,attr"`
+	URI         string   `xml:"uri,attr"`
+	Time        string   `xml:"time,attr"`
+	Copyright   string   `xml:"copyright,attr"`
+	RefreshRate string   `xml:"refresh_rate,attr"`
+	Yahoo       string   `xml:"yahoo,attr"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	Users       struct {
+		Count string `xml:"count,attr"`
+		User  struct {
+			Guid  string `xml:"guid"`
+			Games struct {
+				Count int         `xml:"count,attr"`
+				Game  []YahooGame `xml:"game"`
+			} `xml:"games"`
+		} `xml:"user"`
+	} `xml:"users"`
+}
+
+// GetUserResourcesGameLeaguesResponse
+func (s *Service) GetUserResourcesGameLeaguesResponse(ctx context.Context) (*UserResourcesGameLeaguesResponse, error) {
+	url := s.resourceURL("users;use_login=1", "games", "leagues")
+	res, err := s.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	v := UserResourcesGameLeaguesResponse{}
+	bytes, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	err = xml.Unmarshal(bytes, &v)
+	if err != nil {
+		level.Error(s.logger).Log("message", "could not unmarshal yahoo response", "error", err, "url", url)
+		return nil, err
+	}
+	return &v, nil
+
+}
+
+type UserResourcesGameTeams struct {
+	GUID  string `json:"guid"`
+	Teams []struct {
+		GameKey string `json:"game_key"`
+		GameID  string `json:"game_id"`
+		Name    string `json:"name"`
+		Code    string `json:"code"`
+		Type    string `json:"type"`
+		URL     string `json:"url"`
+		Season  string `json:"season"`
+		Teams   []struct {
+			TeamKey               string    `json:"team_key"`
+			TeamID                string    `json:"team_id"`
+			Name                  string    `json:"name"`
+			IsOwnedByCurrentLogin int       `json:"is_owned_by_current_login"`
+			URL                   string    `json:"url"`
+			TeamLogo              string    `json:"team_logo"`
+			WaiverPriority        int       `json:"waiver_priority"`
+			NumberOfMoves         string    `json:"number_of_moves"`
+			NumberOfTrades        int       `json:"number_of_trades"`
+			Managers              []Manager `json:"managers"`
+			ClinchedPlayoffs      int       `json:"clinched_playoffs,omitempty"`
+		} `json:"teams"`
+	} `json:"teams"`
+}
+
+type Manager struct {
+	ManagerID      string `xml:"manager_id"`
+	Nickname       string `xml:"nickname"`
+	GUID           string `xml:"guid"`
+	IsCurrentLogin string `xml:"is_current_login"`
+	IsCommissioner int    `xml:"is_commissioner"`
+	Email          string `xml:"email"`
+	ImageURL       string `xml:"image_url"`
+}
+
+type UserResourcesGameTeamsResponse struct {
+	XMLName     xml.Name `xml:"fantasy_content"`
+	Text        string   `xml:",chardata"`
+	Lang        string   `xml:"lang,attr"`
+	URI         string   `xml:"uri,attr"`
+	Time        string   `xml:"time,attr"`
+	Copyright   string   `xml:"copyright,attr"`
+	RefreshRate string   `xml:"refresh_rate,attr"`
+	Yahoo       string   `xml:"yahoo,attr"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	Users       struct {
+		Count string `xml:"count,attr"`
+		User  struct {
+			Guid  string `xml:"guid"`
+			Games struct {
+				Count string      `xml:"count,attr"`
+				Game  []YahooGame `xml:"game"`
+			} `xml:"games"`
+		} `xml:"user"`
+	} `xml:"users"`
+}
+
+type TeamResponse struct {
+	Text                  string `xml:",chardata"`
+	Type                  string `xml:"type,attr,omitempty"`
+	TeamKey               string `xml:"team_key,omitempty"`
+	TeamID                string `xml:"team_id,omitempty"`
+	Name                  string `xml:"name,omitempty"`
+	IsOwnedByCurrentLogin string `xml:"is_owned_by_current_login,omitempty"`
+	URL                   string `xml:"url,omitempty"`
+	TeamLogos             struct {
+		TeamLogo struct {
+			Text            string `xml:",chardata"`
+			Size            string `xml:"size"`
+			URL             string `xml:"url"`
+			MissingLogoSize int    `xml:"missing_logo_size"`
+		} `xml:"team_logo"`
+	} `xml:"team_logos"`
+	WaiverPriority string `xml:"waiver_priority"`
+	NumberOfMoves  string `xml:"number_of_moves"`
+	NumberOfTrades string `xml:"number_of_trades"`
+	RosterAdds     struct {
+		Text          string `xml:",chardata"`
+		CoverageType  string `xml:"coverage_type"`
+		CoverageValue string `xml:"coverage_value"`
+		Value         string `xml:"value"`
+	} `xml:"roster_adds"`
+	LeagueScoringType string `xml:"league_scoring_type"`
+	DraftPosition     string `xml:"draft_position"`
+	HasDraftGrade     string `xml:"has_draft_grade"`
+	Managers          struct {
+		Text    string `xml:",chardata"`
+		Manager struct {
+			Text           string `xml:",chardata"`
+			ManagerID      string `xml:"manager_id"`
+			Nickname       string `xml:"nickname"`
+			Guid           string `xml:"guid"`
+			IsCurrentLogin string `xml:"is_current_login"`
+			Email          string `xml:"email"`
+			ImageURL       string `xml:"image_url"`
+			IsCommissioner string `xml:"is_commissioner"`
+		} `xml:"manager"`
+	} `xml:"managers"`
+	ClinchedPlayoffs   string `xml:"clinched_playoffs"`
+	DraftGrade         string `xml:"draft_grade"`
+	DraftRecapURL      string `xml:"draft_recap_url"`
+	AuctionBudgetTotal string `xml:"auction_budget_total"`
+	AuctionBudgetSpent string `xml:"auction_budget_spent"`
+	DivisionID         string `xml:"division_id"`
+	EmailAddress       string `xml:"email_address"`
+	UserDisplayName    string `xml:"user_display_name"`
+	UserProfileImage   string `xml:"user_profile_image"`
+	TeamLogo           string `xml:"team_logo"`
+	LogoType           string `xml:"logo_type"`
+	Status             string `xml:"status"`
+	TotalStrikes       string `xml:"total_strikes"`
+	EliminationWeek    string `xml:"elimination_week"`
+	DoneWeek           string `xml:"done_week"`
+	Manager            struct {
+		Text              string `xml:",chardata"`
+		ManagerID         string `xml:"manager_id"`
+		Guid              string `xml:"guid"`
+		Nickname          string `xml:"nickname"`
+		FantasyProfileURL string `xml:"fantasy_profile_url"`
+		ProfileImageURL   string `xml:"profile_image_url"`
+		IsCommissioner    string `xml:"is_commissioner"`
+		Email             string `xml:"email"`
+		Emails            string `xml:"emails"`
+	} `xml:"manager"`
+	LastEditableWeek   string `xml:"last_editable_week"`
+	CanEditCurrentWeek string `xml:"can_edit_current_week"`
+	IsInContest        string `xml:"is_in_contest"`
+}
+
+// GetUserResourcesGameTeams
+func (s *Service) GetUserResourcesGameTeams(ctx context.Context) (*UserResourcesGameTeamsResponse, error) {
+	url := s.resourceURL("users;use_login=1", "games", "teams")
+	res, err := s.get(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	v := UserResourcesGameTeamsResponse{}
+	bytes, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	err = xml.Unmarshal(bytes, &v)
+	if err != nil {
+		level.Error(s.logger).Log("message", "could not unmarshal yahoo response", "error", err, "url", url)
+		return nil, err
+	}
+
+	return &v, nil
+
+}
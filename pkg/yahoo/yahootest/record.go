@@ -0,0 +1,111 @@
+package yahootest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// FantasyURL is the fantasy API the fixtures are recorded from
+const FantasyURL = "https://fantasysports.yahooapis.com/fantasy/v2"
+
+// Setting RecordTokenEnv to a Yahoo access token makes NewServer record the fixtures of the tests it runs in.
+// RecordScrubEnv is a comma separated list of anything else to keep out of them, like the user's guid or email.
+//
+//	YAHOO_RECORD_TOKEN=... YAHOO_RECORD_SCRUB=guid,email go test ./pkg/league/ -run TestImporter
+const (
+	RecordTokenEnv = "YAHOO_RECORD_TOKEN"
+	RecordScrubEnv = "YAHOO_RECORD_SCRUB"
+)
+
+// scrubbed is what the token and the scrubbed values read as in a recorded fixture
+const scrubbed = "scrubbed"
+
+// recorder sends the requests on to Yahoo with a real token and saves what it answers as fixtures
+type recorder struct {
+	t        testing.TB
+	server   *Server
+	upstream string
+	token    string
+	scrub    *strings.Replacer
+}
+
+// NewRecordingServer sends every request on to upstream with token, whatever token the request was made with, and
+// writes each successful response to the fixture it would be served from. The token and the scrub values are replaced
+// by "scrubbed" before the fixture is written. Only GETs are recorded, anything else would change the real league.
+func NewRecordingServer(t testing.TB, dir, upstream, token string, scrub ...string) *Server {
+	replacements := []string{token, scrubbed}
+	for _, value := range scrub {
+		if value != "" {
+			replacements = append(replacements, value, scrubbed)
+		}
+	}
+
+	s := &Server{dir: dir, mu: &sync.Mutex{}}
+	s.Server = httptest.NewServer(&recorder{
+		t:        t,
+		server:   s,
+		upstream: strings.TrimSuffix(upstream, "/"),
+		token:    token,
+		scrub:    strings.NewReplacer(replacements...),
+	})
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (rec *recorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rec.server.record(r)
+
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "only GETs are recorded")
+		return
+	}
+
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, rec.upstream+r.URL.EscapedPath(), nil)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	req.URL.RawQuery = r.URL.RawQuery
+	req.Header.Set("Authorization", "Bearer "+rec.token)
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	body = []byte(rec.scrub.Replace(string(body)))
+
+	if res.StatusCode < http.StatusMultipleChoices {
+		if err := rec.save(r.URL.Path, body); err != nil {
+			rec.t.Errorf("could not record %s: %v", r.URL.Path, err)
+		}
+	}
+
+	for _, header := range []string{"Content-Type", "Retry-After", "WWW-Authenticate"} {
+		if value := res.Header.Get(header); value != "" {
+			w.Header().Set(header, value)
+		}
+	}
+	w.WriteHeader(res.StatusCode)
+	_, _ = w.Write(body)
+}
+
+func (rec *recorder) save(urlPath string, body []byte) error {
+	file := rec.server.fixture(urlPath)
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, body, 0644)
+}
@@ -0,0 +1,115 @@
+// Package yahootest is a fake Yahoo Fantasy API for tests. It answers with XML fixtures recorded from Yahoo, so the
+// tests of the Yahoo clients and the importers run without credentials or a network.
+package yahootest
+
+import (
+	"context"
+	"fmt"
+	"github.com/thethan/fdr-users/pkg/users/entities"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Token is the access token the fake expects. Any bearer token is accepted, this one reads best in tests.
+const Token = "yahootest-token"
+
+// Server is the fake fantasy API. Point a yahoo.Service or a YahooRepository at its URL with their WithBaseURL.
+//
+// Every resource is a fixture file below the server's directory, named after the request's path with its options:
+// /league/399.l.200/settings is answered with league/399.l.200/settings.xml and
+// /game/399/players;start=0;count=25/stats with game/399/players;start=0;count=25/stats.xml.
+// A resource without a fixture is answered the way Yahoo answers a key it does not know.
+type Server struct {
+	*httptest.Server
+	dir      string
+	mu       *sync.Mutex
+	requests []string
+}
+
+// NewServer serves the fixtures in dir until the test ends. When RecordTokenEnv is set it records them from Yahoo
+// instead, see NewRecordingServer.
+func NewServer(t testing.TB, dir string) *Server {
+	if token := os.Getenv(RecordTokenEnv); token != "" {
+		return NewRecordingServer(t, dir, FantasyURL, token, strings.Split(os.Getenv(RecordScrubEnv), ",")...)
+	}
+
+	s := &Server{dir: dir, mu: &sync.Mutex{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveFixture))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Fixtures is the directory of the fixtures that come with this package
+func Fixtures() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "testdata")
+}
+
+// Requests are the paths the server was asked for, options included, in the order it was asked
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+func (s *Server) record(r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r.URL.Path)
+}
+
+// fixture is the file of the resource at urlPath
+func (s *Server) fixture(urlPath string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(path.Clean("/"+urlPath)+".xml"))
+}
+
+func (s *Server) serveFixture(w http.ResponseWriter, r *http.Request) {
+	s.record(r)
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+		writeError(w, http.StatusUnauthorized, `Please provide valid credentials. OAuth oauth_problem="unable_to_determine_oauth_type", realm="yahooapis.com"`)
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "the fake only serves recorded resources")
+		return
+	}
+
+	body, err := ioutil.ReadFile(s.fixture(r.URL.Path))
+	if os.IsNotExist(err) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Resource %s does not exist.", r.URL.Path))
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/xml; charset=UTF-8")
+	_, _ = w.Write(body)
+}
+
+// writeError answers with Yahoo's error body
+func writeError(w http.ResponseWriter, status int, description string) {
+	w.Header().Set("Content-Type", "application/xml; charset=UTF-8")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+<error xml:lang="en-us" xmlns:yahoo="http://www.yahooapis.com/v1/base.rng" xmlns="http://www.yahooapis.com/v1/base.rng">
+ <description>%s</description>
+ <detail/>
+</error>`, description)
+}
+
+// Credentials is a yahoo.UserInformation that signs every session in with the same access token
+type Credentials string
+
+func (c Credentials) GetCredentialInformation(ctx context.Context, session string) (entities.User, error) {
+	return entities.User{GUID: session, AccessToken: string(c)}, nil
+}
@@ -0,0 +1,98 @@
+package yahootest
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func get(t *testing.T, url, token string) (int, string) {
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if !assert.Nil(t, err) {
+		return 0, ""
+	}
+	defer res.Body.Close()
+	body, _ := ioutil.ReadAll(res.Body)
+	return res.StatusCode, string(body)
+}
+
+func TestServer_ServesFixtures(t *testing.T) {
+	server := NewServer(t, Fixtures())
+
+	status, body := get(t, server.URL+"/league/399.l.200/settings", Token)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, "<league_key>399.l.200</league_key>")
+
+	status, body = get(t, server.URL+"/game/399/players;start=0;count=25/stats", Token)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, "<player_key>399.p.30123</player_key>")
+
+	assert.Equal(t, []string{"/league/399.l.200/settings", "/game/399/players;start=0;count=25/stats"}, server.Requests())
+}
+
+func TestServer_Refuses(t *testing.T) {
+	server := NewServer(t, Fixtures())
+
+	status, body := get(t, server.URL+"/league/399.l.0/settings", Token)
+	assert.Equal(t, http.StatusBadRequest, status, "an unknown key")
+	assert.Contains(t, body, "does not exist")
+
+	status, _ = get(t, server.URL+"/league/399.l.200/../../../server.go", Token)
+	assert.Equal(t, http.StatusBadRequest, status, "nothing outside the fixtures")
+
+	status, _ = get(t, server.URL+"/league/399.l.200/settings", "")
+	assert.Equal(t, http.StatusUnauthorized, status, "requests without a token")
+
+	req, _ := http.NewRequest(http.MethodPut, server.URL+"/league/399.l.200/draftresults", strings.NewReader("<fantasy_content/>"))
+	req.Header.Set("Authorization", "Bearer "+Token)
+	res, err := http.DefaultClient.Do(req)
+	if assert.Nil(t, err) {
+		res.Body.Close()
+		assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode, "only what was recorded")
+	}
+}
+
+func TestRecordingServer(t *testing.T) {
+	var authorizations []string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		if r.URL.Path == "/league/399.l.0/settings" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `<error><description>League key 399.l.0 does not exist.</description></error>`)
+			return
+		}
+		fmt.Fprintf(w, `<fantasy_content yahoo:uri="%s"><guid>guid-manager-1</guid><token>real-token</token></fantasy_content>`, r.URL.Path)
+	}))
+	defer upstream.Close()
+
+	dir := t.TempDir()
+	recording := NewRecordingServer(t, dir, upstream.URL, "real-token", "guid-manager-1", "")
+
+	status, body := get(t, recording.URL+"/users;use_login=1/games;game_keys=399/leagues", Token)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `<fantasy_content yahoo:uri="/users;use_login=1/games;game_keys=399/leagues"><guid>scrubbed</guid><token>scrubbed</token></fantasy_content>`, body)
+
+	status, _ = get(t, recording.URL+"/league/399.l.0/settings", Token)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, []string{"Bearer real-token", "Bearer real-token"}, authorizations, "requests are made with the real token")
+
+	fixture, err := ioutil.ReadFile(filepath.Join(dir, "users;use_login=1", "games;game_keys=399", "leagues.xml"))
+	assert.Nil(t, err)
+	assert.Equal(t, body, string(fixture))
+	_, err = ioutil.ReadFile(filepath.Join(dir, "league", "399.l.0", "settings.xml"))
+	assert.Error(t, err, "failures are not recorded")
+
+	server := NewServer(t, dir)
+	status, replayed := get(t, server.URL+"/users;use_login=1/games;game_keys=399/leagues", Token)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, body, replayed, "the recording is served offline")
+}